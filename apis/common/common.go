@@ -17,6 +17,7 @@ package common
 
 import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // TLSConfig contains TLS configuration for HTTPS requests.
@@ -45,4 +46,400 @@ type TLSConfig struct {
 	// If true, any certificate presented by the server and any host name in that certificate is accepted.
 	// +optional
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// TLSMinVersion is the minimum TLS version accepted when connecting to servers.
+	// If empty, the default minimum version of Go is used.
+	// +kubebuilder:validation:Enum="1.0";"1.1";"1.2";"1.3"
+	// +optional
+	TLSMinVersion string `json:"tlsMinVersion,omitempty"`
+
+	// CipherSuites restricts the cipher suites offered for TLS 1.2 and earlier to the listed ones, by their IANA
+	// name, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256. The cipher suites of TLS 1.3 are not configurable.
+	// If empty, the default cipher suites of Go are used.
+	// +optional
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// TLSHostPolicy chooses how the certificate of the servers of a host is verified, instead of the TLS configuration
+// of the requests.
+type TLSHostPolicy struct {
+	// Host the policy applies to, a host name, e.g. api.internal.example.com, an IP address, or a wildcard
+	// matching the subdomains of a host name, e.g. *.internal.example.com. The port of the URL is ignored.
+	Host string `json:"host"`
+
+	// InsecureSkipVerify skips the verification of the certificate of the servers of the host.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle which will be used to validate the certificate of the servers of the
+	// host. If empty, the CA bundle of the TLS configuration, or the system root CAs, are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// CACertSecretRef is a reference to a secret containing the CA certificate(s) of the host.
+	// +optional
+	CACertSecretRef *xpv1.SecretKeySelector `json:"caCertSecretRef,omitempty"`
+}
+
+// OAuth2Config configures the acquisition of OAuth2 access tokens with the client credentials grant.
+type OAuth2Config struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL"`
+
+	// ClientIDSecretRef is a reference to a secret key containing the client id.
+	ClientIDSecretRef xpv1.SecretKeySelector `json:"clientIDSecretRef"`
+
+	// ClientSecretSecretRef is a reference to a secret key containing the client secret.
+	ClientSecretSecretRef xpv1.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// Scopes are the scopes requested for the access token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// CredentialsEndpointConfig configures fetching the credentials from an endpoint, e.g. a sidecar minting tokens
+// exposed at a local URL.
+type CredentialsEndpointConfig struct {
+	// URL of the endpoint, answering GET requests with the credentials.
+	URL string `json:"url"`
+
+	// ResponseJQ is a jq filter extracting the credentials from a JSON response, e.g. .token. When empty, the
+	// whole body of the response is used, without its leading and trailing white space.
+	// +optional
+	ResponseJQ string `json:"responseJQ,omitempty"`
+
+	// TTL is how long fetched credentials are reused before being fetched again. Defaults to 1m.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// SigV4Config configures the signing of requests with AWS Signature Version 4.
+type SigV4Config struct {
+	// Region is the AWS region the requests are signed for, e.g. us-east-1.
+	Region string `json:"region"`
+
+	// Service is the name of the AWS service the requests are signed for, e.g. execute-api.
+	Service string `json:"service"`
+
+	// AccessKeyIDSecretRef is a reference to a secret key containing the access key id.
+	AccessKeyIDSecretRef xpv1.SecretKeySelector `json:"accessKeyIDSecretRef"`
+
+	// SecretAccessKeySecretRef is a reference to a secret key containing the secret access key.
+	SecretAccessKeySecretRef xpv1.SecretKeySelector `json:"secretAccessKeySecretRef"`
+
+	// SessionTokenSecretRef is a reference to a secret key containing the session token of temporary credentials.
+	// +optional
+	SessionTokenSecretRef *xpv1.SecretKeySelector `json:"sessionTokenSecretRef,omitempty"`
+}
+
+// NTLMConfig configures the authentication of requests with NTLM, e.g. to services behind IIS.
+type NTLMConfig struct {
+	// Domain of the account, e.g. CORP. Empty for a local account of the server.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// UsernameSecretRef is a reference to a secret key containing the user name of the account.
+	UsernameSecretRef xpv1.SecretKeySelector `json:"usernameSecretRef"`
+
+	// PasswordSecretRef is a reference to a secret key containing the password of the account.
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// HMACSigningConfig configures the signing of requests with an HMAC placed in a header.
+type HMACSigningConfig struct {
+	// KeySecretRef is a reference to a secret key containing the shared signing key.
+	KeySecretRef xpv1.SecretKeySelector `json:"keySecretRef"`
+
+	// Algorithm is the hash function of the HMAC. Defaults to SHA256.
+	// +kubebuilder:validation:Enum=SHA256;SHA512
+	// +kubebuilder:default=SHA256
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Header is the name of the header holding the hex encoded signature. Defaults to X-Signature.
+	// +kubebuilder:default=X-Signature
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Prefix is prepended to the signature in the header, e.g. "sha256=".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Payload is what is signed: the request body, or the method, path with query and body of the request
+	// separated by newlines. Defaults to Body.
+	// +kubebuilder:validation:Enum=Body;MethodPathBody
+	// +kubebuilder:default=Body
+	// +optional
+	Payload string `json:"payload,omitempty"`
+}
+
+// SSRFGuardConfig configures the rejection of connections to internal addresses.
+type SSRFGuardConfig struct {
+	// AllowedCIDRs are the loopback, link-local or private address ranges that can still be connected to,
+	// e.g. 10.20.0.0/16.
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+}
+
+// CircuitBreakerConfig configures when the circuit of a host opens and for how long.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed requests to a host opening its circuit. A request
+	// fails when it is not answered or answered with a 5xx or 429 status code. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+
+	// Cooldown is how long the circuit stays open before a request probes the host. Defaults to 30s.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// ResponseSchema is a JSON Schema the body of successful responses must match, inline or referenced from a
+// Secret or ConfigMap key. The schema is written in JSON or YAML.
+// +kubebuilder:validation:XValidation:rule="[has(self.inline), has(self.secretKeyRef), has(self.configMapKeyRef)].filter(x, x).size() == 1",message="exactly one of inline, secretKeyRef and configMapKeyRef must be set"
+type ResponseSchema struct {
+	// Inline is the JSON Schema.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// SecretKeyRef references the Secret key holding the JSON Schema.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef references the ConfigMap key holding the JSON Schema.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// BodySource references a Secret or ConfigMap key holding a request body, or holds a binary body in base64.
+// +kubebuilder:validation:XValidation:rule="[has(self.secretKeyRef), has(self.configMapKeyRef), has(self.base64)].filter(x, x).size() == 1",message="exactly one of secretKeyRef, configMapKeyRef and base64 must be set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.template) && self.template && ((has(self.binary) && self.binary) || has(self.base64)))",message="a binary body cannot be templated"
+type BodySource struct {
+	// SecretKeyRef references the Secret key holding the body.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef references the ConfigMap key holding the body.
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// Base64 holds a binary body encoded in base64, e.g. a serialized protobuf message. It is sent as raw bytes.
+	// +optional
+	Base64 string `json:"base64,omitempty"`
+
+	// Template controls whether the loaded body is evaluated as a jq body template, like the inline body.
+	// Otherwise it is sent verbatim.
+	// +optional
+	Template bool `json:"template,omitempty"`
+
+	// Binary sends the loaded body as raw bytes, e.g. a file upload that is not valid UTF-8, bypassing any
+	// templating and body format. Only its size is recorded in the status. A base64 body is always binary.
+	// +optional
+	Binary bool `json:"binary,omitempty"`
+
+	// ContentType is the Content-Type header sent with a binary body, unless the headers set one. Defaults to
+	// application/octet-stream.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key of the ConfigMap to select.
+	Key string `json:"key"`
+}
+
+// StreamConfig configures reading a chunked response as a stream of lines, e.g. the progress lines of a job
+// terminated by a final status line. The request completes with the first line matching the predicate, which
+// becomes the response body, or with the last line once the stream ends.
+// +kubebuilder:validation:XValidation:rule="has(self.matchJQ) != has(self.matchPattern)",message="exactly one of matchJQ and matchPattern must be set"
+type StreamConfig struct {
+	// MatchJQ is a jq filter evaluated on every line parsed as JSON, e.g. '.status == "done"'.
+	// +optional
+	MatchJQ string `json:"matchJQ,omitempty"`
+
+	// MatchPattern is a regular expression matched against every line.
+	// +optional
+	MatchPattern string `json:"matchPattern,omitempty"`
+
+	// MaxDuration is the maximum time the stream is read for, it replaces the timeout of the request.
+	MaxDuration metav1.Duration `json:"maxDuration"`
+
+	// MaxBytes is the maximum number of bytes read from the stream.
+	// +kubebuilder:validation:Minimum=1
+	MaxBytes int64 `json:"maxBytes"`
+}
+
+// WaitForReadyConfig configures polling a resource after its creation until it is ready, for APIs creating
+// resources asynchronously, e.g. answering 202 with a resource still provisioning.
+type WaitForReadyConfig struct {
+	// ReadyJQ is a jq filter evaluated against every response of the OBSERVE request, e.g.
+	// '.body.status == "ACTIVE"'. The resource is ready once it evaluates to true.
+	ReadyJQ string `json:"readyJQ"`
+
+	// Interval is the time between two polls. Defaults to 5s.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Deadline is the maximum time waited for the resource to be ready. The wait is also bounded by the
+	// timeout of the reconcile.
+	Deadline metav1.Duration `json:"deadline"`
+}
+
+// DriftDiffConfig configures recording the fields of the desired state that differ from the observed state when a
+// resource is not up to date.
+type DriftDiffConfig struct {
+	// NormalizeJQ is a jq filter applied to the body of the OBSERVE response before it is diffed, returning an
+	// object, e.g. .data to unwrap an envelope. It only changes the recorded diff, not whether the resource is up
+	// to date.
+	// +optional
+	NormalizeJQ string `json:"normalizeJQ,omitempty"`
+}
+
+// ResponseTransformConfig configures the jq filter reducing the body of the responses to a projection.
+type ResponseTransformConfig struct {
+	// JQ is the jq filter applied to the JSON body of a response, e.g. '{id, status: .status.phase}'. Its result is
+	// the transformed body, JSON encoded unless it is a string. A body that is not JSON is kept as is.
+	JQ string `json:"jq"`
+
+	// Scope chooses what operates on the transformed body. Status, the default, only stores the transformed body,
+	// while the checks and the secret injection operate on the raw body. The last successful response keeps the raw
+	// body too when secrets are injected from it. Response transforms the body as soon as it is received, so the
+	// checks and the secret injection operate on the transformed body too.
+	// +kubebuilder:validation:Enum=Status;Response
+	// +optional
+	Scope string `json:"scope,omitempty"`
+}
+
+// ComparisonConfig configures the normalization applied to both bodies compared by the default up-to-date check.
+type ComparisonConfig struct {
+	// UnorderedArrays are the arrays compared as sets, ignoring the order of their elements. Both bodies sort
+	// them before they are compared.
+	// +optional
+	UnorderedArrays []UnorderedArray `json:"unorderedArrays,omitempty"`
+
+	// NormalizeJQ is a jq filter applied to both bodies after the unordered arrays are sorted, returning an
+	// object, e.g. '.rules |= map(del(.id))'.
+	// +optional
+	NormalizeJQ string `json:"normalizeJQ,omitempty"`
+}
+
+// StoredHeadersConfig bounds the response headers and trailers stored in status.response.
+type StoredHeadersConfig struct {
+	// MaxCount is the maximum number of header values stored. Defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxCount int `json:"maxCount,omitempty"`
+
+	// MaxBytes is the maximum total size of the stored headers, counting the name and the value of each header
+	// value. Defaults to 16384.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBytes int `json:"maxBytes,omitempty"`
+}
+
+// UnorderedArray is an array of the bodies compared as a set.
+type UnorderedArray struct {
+	// Path is the jq path of the array, e.g. .tags or .rules[].ports. A path that is missing or does not hold an
+	// array is left unchanged.
+	Path string `json:"path"`
+
+	// SortBy is a jq filter selecting the key the elements are sorted by, e.g. .name for an array of objects.
+	// Defaults to the whole element.
+	// +optional
+	SortBy string `json:"sortBy,omitempty"`
+}
+
+// FieldDiff is a field of the desired state that differs from the observed state.
+type FieldDiff struct {
+	// Path of the field in the body, as a jq path, e.g. .settings.tier.
+	Path string `json:"path"`
+
+	// Desired is the JSON value of the field in the desired state.
+	Desired string `json:"desired"`
+
+	// Observed is the JSON value of the field in the observed state, empty if the field is missing.
+	// +optional
+	Observed string `json:"observed,omitempty"`
+}
+
+// Attempt records the outcome of a request sent for an action.
+type Attempt struct {
+	// Time the response was received at, or the request failed at.
+	Time metav1.Time `json:"time"`
+
+	// Action of the mapping the request was sent for, e.g. OBSERVE.
+	Action string `json:"action"`
+
+	// StatusCode of the response, unset if no response was received.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Error the attempt failed with, truncated. Unset if the attempt succeeded.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// MultiStatusResult reports the per-item outcome of a 207 Multi-Status response.
+type MultiStatusResult struct {
+	// Total is the number of items in the response.
+	Total int `json:"total"`
+
+	// Succeeded is the number of items that succeeded.
+	Succeeded int `json:"succeeded"`
+
+	// FailedItems are the indexes of the items that did not succeed.
+	// +optional
+	FailedItems []int `json:"failedItems,omitempty"`
+}
+
+// ItemResult reports the outcome of the request sent for an element of a forEach array.
+type ItemResult struct {
+	// Index is the position of the element in the array.
+	Index int `json:"index"`
+
+	// StatusCode is the status code of the response, if one was received.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Body is the body of the response.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Error describes why the request failed, empty if it succeeded.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// RequestHash is the hash of the request sent for the element, telling whether the element changed since.
+	// +optional
+	RequestHash string `json:"requestHash,omitempty"`
+}
+
+// Timing contains the latency breakdown of a single HTTP request, in milliseconds.
+type Timing struct {
+	// DNSMs is the time spent resolving the host name.
+	// +optional
+	DNSMs int64 `json:"dnsMs,omitempty"`
+
+	// ConnectMs is the time spent establishing the TCP connection.
+	// +optional
+	ConnectMs int64 `json:"connectMs,omitempty"`
+
+	// TLSHandshakeMs is the time spent performing the TLS handshake.
+	// +optional
+	TLSHandshakeMs int64 `json:"tlsHandshakeMs,omitempty"`
+
+	// TTFBMs is the time from sending the request until the first response byte was received.
+	// +optional
+	TTFBMs int64 `json:"ttfbMs,omitempty"`
+
+	// TotalMs is the total time spent on the request, including reading the response body.
+	// +optional
+	TotalMs int64 `json:"totalMs,omitempty"`
 }
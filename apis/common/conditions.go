@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TypeFailed resources have permanently failed and are not retried anymore.
+const TypeFailed xpv1.ConditionType = "Failed"
+
+// Reasons a resource has or has not permanently failed.
+const (
+	ReasonRetriesExhausted xpv1.ConditionReason = "RetriesExhausted"
+	ReasonRetryForced      xpv1.ConditionReason = "RetryForced"
+	ReasonDNSFailure       xpv1.ConditionReason = "DNSFailure"
+)
+
+// TypeDryRun resources render their requests without sending them.
+const TypeDryRun xpv1.ConditionType = "DryRun"
+
+// ReasonRendered indicates that the request was rendered without being sent.
+const ReasonRendered xpv1.ConditionReason = "Rendered"
+
+// ReasonStabilizing indicates that a resource was not observed successfully enough times in a row to be ready.
+const ReasonStabilizing xpv1.ConditionReason = "Stabilizing"
+
+// ReasonWaitingForDependencies indicates that a resource waits for its dependencies to be ready before it is created.
+const ReasonWaitingForDependencies xpv1.ConditionReason = "WaitingForDependencies"
+
+// AnnotationKeyForceRetryAfter is the annotation holding an RFC 3339 time after which a permanently failed
+// DisposableRequest is retried again.
+const AnnotationKeyForceRetryAfter = "http.crossplane.io/force-retry-after"
+
+// AnnotationKeyRerunRequestedAt is the annotation holding the RFC 3339 time a DisposableRequest was triggered at. It is
+// sent again if it was not sent since.
+const AnnotationKeyRerunRequestedAt = "http.crossplane.io/rerun-requested-at"
+
+// AnnotationKeyRefreshRequestedAt is the annotation holding the RFC 3339 time a refresh of a Request was requested
+// at. Its OBSERVE request is sent even if its cached response is fresh, unless the response was received since.
+const AnnotationKeyRefreshRequestedAt = "http.crossplane.io/refresh-requested-at"
+
+// RetriesExhausted returns a condition indicating that the resource failed and is not retried anymore.
+func RetriesExhausted(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetriesExhausted,
+		Message:            message,
+	}
+}
+
+// DNSFailure returns a condition indicating that the resource failed because the host of its URL does not resolve,
+// and is not retried anymore.
+func DNSFailure(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDNSFailure,
+		Message:            message,
+	}
+}
+
+// RetryForced returns a condition indicating that a permanently failed resource is retried again.
+func RetryForced() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetryForced,
+	}
+}
+
+// DryRun returns a condition indicating that the request of the resource was rendered into its status without
+// being sent.
+func DryRun() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDryRun,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRendered,
+		Message:            "The request was rendered into status.requestDetails without being sent",
+	}
+}
+
+// Stabilizing returns a condition indicating that the resource is not ready until it was successfully observed the
+// required number of times in a row.
+func Stabilizing(successes, required int32) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonStabilizing,
+		Message:            fmt.Sprintf("%d of %d consecutive successful observations", successes, required),
+	}
+}
+
+// WaitingForDependencies returns a condition indicating that the resource is not created until its dependencies are
+// ready.
+func WaitingForDependencies(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForDependencies,
+		Message:            message,
+	}
+}
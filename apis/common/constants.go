@@ -29,3 +29,66 @@ const (
 	ExpectedResponseCheckTypeDefault = "DEFAULT"
 	ExpectedResponseCheckTypeCustom  = "CUSTOM"
 )
+
+// ResponseCheckCombinator constants define how the sub-checks of a response check are combined
+const (
+	ResponseCheckCombinatorAll = "all"
+	ResponseCheckCombinatorAny = "any"
+)
+
+// IPFamily constants define the address family used when connecting to a server
+const (
+	IPFamilyIPv4       = "IPv4"
+	IPFamilyIPv6       = "IPv6"
+	IPFamilyPreferIPv4 = "PreferIPv4"
+	IPFamilyPreferIPv6 = "PreferIPv6"
+)
+
+// Protocol constants define the HTTP protocol versions used to send requests
+const (
+	ProtocolAuto  = "auto"
+	ProtocolHTTP1 = "http1"
+	ProtocolH2    = "h2"
+	ProtocolH2C   = "h2c"
+)
+
+// ResponseTransformScope constants define what operates on the transformed body of a response
+const (
+	ResponseTransformScopeStatus   = "Status"
+	ResponseTransformScopeResponse = "Response"
+)
+
+// ResponseFormat constants define the format of response bodies
+const (
+	ResponseFormatJSON = "json"
+	ResponseFormatXML  = "xml"
+	ResponseFormatText = "text"
+)
+
+// BodyFormat constants define how the templated body of a mapping is serialized
+const (
+	BodyFormatJSON           = "json"
+	BodyFormatNDJSON         = "ndjson"
+	BodyFormatAutoMergePatch = "autoMergePatch"
+)
+
+// HMACAlgorithm constants define the hash function of request HMAC signatures
+const (
+	HMACAlgorithmSHA256 = "SHA256"
+	HMACAlgorithmSHA512 = "SHA512"
+)
+
+// HMACPayload constants define what the request HMAC signature is computed over
+const (
+	// HMACPayloadBody signs the request body.
+	HMACPayloadBody = "Body"
+	// HMACPayloadMethodPathBody signs the method, path with query and body of the request, separated by newlines.
+	HMACPayloadMethodPathBody = "MethodPathBody"
+)
+
+// TruncatedHeadersMarker is the header added to the response headers stored in status.response when some of them
+// were left out, holding the number of values left out
+const TruncatedHeadersMarker = "X-Provider-Http-Truncated"
+
+// DefaultIdempotencyKeyHeader is the header carrying the idempotency key of CREATE requests by default
+const DefaultIdempotencyKeyHeader = "Idempotency-Key"
@@ -30,6 +30,32 @@ type SecretInjectionConfig struct {
 
 	// SetOwnerReference determines whether to set the owner reference on the Kubernetes secret.
 	SetOwnerReference bool `json:"setOwnerReference,omitempty"`
+
+	// When is a jq predicate evaluated against the response, e.g. '.statusCode == 201' to only write a token
+	// when it is newly issued. The secret is left untouched when it evaluates to false, so it is not rewritten
+	// on every poll. Values of the response already held by the secret are still replaced by placeholders.
+	// +optional
+	When string `json:"when,omitempty"`
+
+	// Pagination, when set, follows the pages of a list response and aggregates the arrays extracted by the
+	// responseJQ of every key mapping across all pages, before writing them as a JSON array.
+	// Pages are only followed for Requests, a DisposableRequest aggregates its response only.
+	// +optional
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination configures following the pages of a list response.
+type Pagination struct {
+	// NextURLJQ is a jq filter extracting the URL of the next page from a response, e.g. '.body.next'.
+	// A relative URL is resolved against the URL of the current page. An empty or missing URL ends pagination.
+	NextURLJQ string `json:"nextURLJQ"`
+
+	// MaxPages bounds the number of pages fetched, including the first one.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	MaxPages int `json:"maxPages,omitempty"`
 }
 
 // MissingFieldStrategy defines how to handle missing fields in the response
@@ -45,12 +71,18 @@ const (
 )
 
 // KeyInjection represents the configuration for injecting data into a specific key in a Kubernetes secret.
+// +kubebuilder:validation:XValidation:rule="has(self.responseJQ) != has(self.fromHeader)",message="exactly one of responseJQ and fromHeader must be set"
 type KeyInjection struct {
 	// SecretKey is the key within the Kubernetes secret where the data will be injected.
 	SecretKey string `json:"secretKey"`
 
 	// ResponseJQ is a jq filter expression representing the path in the response where the secret value will be extracted from.
-	ResponseJQ string `json:"responseJQ"`
+	// +optional
+	ResponseJQ string `json:"responseJQ,omitempty"`
+
+	// FromHeader extracts the secret value from a response header instead, e.g. a token issued in X-Api-Token.
+	// +optional
+	FromHeader *HeaderSource `json:"fromHeader,omitempty"`
 
 	// MissingFieldStrategy determines how to handle cases where the field is missing from the response.
 	// Possible values are:
@@ -62,6 +94,17 @@ type KeyInjection struct {
 	MissingFieldStrategy MissingFieldStrategy `json:"missingFieldStrategy,omitempty"`
 }
 
+// HeaderSource selects the value of a response header.
+type HeaderSource struct {
+	// Name is the name of the response header, matched case-insensitively.
+	Name string `json:"name"`
+
+	// Join, when set, joins all the values of a header sent several times with this separator, instead of
+	// taking its first value.
+	// +optional
+	Join string `json:"join,omitempty"`
+}
+
 // Metadata contains labels and annotations to apply to a Kubernetes secret.
 type Metadata struct {
 	// Labels contains key-value pairs to apply as labels to the Kubernetes secret.
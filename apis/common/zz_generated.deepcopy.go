@@ -22,11 +22,209 @@ package common
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Attempt) DeepCopyInto(out *Attempt) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Attempt.
+func (in *Attempt) DeepCopy() *Attempt {
+	if in == nil {
+		return nil
+	}
+	out := new(Attempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodySource) DeepCopyInto(out *BodySource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodySource.
+func (in *BodySource) DeepCopy() *BodySource {
+	if in == nil {
+		return nil
+	}
+	out := new(BodySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerConfig) DeepCopyInto(out *CircuitBreakerConfig) {
+	*out = *in
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerConfig.
+func (in *CircuitBreakerConfig) DeepCopy() *CircuitBreakerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComparisonConfig) DeepCopyInto(out *ComparisonConfig) {
+	*out = *in
+	if in.UnorderedArrays != nil {
+		in, out := &in.UnorderedArrays, &out.UnorderedArrays
+		*out = make([]UnorderedArray, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComparisonConfig.
+func (in *ComparisonConfig) DeepCopy() *ComparisonConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComparisonConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsEndpointConfig) DeepCopyInto(out *CredentialsEndpointConfig) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsEndpointConfig.
+func (in *CredentialsEndpointConfig) DeepCopy() *CredentialsEndpointConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsEndpointConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDiffConfig) DeepCopyInto(out *DriftDiffConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDiffConfig.
+func (in *DriftDiffConfig) DeepCopy() *DriftDiffConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDiffConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldDiff) DeepCopyInto(out *FieldDiff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldDiff.
+func (in *FieldDiff) DeepCopy() *FieldDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACSigningConfig) DeepCopyInto(out *HMACSigningConfig) {
+	*out = *in
+	out.KeySecretRef = in.KeySecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACSigningConfig.
+func (in *HMACSigningConfig) DeepCopy() *HMACSigningConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACSigningConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderSource) DeepCopyInto(out *HeaderSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderSource.
+func (in *HeaderSource) DeepCopy() *HeaderSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemResult) DeepCopyInto(out *ItemResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemResult.
+func (in *ItemResult) DeepCopy() *ItemResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyInjection) DeepCopyInto(out *KeyInjection) {
 	*out = *in
+	if in.FromHeader != nil {
+		in, out := &in.FromHeader, &out.FromHeader
+		*out = new(HeaderSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyInjection.
@@ -68,6 +266,140 @@ func (in *Metadata) DeepCopy() *Metadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStatusResult) DeepCopyInto(out *MultiStatusResult) {
+	*out = *in
+	if in.FailedItems != nil {
+		in, out := &in.FailedItems, &out.FailedItems
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStatusResult.
+func (in *MultiStatusResult) DeepCopy() *MultiStatusResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStatusResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NTLMConfig) DeepCopyInto(out *NTLMConfig) {
+	*out = *in
+	out.UsernameSecretRef = in.UsernameSecretRef
+	out.PasswordSecretRef = in.PasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NTLMConfig.
+func (in *NTLMConfig) DeepCopy() *NTLMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NTLMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2Config) DeepCopyInto(out *OAuth2Config) {
+	*out = *in
+	out.ClientIDSecretRef = in.ClientIDSecretRef
+	out.ClientSecretSecretRef = in.ClientSecretSecretRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2Config.
+func (in *OAuth2Config) DeepCopy() *OAuth2Config {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pagination) DeepCopyInto(out *Pagination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pagination.
+func (in *Pagination) DeepCopy() *Pagination {
+	if in == nil {
+		return nil
+	}
+	out := new(Pagination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseSchema) DeepCopyInto(out *ResponseSchema) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseSchema.
+func (in *ResponseSchema) DeepCopy() *ResponseSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseTransformConfig) DeepCopyInto(out *ResponseTransformConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseTransformConfig.
+func (in *ResponseTransformConfig) DeepCopy() *ResponseTransformConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseTransformConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSRFGuardConfig) DeepCopyInto(out *SSRFGuardConfig) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSRFGuardConfig.
+func (in *SSRFGuardConfig) DeepCopy() *SSRFGuardConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SSRFGuardConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretInjectionConfig) DeepCopyInto(out *SecretInjectionConfig) {
 	*out = *in
@@ -75,9 +407,16 @@ func (in *SecretInjectionConfig) DeepCopyInto(out *SecretInjectionConfig) {
 	if in.KeyMappings != nil {
 		in, out := &in.KeyMappings, &out.KeyMappings
 		*out = make([]KeyInjection, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.Pagination != nil {
+		in, out := &in.Pagination, &out.Pagination
+		*out = new(Pagination)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretInjectionConfig.
@@ -105,6 +444,59 @@ func (in *SecretRef) DeepCopy() *SecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SigV4Config) DeepCopyInto(out *SigV4Config) {
+	*out = *in
+	out.AccessKeyIDSecretRef = in.AccessKeyIDSecretRef
+	out.SecretAccessKeySecretRef = in.SecretAccessKeySecretRef
+	if in.SessionTokenSecretRef != nil {
+		in, out := &in.SessionTokenSecretRef, &out.SessionTokenSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SigV4Config.
+func (in *SigV4Config) DeepCopy() *SigV4Config {
+	if in == nil {
+		return nil
+	}
+	out := new(SigV4Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StoredHeadersConfig) DeepCopyInto(out *StoredHeadersConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoredHeadersConfig.
+func (in *StoredHeadersConfig) DeepCopy() *StoredHeadersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StoredHeadersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamConfig) DeepCopyInto(out *StreamConfig) {
+	*out = *in
+	out.MaxDuration = in.MaxDuration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamConfig.
+func (in *StreamConfig) DeepCopy() *StreamConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -128,6 +520,11 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 		*out = new(v1.SecretKeySelector)
 		**out = **in
 	}
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
@@ -139,3 +536,79 @@ func (in *TLSConfig) DeepCopy() *TLSConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSHostPolicy) DeepCopyInto(out *TLSHostPolicy) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSHostPolicy.
+func (in *TLSHostPolicy) DeepCopy() *TLSHostPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSHostPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Timing) DeepCopyInto(out *Timing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timing.
+func (in *Timing) DeepCopy() *Timing {
+	if in == nil {
+		return nil
+	}
+	out := new(Timing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnorderedArray) DeepCopyInto(out *UnorderedArray) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnorderedArray.
+func (in *UnorderedArray) DeepCopy() *UnorderedArray {
+	if in == nil {
+		return nil
+	}
+	out := new(UnorderedArray)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForReadyConfig) DeepCopyInto(out *WaitForReadyConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	out.Deadline = in.Deadline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForReadyConfig.
+func (in *WaitForReadyConfig) DeepCopy() *WaitForReadyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForReadyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
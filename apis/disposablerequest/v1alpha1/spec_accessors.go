@@ -75,6 +75,12 @@ func (d *DisposableRequestParameters) GetRollbackRetriesLimit() *int32 {
 	return d.RollbackRetriesLimit
 }
 
+// GetRetryableStatusCodes returns the HTTP error status codes that are retried.
+// v1alpha1 does not support retryable status codes, so this returns nil.
+func (d *DisposableRequestParameters) GetRetryableStatusCodes() []string {
+	return nil
+}
+
 // Ensure Response implements HTTPResponse
 var _ interfaces.HTTPResponse = (*Response)(nil)
 
@@ -92,3 +98,15 @@ func (r *Response) GetBody() string {
 func (r *Response) GetHeaders() map[string][]string {
 	return r.Headers
 }
+
+// GetTrailers returns the response trailers.
+// v1alpha1 does not support trailers, so this returns nil.
+func (r *Response) GetTrailers() map[string][]string {
+	return nil
+}
+
+// GetStatusText returns the reason phrase of the response status line.
+// v1alpha1 does not record it, so this returns an empty string.
+func (r *Response) GetStatusText() string {
+	return ""
+}
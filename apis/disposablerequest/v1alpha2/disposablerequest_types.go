@@ -41,9 +41,29 @@ type DisposableRequestParameters struct {
 	// WaitTimeout specifies the maximum time duration for waiting.
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
+	// Deadline caps the whole sequence of attempts of the request of the current generation, the waits between
+	// retries included, measured from when it was first sent. The timeout of an attempt is cut down to the time
+	// left, and a failed request is not retried anymore once it passed, or when the delay requested by the
+	// Retry-After header of its last response ends after it.
+	// +optional
+	Deadline *metav1.Duration `json:"deadline,omitempty"`
+
 	// RollbackRetriesLimit is max number of attempts to retry HTTP request by sending again the request.
 	RollbackRetriesLimit *int32 `json:"rollbackRetriesLimit,omitempty"`
 
+	// RetryableStatusCodes lists the HTTP error status codes that are retried, either single codes or inclusive ranges.
+	// Any other error status code is a terminal failure that stops retrying immediately.
+	// If empty, every error status code is retried.
+	// Example: ['429', '500-599']
+	// +kubebuilder:validation:items:Pattern=`^[1-5][0-9]{2}(-[1-5][0-9]{2})?$`
+	// +optional
+	RetryableStatusCodes []string `json:"retryableStatusCodes,omitempty"`
+
+	// RetryDNSFailures retries the request when the host of its URL does not resolve. By default, such a failure
+	// stops retrying immediately with a DNSFailure reason, since it usually comes from a mistyped URL.
+	// +optional
+	RetryDNSFailures bool `json:"retryDNSFailures,omitempty"`
+
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request.
 	// This field is mutually exclusive with TLSConfig.
 	// +optional
@@ -54,19 +74,149 @@ type DisposableRequestParameters struct {
 	// +optional
 	TLSConfig *common.TLSConfig `json:"tlsConfig,omitempty"`
 
+	// HMACSigning signs the requests with an HMAC of their body, or canonical form, placed in a header,
+	// e.g. for webhook targets verifying the signature with a shared key.
+	// +optional
+	HMACSigning *common.HMACSigningConfig `json:"hmacSigning,omitempty"`
+
+	// ResponseFormat is the format of the response bodies. With xml, XML bodies are converted to JSON when they
+	// are received, so jq filters, checks and templates work against them: elements become keys, attributes
+	// keys prefixed with @, the text of elements with attributes or children the #text key, and repeated elements
+	// arrays. The converted body is stored in the status. With text, the body is never parsed as JSON: checks
+	// compare it as a raw string, also exposed as bodyText. Defaults to json.
+	// +kubebuilder:validation:Enum=json;xml;text
+	// +optional
+	ResponseFormat string `json:"responseFormat,omitempty"`
+
+	// UserAgent is sent in the User-Agent header of the requests that do not set this header. Defaults to
+	// provider-http/<version>.
+	// +optional
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// Protocol is the HTTP protocol version the requests are sent with. auto and http1 use HTTP/1.1, as the
+	// requests always did, h2 requires HTTP/2 over TLS, and h2c sends cleartext requests with HTTP/2 prior
+	// knowledge, e.g. to a gRPC gateway, and TLS requests with HTTP/2. Defaults to the protocol of the provider config.
+	// +kubebuilder:validation:Enum=auto;http1;h2;h2c
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// ExpectContinueTimeout sends the requests bearing a body with an Expect: 100-continue header, and waits up to
+	// this long for the interim response of the server before sending the body. A final response received first,
+	// e.g. a 401, completes the request without sending the body, which saves streaming a large upload the server
+	// rejects. A server not answering in time receives the body anyway. Disabled when unset.
+	// +optional
+	ExpectContinueTimeout *metav1.Duration `json:"expectContinueTimeout,omitempty"`
+
 	// ExpectedResponse is a jq filter expression used to evaluate the HTTP response and determine if it matches the expected criteria.
 	// The expression should return a boolean; if true, the response is considered expected.
 	// Example: '.body.job_status == "success"'
 	ExpectedResponse string `json:"expectedResponse,omitempty"`
 
+	// ContinuationJQ is a jq filter extracting the continuation token of the next page from every expected
+	// response, evaluated against the same input as ExpectedResponse, e.g. '.body.next_cursor'. The token is
+	// stored in status.continuation and replaces the {{ .continuation }} placeholders of the url, body and
+	// headers of the next request. While it is not empty or null, the request is sent again on every reconcile,
+	// so a queue endpoint is drained page by page.
+	// +optional
+	ContinuationJQ string `json:"continuationJQ,omitempty"`
+
+	// ExpectedContentType is the media type the response Content-Type must match before ExpectedResponse is evaluated.
+	// Parameters such as charset are ignored. A mismatching response is treated as a failed attempt.
+	// Example: 'application/json'
+	// +optional
+	ExpectedContentType string `json:"expectedContentType,omitempty"`
+
+	// MaxBodyBytes is the maximum size of the response body, in bytes, that ExpectedResponse is evaluated against.
+	// A larger response is treated as a failed attempt, and its body is stored truncated to the limit.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBodyBytes *int64 `json:"maxBodyBytes,omitempty"`
+
+	// ResponseSchema is a JSON Schema the body of a successful response must match before ExpectedResponse is
+	// evaluated, e.g. to catch a drift of the contract of the API early. A mismatching response is treated as a
+	// failed attempt, with an error naming the failing path.
+	// +optional
+	ResponseSchema *common.ResponseSchema `json:"responseSchema,omitempty"`
+
+	// StoredHeaders bounds the response headers and trailers stored in status.response, e.g. to raise the limits
+	// for an API answering with many headers. Only status.response is bounded: the response is processed with
+	// all its headers, and the responses read back from the status, e.g. the cached response, keep them all.
+	// +optional
+	StoredHeaders *common.StoredHeadersConfig `json:"storedHeaders,omitempty"`
+
+	// MultiStatus configures how 207 Multi-Status responses are evaluated item by item.
+	// When unset, a 207 response is handled like any other successful response.
+	// +optional
+	MultiStatus *MultiStatusCheck `json:"multiStatus,omitempty"`
+
+	// ServerSentEvents consumes the response as a stream of server-sent events, e.g. to wait for the completion
+	// event of a job instead of polling it. ExpectedResponse is evaluated against every event, with its data as
+	// the body, until one matches, and the matching event becomes the response.
+	// +optional
+	ServerSentEvents *ServerSentEvents `json:"serverSentEvents,omitempty"`
+
 	// NextReconcile specifies the duration after which the next reconcile should occur.
 	NextReconcile *metav1.Duration `json:"nextReconcile,omitempty"`
 
+	// NextReconcileJitter adds a random delay of up to this duration to every NextReconcile, so the loops of
+	// DisposableRequests created together do not stay synchronized.
+	// +optional
+	NextReconcileJitter *metav1.Duration `json:"nextReconcileJitter,omitempty"`
+
+	// MaxFailureBackoff makes a failing request back off instead of being retried at once: it is sent again after
+	// NextReconcile, doubled for each consecutive failure after the first one, up to this duration. The cadence
+	// returns to NextReconcile once the request succeeds. Requires NextReconcile.
+	// +optional
+	MaxFailureBackoff *metav1.Duration `json:"maxFailureBackoff,omitempty"`
+
+	// MaxRetryAfter caps the delay the Retry-After header of a 429 or 503 response asks to wait before the
+	// request is sent again, instead of the usual requeue. Defaults to 10m.
+	// +optional
+	MaxRetryAfter *metav1.Duration `json:"maxRetryAfter,omitempty"`
+
 	// ShouldLoopInfinitely specifies whether the reconciliation should loop indefinitely.
 	ShouldLoopInfinitely bool `json:"shouldLoopInfinitely,omitempty"`
 
 	// SecretInjectionConfig specifies the secrets receiving patches from response data.
 	SecretInjectionConfigs []common.SecretInjectionConfig `json:"secretInjectionConfigs,omitempty"`
+
+	// Trigger allows an external system to re-run the request on demand through the trigger endpoint of the
+	// provider, instead of waiting for the next reconcile.
+	// +optional
+	Trigger *Trigger `json:"trigger,omitempty"`
+}
+
+// Trigger configures re-running a DisposableRequest on demand.
+type Trigger struct {
+	// TokenSecretRef is a reference to a secret key containing the token a trigger must present. The request is
+	// triggered through the namespace of this secret only.
+	TokenSecretRef xpv1.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// MultiStatusCheck defines how the items of a 207 Multi-Status response are evaluated.
+type MultiStatusCheck struct {
+	// Items is a jq filter selecting the array of per-item results from the response.
+	// XML bodies are converted to objects first, with attributes prefixed by '@' and element text under '#text'.
+	// Example: '.body.multistatus.response'
+	Items string `json:"items"`
+
+	// ItemSucceeded is a jq filter evaluated on each item, returning true if the item succeeded.
+	// Example: '.status | test("HTTP/1.1 2")'
+	ItemSucceeded string `json:"itemSucceeded"`
+}
+
+// ServerSentEvents configures consuming a response as a stream of server-sent events.
+type ServerSentEvents struct {
+	// MaxDuration is the maximum time events are waited for, reconnections included. It replaces the timeout of
+	// the request.
+	MaxDuration metav1.Duration `json:"maxDuration"`
+
+	// MaxReconnects is the maximum number of times the stream is reopened after it was interrupted before an event
+	// matched, sending the ID of the last received event in the Last-Event-ID header. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxReconnects *int32 `json:"maxReconnects,omitempty"`
 }
 
 // A DisposableRequestSpec defines the desired state of a DisposableRequest.
@@ -79,6 +229,18 @@ type Response struct {
 	StatusCode int                 `json:"statusCode,omitempty"`
 	Body       string              `json:"body,omitempty"`
 	Headers    map[string][]string `json:"headers,omitempty"`
+
+	// Trailers contains the HTTP trailers sent by the server after the response body.
+	// +optional
+	Trailers map[string][]string `json:"trailers,omitempty"`
+
+	// StatusText contains the reason phrase of the response status line, e.g. "Not Found".
+	// +optional
+	StatusText string `json:"statusText,omitempty"`
+
+	// Timing contains the latency breakdown of the request that produced this response.
+	// +optional
+	Timing *common.Timing `json:"timing,omitempty"`
 }
 
 type Mapping struct {
@@ -97,8 +259,31 @@ type DisposableRequestStatus struct {
 	Synced              bool     `json:"synced,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
 
+	// MultiStatus reports the per-item outcome of the last 207 Multi-Status response.
+	// +optional
+	MultiStatus *common.MultiStatusResult `json:"multiStatus,omitempty"`
+
 	// LastReconcileTime records the last time the resource was reconciled.
 	LastReconcileTime metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// RetryAfter is the delay, requested by the Retry-After header of the last response and capped by
+	// maxRetryAfter, waited from lastReconcileTime before the request is sent again.
+	// +optional
+	RetryAfter *metav1.Duration `json:"retryAfter,omitempty"`
+
+	// StartTime is when the request of the current generation of the resource was first sent. The seconds
+	// elapsed since then are exposed to expectedResponse as .elapsed.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// StartGeneration is the generation of the resource StartTime was recorded for.
+	// +optional
+	StartGeneration int64 `json:"startGeneration,omitempty"`
+
+	// Continuation is the continuation token extracted by continuationJQ from the last expected response, sent
+	// with the next request.
+	// +optional
+	Continuation string `json:"continuation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
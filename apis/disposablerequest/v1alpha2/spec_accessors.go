@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
@@ -32,6 +34,21 @@ var _ interfaces.ReconciliationPolicyAware = (*DisposableRequestParameters)(nil)
 // Ensure DisposableRequestParameters implements RollbackAware
 var _ interfaces.RollbackAware = (*DisposableRequestParameters)(nil)
 
+// Ensure DisposableRequestParameters implements ResponseGuardAware
+var _ interfaces.ResponseGuardAware = (*DisposableRequestParameters)(nil)
+
+// Ensure DisposableRequestParameters implements ServerSentEventsAware
+var _ interfaces.ServerSentEventsAware = (*DisposableRequestParameters)(nil)
+
+// Ensure DisposableRequestParameters implements RetryAfterAware
+var _ interfaces.RetryAfterAware = (*DisposableRequestParameters)(nil)
+
+// defaultServerSentEventsMaxReconnects is the number of times an interrupted event stream is reopened by default.
+const defaultServerSentEventsMaxReconnects = 3
+
+// defaultMaxRetryAfter is the maximum delay waited for the Retry-After header of a response by default.
+const defaultMaxRetryAfter = 10 * time.Minute
+
 // GetWaitTimeout returns the maximum time duration for waiting.
 func (d *DisposableRequestParameters) GetWaitTimeout() *metav1.Duration {
 	return d.WaitTimeout
@@ -72,6 +89,47 @@ func (d *DisposableRequestParameters) GetExpectedResponse() string {
 	return d.ExpectedResponse
 }
 
+// GetMaxRetryAfter returns the maximum delay waited for the Retry-After header of a response.
+func (d *DisposableRequestParameters) GetMaxRetryAfter() time.Duration {
+	if d.MaxRetryAfter == nil {
+		return defaultMaxRetryAfter
+	}
+	return d.MaxRetryAfter.Duration
+}
+
+// GetResponseFormat returns the format of the response bodies.
+func (d *DisposableRequestParameters) GetResponseFormat() string {
+	return d.ResponseFormat
+}
+
+// GetDeadline returns the maximum duration of the attempts of the request, or zero if it is not capped.
+func (d *DisposableRequestParameters) GetDeadline() time.Duration {
+	if d.Deadline == nil {
+		return 0
+	}
+	return d.Deadline.Duration
+}
+
+// GetRetryDNSFailures returns whether a request whose host does not resolve is retried.
+func (d *DisposableRequestParameters) GetRetryDNSFailures() bool {
+	return d.RetryDNSFailures
+}
+
+// GetContinuationJQ returns the jq filter extracting the continuation token from the responses, or an empty
+// string.
+func (d *DisposableRequestParameters) GetContinuationJQ() string {
+	return d.ContinuationJQ
+}
+
+// GetExpectContinueTimeout returns the time waited for the interim response before sending the body of a request,
+// or zero if the requests are sent without an Expect: 100-continue header.
+func (d *DisposableRequestParameters) GetExpectContinueTimeout() time.Duration {
+	if d.ExpectContinueTimeout == nil {
+		return 0
+	}
+	return d.ExpectContinueTimeout.Duration
+}
+
 // GetNextReconcile returns the duration after which the next reconcile should occur.
 func (d *DisposableRequestParameters) GetNextReconcile() *metav1.Duration {
 	return d.NextReconcile
@@ -87,6 +145,64 @@ func (d *DisposableRequestParameters) GetRollbackRetriesLimit() *int32 {
 	return d.RollbackRetriesLimit
 }
 
+// GetRetryableStatusCodes returns the HTTP error status codes that are retried.
+func (d *DisposableRequestParameters) GetRetryableStatusCodes() []string {
+	return d.RetryableStatusCodes
+}
+
+// GetExpectedContentType returns the media type the response must have.
+func (d *DisposableRequestParameters) GetExpectedContentType() string {
+	return d.ExpectedContentType
+}
+
+// GetMaxBodyBytes returns the maximum accepted response body size.
+func (d *DisposableRequestParameters) GetMaxBodyBytes() *int64 {
+	return d.MaxBodyBytes
+}
+
+// GetMultiStatusItems returns the jq filter selecting the items of a multi-status response.
+func (d *DisposableRequestParameters) GetMultiStatusItems() string {
+	if d.MultiStatus == nil {
+		return ""
+	}
+	return d.MultiStatus.Items
+}
+
+// GetMultiStatusItemSucceeded returns the jq filter checking if a single multi-status item succeeded.
+func (d *DisposableRequestParameters) GetMultiStatusItemSucceeded() string {
+	if d.MultiStatus == nil {
+		return ""
+	}
+	return d.MultiStatus.ItemSucceeded
+}
+
+// GetServerSentEventsMaxDuration returns the maximum time server-sent events are waited for, or nil if the
+// response is not consumed as server-sent events.
+func (d *DisposableRequestParameters) GetServerSentEventsMaxDuration() *metav1.Duration {
+	if d.ServerSentEvents == nil {
+		return nil
+	}
+	return &d.ServerSentEvents.MaxDuration
+}
+
+// GetServerSentEventsMaxReconnects returns the maximum number of times an interrupted event stream is reopened.
+func (d *DisposableRequestParameters) GetServerSentEventsMaxReconnects() int {
+	if d.ServerSentEvents == nil || d.ServerSentEvents.MaxReconnects == nil {
+		return defaultServerSentEventsMaxReconnects
+	}
+	return int(*d.ServerSentEvents.MaxReconnects)
+}
+
+// GetResponseSchema returns the JSON Schema the body of successful responses must match, or nil.
+func (d *DisposableRequestParameters) GetResponseSchema() *common.ResponseSchema {
+	return d.ResponseSchema
+}
+
+// GetStoredHeaders returns the bounds of the response headers stored in the status, or nil for the defaults.
+func (d *DisposableRequestParameters) GetStoredHeaders() *common.StoredHeadersConfig {
+	return d.StoredHeaders
+}
+
 // Ensure Response implements HTTPResponse
 var _ interfaces.HTTPResponse = (*Response)(nil)
 
@@ -105,6 +221,16 @@ func (r *Response) GetHeaders() map[string][]string {
 	return r.Headers
 }
 
+// GetTrailers returns the response trailers.
+func (r *Response) GetTrailers() map[string][]string {
+	return r.Trailers
+}
+
+// GetStatusText returns the reason phrase of the response status line.
+func (r *Response) GetStatusText() string {
+	return r.StatusText
+}
+
 // Ensure DisposableRequest implements CachedResponse
 var _ interfaces.CachedResponse = (*DisposableRequest)(nil)
 
@@ -131,6 +257,31 @@ func (d *DisposableRequest) GetResponse() interfaces.HTTPResponse {
 	return &d.Status.Response
 }
 
+// GetElapsed returns the time elapsed since the request of the current generation was first sent, or zero if it
+// was not sent yet.
+func (d *DisposableRequest) GetElapsed(now time.Time) time.Duration {
+	if d.Status.StartTime == nil || d.Status.StartGeneration != d.Generation {
+		return 0
+	}
+
+	return now.Sub(d.Status.StartTime.Time)
+}
+
+// GetRetryAfterWait returns the time left to wait before the request is sent again, as requested by the
+// Retry-After header of the last response, or zero if there is nothing to wait for.
+func (d *DisposableRequest) GetRetryAfterWait(now time.Time) time.Duration {
+	if d.Status.Synced || d.Status.RetryAfter == nil {
+		return 0
+	}
+
+	return max(d.Status.LastReconcileTime.Add(d.Status.RetryAfter.Duration).Sub(now), 0)
+}
+
+// GetContinuation returns the continuation token extracted from the last expected response, or an empty string.
+func (d *DisposableRequest) GetContinuation() string {
+	return d.Status.Continuation
+}
+
 // SetFailed sets the failure count.
 func (d *DisposableRequest) SetFailed(failed int32) {
 	d.Status.Failed = failed
@@ -170,3 +170,29 @@ func TestDisposableRequest_StatusAccessors(t *testing.T) {
 		t.Errorf("After SetFailed(5), GetFailed() = %v, want 5", got)
 	}
 }
+
+func TestDisposableRequest_Elapsed(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	d := &DisposableRequest{}
+	d.Generation = 1
+	if got := d.GetElapsed(start); got != 0 {
+		t.Errorf("GetElapsed() before the start time is recorded = %v, want 0", got)
+	}
+
+	d.SetStartTime(start)
+	d.SetStartTime(start.Add(time.Minute))
+	if got := d.GetElapsed(start.Add(5 * time.Minute)); got != 5*time.Minute {
+		t.Errorf("GetElapsed() = %v, want the time elapsed since the first start time 5m0s", got)
+	}
+
+	d.Generation = 2
+	if got := d.GetElapsed(start.Add(5 * time.Minute)); got != 0 {
+		t.Errorf("GetElapsed() after a spec change = %v, want 0", got)
+	}
+
+	d.SetStartTime(start.Add(10 * time.Minute))
+	if got := d.GetElapsed(start.Add(12 * time.Minute)); got != 2*time.Minute {
+		t.Errorf("GetElapsed() for the new generation = %v, want 2m0s", got)
+	}
+}
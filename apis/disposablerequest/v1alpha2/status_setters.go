@@ -4,6 +4,8 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
 )
 
 func (d *DisposableRequest) SetStatusCode(statusCode int) {
@@ -18,6 +20,22 @@ func (d *DisposableRequest) SetBody(body string) {
 	d.Status.Response.Body = body
 }
 
+func (d *DisposableRequest) SetTrailers(trailers map[string][]string) {
+	d.Status.Response.Trailers = trailers
+}
+
+func (d *DisposableRequest) SetStatusText(statusText string) {
+	d.Status.Response.StatusText = statusText
+}
+
+func (d *DisposableRequest) SetTiming(timing common.Timing) {
+	d.Status.Response.Timing = &timing
+}
+
+func (d *DisposableRequest) SetMultiStatus(result *common.MultiStatusResult) {
+	d.Status.MultiStatus = result
+}
+
 func (d *DisposableRequest) SetSynced(synced bool) {
 	d.Status.Synced = synced
 	d.Status.Failed = 0
@@ -28,6 +46,26 @@ func (d *DisposableRequest) SetLastReconcileTime() {
 	d.Status.LastReconcileTime = metav1.NewTime(time.Now())
 }
 
+func (d *DisposableRequest) SetRetryAfter(delay *metav1.Duration) {
+	d.Status.RetryAfter = delay
+}
+
+func (d *DisposableRequest) SetContinuation(token string) {
+	d.Status.Continuation = token
+}
+
+// SetStartTime records now as the time the request of the current generation was first sent, unless it is
+// already recorded for this generation.
+func (d *DisposableRequest) SetStartTime(now time.Time) {
+	if d.Status.StartTime != nil && d.Status.StartGeneration == d.Generation {
+		return
+	}
+
+	startTime := metav1.NewTime(now)
+	d.Status.StartTime = &startTime
+	d.Status.StartGeneration = d.Generation
+}
+
 func (d *DisposableRequest) SetError(err error) {
 	d.Status.Failed++
 	d.Status.Synced = false
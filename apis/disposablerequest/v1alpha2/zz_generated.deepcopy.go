@@ -109,21 +109,81 @@ func (in *DisposableRequestParameters) DeepCopyInto(out *DisposableRequestParame
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.RollbackRetriesLimit != nil {
 		in, out := &in.RollbackRetriesLimit, &out.RollbackRetriesLimit
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RetryableStatusCodes != nil {
+		in, out := &in.RetryableStatusCodes, &out.RetryableStatusCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TLSConfig != nil {
 		in, out := &in.TLSConfig, &out.TLSConfig
 		*out = new(common.TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HMACSigning != nil {
+		in, out := &in.HMACSigning, &out.HMACSigning
+		*out = new(common.HMACSigningConfig)
+		**out = **in
+	}
+	if in.ExpectContinueTimeout != nil {
+		in, out := &in.ExpectContinueTimeout, &out.ExpectContinueTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBodyBytes != nil {
+		in, out := &in.MaxBodyBytes, &out.MaxBodyBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResponseSchema != nil {
+		in, out := &in.ResponseSchema, &out.ResponseSchema
+		*out = new(common.ResponseSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StoredHeaders != nil {
+		in, out := &in.StoredHeaders, &out.StoredHeaders
+		*out = new(common.StoredHeadersConfig)
+		**out = **in
+	}
+	if in.MultiStatus != nil {
+		in, out := &in.MultiStatus, &out.MultiStatus
+		*out = new(MultiStatusCheck)
+		**out = **in
+	}
+	if in.ServerSentEvents != nil {
+		in, out := &in.ServerSentEvents, &out.ServerSentEvents
+		*out = new(ServerSentEvents)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NextReconcile != nil {
 		in, out := &in.NextReconcile, &out.NextReconcile
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.NextReconcileJitter != nil {
+		in, out := &in.NextReconcileJitter, &out.NextReconcileJitter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxFailureBackoff != nil {
+		in, out := &in.MaxFailureBackoff, &out.MaxFailureBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetryAfter != nil {
+		in, out := &in.MaxRetryAfter, &out.MaxRetryAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.SecretInjectionConfigs != nil {
 		in, out := &in.SecretInjectionConfigs, &out.SecretInjectionConfigs
 		*out = make([]common.SecretInjectionConfig, len(*in))
@@ -131,6 +191,11 @@ func (in *DisposableRequestParameters) DeepCopyInto(out *DisposableRequestParame
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(Trigger)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequestParameters.
@@ -166,7 +231,21 @@ func (in *DisposableRequestStatus) DeepCopyInto(out *DisposableRequestStatus) {
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.Response.DeepCopyInto(&out.Response)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	if in.MultiStatus != nil {
+		in, out := &in.MultiStatus, &out.MultiStatus
+		*out = new(common.MultiStatusResult)
+		(*in).DeepCopyInto(*out)
+	}
 	in.LastReconcileTime.DeepCopyInto(&out.LastReconcileTime)
+	if in.RetryAfter != nil {
+		in, out := &in.RetryAfter, &out.RetryAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequestStatus.
@@ -210,6 +289,21 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiStatusCheck) DeepCopyInto(out *MultiStatusCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiStatusCheck.
+func (in *MultiStatusCheck) DeepCopy() *MultiStatusCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiStatusCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Response) DeepCopyInto(out *Response) {
 	*out = *in
@@ -229,6 +323,27 @@ func (in *Response) DeepCopyInto(out *Response) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Trailers != nil {
+		in, out := &in.Trailers, &out.Trailers
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Timing != nil {
+		in, out := &in.Timing, &out.Timing
+		*out = new(common.Timing)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Response.
@@ -240,3 +355,40 @@ func (in *Response) DeepCopy() *Response {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerSentEvents) DeepCopyInto(out *ServerSentEvents) {
+	*out = *in
+	out.MaxDuration = in.MaxDuration
+	if in.MaxReconnects != nil {
+		in, out := &in.MaxReconnects, &out.MaxReconnects
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerSentEvents.
+func (in *ServerSentEvents) DeepCopy() *ServerSentEvents {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerSentEvents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trigger) DeepCopyInto(out *Trigger) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trigger.
+func (in *Trigger) DeepCopy() *Trigger {
+	if in == nil {
+		return nil
+	}
+	out := new(Trigger)
+	in.DeepCopyInto(out)
+	return out
+}
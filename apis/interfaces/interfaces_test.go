@@ -68,6 +68,159 @@ func TestV1Alpha2SpecificInterfaces(t *testing.T) {
 	// Test v1alpha1.DisposableRequestParameters implements RollbackAware
 	var _ interfaces.RollbackAware = (*disposablerequestv1alpha1.DisposableRequestParameters)(nil)
 
+	// Test v1alpha2.DisposableRequestParameters implements MultiStatusAware
+	var _ interfaces.MultiStatusAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements ResponseSchemaAware
+	var _ interfaces.ResponseSchemaAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements ResponseSchemaAware
+	var _ interfaces.ResponseSchemaAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements ExternalNameAware
+	var _ interfaces.ExternalNameAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements RetryAfterAware
+	var _ interfaces.RetryAfterAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequest implements RetryAfterWriter
+	var _ interfaces.RetryAfterWriter = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+
+	// Test v1alpha2.DisposableRequest implements StartTimeWriter and ElapsedReader
+	var _ interfaces.StartTimeWriter = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+	var _ interfaces.ElapsedReader = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+
+	// Test v1alpha2.RequestParameters and v1alpha2.DisposableRequestParameters implement ResponseFormatAware
+	var _ interfaces.ResponseFormatAware = (*requestv1alpha2.RequestParameters)(nil)
+	var _ interfaces.ResponseFormatAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements DeadlineAware
+	var _ interfaces.DeadlineAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements DNSFailureRetryAware
+	var _ interfaces.DNSFailureRetryAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements ContinuationAware
+	var _ interfaces.ContinuationAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequest implements ContinuationWriter and ContinuationReader
+	var _ interfaces.ContinuationWriter = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+	var _ interfaces.ContinuationReader = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+
+	// Test v1alpha2.DisposableRequest implements RetryAfterReader
+	var _ interfaces.RetryAfterReader = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements ServerSentEventsAware
+	var _ interfaces.ServerSentEventsAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequest implements MultiStatusWriter
+	var _ interfaces.MultiStatusWriter = (*disposablerequestv1alpha2.DisposableRequest)(nil)
+
+	// Test v1alpha2.RequestParameters implements ObservePolicyAware
+	var _ interfaces.ObservePolicyAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements ConditionalObserveAware
+	var _ interfaces.ConditionalObserveAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.Request implements ResponseCacheReader
+	var _ interfaces.ResponseCacheReader = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.RequestParameters implements BodyDenylistAware
+	var _ interfaces.BodyDenylistAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.Mapping implements BodySourceAware
+	var _ interfaces.BodySourceAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.Mapping implements BodyFormatAware
+	var _ interfaces.BodyFormatAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.RequestParameters implements IdempotencyKeyAware
+	var _ interfaces.IdempotencyKeyAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements SuccessConditionAware
+	var _ interfaces.SuccessConditionAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements StatusExtractionsAware
+	var _ interfaces.StatusExtractionsAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements RequestRefsAware
+	var _ interfaces.RequestRefsAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements SecretRefsAware
+	var _ interfaces.SecretRefsAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements HeaderOptionsAware
+	var _ interfaces.HeaderOptionsAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.Mapping implements MappingTimeoutAware
+	var _ interfaces.MappingTimeoutAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.Mapping implements MappingStreamAware
+	var _ interfaces.MappingStreamAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.Mapping implements MappingWaitForReadyAware
+	var _ interfaces.MappingWaitForReadyAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.Mapping implements MappingConditionAware
+	var _ interfaces.MappingConditionAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.Mapping implements MappingForEachAware
+	var _ interfaces.MappingForEachAware = (*requestv1alpha2.Mapping)(nil)
+
+	// Test v1alpha2.Request implements ItemsWriter
+	var _ interfaces.ItemsWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.ExpectedResponseCheck implements CombinedResponseCheck
+	var _ interfaces.CombinedResponseCheck = (*requestv1alpha2.ExpectedResponseCheck)(nil)
+
+	// Test v1alpha2.Request implements FailedCheckWriter
+	var _ interfaces.FailedCheckWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.Request implements ConsecutiveSuccessesWriter
+	var _ interfaces.ConsecutiveSuccessesWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.Request implements CacheObservedGenerationWriter
+	var _ interfaces.CacheObservedGenerationWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.Request implements HistoryWriter
+	var _ interfaces.HistoryWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.Request implements LastSuccessfulResponseReader
+	var _ interfaces.LastSuccessfulResponseReader = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.Request implements LastSuccessfulResponseWriter
+	var _ interfaces.LastSuccessfulResponseWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.Request implements ResponseJSONWriter
+	var _ interfaces.ResponseJSONWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.RequestParameters implements ResponseTransformAware
+	var _ interfaces.ResponseTransformAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements StoredHeadersAware
+	var _ interfaces.StoredHeadersAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.DisposableRequestParameters implements StoredHeadersAware
+	var _ interfaces.StoredHeadersAware = (*disposablerequestv1alpha2.DisposableRequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements ComparisonAware
+	var _ interfaces.ComparisonAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements DriftDiffAware
+	var _ interfaces.DriftDiffAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.Request implements DriftWriter
+	var _ interfaces.DriftWriter = (*requestv1alpha2.Request)(nil)
+
+	// Test v1alpha2.RequestParameters implements ResponseJSONAware
+	var _ interfaces.ResponseJSONAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.RequestParameters implements LastSuccessfulResponseAware
+	var _ interfaces.LastSuccessfulResponseAware = (*requestv1alpha2.RequestParameters)(nil)
+
+	// Test v1alpha2.Request implements ExtractedWriter
+	var _ interfaces.ExtractedWriter = (*requestv1alpha2.Request)(nil)
+
 	// Test v1alpha2.Request implements RequestStatus
 	var _ interfaces.RequestStatus = (*requestv1alpha2.Request)(nil)
 
@@ -17,7 +17,11 @@ limitations under the License.
 package interfaces
 
 import (
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
@@ -91,6 +95,56 @@ type HTTPMapping interface {
 	GetHeaders() map[string][]string
 }
 
+// BodySourceAware indicates that a mapping supports loading its body from a Secret or ConfigMap key.
+// This is a v1alpha2 Request-specific feature.
+type BodySourceAware interface {
+	// GetBodyFrom returns the Secret or ConfigMap key the body is loaded from, or nil.
+	GetBodyFrom() *common.BodySource
+}
+
+// BodyFormatAware indicates that a mapping supports serializing its body in another format than JSON.
+// This is a v1alpha2 Request-specific feature.
+type BodyFormatAware interface {
+	// GetBodyFormat returns how the body of the mapping is serialized, or an empty string for JSON.
+	GetBodyFormat() string
+}
+
+// MappingTimeoutAware indicates that a mapping supports overriding the resource WaitTimeout.
+// This is a v1alpha2 Request-specific feature.
+type MappingTimeoutAware interface {
+	// GetTimeout returns the timeout of the mapping request, or nil to use the WaitTimeout.
+	GetTimeout() *metav1.Duration
+}
+
+// MappingStreamAware indicates that a mapping supports reading its response as a stream of lines.
+// This is a v1alpha2 Request-specific feature.
+type MappingStreamAware interface {
+	// GetStream returns the configuration of the streamed response, or nil to read the whole body.
+	GetStream() *common.StreamConfig
+}
+
+// MappingWaitForReadyAware indicates that a mapping supports waiting for the resource it created to be ready.
+// This is a v1alpha2 Request-specific feature.
+type MappingWaitForReadyAware interface {
+	// GetWaitForReady returns the configuration of the wait, or nil to not wait.
+	GetWaitForReady() *common.WaitForReadyConfig
+}
+
+// MappingConditionAware indicates that a mapping supports being skipped unless a jq filter holds.
+// This is a v1alpha2 Request-specific feature.
+type MappingConditionAware interface {
+	// GetWhen returns the jq filter deciding whether the request of the mapping is sent, or an empty string.
+	GetWhen() string
+}
+
+// MappingForEachAware indicates that a mapping supports sending its request once per element of an array.
+// This is a v1alpha2 Request-specific feature.
+type MappingForEachAware interface {
+	// GetForEach returns the jq filter selecting the array the request of the mapping is sent for, or an empty
+	// string.
+	GetForEach() string
+}
+
 // HTTPPayload represents the payload configuration.
 type HTTPPayload interface {
 	// GetBaseURL returns the base URL.
@@ -119,6 +173,139 @@ type ResponseCheck interface {
 	GetLogic() string
 }
 
+// ObservePolicyAware indicates that a spec supports configuring whether to observe before the first creation.
+// This is a v1alpha2 Request-specific feature.
+type ObservePolicyAware interface {
+	// GetObserveBeforeCreate returns whether the OBSERVE request is sent before the resource was ever created.
+	GetObserveBeforeCreate() bool
+
+	// GetAssumeExists returns whether the external resource is assumed to exist when the OBSERVE request cannot
+	// confirm it, instead of being created.
+	GetAssumeExists() bool
+
+	// GetNotFoundStatusCodes returns the status codes of OBSERVE responses reporting that the external resource
+	// does not exist.
+	GetNotFoundStatusCodes() []int
+}
+
+// ConditionalObserveAware indicates that a spec supports sending the OBSERVE request conditionally on the
+// Last-Modified value of the cached response.
+// This is a v1alpha2 Request-specific feature.
+type ConditionalObserveAware interface {
+	// GetIfModifiedSince returns whether the If-Modified-Since header is sent with the OBSERVE request.
+	GetIfModifiedSince() bool
+}
+
+// IdempotencyKeyAware indicates that a spec supports sending an idempotency key with the CREATE request.
+// This is a v1alpha2 Request-specific feature.
+type IdempotencyKeyAware interface {
+	// GetIdempotencyKeyHeader returns the header carrying the idempotency key, or an empty string.
+	GetIdempotencyKeyHeader() string
+}
+
+// SuccessConditionAware indicates that a spec supports deciding whether a response is successful with a jq filter.
+// This is a v1alpha2 Request-specific feature.
+type SuccessConditionAware interface {
+	// GetSuccessCondition returns the jq filter deciding whether a response is successful, or an empty string.
+	GetSuccessCondition() string
+}
+
+// StatusExtractionsAware indicates that a spec supports extracting response values into the status.
+// This is a v1alpha2 Request-specific feature.
+type StatusExtractionsAware interface {
+	// GetStatusExtractions returns the jq filters of the values extracted into the status, by key.
+	GetStatusExtractions() map[string]string
+}
+
+// ResponseFormatAware indicates that a spec supports choosing the format of the response bodies.
+type ResponseFormatAware interface {
+	// GetResponseFormat returns the format of the response bodies, or an empty string for JSON.
+	GetResponseFormat() string
+}
+
+// ResponseJSONAware indicates that a spec supports storing the body of a JSON response as a structured object.
+// This is a v1alpha2 Request-specific feature.
+type ResponseJSONAware interface {
+	// GetStoreResponseJSON returns whether the body of a JSON response is stored as a structured object.
+	GetStoreResponseJSON() bool
+}
+
+// ResponseTransformAware indicates that a spec supports storing a projection of the response bodies.
+// This is a v1alpha2 Request-specific feature.
+type ResponseTransformAware interface {
+	// GetResponseTransform returns the configuration of the projection, or nil if the bodies are stored whole.
+	GetResponseTransform() *common.ResponseTransformConfig
+}
+
+// LastSuccessfulResponseAware indicates that a spec supports injecting secrets from the last successful response
+// when a request fails.
+// This is a v1alpha2 Request-specific feature.
+type LastSuccessfulResponseAware interface {
+	// GetSecretsFromLastSuccessfulResponse returns whether secrets are injected from the last successful response
+	// when a request fails.
+	GetSecretsFromLastSuccessfulResponse() bool
+}
+
+// ComparisonAware indicates that a spec supports normalizing the bodies compared by the default up-to-date check.
+// This is a v1alpha2 Request-specific feature.
+type ComparisonAware interface {
+	// GetComparison returns the normalization of the compared bodies, or nil if they are compared as is.
+	GetComparison() *common.ComparisonConfig
+}
+
+// DriftDiffAware indicates that a spec supports recording the fields differing from the observed state.
+// This is a v1alpha2 Request-specific feature.
+type DriftDiffAware interface {
+	// GetDriftDiff returns the configuration of the recorded diff, or nil if it is not recorded.
+	GetDriftDiff() *common.DriftDiffConfig
+}
+
+// HeaderOptionsAware indicates that a spec supports omitting headers whose template resolves to empty.
+// This is a v1alpha2 Request-specific feature.
+type HeaderOptionsAware interface {
+	// GetOmitIfEmptyHeaders returns the names of the headers omitted when their template resolves to empty.
+	GetOmitIfEmptyHeaders() []string
+}
+
+// RequestRefsAware indicates that a spec supports exposing the status of other Requests to the mappings.
+// This is a v1alpha2 Request-specific feature.
+type RequestRefsAware interface {
+	// GetRequestRefs returns the names of the referenced Requests.
+	GetRequestRefs() []string
+}
+
+// SecretRefsAware indicates that a spec supports exposing the keys of Secrets to the mappings.
+// This is a v1alpha2 Request-specific feature.
+type SecretRefsAware interface {
+	// GetSecretRefs returns the referenced Secrets.
+	GetSecretRefs() []xpv1.SecretReference
+}
+
+// BodyDenylistAware indicates that a spec supports refusing to send request bodies matching deny patterns.
+// This is a v1alpha2 Request-specific feature.
+type BodyDenylistAware interface {
+	// GetBodyDenyPatterns returns the regular expressions the rendered request body must not match.
+	GetBodyDenyPatterns() []string
+}
+
+// CombinedResponseCheck indicates that a response check supports combining several sub-checks.
+// This is a v1alpha2 Request-specific feature.
+type CombinedResponseCheck interface {
+	// GetChecks returns the sub-checks, if any.
+	GetChecks() []DescribedResponseCheck
+
+	// GetCombinator returns whether all of the sub-checks or any of them must pass.
+	GetCombinator() string
+}
+
+// DescribedResponseCheck is a sub-check of a combined response check.
+type DescribedResponseCheck interface {
+	ResponseCheck
+
+	// GetDescription returns the description reported when the sub-check fails.
+	GetDescription() string
+}
+
 // ReconciliationPolicyAware indicates that a spec supports custom reconciliation policies.
 // This is a v1alpha2 DisposableRequest-specific feature.
 type ReconciliationPolicyAware interface {
@@ -134,6 +321,91 @@ type ReconciliationPolicyAware interface {
 type RollbackAware interface {
 	// GetRollbackRetriesLimit returns the maximum number of rollback retry attempts.
 	GetRollbackRetriesLimit() *int32
+
+	// GetRetryableStatusCodes returns the HTTP error status codes that are retried.
+	GetRetryableStatusCodes() []string
+}
+
+// ResponseGuardAware indicates that a spec supports guarding the response before it is evaluated.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type ResponseGuardAware interface {
+	// GetExpectedContentType returns the media type the response must have.
+	GetExpectedContentType() string
+
+	// GetMaxBodyBytes returns the maximum accepted response body size.
+	GetMaxBodyBytes() *int64
+}
+
+// MultiStatusAware indicates that a spec supports evaluating 207 Multi-Status responses item by item.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type MultiStatusAware interface {
+	// GetMultiStatusItems returns the jq filter selecting the items of a multi-status response.
+	GetMultiStatusItems() string
+
+	// GetMultiStatusItemSucceeded returns the jq filter checking if a single multi-status item succeeded.
+	GetMultiStatusItemSucceeded() string
+}
+
+// ServerSentEventsAware indicates that a spec supports consuming the response as a stream of server-sent events.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type ServerSentEventsAware interface {
+	// GetServerSentEventsMaxDuration returns the maximum time events are waited for, or nil if the response is not
+	// consumed as server-sent events.
+	GetServerSentEventsMaxDuration() *metav1.Duration
+
+	// GetServerSentEventsMaxReconnects returns the maximum number of times an interrupted event stream is reopened.
+	GetServerSentEventsMaxReconnects() int
+}
+
+// ResponseSchemaAware indicates that a spec supports validating the body of successful responses against a JSON
+// Schema.
+type ResponseSchemaAware interface {
+	// GetResponseSchema returns the JSON Schema the body of successful responses must match, or nil.
+	GetResponseSchema() *common.ResponseSchema
+}
+
+// StoredHeadersAware indicates that a spec supports bounding the response headers stored in the status.
+type StoredHeadersAware interface {
+	// GetStoredHeaders returns the bounds of the stored headers, or nil for the defaults.
+	GetStoredHeaders() *common.StoredHeadersConfig
+}
+
+// ExternalNameAware indicates that a spec supports extracting the external name from the response of the CREATE
+// request.
+type ExternalNameAware interface {
+	// GetExternalNameFrom returns the jq filter selecting the external name from the response of the CREATE
+	// request, or an empty string.
+	GetExternalNameFrom() string
+}
+
+// RetryAfterAware indicates that a spec supports waiting for the Retry-After header of a response before the
+// request is sent again.
+type RetryAfterAware interface {
+	// GetMaxRetryAfter returns the maximum delay waited for the Retry-After header of a response.
+	GetMaxRetryAfter() time.Duration
+}
+
+// DeadlineAware indicates that a spec supports capping the whole sequence of attempts of its request.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type DeadlineAware interface {
+	// GetDeadline returns the maximum duration of the attempts of the request, or zero if it is not capped.
+	GetDeadline() time.Duration
+}
+
+// DNSFailureRetryAware indicates that a spec supports retrying the requests whose host does not resolve.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type DNSFailureRetryAware interface {
+	// GetRetryDNSFailures returns whether a request whose host does not resolve is retried.
+	GetRetryDNSFailures() bool
+}
+
+// ContinuationAware indicates that a spec supports extracting a continuation token from its responses, sent with
+// the next request.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type ContinuationAware interface {
+	// GetContinuationJQ returns the jq filter extracting the continuation token from the responses, or an empty
+	// string.
+	GetContinuationJQ() string
 }
 
 // HTTPResponse represents the common interface for HTTP response data.
@@ -146,6 +418,12 @@ type HTTPResponse interface {
 
 	// GetHeaders returns the response headers.
 	GetHeaders() map[string][]string
+
+	// GetTrailers returns the response trailers.
+	GetTrailers() map[string][]string
+
+	// GetStatusText returns the reason phrase of the response status line.
+	GetStatusText() string
 }
 
 // CachedResponse represents a response that can be retrieved from cache.
@@ -178,6 +456,15 @@ type BaseStatusWriter interface {
 	// SetBody sets the response body.
 	SetBody(body string)
 
+	// SetTrailers sets the response trailers.
+	SetTrailers(trailers map[string][]string)
+
+	// SetStatusText sets the reason phrase of the response status line.
+	SetStatusText(statusText string)
+
+	// SetTiming sets the latency breakdown of the request.
+	SetTiming(timing common.Timing)
+
 	// SetError sets the error message.
 	SetError(err error)
 
@@ -199,6 +486,13 @@ type DisposableRequestStatusWriter interface {
 	SetLastReconcileTime()
 }
 
+// MultiStatusWriter provides write access to the per-item outcome of a 207 Multi-Status response.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type MultiStatusWriter interface {
+	// SetMultiStatus sets the per-item outcome of the last multi-status response.
+	SetMultiStatus(result *common.MultiStatusResult)
+}
+
 // DisposableRequestStatus combines read and write access to DisposableRequest status.
 type DisposableRequestStatus interface {
 	DisposableRequestStatusReader
@@ -217,6 +511,26 @@ type RequestStatusReader interface {
 	GetRequestDetails() HTTPMapping
 }
 
+// ResponseCacheReader provides read-only access to the cached response of the last successful request.
+type ResponseCacheReader interface {
+	// GetResponseCache returns the cached response, or nil if there is none.
+	GetResponseCache() HTTPResponse
+}
+
+// LastSuccessfulResponseReader provides read-only access to the last successful response.
+// This is a v1alpha2 Request-specific feature.
+type LastSuccessfulResponseReader interface {
+	// GetLastSuccessfulResponse returns the last successful response, or nil if there is none.
+	GetLastSuccessfulResponse() HTTPResponse
+}
+
+// LastSuccessfulResponseWriter provides write access to the last successful response.
+// This is a v1alpha2 Request-specific feature.
+type LastSuccessfulResponseWriter interface {
+	// SetLastSuccessfulResponse sets the last successful response.
+	SetLastSuccessfulResponse(statusCode int, headers map[string][]string, body string)
+}
+
 // RequestStatusWriter provides write access to Request status fields.
 type RequestStatusWriter interface {
 	BaseStatusWriter
@@ -228,6 +542,109 @@ type RequestStatusWriter interface {
 	ResetFailures()
 }
 
+// FailedCheckWriter provides write access to the description of the failed response sub-checks.
+// This is a v1alpha2 Request-specific feature.
+type FailedCheckWriter interface {
+	// SetFailedCheck sets the description of the sub-checks that failed on the last observation.
+	SetFailedCheck(description string)
+}
+
+// DriftWriter provides write access to the fields that differed from the observed state.
+// This is a v1alpha2 Request-specific feature.
+type DriftWriter interface {
+	// SetDrift sets the fields that differed when the resource was last found not up to date.
+	SetDrift(drift []common.FieldDiff)
+}
+
+// ConsecutiveSuccessesWriter provides write access to the count of consecutive successful observations.
+// This is a v1alpha2 Request-specific feature.
+type ConsecutiveSuccessesWriter interface {
+	// IncrementConsecutiveSuccesses counts one more successful observation since the last failed request.
+	IncrementConsecutiveSuccesses()
+}
+
+// CacheObservedGenerationWriter provides write access to the generation of the spec the cached response found the
+// resource up to date for.
+// This is a v1alpha2 Request-specific feature.
+type CacheObservedGenerationWriter interface {
+	// SetCacheObservedGeneration sets the generation the last OBSERVE request found the resource up to date for,
+	// or clears it when zero.
+	SetCacheObservedGeneration(generation int64)
+}
+
+// HistoryWriter provides write access to the history of the attempts.
+// This is a v1alpha2 Request-specific feature.
+type HistoryWriter interface {
+	// RecordAttempt adds the attempt to the history, dropping the oldest attempts beyond its size.
+	RecordAttempt(attempt common.Attempt)
+}
+
+// ResponseJSONWriter provides write access to the body of the response parsed as a structured object.
+// This is a v1alpha2 Request-specific feature.
+type ResponseJSONWriter interface {
+	// SetResponseJSON sets the body of the last response parsed as a JSON object, or clears it when nil.
+	SetResponseJSON(raw *runtime.RawExtension)
+}
+
+// ExtractedWriter provides write access to the values extracted from the response.
+// This is a v1alpha2 Request-specific feature.
+type ExtractedWriter interface {
+	// SetExtracted sets the values extracted from the last successful response.
+	SetExtracted(values map[string]string)
+}
+
+// ItemsWriter provides access to the outcome of the requests sent for the elements of a forEach array.
+// This is a v1alpha2 Request-specific feature.
+type ItemsWriter interface {
+	// GetItems returns the outcome of the requests of the last attempt, by index.
+	GetItems() []common.ItemResult
+
+	// SetItems sets the outcome of the requests of the last attempt, by index.
+	SetItems(items []common.ItemResult)
+}
+
+// RetryAfterWriter provides write access to the delay requested by the Retry-After header of the last response.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type RetryAfterWriter interface {
+	// SetRetryAfter sets the delay waited before the request is sent again, or clears it when nil.
+	SetRetryAfter(delay *metav1.Duration)
+}
+
+// ContinuationWriter provides write access to the continuation token extracted from the last expected response.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type ContinuationWriter interface {
+	// SetContinuation sets the continuation token sent with the next request, or clears it when empty.
+	SetContinuation(token string)
+}
+
+// ContinuationReader provides access to the continuation token extracted from the last expected response.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type ContinuationReader interface {
+	// GetContinuation returns the continuation token sent with the next request, or an empty string.
+	GetContinuation() string
+}
+
+// StartTimeWriter provides write access to the time the request of the current generation was first sent.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type StartTimeWriter interface {
+	// SetStartTime records now as the start time, unless it is already recorded for the current generation.
+	SetStartTime(now time.Time)
+}
+
+// ElapsedReader provides access to the time elapsed since the request of the current generation was first sent.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type ElapsedReader interface {
+	// GetElapsed returns the time elapsed since the start time, or zero if it is not recorded.
+	GetElapsed(now time.Time) time.Duration
+}
+
+// RetryAfterReader provides access to the delay requested by the Retry-After header of the last response.
+// This is a v1alpha2 DisposableRequest-specific feature.
+type RetryAfterReader interface {
+	// GetRetryAfterWait returns the time left to wait before the request is sent again, or zero.
+	GetRetryAfterWait(now time.Time) time.Duration
+}
+
 // RequestStatus combines read and write access to Request status.
 type RequestStatus interface {
 	RequestStatusReader
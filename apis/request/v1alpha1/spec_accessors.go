@@ -125,3 +125,15 @@ func (r *Response) GetBody() string {
 func (r *Response) GetHeaders() map[string][]string {
 	return r.Headers
 }
+
+// GetTrailers returns the response trailers.
+// v1alpha1 does not support trailers, so this returns nil.
+func (r *Response) GetTrailers() map[string][]string {
+	return nil
+}
+
+// GetStatusText returns the reason phrase of the response status line.
+// v1alpha1 does not record it, so this returns an empty string.
+func (r *Response) GetStatusText() string {
+	return ""
+}
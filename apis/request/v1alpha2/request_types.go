@@ -20,6 +20,7 @@ import (
 	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
@@ -39,6 +40,14 @@ const (
 	ActionRemove  = common.ActionRemove
 )
 
+const (
+	// DefaultHistorySize is the number of the last attempts recorded in the status by default.
+	DefaultHistorySize = 5
+
+	// maxAttemptErrorLength bounds the length of the errors recorded in the history.
+	maxAttemptErrorLength = 256
+)
+
 // RequestParameters are the configurable fields of a Request.
 // +kubebuilder:validation:XValidation:rule="!(self.insecureSkipTLSVerify == true && has(self.tlsConfig))",message="insecureSkipTLSVerify and tlsConfig are mutually exclusive"
 type RequestParameters struct {
@@ -53,6 +62,11 @@ type RequestParameters struct {
 	// Headers defines default headers for each request.
 	Headers map[string][]string `json:"headers,omitempty"`
 
+	// HeaderOptions configures how the headers of the requests are templated, by header name, e.g.
+	// '{"X-Token": {"omitIfEmpty": true}}'. It applies to the default headers and the headers of the mappings.
+	// +optional
+	HeaderOptions map[string]HeaderOptions `json:"headerOptions,omitempty"`
+
 	// WaitTimeout specifies the maximum time duration for waiting.
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
@@ -66,19 +80,270 @@ type RequestParameters struct {
 	// +optional
 	TLSConfig *common.TLSConfig `json:"tlsConfig,omitempty"`
 
+	// HMACSigning signs the requests with an HMAC of their body, or canonical form, placed in a header,
+	// e.g. for webhook targets verifying the signature with a shared key.
+	// +optional
+	HMACSigning *common.HMACSigningConfig `json:"hmacSigning,omitempty"`
+
+	// ResponseFormat is the format of the response bodies. With xml, XML bodies are converted to JSON when they
+	// are received, so jq filters, checks and templates work against them: elements become keys, attributes
+	// keys prefixed with @, the text of elements with attributes or children the #text key, and repeated elements
+	// arrays. The converted body is stored in the status. With text, the body is never parsed as JSON: checks
+	// compare it as a raw string, also exposed as bodyText. Defaults to json.
+	// +kubebuilder:validation:Enum=json;xml;text
+	// +optional
+	ResponseFormat string `json:"responseFormat,omitempty"`
+
+	// UserAgent is sent in the User-Agent header of the requests that do not set this header. Defaults to
+	// provider-http/<version>.
+	// +optional
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// Protocol is the HTTP protocol version the requests are sent with. auto and http1 use HTTP/1.1, as the
+	// requests always did, h2 requires HTTP/2 over TLS, and h2c sends cleartext requests with HTTP/2 prior
+	// knowledge, e.g. to a gRPC gateway, and TLS requests with HTTP/2. Defaults to the protocol of the provider config.
+	// +kubebuilder:validation:Enum=auto;http1;h2;h2c
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// ExpectContinueTimeout sends the requests bearing a body with an Expect: 100-continue header, and waits up to
+	// this long for the interim response of the server before sending the body. A final response received first,
+	// e.g. a 401, completes the request without sending the body, which saves streaming a large upload the server
+	// rejects. A server not answering in time receives the body anyway. Disabled when unset.
+	// +optional
+	ExpectContinueTimeout *metav1.Duration `json:"expectContinueTimeout,omitempty"`
+
+	// BodyDenyPatterns lists regular expressions the rendered request body must not match, e.g. a raw private key
+	// leaked by a templating mistake. A request whose body matches any of them is not sent.
+	// +optional
+	BodyDenyPatterns []string `json:"bodyDenyPatterns,omitempty"`
+
 	// SecretInjectionConfig specifies the secrets receiving patches for response data.
 	SecretInjectionConfigs []common.SecretInjectionConfig `json:"secretInjectionConfigs,omitempty"`
 
 	// ExpectedResponseCheck specifies the mechanism to validate the OBSERVE response against expected value.
 	ExpectedResponseCheck ExpectedResponseCheck `json:"expectedResponseCheck,omitempty"`
 
+	// Comparison normalizes both the OBSERVE response body and the body of the UPDATE request before the default
+	// expectedResponseCheck compares them, e.g. to compare the arrays an API reorders as sets.
+	// +optional
+	Comparison *common.ComparisonConfig `json:"comparison,omitempty"`
+
 	// IsRemovedCheck specifies the mechanism to validate the OBSERVE response after removal against expected value.
 	IsRemovedCheck ExpectedResponseCheck `json:"isRemovedCheck,omitempty"`
+
+	// ObserveBeforeCreate controls what happens when the resource has never been created by the provider.
+	// When true, the OBSERVE request is sent first, if it can be templated, and an existing external resource
+	// answering with a successful response is adopted instead of being created. When false, the resource is
+	// created first, unless its crossplane.io/external-name annotation identifies an external resource to adopt.
+	// Defaults to true.
+	// +optional
+	ObserveBeforeCreate *bool `json:"observeBeforeCreate,omitempty"`
+
+	// AssumeExists, when true, never creates the external resource: an OBSERVE request that cannot be
+	// templated or cannot confirm the resource exists, including one failing isRemovedCheck, reports it as
+	// existing but not up to date, so the UPDATE request is sent instead of the CREATE request. This is meant
+	// for adopting pre-existing external resources that must never be created by the provider. It implies
+	// observeBeforeCreate.
+	// +optional
+	AssumeExists bool `json:"assumeExists,omitempty"`
+
+	// NotFoundStatusCodes lists the status codes of OBSERVE responses reporting that the external resource does
+	// not exist, whatever isRemovedCheck evaluates to. An empty list leaves it to isRemovedCheck. They also report
+	// the elements of the forEach array of the REMOVE mapping that are already removed. Defaults to 404 and 410.
+	// +optional
+	NotFoundStatusCodes []int `json:"notFoundStatusCodes,omitempty"`
+
+	// ReadyAfterSuccesses is the number of consecutive successful OBSERVE requests after which the resource is
+	// reported Ready, to keep the readiness of an eventually-consistent backend from flapping. Any failed request
+	// starts the count over. Defaults to reporting it Ready on the first successful OBSERVE request.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ReadyAfterSuccesses int32 `json:"readyAfterSuccesses,omitempty"`
+
+	// IfModifiedSince, when set to true, sends the Last-Modified value of the cached response of the previous
+	// OBSERVE request in an If-Modified-Since header. A 304 Not Modified response is then answered with the
+	// cached response, which the response checks use instead.
+	// +optional
+	IfModifiedSince bool `json:"ifModifiedSince,omitempty"`
+
+	// CacheTTL skips the OBSERVE requests while the cached response of the last one is younger than this, that
+	// request found the resource up to date, and the spec did not change since, e.g. `cacheTTL: 30m` for an
+	// expensive OBSERVE endpoint. The resource is reported up to date meanwhile, so a drift of the external
+	// resource is only detected once the cached response expired. Setting the
+	// http.crossplane.io/refresh-requested-at annotation to a later time sends the OBSERVE request anyway.
+	// Disabled when unset.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+
+	// ConfirmDeletion, when set to true, sends the OBSERVE request after the REMOVE request and only reports
+	// the external resource as deleted once IsRemovedCheck passes. Otherwise the deletion is retried.
+	// +optional
+	ConfirmDeletion bool `json:"confirmDeletion,omitempty"`
+
+	// IdempotencyKey, when set, sends a key derived from the resource UID and generation in a header of the
+	// CREATE request. The key stays the same when the CREATE request is retried for the same generation, so the
+	// server can deduplicate a request retried after a timeout.
+	// +optional
+	IdempotencyKey *IdempotencyKey `json:"idempotencyKey,omitempty"`
+
+	// DryRun, when true, renders the CREATE request into status.requestDetails without ever sending a request,
+	// e.g. to validate a Composition in CI. Secret references are left unresolved in the rendered request.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// DependsOn lists what must be ready before the CREATE request is sent, e.g. another managed resource the
+	// request relies on. Until then the Request waits with a WaitingForDependencies condition, without failing.
+	// While waiting, it is reported as existing and up to date rather than as not existing, since a Request
+	// reported as not existing is created right away, and it is observed again at the next poll. Dependencies are
+	// only checked until a first response was received.
+	// +optional
+	DependsOn *DependsOn `json:"dependsOn,omitempty"`
+
+	// SuccessCondition is a jq filter evaluated against the response of every request, e.g. '.body.ok == true'.
+	// When set, it decides whether the request succeeded whatever the status code, instead of treating 2xx
+	// responses as successful and 4xx and 5xx responses as failed.
+	// +optional
+	SuccessCondition string `json:"successCondition,omitempty"`
+
+	// ResponseSchema is a JSON Schema the body of every successful response must match, e.g. to catch a drift of
+	// the contract of the API early. A mismatching response fails like an error response, before its data is
+	// injected into secrets, with an error naming the failing path.
+	// +optional
+	ResponseSchema *common.ResponseSchema `json:"responseSchema,omitempty"`
+
+	// ExternalNameFrom is a jq filter selecting the external name from the response of a successful CREATE
+	// request, e.g. '.body.id'. The value is written into the crossplane.io/external-name annotation, unless
+	// the annotation is already set, and is available to the mappings under externalName. When set, the
+	// annotation is not defaulted to the name of the Request.
+	// +optional
+	ExternalNameFrom string `json:"externalNameFrom,omitempty"`
+
+	// StatusExtractions lists values extracted from the response of every successful request into
+	// status.extracted, e.g. to expose an ID or URL to a Composition with fromFieldPath.
+	// +optional
+	StatusExtractions []StatusExtraction `json:"statusExtractions,omitempty"`
+
+	// RequestRefs lists other Requests whose status is exposed to the mappings under refs, by name, e.g.
+	// '.refs["create-team"].response.body.id'. Only the listed Requests are read, and templating fails while
+	// one of them does not exist or is not ready.
+	// +optional
+	RequestRefs []RequestReference `json:"requestRefs,omitempty"`
+
+	// SecretRefs lists Secrets whose keys are exposed to the mappings under secrets, by name, e.g.
+	// '"Bearer \(.secrets["api"]["token"])"', so expressions can combine secret values freely. The values the
+	// mapping references, and their JSON-escaped and URL-encoded forms, are redacted from the request details
+	// written to the status.
+	// +optional
+	SecretRefs []xpv1.SecretReference `json:"secretRefs,omitempty"`
+
+	// StoreResponseJSON, when true, also stores the body of a JSON response as a structured object in
+	// status.response.json. It is off by default since the body is then stored twice.
+	// +optional
+	StoreResponseJSON bool `json:"storeResponseJSON,omitempty"`
+
+	// ResponseTransform, when set, stores a projection of the response body in status.response instead of the
+	// whole body, e.g. to keep the status of a verbose API small.
+	// +optional
+	ResponseTransform *common.ResponseTransformConfig `json:"responseTransform,omitempty"`
+
+	// StoredHeaders bounds the response headers and trailers stored in status.response, e.g. to raise the limits
+	// for an API answering with many headers. Only status.response is bounded: the response is processed with
+	// all its headers, and the responses read back from the status, e.g. the cached response, keep them all.
+	// +optional
+	StoredHeaders *common.StoredHeadersConfig `json:"storedHeaders,omitempty"`
+
+	// SecretsFromLastSuccessfulResponse, when true, injects the secrets of secretInjectionConfigs from
+	// status.lastSuccessfulResponse when a request fails, e.g. during a transient outage, instead of from the failed
+	// response, so dependents keep the last good values.
+	// +optional
+	SecretsFromLastSuccessfulResponse bool `json:"secretsFromLastSuccessfulResponse,omitempty"`
+
+	// DriftDiff, when set, records in status.drift the fields of the body of the UPDATE request that differ from
+	// the OBSERVE response when the default expectedResponseCheck finds the resource not up to date. It is off
+	// by default since the diff grows the status.
+	// +optional
+	DriftDiff *common.DriftDiffConfig `json:"driftDiff,omitempty"`
+
+	// HistorySize is the number of the last attempts recorded in status.history, newest first. 0 disables the
+	// history. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=20
+	// +optional
+	HistorySize *int32 `json:"historySize,omitempty"`
+}
+
+// HeaderOptions configures how a header is templated.
+type HeaderOptions struct {
+	// OmitIfEmpty, when true, omits the values of the header whose template resolves to an empty string or null,
+	// and the header entirely when all of them do, instead of sending an empty value.
+	// +optional
+	OmitIfEmpty bool `json:"omitIfEmpty,omitempty"`
+}
+
+// RequestReference references another Request.
+type RequestReference struct {
+	// Name is the name of the referenced Request.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
+// StatusExtraction extracts a value from the response into status.extracted.
+type StatusExtraction struct {
+	// Key is the key of the value in status.extracted.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// ResponseJQ is a jq filter expression selecting the value in the response. Values other than strings
+	// are JSON encoded, and the key is left absent if the value is missing.
+	ResponseJQ string `json:"responseJQ"`
+}
+
+// IdempotencyKey configures the idempotency key sent with the CREATE request.
+type IdempotencyKey struct {
+	// Header is the name of the header carrying the key.
+	// +kubebuilder:default=Idempotency-Key
+	// +optional
+	Header string `json:"header,omitempty"`
+}
+
+// DependsOn configures the dependencies that must be ready before the CREATE request is sent.
+type DependsOn struct {
+	// Resources lists the resources whose Ready condition must be true.
+	// +optional
+	Resources []DependencyReference `json:"resources,omitempty"`
+
+	// ReadinessProbeURL is a URL a GET request is sent to, which must respond with a 2xx status code. The probe only
+	// carries the TLS configuration: it is sent without the credentials and signatures of the provider config.
+	// +optional
+	ReadinessProbeURL string `json:"readinessProbeURL,omitempty"`
+}
+
+// DependencyReference references a resource, e.g. another managed resource, by kind and name.
+type DependencyReference struct {
+	// APIVersion of the resource, e.g. 'http.crossplane.io/v1alpha2'.
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resource, e.g. 'Request'.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Name of the resource.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the resource, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!(has(self.body) && has(self.bodyFrom))",message="body and bodyFrom are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.bodyFrom) && ((has(self.bodyFrom.binary) && self.bodyFrom.binary) || has(self.bodyFrom.base64)) && has(self.bodyFormat) && self.bodyFormat != 'json')",message="a binary body cannot have a body format"
 type Mapping struct {
-	// +kubebuilder:validation:Enum=POST;GET;PUT;DELETE;PATCH;HEAD;OPTIONS
-	// Method specifies the HTTP method for the request.
+	// +kubebuilder:validation:Pattern=`^[A-Z][A-Z0-9_-]*$`
+	// Method specifies the HTTP method for the request. Besides the standard methods, custom
+	// methods such as PURGE are sent as is.
 	Method string `json:"method,omitempty"`
 
 	// +kubebuilder:validation:Enum=CREATE;OBSERVE;UPDATE;REMOVE
@@ -88,11 +353,55 @@ type Mapping struct {
 	// Body specifies the body of the request.
 	Body string `json:"body,omitempty"`
 
+	// BodyFrom loads the body of the request from a Secret or ConfigMap key, or from base64 for a binary body,
+	// instead of Body.
+	// +optional
+	BodyFrom *common.BodySource `json:"bodyFrom,omitempty"`
+
+	// BodyFormat is how the body is serialized. json sends it as is, and ndjson sends the elements of a JSON
+	// array one per line, with the application/x-ndjson Content-Type unless the headers set one, e.g. for a log
+	// ingestion endpoint. An empty array sends an empty body. autoMergePatch sends an RFC 7386 merge patch of the
+	// fields of the JSON object that differ from the body of the last response, with the
+	// application/merge-patch+json Content-Type unless the headers set one. Defaults to json.
+	// +kubebuilder:validation:Enum=json;ndjson;autoMergePatch
+	// +optional
+	BodyFormat string `json:"bodyFormat,omitempty"`
+
 	// URL specifies the URL for the request.
 	URL string `json:"url"`
 
 	// Headers specifies the headers for the request.
 	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Timeout overrides WaitTimeout for the request of this mapping, e.g. for a long-running CREATE
+	// next to a fast OBSERVE.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Stream reads the response of this mapping as a stream of lines until one matches, instead of reading
+	// the whole body, e.g. for endpoints reporting the progress of a long-running job.
+	// +optional
+	Stream *common.StreamConfig `json:"stream,omitempty"`
+
+	// WaitForReady keeps polling the resource with the OBSERVE request after the request of this CREATE
+	// mapping succeeds, until it is ready, instead of leaving it to the next observations.
+	// +optional
+	WaitForReady *common.WaitForReadyConfig `json:"waitForReady,omitempty"`
+
+	// When is a jq filter evaluated against the template context of the mapping, e.g.
+	// '.payload.body.tier != .response.body.tier'. When it evaluates to false, the action of the mapping is
+	// skipped without sending a request and treated as successful.
+	// +optional
+	When string `json:"when,omitempty"`
+
+	// ForEach is a jq filter evaluated against the template context of the CREATE or REMOVE mapping, selecting
+	// an array, e.g. '.payload.body.users'. One request is sent per element, which is exposed to the mapping under
+	// item, and its position under index. The outcome of every request is reported in status.items. The
+	// responses of the CREATE requests are aggregated into status.response, whose body is the array of their
+	// bodies. The REMOVE requests keep status.response, e.g. to remove every element of '.response.body', and
+	// the deletion only completes once every element is removed.
+	// +optional
+	ForEach string `json:"forEach,omitempty"`
 }
 
 type ExpectedResponseCheck struct {
@@ -102,6 +411,29 @@ type ExpectedResponseCheck struct {
 
 	// Logic specifies the custom logic for the expected response check.
 	Logic string `json:"logic,omitempty"`
+
+	// Checks specifies several sub-checks combined according to Combinator. When set, Type and Logic are ignored.
+	// +optional
+	Checks []ResponseSubCheck `json:"checks,omitempty"`
+
+	// Combinator specifies whether all of the Checks or any of them must pass. Defaults to all.
+	// +kubebuilder:validation:Enum=all;any
+	// +optional
+	Combinator string `json:"combinator,omitempty"`
+}
+
+// ResponseSubCheck is a single check combined with others in an ExpectedResponseCheck.
+type ResponseSubCheck struct {
+	// Description describes the sub-check, it is reported in the status when the sub-check fails.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Type specifies the type of the sub-check.
+	// +kubebuilder:validation:Enum=DEFAULT;CUSTOM
+	Type string `json:"type,omitempty"`
+
+	// Logic specifies the custom logic for the sub-check.
+	Logic string `json:"logic,omitempty"`
 }
 
 type Payload struct {
@@ -123,6 +455,24 @@ type Response struct {
 	StatusCode int                 `json:"statusCode,omitempty"`
 	Body       string              `json:"body,omitempty"`
 	Headers    map[string][]string `json:"headers,omitempty"`
+
+	// Trailers contains the HTTP trailers sent by the server after the response body.
+	// +optional
+	Trailers map[string][]string `json:"trailers,omitempty"`
+
+	// StatusText contains the reason phrase of the response status line, e.g. "Not Found".
+	// +optional
+	StatusText string `json:"statusText,omitempty"`
+
+	// Timing contains the latency breakdown of the request that produced this response.
+	// +optional
+	Timing *common.Timing `json:"timing,omitempty"`
+
+	// JSON contains the body parsed as a JSON object, when storeResponseJSON is set and the response has a
+	// JSON Content-Type, e.g. for a Composition to read nested fields with fromFieldPath.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	JSON *runtime.RawExtension `json:"json,omitempty"`
 }
 
 // A RequestStatus represents the observed state of a Request.
@@ -133,11 +483,45 @@ type RequestStatus struct {
 	Failed              int32    `json:"failed,omitempty"`
 	Error               string   `json:"error,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
+
+	// LastSuccessfulResponse is the last successful response, kept when later requests fail, unlike response.
+	// +optional
+	LastSuccessfulResponse *Response `json:"lastSuccessfulResponse,omitempty"`
+
+	// FailedCheck describes the expectedResponseCheck sub-checks that failed on the last observation.
+	// +optional
+	FailedCheck string `json:"failedCheck,omitempty"`
+
+	// ConsecutiveSuccesses counts the successful OBSERVE requests since the last failed request.
+	// +optional
+	ConsecutiveSuccesses int32 `json:"consecutiveSuccesses,omitempty"`
+
+	// Drift lists the fields that differed when the resource was last found not up to date, if driftDiff is set.
+	// +optional
+	Drift []common.FieldDiff `json:"drift,omitempty"`
+
+	// History records the last attempts of the actions, newest first, bounded by historySize.
+	// +optional
+	History []common.Attempt `json:"history,omitempty"`
+
+	// Extracted holds the values extracted from the last successful response by statusExtractions.
+	// +optional
+	Extracted map[string]string `json:"extracted,omitempty"`
+
+	// Items reports the outcome of the request sent for every element of the forEach array of the last CREATE
+	// or REMOVE mapping, by index.
+	// +optional
+	Items []common.ItemResult `json:"items,omitempty"`
 }
 
 type Cache struct {
 	LastUpdated string   `json:"lastUpdated,omitempty"`
 	Response    Response `json:"response,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the last OBSERVE request found the resource up to date
+	// for, or zero if it did not find it up to date.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"net/http"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
@@ -73,6 +77,172 @@ func (r *RequestParameters) GetIsRemovedCheck() interfaces.ResponseCheck {
 	return &r.IsRemovedCheck
 }
 
+// GetObserveBeforeCreate returns whether the OBSERVE request is sent before the resource was ever created.
+func (r *RequestParameters) GetObserveBeforeCreate() bool {
+	return r.ObserveBeforeCreate == nil || *r.ObserveBeforeCreate
+}
+
+// GetAssumeExists returns whether the external resource is assumed to exist when the OBSERVE request cannot
+// confirm it, instead of being created.
+func (r *RequestParameters) GetAssumeExists() bool {
+	return r.AssumeExists
+}
+
+// GetNotFoundStatusCodes returns the status codes of OBSERVE responses reporting that the external resource does
+// not exist.
+func (r *RequestParameters) GetNotFoundStatusCodes() []int {
+	if r.NotFoundStatusCodes == nil {
+		return []int{http.StatusNotFound, http.StatusGone}
+	}
+	return r.NotFoundStatusCodes
+}
+
+// GetHistorySize returns the number of the last attempts recorded in the status.
+func (r *RequestParameters) GetHistorySize() int {
+	if r.HistorySize == nil {
+		return DefaultHistorySize
+	}
+	return int(*r.HistorySize)
+}
+
+// GetIfModifiedSince returns whether the OBSERVE request is sent conditionally on the Last-Modified value of the
+// cached response.
+func (r *RequestParameters) GetIfModifiedSince() bool {
+	return r.IfModifiedSince
+}
+
+// GetCacheTTL returns how long the cached response of an OBSERVE request finding the resource up to date is used
+// instead of sending the request again, or zero if it is always sent.
+func (r *RequestParameters) GetCacheTTL() time.Duration {
+	if r.CacheTTL == nil {
+		return 0
+	}
+	return r.CacheTTL.Duration
+}
+
+// GetIdempotencyKeyHeader returns the header carrying the idempotency key of the CREATE request, or an empty
+// string if no key is sent.
+func (r *RequestParameters) GetIdempotencyKeyHeader() string {
+	if r.IdempotencyKey == nil {
+		return ""
+	}
+
+	if r.IdempotencyKey.Header == "" {
+		return common.DefaultIdempotencyKeyHeader
+	}
+
+	return r.IdempotencyKey.Header
+}
+
+// GetSuccessCondition returns the jq filter deciding whether a response is successful.
+func (r *RequestParameters) GetSuccessCondition() string {
+	return r.SuccessCondition
+}
+
+// GetRequestRefs returns the names of the Requests whose status is exposed to the mappings.
+func (r *RequestParameters) GetRequestRefs() []string {
+	names := make([]string, 0, len(r.RequestRefs))
+	for _, ref := range r.RequestRefs {
+		names = append(names, ref.Name)
+	}
+
+	return names
+}
+
+// GetSecretRefs returns the Secrets whose keys are exposed to the mappings.
+func (r *RequestParameters) GetSecretRefs() []xpv1.SecretReference {
+	return r.SecretRefs
+}
+
+// GetOmitIfEmptyHeaders returns the names of the headers omitted when their template resolves to empty.
+func (r *RequestParameters) GetOmitIfEmptyHeaders() []string {
+	var names []string
+	for name, options := range r.HeaderOptions {
+		if options.OmitIfEmpty {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// GetResponseSchema returns the JSON Schema the body of successful responses must match, or nil.
+func (r *RequestParameters) GetResponseSchema() *common.ResponseSchema {
+	return r.ResponseSchema
+}
+
+// GetExternalNameFrom returns the jq filter selecting the external name from the response of the CREATE request.
+func (r *RequestParameters) GetExternalNameFrom() string {
+	return r.ExternalNameFrom
+}
+
+// GetComparison returns the normalization of the bodies compared by the default up-to-date check, or nil if they
+// are compared as is.
+func (r *RequestParameters) GetComparison() *common.ComparisonConfig {
+	return r.Comparison
+}
+
+// GetDriftDiff returns the configuration of the diff recorded when the resource is not up to date, or nil if it
+// is not recorded.
+func (r *RequestParameters) GetDriftDiff() *common.DriftDiffConfig {
+	return r.DriftDiff
+}
+
+// GetResponseFormat returns the format of the response bodies.
+func (r *RequestParameters) GetResponseFormat() string {
+	return r.ResponseFormat
+}
+
+// GetExpectContinueTimeout returns the time waited for the interim response before sending the body of a request,
+// or zero if the requests are sent without an Expect: 100-continue header.
+func (r *RequestParameters) GetExpectContinueTimeout() time.Duration {
+	if r.ExpectContinueTimeout == nil {
+		return 0
+	}
+	return r.ExpectContinueTimeout.Duration
+}
+
+// GetStoreResponseJSON returns whether the body of a JSON response is stored as a structured object in the status.
+func (r *RequestParameters) GetStoreResponseJSON() bool {
+	return r.StoreResponseJSON
+}
+
+// GetResponseTransform returns the configuration of the projection of the response bodies, or nil if they are
+// stored whole.
+func (r *RequestParameters) GetResponseTransform() *common.ResponseTransformConfig {
+	return r.ResponseTransform
+}
+
+// GetStoredHeaders returns the bounds of the response headers stored in the status, or nil for the defaults.
+func (r *RequestParameters) GetStoredHeaders() *common.StoredHeadersConfig {
+	return r.StoredHeaders
+}
+
+// GetSecretsFromLastSuccessfulResponse returns whether secrets are injected from the last successful response when
+// a request fails.
+func (r *RequestParameters) GetSecretsFromLastSuccessfulResponse() bool {
+	return r.SecretsFromLastSuccessfulResponse
+}
+
+// GetStatusExtractions returns the jq filters of the values extracted into the status, by key.
+func (r *RequestParameters) GetStatusExtractions() map[string]string {
+	if len(r.StatusExtractions) == 0 {
+		return nil
+	}
+
+	extractions := make(map[string]string, len(r.StatusExtractions))
+	for _, extraction := range r.StatusExtractions {
+		extractions[extraction.Key] = extraction.ResponseJQ
+	}
+
+	return extractions
+}
+
+// GetBodyDenyPatterns returns the regular expressions the rendered request body must not match.
+func (r *RequestParameters) GetBodyDenyPatterns() []string {
+	return r.BodyDenyPatterns
+}
+
 // Ensure Mapping implements HTTPMapping
 var _ interfaces.HTTPMapping = (*Mapping)(nil)
 
@@ -96,6 +266,16 @@ func (m *Mapping) GetBody() string {
 	return m.Body
 }
 
+// GetBodyFrom returns the Secret or ConfigMap key the body is loaded from, if any.
+func (m *Mapping) GetBodyFrom() *common.BodySource {
+	return m.BodyFrom
+}
+
+// GetBodyFormat returns how the body of this mapping is serialized.
+func (m *Mapping) GetBodyFormat() string {
+	return m.BodyFormat
+}
+
 // GetURL returns the URL template for this mapping.
 func (m *Mapping) GetURL() string {
 	return m.URL
@@ -106,6 +286,31 @@ func (m *Mapping) GetHeaders() map[string][]string {
 	return m.Headers
 }
 
+// GetTimeout returns the timeout overriding WaitTimeout for this mapping, if any.
+func (m *Mapping) GetTimeout() *metav1.Duration {
+	return m.Timeout
+}
+
+// GetStream returns the configuration of the streamed response of this mapping, if any.
+func (m *Mapping) GetStream() *common.StreamConfig {
+	return m.Stream
+}
+
+// GetWaitForReady returns the configuration of the wait for the created resource to be ready, if any.
+func (m *Mapping) GetWaitForReady() *common.WaitForReadyConfig {
+	return m.WaitForReady
+}
+
+// GetWhen returns the jq filter deciding whether the request of this mapping is sent.
+func (m *Mapping) GetWhen() string {
+	return m.When
+}
+
+// GetForEach returns the jq filter selecting the array the request of this mapping is sent for.
+func (m *Mapping) GetForEach() string {
+	return m.ForEach
+}
+
 // Ensure Payload implements HTTPPayload
 var _ interfaces.HTTPPayload = (*Payload)(nil)
 
@@ -132,6 +337,38 @@ func (e *ExpectedResponseCheck) GetLogic() string {
 	return e.Logic
 }
 
+// Ensure ExpectedResponseCheck implements CombinedResponseCheck
+var _ interfaces.CombinedResponseCheck = (*ExpectedResponseCheck)(nil)
+
+// GetChecks returns the sub-checks of the check.
+func (e *ExpectedResponseCheck) GetChecks() []interfaces.DescribedResponseCheck {
+	checks := make([]interfaces.DescribedResponseCheck, len(e.Checks))
+	for i := range e.Checks {
+		checks[i] = &e.Checks[i]
+	}
+	return checks
+}
+
+// GetCombinator returns how the sub-checks are combined.
+func (e *ExpectedResponseCheck) GetCombinator() string {
+	return e.Combinator
+}
+
+// GetDescription returns the description of the sub-check.
+func (s *ResponseSubCheck) GetDescription() string {
+	return s.Description
+}
+
+// GetType returns the sub-check type.
+func (s *ResponseSubCheck) GetType() string {
+	return s.Type
+}
+
+// GetLogic returns the custom logic for the sub-check.
+func (s *ResponseSubCheck) GetLogic() string {
+	return s.Logic
+}
+
 // Ensure Response implements HTTPResponse
 var _ interfaces.HTTPResponse = (*Response)(nil)
 
@@ -150,6 +387,16 @@ func (r *Response) GetHeaders() map[string][]string {
 	return r.Headers
 }
 
+// GetTrailers returns the response trailers.
+func (r *Response) GetTrailers() map[string][]string {
+	return r.Trailers
+}
+
+// GetStatusText returns the reason phrase of the response status line.
+func (r *Response) GetStatusText() string {
+	return r.StatusText
+}
+
 // Ensure Request implements CachedResponse
 var _ interfaces.CachedResponse = (*Request)(nil)
 
@@ -161,6 +408,22 @@ func (r *Request) GetCachedResponse() interfaces.HTTPResponse {
 	return &r.Status.Response
 }
 
+// GetResponseCache returns the cached response of the last successful request, or nil if there is none.
+func (r *Request) GetResponseCache() interfaces.HTTPResponse {
+	if r.Status.Cache.Response.StatusCode == 0 {
+		return nil
+	}
+	return &r.Status.Cache.Response
+}
+
+// GetLastSuccessfulResponse returns the last successful response, or nil if there is none.
+func (r *Request) GetLastSuccessfulResponse() interfaces.HTTPResponse {
+	if r.Status.LastSuccessfulResponse == nil {
+		return nil
+	}
+	return r.Status.LastSuccessfulResponse
+}
+
 // Ensure Request implements RequestStatusReader
 var _ interfaces.RequestStatusReader = (*Request)(nil)
 
@@ -1,6 +1,13 @@
 package v1alpha2
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
 
 func (d *Request) SetStatusCode(statusCode int) {
 	d.Status.Response.StatusCode = statusCode
@@ -14,8 +21,21 @@ func (d *Request) SetBody(body string) {
 	d.Status.Response.Body = body
 }
 
+func (d *Request) SetTrailers(trailers map[string][]string) {
+	d.Status.Response.Trailers = trailers
+}
+
+func (d *Request) SetStatusText(statusText string) {
+	d.Status.Response.StatusText = statusText
+}
+
+func (d *Request) SetTiming(timing common.Timing) {
+	d.Status.Response.Timing = &timing
+}
+
 func (d *Request) SetError(err error) {
 	d.Status.Failed++
+	d.Status.ConsecutiveSuccesses = 0
 	if err != nil {
 		d.Status.Error = err.Error()
 	}
@@ -26,6 +46,66 @@ func (d *Request) ResetFailures() {
 	d.Status.Error = ""
 }
 
+func (d *Request) SetFailedCheck(description string) {
+	d.Status.FailedCheck = description
+}
+
+func (d *Request) SetDrift(drift []common.FieldDiff) {
+	d.Status.Drift = drift
+}
+
+// RecordAttempt adds the attempt to the front of the history, dropping the oldest attempts beyond historySize.
+// The error of the attempt is truncated to bound the size of the status.
+func (d *Request) RecordAttempt(attempt common.Attempt) {
+	size := d.Spec.ForProvider.GetHistorySize()
+	if size <= 0 {
+		d.Status.History = nil
+		return
+	}
+
+	if len(attempt.Error) > maxAttemptErrorLength {
+		attempt.Error = strings.ToValidUTF8(attempt.Error[:maxAttemptErrorLength], "") + "..."
+	}
+
+	history := append([]common.Attempt{attempt}, d.Status.History...)
+	if len(history) > size {
+		history = history[:size]
+	}
+	d.Status.History = history
+}
+
+func (d *Request) IncrementConsecutiveSuccesses() {
+	d.Status.ConsecutiveSuccesses++
+}
+
+func (d *Request) SetCacheObservedGeneration(generation int64) {
+	d.Status.Cache.ObservedGeneration = generation
+}
+
+func (d *Request) SetLastSuccessfulResponse(statusCode int, headers map[string][]string, body string) {
+	d.Status.LastSuccessfulResponse = &Response{
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       body,
+	}
+}
+
+func (d *Request) SetExtracted(values map[string]string) {
+	d.Status.Extracted = values
+}
+
+func (d *Request) SetItems(items []common.ItemResult) {
+	d.Status.Items = items
+}
+
+func (d *Request) GetItems() []common.ItemResult {
+	return d.Status.Items
+}
+
+func (d *Request) SetResponseJSON(raw *runtime.RawExtension) {
+	d.Status.Response.JSON = raw
+}
+
 func (d *Request) SetRequestDetails(url, method, body string, headers map[string][]string) {
 	d.Status.RequestDetails.Body = body
 	d.Status.RequestDetails.URL = url
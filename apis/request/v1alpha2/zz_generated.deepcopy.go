@@ -22,8 +22,9 @@ package v1alpha2
 
 import (
 	"github.com/crossplane-contrib/provider-http/apis/common"
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -42,9 +43,49 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyReference) DeepCopyInto(out *DependencyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyReference.
+func (in *DependencyReference) DeepCopy() *DependencyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependsOn) DeepCopyInto(out *DependsOn) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]DependencyReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependsOn.
+func (in *DependsOn) DeepCopy() *DependsOn {
+	if in == nil {
+		return nil
+	}
+	out := new(DependsOn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpectedResponseCheck) DeepCopyInto(out *ExpectedResponseCheck) {
 	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ResponseSubCheck, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpectedResponseCheck.
@@ -57,9 +98,44 @@ func (in *ExpectedResponseCheck) DeepCopy() *ExpectedResponseCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderOptions) DeepCopyInto(out *HeaderOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderOptions.
+func (in *HeaderOptions) DeepCopy() *HeaderOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdempotencyKey) DeepCopyInto(out *IdempotencyKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdempotencyKey.
+func (in *IdempotencyKey) DeepCopy() *IdempotencyKey {
+	if in == nil {
+		return nil
+	}
+	out := new(IdempotencyKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(common.BodySource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make(map[string][]string, len(*in))
@@ -76,6 +152,21 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(common.StreamConfig)
+		**out = **in
+	}
+	if in.WaitForReady != nil {
+		in, out := &in.WaitForReady, &out.WaitForReady
+		*out = new(common.WaitForReadyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mapping.
@@ -189,6 +280,13 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.HeaderOptions != nil {
+		in, out := &in.HeaderOptions, &out.HeaderOptions
+		*out = make(map[string]HeaderOptions, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.WaitTimeout != nil {
 		in, out := &in.WaitTimeout, &out.WaitTimeout
 		*out = new(v1.Duration)
@@ -199,6 +297,21 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 		*out = new(common.TLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HMACSigning != nil {
+		in, out := &in.HMACSigning, &out.HMACSigning
+		*out = new(common.HMACSigningConfig)
+		**out = **in
+	}
+	if in.ExpectContinueTimeout != nil {
+		in, out := &in.ExpectContinueTimeout, &out.ExpectContinueTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BodyDenyPatterns != nil {
+		in, out := &in.BodyDenyPatterns, &out.BodyDenyPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SecretInjectionConfigs != nil {
 		in, out := &in.SecretInjectionConfigs, &out.SecretInjectionConfigs
 		*out = make([]common.SecretInjectionConfig, len(*in))
@@ -206,8 +319,78 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ExpectedResponseCheck = in.ExpectedResponseCheck
-	out.IsRemovedCheck = in.IsRemovedCheck
+	in.ExpectedResponseCheck.DeepCopyInto(&out.ExpectedResponseCheck)
+	if in.Comparison != nil {
+		in, out := &in.Comparison, &out.Comparison
+		*out = new(common.ComparisonConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.IsRemovedCheck.DeepCopyInto(&out.IsRemovedCheck)
+	if in.ObserveBeforeCreate != nil {
+		in, out := &in.ObserveBeforeCreate, &out.ObserveBeforeCreate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NotFoundStatusCodes != nil {
+		in, out := &in.NotFoundStatusCodes, &out.NotFoundStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IdempotencyKey != nil {
+		in, out := &in.IdempotencyKey, &out.IdempotencyKey
+		*out = new(IdempotencyKey)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = new(DependsOn)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseSchema != nil {
+		in, out := &in.ResponseSchema, &out.ResponseSchema
+		*out = new(common.ResponseSchema)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatusExtractions != nil {
+		in, out := &in.StatusExtractions, &out.StatusExtractions
+		*out = make([]StatusExtraction, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestRefs != nil {
+		in, out := &in.RequestRefs, &out.RequestRefs
+		*out = make([]RequestReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]commonv1.SecretReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseTransform != nil {
+		in, out := &in.ResponseTransform, &out.ResponseTransform
+		*out = new(common.ResponseTransformConfig)
+		**out = **in
+	}
+	if in.StoredHeaders != nil {
+		in, out := &in.StoredHeaders, &out.StoredHeaders
+		*out = new(common.StoredHeadersConfig)
+		**out = **in
+	}
+	if in.DriftDiff != nil {
+		in, out := &in.DriftDiff, &out.DriftDiff
+		*out = new(common.DriftDiffConfig)
+		**out = **in
+	}
+	if in.HistorySize != nil {
+		in, out := &in.HistorySize, &out.HistorySize
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestParameters.
@@ -220,6 +403,21 @@ func (in *RequestParameters) DeepCopy() *RequestParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestReference) DeepCopyInto(out *RequestReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestReference.
+func (in *RequestReference) DeepCopy() *RequestReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestSpec) DeepCopyInto(out *RequestSpec) {
 	*out = *in
@@ -244,6 +442,35 @@ func (in *RequestStatus) DeepCopyInto(out *RequestStatus) {
 	in.Response.DeepCopyInto(&out.Response)
 	in.Cache.DeepCopyInto(&out.Cache)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	if in.LastSuccessfulResponse != nil {
+		in, out := &in.LastSuccessfulResponse, &out.LastSuccessfulResponse
+		*out = new(Response)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]common.FieldDiff, len(*in))
+		copy(*out, *in)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]common.Attempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Extracted != nil {
+		in, out := &in.Extracted, &out.Extracted
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]common.ItemResult, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestStatus.
@@ -275,6 +502,32 @@ func (in *Response) DeepCopyInto(out *Response) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Trailers != nil {
+		in, out := &in.Trailers, &out.Trailers
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Timing != nil {
+		in, out := &in.Timing, &out.Timing
+		*out = new(common.Timing)
+		**out = **in
+	}
+	if in.JSON != nil {
+		in, out := &in.JSON, &out.JSON
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Response.
@@ -286,3 +539,33 @@ func (in *Response) DeepCopy() *Response {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseSubCheck) DeepCopyInto(out *ResponseSubCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseSubCheck.
+func (in *ResponseSubCheck) DeepCopy() *ResponseSubCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseSubCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusExtraction) DeepCopyInto(out *StatusExtraction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusExtraction.
+func (in *StatusExtraction) DeepCopy() *StatusExtraction {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusExtraction)
+	in.DeepCopyInto(out)
+	return out
+}
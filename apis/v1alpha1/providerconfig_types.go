@@ -27,6 +27,8 @@ import (
 )
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
+// +kubebuilder:validation:XValidation:rule="!(has(self.oauth2) && has(self.sigv4))",message="oauth2 and sigv4 are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.ntlm) && (has(self.oauth2) || has(self.sigv4)))",message="ntlm is mutually exclusive with oauth2 and sigv4"
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
@@ -34,15 +36,127 @@ type ProviderConfigSpec struct {
 	// TLS configuration for HTTPS requests.
 	// +optional
 	TLS *common.TLSConfig `json:"tls,omitempty"`
+
+	// TLSHostPolicies choose how the certificate of the servers is verified by the host of the request, e.g. to
+	// trust a self-signed internal endpoint without skipping the verification of the other hosts. The first policy
+	// matching a host applies, the verification of the other hosts follows the TLS configuration. A redirect to
+	// a host with another policy fails.
+	// +listType=map
+	// +listMapKey=host
+	// +optional
+	TLSHostPolicies []common.TLSHostPolicy `json:"tlsHostPolicies,omitempty"`
+
+	// IPFamily restricts or prefers the address family used to connect to servers.
+	// IPv4 and IPv6 only dial addresses of that family, PreferIPv4 and PreferIPv6 try
+	// addresses of that family first and fall back to the other one.
+	// If empty, the Go resolver's default behavior is used.
+	// +kubebuilder:validation:Enum=IPv4;IPv6;PreferIPv4;PreferIPv6
+	// +optional
+	IPFamily string `json:"ipFamily,omitempty"`
+
+	// HostAliases resolve host names statically to IP addresses, e.g. to send the requests to a staging server or
+	// through split-horizon DNS without touching the cluster DNS. The requests keep the host of their URL in the
+	// Host header and as the TLS server name, so the certificate of the server is verified against it.
+	// Example: {"api.example.com": "10.0.12.7"}
+	// +optional
+	HostAliases map[string]string `json:"hostAliases,omitempty"`
+
+	// Protocol is the HTTP protocol version the requests are sent with. auto and http1 use HTTP/1.1, as the
+	// requests always did, h2 requires HTTP/2 over TLS, and h2c sends cleartext requests with HTTP/2 prior
+	// knowledge, e.g. to a gRPC gateway, and TLS requests with HTTP/2. Resources can override it. Defaults to auto.
+	// +kubebuilder:validation:Enum=auto;http1;h2;h2c
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// OAuth2 configures the acquisition of an access token with the OAuth2 client credentials grant.
+	// The token is cached, refreshed before it expires and sent as a Bearer token in the Authorization
+	// header of the requests that do not set this header.
+	// +optional
+	OAuth2 *common.OAuth2Config `json:"oauth2,omitempty"`
+
+	// SigV4 signs the requests with AWS Signature Version 4, e.g. to call API Gateway endpoints using IAM
+	// authorization. Requests are signed right before they are sent, again for every redirect.
+	// +optional
+	SigV4 *common.SigV4Config `json:"sigv4,omitempty"`
+
+	// NTLM authenticates the requests that do not set the Authorization header with NTLM, e.g. to on-premises
+	// Windows services behind IIS. The handshake is negotiated over a keep-alive HTTP/1.1 connection, so
+	// requests are always sent with HTTP/1.1.
+	// +optional
+	NTLM *common.NTLMConfig `json:"ntlm,omitempty"`
+
+	// SSRFGuard, when set, only allows http and https URLs and rejects connections to loopback, link-local
+	// (e.g. the cloud metadata service) and private addresses, unless they are in AllowedCIDRs. Addresses
+	// are checked when connecting, after DNS resolution, so a host name cannot be rebound to an internal address.
+	// +optional
+	SSRFGuard *common.SSRFGuardConfig `json:"ssrfGuard,omitempty"`
+
+	// DisallowBodyRedirects makes requests with a body fail with an error when the server answers
+	// with a 307 or 308 redirect, instead of resubmitting the body to the new location.
+	// +optional
+	DisallowBodyRedirects bool `json:"disallowBodyRedirects,omitempty"`
+
+	// MaxConcurrentPerHost bounds the number of requests in flight to each host, e.g. to protect a fragile API
+	// from bursts of concurrent reconciles. The requests in flight are counted across all the resources sending
+	// requests to the host, including those of other provider configs. Requests beyond the bound wait for one in
+	// flight to complete. This bounds concurrency, not the request rate.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentPerHost int `json:"maxConcurrentPerHost,omitempty"`
+
+	// CircuitBreaker, when set, stops sending requests to a host after consecutive failures, shared by all the
+	// resources using this provider config, so a failing API is not hammered by every reconcile. The requests
+	// fail fast until the cooldown elapsed, then a single request probes the host.
+	// +optional
+	CircuitBreaker *common.CircuitBreakerConfig `json:"circuitBreaker,omitempty"`
+
+	// BaseURL is the base URL of the API the Requests using this provider config talk to. It is exposed
+	// to the Request mappings as .providerConfig.baseURL, e.g. to build "\(.providerConfig.baseURL)/things".
+	// +optional
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// DefaultHeaders are merged into the headers of every Request and DisposableRequest using this provider
+	// config, e.g. an Accept or an organization header. A header set by the resource takes precedence over a
+	// default header with the same name, compared case-insensitively. The default headers are templated like the
+	// headers of the resource.
+	// +optional
+	DefaultHeaders map[string][]string `json:"defaultHeaders,omitempty"`
+
+	// HealthCheck, when set, periodically probes a canary endpoint with a GET request and reports the provider
+	// as not ready while the probe fails, e.g. to detect misconfigured egress.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
 }
 
+// HealthCheck configures the probe of a canary endpoint reflecting the connectivity of the provider.
+type HealthCheck struct {
+	// URL is the URL of the canary endpoint.
+	URL string `json:"url"`
+
+	// ExpectedStatusCode is the status code of a passing probe. Defaults to 200.
+	// +optional
+	ExpectedStatusCode int `json:"expectedStatusCode,omitempty"`
+
+	// Interval between two probes. Defaults to 1m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// CredentialsSourceEndpoint fetches the credentials from an endpoint, e.g. a sidecar minting tokens.
+const CredentialsSourceEndpoint xpv1.CredentialsSource = "Endpoint"
+
 // ProviderCredentials required to authenticate.
+// +kubebuilder:validation:XValidation:rule="self.source != 'Endpoint' || has(self.endpoint)",message="endpoint is required when source is Endpoint"
 type ProviderCredentials struct {
 	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
+	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem;Endpoint
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// Endpoint the credentials are fetched from when the source is Endpoint.
+	// +optional
+	Endpoint *common.CredentialsEndpointConfig `json:"endpoint,omitempty"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
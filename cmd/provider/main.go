@@ -22,7 +22,10 @@ import (
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -35,6 +38,13 @@ import (
 
 	"github.com/crossplane-contrib/provider-http/apis"
 	template "github.com/crossplane-contrib/provider-http/internal/controller"
+	"github.com/crossplane-contrib/provider-http/internal/controller/request"
+	"github.com/crossplane-contrib/provider-http/internal/health"
+	"github.com/crossplane-contrib/provider-http/internal/metrics"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/trigger"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/crossplane-contrib/provider-http/internal/webhook"
 )
 
 func main() {
@@ -45,8 +55,18 @@ func main() {
 		timeout                  = app.Flag("timeout", "Controls how long http requests may take before they are failed.").Default("10m").Duration()
 		syncInterval             = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval             = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		pollJitter               = app.Flag("poll-jitter", "Fraction of the poll interval added at random to the poll interval of every resource, e.g. 0.1, to spread out the polls of resources created together. Zero disables the jitter.").Default("0").Float64()
 		maxReconcileRate         = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
 		enableManagementPolicies = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("true").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
+		metricsResourceLabels    = app.Flag("metrics-resource-label", "Managed resource label key to project into the request metric labels, e.g. team. Can be repeated, at most 5 keys.").Strings()
+		observeBackoffBase       = app.Flag("observe-backoff-base", "Poll interval of a Request after its first consecutive failure, doubled on each further failure.").Default("1m").Duration()
+		observeBackoffMax        = app.Flag("observe-backoff-max", "Maximum poll interval of a Request failing consecutively. Zero disables the backoff.").Default("30m").Duration()
+		healthProbeAddress       = app.Flag("health-probe-bind-address", "Address the readiness and liveness probe endpoints bind to.").Default(":8081").String()
+		triggerAddress           = app.Flag("trigger-bind-address", "Address the endpoint triggering DisposableRequests on demand binds to, e.g. :8082. Disabled when empty.").Default("").String()
+		templateEnv              = app.Flag("template-env", "Environment variable name exposed to the Request templates under .env, e.g. BUILD_SHA. Can be repeated, other variables are never exposed.").Strings()
+		enableValidationWebhook  = app.Flag("enable-validation-webhook", "Serve the webhook rejecting the Requests whose jq expressions do not compile. Its ValidatingWebhookConfiguration is deployed separately.").Default("false").Envar("ENABLE_VALIDATION_WEBHOOK").Bool()
+		webhookPort              = app.Flag("webhook-port", "Port the validation webhook listens on.").Default("9443").Int()
+		webhookCertDir           = app.Flag("webhook-tls-cert-dir", "Directory holding the tls.crt and tls.key files the validation webhook serves.").Default("").Envar("WEBHOOK_TLS_CERT_DIR").String()
 
 		// namespace = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 	)
@@ -59,6 +79,11 @@ func main() {
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
+	var webhookServer ctrlwebhook.Server
+	if *enableValidationWebhook {
+		webhookServer = ctrlwebhook.NewServer(ctrlwebhook.Options{Port: *webhookPort, CertDir: *webhookCertDir})
+	}
+
 	mgr, err := ctrl.NewManager(ratelimiter.LimitRESTConfig(cfg, *maxReconcileRate), ctrl.Options{
 		Cache: cache.Options{
 			SyncPeriod: syncInterval,
@@ -75,6 +100,8 @@ func main() {
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		HealthProbeBindAddress:     *healthProbeAddress,
+		WebhookServer:              webhookServer,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Http APIs to scheme")
@@ -93,6 +120,21 @@ func main() {
 		log.Info("Beta feature enabled", "flag", feature.EnableBetaManagementPolicies)
 	}
 
+	kingpin.FatalIfError(metrics.Setup(ctrlmetrics.Registry, *metricsResourceLabels), "Cannot setup request metrics")
+	requestgen.SetEnvAllowlist(*templateEnv)
+	request.SetObserveBackoff(*observeBackoffBase, *observeBackoffMax)
+	utils.SetPollJitter(*pollJitter)
 	kingpin.FatalIfError(template.Setup(mgr, o, *timeout), "Cannot setup Template controllers")
+	if *enableValidationWebhook {
+		kingpin.FatalIfError(webhook.SetupRequestValidator(mgr), "Cannot setup Request validation webhook")
+	}
+
+	prober := health.NewProber(mgr.GetClient(), log.WithValues("component", "health"), *timeout)
+	kingpin.FatalIfError(mgr.Add(prober), "Cannot add health checks prober")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add liveness check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("health-checks", prober.Check), "Cannot add readiness check")
+	if *triggerAddress != "" {
+		kingpin.FatalIfError(mgr.Add(trigger.NewServer(mgr.GetClient(), log.WithValues("component", "trigger"), *triggerAddress)), "Cannot add trigger endpoint")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	errBlockedAddress     = "connection to %s blocked by the SSRF guard: %s is a %s address, allow it with ssrfGuard.allowedCIDRs in the ProviderConfig"
+	errBlockedScheme      = "URL %s blocked by the SSRF guard: only http, https, ws and wss URLs are allowed"
+	errInvalidAllowedCIDR = "invalid ssrfGuard.allowedCIDRs[%d] %q: %w"
+)
+
+// AddressGuard rejects URLs with other schemes than http and https, and connections to loopback, link-local and
+// private addresses that are not allowlisted.
+type AddressGuard struct {
+	allowed []netip.Prefix
+}
+
+// NewAddressGuard returns an AddressGuard allowing the given CIDRs, or nil if the guard is not configured.
+func NewAddressGuard(config *common.SSRFGuardConfig) (*AddressGuard, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	guard := &AddressGuard{}
+	for i, cidr := range config.AllowedCIDRs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidAllowedCIDR, i, cidr, err)
+		}
+		guard.allowed = append(guard.allowed, prefix.Masked())
+	}
+
+	return guard, nil
+}
+
+// WithAddressGuard makes the client reject the URLs and addresses blocked by the guard.
+func WithAddressGuard(guard *AddressGuard) ClientOption {
+	return func(c *client) {
+		c.addressGuard = guard
+	}
+}
+
+// checkURL rejects URLs with other schemes than http and https, or their WebSocket counterparts ws and wss.
+func (g *AddressGuard) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" && !isWebSocketURL(u) {
+		return fmt.Errorf(errBlockedScheme, u.Redacted())
+	}
+
+	return nil
+}
+
+// control is a net.Dialer Control function rejecting blocked addresses. It is called with the resolved address of
+// every connection attempt, so a host name cannot be resolved to an allowed address and then rebound to a blocked one.
+func (g *AddressGuard) control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr := addrPort.Addr().Unmap()
+	kind := blockedAddressKind(addr)
+	if kind == "" {
+		return nil
+	}
+
+	for _, prefix := range g.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(errBlockedAddress, address, addr, kind)
+}
+
+// blockedAddressKind returns the kind of internal address, or an empty string if the address is not blocked.
+func blockedAddressKind(addr netip.Addr) string {
+	switch {
+	case addr.IsLoopback():
+		return "loopback"
+	case addr.IsLinkLocalUnicast(), addr.IsLinkLocalMulticast():
+		return "link-local"
+	case addr.IsPrivate():
+		return "private"
+	case addr.IsUnspecified():
+		return "unspecified"
+	default:
+		return ""
+	}
+}
+
+// guardedDialer returns a dialer enforcing the guard, if any.
+func guardedDialer(guard *AddressGuard) *net.Dialer {
+	if guard == nil {
+		return &net.Dialer{}
+	}
+
+	return &net.Dialer{Control: guard.control}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestAddressGuardControl(t *testing.T) {
+	cases := map[string]struct {
+		allowedCIDRs []string
+		address      string
+		wantBlocked  bool
+	}{
+		"MetadataAddressBlocked": {
+			address:     "169.254.169.254:80",
+			wantBlocked: true,
+		},
+		"LoopbackBlocked": {
+			address:     "127.0.0.1:8080",
+			wantBlocked: true,
+		},
+		"PrivateIPv6Blocked": {
+			address:     "[fd00::1]:443",
+			wantBlocked: true,
+		},
+		"IPv4MappedIPv6Blocked": {
+			address:     "[::ffff:10.0.0.1]:443",
+			wantBlocked: true,
+		},
+		"PublicAddressAllowed": {
+			address: "93.184.216.34:443",
+		},
+		"AllowlistedPrivateRangeAllowed": {
+			allowedCIDRs: []string{"10.0.0.0/8"},
+			address:      "10.1.2.3:80",
+		},
+		"PrivateAddressOutsideAllowlistBlocked": {
+			allowedCIDRs: []string{"10.0.0.0/8"},
+			address:      "192.168.1.10:80",
+			wantBlocked:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			guard, err := NewAddressGuard(&common.SSRFGuardConfig{AllowedCIDRs: tc.allowedCIDRs})
+			if err != nil {
+				t.Fatalf("NewAddressGuard(...): unexpected error: %v", err)
+			}
+
+			err = guard.control("tcp", tc.address, nil)
+			if tc.wantBlocked && (err == nil || !strings.Contains(err.Error(), "blocked by the SSRF guard")) {
+				t.Errorf("control(%q): want blocked error, got %v", tc.address, err)
+			}
+			if !tc.wantBlocked && err != nil {
+				t.Errorf("control(%q): unexpected error: %v", tc.address, err)
+			}
+		})
+	}
+}
+
+func TestNewAddressGuard(t *testing.T) {
+	if guard, err := NewAddressGuard(nil); guard != nil || err != nil {
+		t.Errorf("NewAddressGuard(nil): want no guard and no error, got %v, %v", guard, err)
+	}
+
+	if _, err := NewAddressGuard(&common.SSRFGuardConfig{AllowedCIDRs: []string{"10.0.0.0/33"}}); err == nil {
+		t.Errorf("NewAddressGuard(...): want error for invalid CIDR, got nil")
+	}
+}
+
+func TestSendRequestAddressGuard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		allowedCIDRs []string
+		url          string
+		wantErr      string
+	}{
+		"LoopbackServerBlocked": {
+			url:     server.URL,
+			wantErr: "blocked by the SSRF guard",
+		},
+		"AllowlistedLoopbackServerAllowed": {
+			allowedCIDRs: []string{"127.0.0.0/8"},
+			url:          server.URL,
+		},
+		"UnsupportedSchemeBlocked": {
+			allowedCIDRs: []string{"127.0.0.0/8"},
+			url:          "ftp://127.0.0.1/file",
+			wantErr:      "only http, https, ws and wss URLs are allowed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			guard, err := NewAddressGuard(&common.SSRFGuardConfig{AllowedCIDRs: tc.allowedCIDRs})
+			if err != nil {
+				t.Fatalf("NewAddressGuard(...): unexpected error: %v", err)
+			}
+
+			c, _ := NewClient(logging.NewNopLogger(), 0, "", WithAddressGuard(guard))
+			headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, tc.url, Data{Encrypted: "", Decrypted: ""}, headers, nil)
+
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("SendRequest(...): unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("SendRequest(...): want error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		client    *client
+		wantProxy bool
+	}{
+		"NoGuardNorAliases": {
+			reason:    "The proxy settings from the environment should be used without address guard nor host aliases",
+			client:    &client{},
+			wantProxy: true,
+		},
+		"AddressGuard": {
+			reason: "No proxy should be used with an address guard, which would only check the address of the proxy",
+			client: &client{addressGuard: &AddressGuard{}},
+		},
+		"HostAliases": {
+			reason: "No proxy should be used with host aliases, which would only alias the host of the proxy",
+			client: &client{hostAliases: HostAliases{"example.com": netip.MustParseAddr("127.0.0.1")}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.client.proxy() != nil; got != tc.wantProxy {
+				t.Errorf("\n%s\nproxy(): want a proxy function %t, got %t", tc.reason, tc.wantProxy, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	errOpenBodyStream = "failed to open the request body"
+)
+
+// BodyStream is a request body read while the request is sent, instead of being held in memory first, e.g. for
+// uploads of several megabytes. It is passed to SendRequest as the decrypted data of the body, whose encrypted
+// data is the string shown in the status in its place. Signed requests and requests sent over a WebSocket or
+// reading a stream of server-sent events still read the whole body first.
+type BodyStream struct {
+	// Open returns a reader of the body from its start. It is called again for every further attempt to send the
+	// body, e.g. when a 307 or 308 redirect is followed.
+	Open func() (io.ReadCloser, error)
+
+	// Size is the length of the body in bytes, sent in the Content-Length header. A negative size sends the body
+	// with chunked transfer encoding.
+	Size int64
+}
+
+// NewBytesBodyStream returns a BodyStream reading the data, without copying it.
+func NewBytesBodyStream(data []byte) *BodyStream {
+	return &BodyStream{
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		},
+		Size: int64(len(data)),
+	}
+}
+
+// newRequest returns a request sending the body, streamed from its reader if it is a BodyStream. The body of a
+// request that is not streamed is returned as well, since it is fully buffered anyway.
+func newRequest(ctx context.Context, method, url string, body Data) (*http.Request, []byte, error) {
+	stream, ok := body.Decrypted.(*BodyStream)
+	if !ok {
+		requestBody := []byte(body.Decrypted.(string))
+
+		// The body is fully buffered so it can be replayed when a 307 or 308 redirect is followed.
+		request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
+		return request, requestBody, err
+	}
+
+	if stream.Size == 0 {
+		request, err := http.NewRequestWithContext(ctx, method, url, http.NoBody)
+		return request, nil, err
+	}
+
+	reader, err := stream.Open()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", errOpenBodyStream, err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		_ = reader.Close()
+		return nil, nil, err
+	}
+
+	// The body is replayed by opening the stream again.
+	request.ContentLength = stream.Size
+	if stream.Size < 0 {
+		request.ContentLength = -1
+	}
+	request.GetBody = stream.Open
+
+	return request, nil, nil
+}
+
+// bufferBody reads the whole body of a streamed request, for the exchanges that need it in memory. The body of the
+// request is replaced with the buffered one.
+func bufferBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer func() { _ = req.Body.Close() }()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errOpenBodyStream, err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+
+	return body, nil
+}
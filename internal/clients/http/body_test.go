@@ -0,0 +1,134 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	testStreamChunk = 1 << 20
+	testStreamSize  = 32 << 20
+)
+
+// gatedReader reads size zero bytes, but blocks after the first chunk until the server received it. A client
+// buffering the whole body before sending it never gets past the first chunk.
+type gatedReader struct {
+	size     int64
+	read     int64
+	received <-chan struct{}
+}
+
+func (r *gatedReader) Read(p []byte) (int, error) {
+	if r.read >= r.size {
+		return 0, io.EOF
+	}
+	if r.read >= testStreamChunk {
+		select {
+		case <-r.received:
+		case <-time.After(5 * time.Second):
+			return 0, errors.New("the body was not streamed")
+		}
+	}
+
+	n := int64(len(p))
+	if r.size-r.read < n {
+		n = r.size - r.read
+	}
+	if r.read < testStreamChunk && testStreamChunk-r.read < n {
+		n = testStreamChunk - r.read
+	}
+	clear(p[:n])
+	r.read += n
+
+	return int(n), nil
+}
+
+func (r *gatedReader) Close() error {
+	return nil
+}
+
+func TestSendRequestBodyStream(t *testing.T) {
+	cases := map[string]struct {
+		reason            string
+		size              int64
+		wantContentLength int64
+		wantChunked       bool
+	}{
+		"ContentLength": {
+			reason:            "Should stream a body of a known size with its Content-Length",
+			size:              testStreamSize,
+			wantContentLength: testStreamSize,
+		},
+		"Chunked": {
+			reason:            "Should stream a body of an unknown size with chunked transfer encoding",
+			size:              -1,
+			wantContentLength: -1,
+			wantChunked:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			received := make(chan struct{})
+			var gotContentLength, gotBytes int64
+			var gotChunked bool
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotContentLength = r.ContentLength
+				gotChunked = len(r.TransferEncoding) > 0 && r.TransferEncoding[0] == "chunked"
+
+				n, err := io.CopyN(io.Discard, r.Body, testStreamChunk)
+				gotBytes = n
+				close(received)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				n, _ = io.Copy(io.Discard, r.Body)
+				gotBytes += n
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			stream := &BodyStream{
+				Open: func() (io.ReadCloser, error) {
+					return &gatedReader{size: testStreamSize, received: received}, nil
+				},
+				Size: tc.size,
+			}
+
+			client, err := NewClient(logging.NewNopLogger(), 30*time.Second, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			details, err := client.SendRequest(context.Background(), http.MethodPut, server.URL,
+				Data{Encrypted: "<streamed>", Decrypted: stream},
+				Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+				&TLSConfigData{})
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			if details.HttpResponse.StatusCode != http.StatusOK {
+				t.Errorf("\n%s\nSendRequest(...): want status %d, got %d", tc.reason, http.StatusOK, details.HttpResponse.StatusCode)
+			}
+			if gotBytes != testStreamSize {
+				t.Errorf("\n%s\nSendRequest(...): want %d bytes received, got %d", tc.reason, testStreamSize, gotBytes)
+			}
+			if gotContentLength != tc.wantContentLength || gotChunked != tc.wantChunked {
+				t.Errorf("\n%s\nSendRequest(...): want Content-Length %d and chunked %t, got %d and %t", tc.reason, tc.wantContentLength, tc.wantChunked, gotContentLength, gotChunked)
+			}
+			if details.HttpRequest.Body != "<streamed>" {
+				t.Errorf("\n%s\nSendRequest(...): want the encrypted body %q in the request details, got %q", tc.reason, "<streamed>", details.HttpRequest.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"cmp"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitCooldown         = 30 * time.Second
+
+	// circuitIdleTimeout is how long a circuit breaker is kept once no request checks it anymore, e.g. after its
+	// ProviderConfig was deleted. A breaker is only evicted once its cooldown elapsed too, so an open circuit is
+	// not closed early.
+	circuitIdleTimeout = 10 * time.Minute
+)
+
+// circuitState is the state of the circuit breaker of a host.
+type circuitState string
+
+const (
+	// circuitClosed lets every request through, counting the consecutive failures.
+	circuitClosed circuitState = "Closed"
+	// circuitOpen fails the requests fast until the cooldown elapsed.
+	circuitOpen circuitState = "Open"
+	// circuitHalfOpen lets a single probe request through, whose outcome closes or reopens the circuit.
+	circuitHalfOpen circuitState = "HalfOpen"
+)
+
+// circuitNow returns the current time, replaced by the tests.
+var circuitNow = time.Now
+
+// circuitKey identifies the circuit breaker of a host shared by the clients of a ProviderConfig.
+type circuitKey struct {
+	providerConfig   string
+	host             string
+	failureThreshold int
+	cooldown         time.Duration
+}
+
+var (
+	circuitBreakersMu sync.Mutex
+	circuitBreakers   = map[circuitKey]*circuitBreaker{}
+	// circuitsSweptAt is when the idle circuit breakers were last evicted.
+	circuitsSweptAt time.Time
+)
+
+// circuitBreaker stops the requests to a host after consecutive failures, so a failing upstream is not hammered
+// by every reconcile, and lets a probe request through once the cooldown elapsed to detect its recovery.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	host             string
+	failureThreshold int
+	cooldown         time.Duration
+
+	state    circuitState
+	failures int
+	openedAt time.Time
+	// usedAt is when a request last checked the circuit.
+	usedAt time.Time
+}
+
+// WithCircuitBreaker opens the circuit of a host after consecutive failed requests, across all the clients of the
+// named ProviderConfig. While it is open, the requests to the host fail fast with a CircuitOpenError. Once the
+// cooldown elapsed, a single request probes the host: its success closes the circuit while its failure opens it
+// again. A nil config disables the circuit breaker.
+func WithCircuitBreaker(providerConfig string, config *common.CircuitBreakerConfig) ClientOption {
+	return func(c *client) {
+		if config == nil {
+			return
+		}
+		c.circuitProviderConfig = providerConfig
+		c.circuitFailureThreshold = cmp.Or(config.FailureThreshold, defaultCircuitFailureThreshold)
+		c.circuitCooldown = defaultCircuitCooldown
+		if config.Cooldown != nil && config.Cooldown.Duration > 0 {
+			c.circuitCooldown = config.Cooldown.Duration
+		}
+	}
+}
+
+// hostCircuitBreaker returns the circuit breaker of the host, or nil if the client does not use circuit breakers.
+func (hc *client) hostCircuitBreaker(host string) *circuitBreaker {
+	if hc.circuitFailureThreshold <= 0 {
+		return nil
+	}
+
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+
+	evictIdleCircuitBreakers(circuitNow())
+
+	key := circuitKey{
+		providerConfig:   hc.circuitProviderConfig,
+		host:             host,
+		failureThreshold: hc.circuitFailureThreshold,
+		cooldown:         hc.circuitCooldown,
+	}
+	breaker, ok := circuitBreakers[key]
+	if !ok {
+		breaker = &circuitBreaker{
+			host:             host,
+			failureThreshold: hc.circuitFailureThreshold,
+			cooldown:         hc.circuitCooldown,
+			state:            circuitClosed,
+			usedAt:           circuitNow(),
+		}
+		circuitBreakers[key] = breaker
+	}
+
+	return breaker
+}
+
+// evictIdleCircuitBreakers removes the circuit breakers no request checked for circuitIdleTimeout, at most once
+// per circuitIdleTimeout. The caller must hold circuitBreakersMu.
+func evictIdleCircuitBreakers(now time.Time) {
+	if now.Sub(circuitsSweptAt) < circuitIdleTimeout {
+		return
+	}
+	circuitsSweptAt = now
+
+	for key, breaker := range circuitBreakers {
+		if breaker.idle(now) {
+			delete(circuitBreakers, key)
+		}
+	}
+}
+
+// idle checks whether no request checked the circuit for circuitIdleTimeout, or for its cooldown if longer.
+func (cb *circuitBreaker) idle(now time.Time) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return now.Sub(cb.usedAt) >= max(circuitIdleTimeout, cb.cooldown)
+}
+
+// allow checks whether a request may be sent to the host, and returns a CircuitOpenError otherwise. The first
+// request allowed once the cooldown elapsed half-opens the circuit, and the others fail until its outcome is
+// recorded.
+func (cb *circuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.usedAt = circuitNow()
+	switch cb.state {
+	case circuitOpen:
+		if retryIn := cb.openedAt.Add(cb.cooldown).Sub(circuitNow()); retryIn > 0 {
+			return &CircuitOpenError{Host: cb.host, RetryIn: retryIn}
+		}
+		cb.state = circuitHalfOpen
+	case circuitHalfOpen:
+		return &CircuitOpenError{Host: cb.host}
+	}
+
+	return nil
+}
+
+// record updates the circuit with the outcome of a request it allowed, i.e. of the round trip to the host. A request
+// interrupted by its context says nothing about the host and only gives the probe of a half-open circuit to the
+// next request.
+func (cb *circuitBreaker) record(statusCode int, err error) {
+	if cb == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch {
+	case IsCanceled(err):
+		if cb.state == circuitHalfOpen {
+			cb.state = circuitOpen
+			cb.openedAt = circuitNow().Add(-cb.cooldown)
+		}
+	case err != nil || isUpstreamFailure(statusCode):
+		cb.failures++
+		if cb.state == circuitHalfOpen || cb.failures >= cb.failureThreshold {
+			cb.state = circuitOpen
+			cb.openedAt = circuitNow()
+		}
+	default:
+		cb.state = circuitClosed
+		cb.failures = 0
+	}
+}
+
+// responseStatusCode returns the status code of the response, or 0 if the host did not answer.
+func responseStatusCode(response *http.Response) int {
+	if response == nil {
+		return 0
+	}
+
+	return response.StatusCode
+}
+
+// isUpstreamFailure checks whether the status code reports a failing or overloaded host, rather than a rejected
+// request.
+func isUpstreamFailure(statusCode int) bool {
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
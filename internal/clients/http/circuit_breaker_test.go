@@ -0,0 +1,176 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+func TestSendRequestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	circuitNow = func() time.Time { return now }
+	defer func() { circuitNow = time.Now }()
+
+	var status, hits int32 = http.StatusServiceUnavailable, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+
+	config := &common.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: &metav1.Duration{Duration: time.Minute}}
+	newClient := func() Client {
+		c, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithCircuitBreaker("http-api", config))
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %v", err)
+		}
+		return c
+	}
+	// Both clients share the circuit of the host.
+	first, second := newClient(), newClient()
+	serverURL, _ := url.Parse(server.URL)
+	breaker := first.(*client).hostCircuitBreaker(serverURL.Host)
+
+	send := func(c Client) error {
+		_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+		return err
+	}
+
+	steps := []struct {
+		reason      string
+		client      Client
+		advance     time.Duration
+		status      int32
+		wantOpenErr bool
+		wantHits    int32
+		wantState   circuitState
+	}{
+		{reason: "Should count a first failure while closed", client: first, status: http.StatusServiceUnavailable, wantHits: 1, wantState: circuitClosed},
+		{reason: "Should open the circuit once the threshold is reached", client: first, status: http.StatusServiceUnavailable, wantHits: 2, wantState: circuitOpen},
+		{reason: "Should fail fast without sending the request while open, for every client", client: second, status: http.StatusOK, wantOpenErr: true, wantHits: 2, wantState: circuitOpen},
+		{reason: "Should reopen the circuit when the probe of the half-open circuit fails", client: second, advance: time.Minute, status: http.StatusServiceUnavailable, wantHits: 3, wantState: circuitOpen},
+		{reason: "Should fail fast again until the cooldown elapsed", client: first, advance: 30 * time.Second, status: http.StatusOK, wantOpenErr: true, wantHits: 3, wantState: circuitOpen},
+		{reason: "Should close the circuit when the probe of the half-open circuit succeeds", client: first, advance: 30 * time.Second, status: http.StatusOK, wantHits: 4, wantState: circuitClosed},
+		{reason: "Should send the requests once closed", client: second, status: http.StatusOK, wantHits: 5, wantState: circuitClosed},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		atomic.StoreInt32(&status, step.status)
+
+		err := send(step.client)
+		if IsCircuitOpen(err) != step.wantOpenErr {
+			t.Errorf("\n%s\nSendRequest(...): want a CircuitOpenError %t, got error %v", step.reason, step.wantOpenErr, err)
+		}
+		if got := atomic.LoadInt32(&hits); got != step.wantHits {
+			t.Errorf("\n%s\nSendRequest(...): want %d requests received by the server, got %d", step.reason, step.wantHits, got)
+		}
+		if breaker.state != step.wantState {
+			t.Errorf("\n%s\nSendRequest(...): want the circuit %s, got %s", step.reason, step.wantState, breaker.state)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	now := time.Now()
+	circuitNow = func() time.Time { return now }
+	defer func() { circuitNow = time.Now }()
+
+	cb := &circuitBreaker{host: "api.example.com", failureThreshold: 1, cooldown: time.Minute, state: circuitOpen, openedAt: now.Add(-time.Minute)}
+
+	if err := cb.allow(); err != nil {
+		t.Fatalf("allow(): want the probe of the half-open circuit allowed, got %v", err)
+	}
+	if err := cb.allow(); !IsCircuitOpen(err) {
+		t.Errorf("allow(): want a CircuitOpenError while the probe is in flight, got %v", err)
+	}
+
+	cb.record(0, &CanceledError{Err: errors.New("context canceled")})
+	if err := cb.allow(); err != nil {
+		t.Errorf("allow(): want the next request to probe the circuit after a canceled probe, got %v", err)
+	}
+}
+
+// failingTokenSource fails to issue tokens, e.g. because the token endpoint is down.
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, errors.New("token endpoint unavailable")
+}
+
+func TestCircuitBreakerScope(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &common.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: &metav1.Duration{Duration: time.Minute}}
+	newClient := func(providerConfig string, opts ...ClientOption) Client {
+		c, err := NewClient(logging.NewNopLogger(), time.Minute, "", append(opts, WithCircuitBreaker(providerConfig, config))...)
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %v", err)
+		}
+		return c
+	}
+	send := func(c Client) error {
+		_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+		return err
+	}
+
+	// A failure before the round trip, e.g. to acquire a token from another host, says nothing about the host.
+	unauthorized := newClient("unauthorized", WithTokenSource(failingTokenSource{}))
+	for range 2 {
+		if err := send(unauthorized); err == nil || IsCircuitOpen(err) {
+			t.Fatalf("SendRequest(...): want the token error, got %v", err)
+		}
+	}
+
+	failing := newClient("failing")
+	if err := send(failing); IsCircuitOpen(err) {
+		t.Fatalf("SendRequest(...): want the first request sent, got %v", err)
+	}
+	if err := send(failing); !IsCircuitOpen(err) {
+		t.Errorf("SendRequest(...): want the circuit of the ProviderConfig open, got %v", err)
+	}
+
+	// Another ProviderConfig with the same thresholds has its own circuit.
+	if err := send(newClient("other")); IsCircuitOpen(err) {
+		t.Errorf("SendRequest(...): want the circuit of another ProviderConfig closed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("SendRequest(...): want 2 requests received by the server, got %d", got)
+	}
+}
+
+func TestEvictIdleCircuitBreakers(t *testing.T) {
+	now := time.Now()
+	circuitNow = func() time.Time { return now }
+	defer func() { circuitNow = time.Now }()
+
+	c := &client{circuitProviderConfig: "evicted", circuitFailureThreshold: 1, circuitCooldown: time.Hour}
+	breaker := c.hostCircuitBreaker("api.example.com")
+	breaker.record(http.StatusServiceUnavailable, nil)
+
+	now = now.Add(2 * circuitIdleTimeout)
+	if got := c.hostCircuitBreaker("api.example.com"); got != breaker {
+		t.Errorf("hostCircuitBreaker(...): want an open circuit kept until its cooldown elapsed")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if got := c.hostCircuitBreaker("api.example.com"); got == breaker || got.state != circuitClosed {
+		t.Errorf("hostCircuitBreaker(...): want an idle circuit evicted and a closed circuit in its place")
+	}
+}
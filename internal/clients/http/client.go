@@ -1,7 +1,7 @@
 package http
 
 import (
-	"bytes"
+	"cmp"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -9,14 +9,26 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/version"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"golang.org/x/oauth2"
 )
 
 const (
-	authKey = "Authorization"
+	authKey      = "Authorization"
+	userAgentKey = "User-Agent"
+	maxRedirects = 10
+
+	errBodyRedirectDisallowed = "body-bearing redirects are disallowed"
+	errAcquireOAuth2Token     = "failed to acquire OAuth2 token"
 )
 
 // TLSConfigData contains the TLS configuration data loaded from secrets or inline.
@@ -29,6 +41,12 @@ type TLSConfigData struct {
 	ClientKey []byte
 	// InsecureSkipVerify controls whether to skip TLS verification
 	InsecureSkipVerify bool
+	// MinVersion is the minimum TLS version, zero for the default of Go
+	MinVersion uint16
+	// CipherSuites restricts the TLS 1.2 and earlier cipher suites, nil for the defaults of Go
+	CipherSuites []uint16
+	// HostPolicies choose how the server certificate is verified by host, instead of InsecureSkipVerify and CABundle
+	HostPolicies []HostTLSPolicy
 }
 
 // Client is the interface to interact with Http
@@ -40,12 +58,110 @@ type client struct {
 	log                logging.Logger
 	timeout            time.Duration
 	authorizationToken string
+	ipFamily           string
+	protocol           string
+	tokenSource        oauth2.TokenSource
+	signers            []RequestSigner
+	ntlm               *NTLMCredentials
+	addressGuard       *AddressGuard
+	hostAliases        HostAliases
+	responseFormat     string
+	userAgent          string
+
+	maxConcurrentPerHost int
+
+	circuitProviderConfig   string
+	circuitFailureThreshold int
+	circuitCooldown         time.Duration
+
+	expectContinueTimeout time.Duration
+
+	disallowBodyRedirects bool
+}
+
+// ClientOption configures optional behavior of the Http client.
+type ClientOption func(*client)
+
+// WithDisallowBodyRedirects makes 307 and 308 redirects of requests with a body fail instead of resubmitting the body.
+func WithDisallowBodyRedirects(disallow bool) ClientOption {
+	return func(c *client) {
+		c.disallowBodyRedirects = disallow
+	}
+}
+
+// WithTokenSource makes the client send a Bearer access token from the token source in the Authorization header,
+// instead of the authorization token.
+func WithTokenSource(tokenSource oauth2.TokenSource) ClientOption {
+	return func(c *client) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// WithUserAgent makes the client send the user agent in the User-Agent header of the requests that do not set this
+// header, instead of the default user agent of the provider. An empty user agent keeps the default.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// DefaultUserAgent returns the user agent sent by default, identifying the provider and its version.
+func DefaultUserAgent() string {
+	return "provider-http/" + version.Version
+}
+
+// WithIPFamily restricts or prefers the address family used when connecting to servers.
+func WithIPFamily(ipFamily string) ClientOption {
+	return func(c *client) {
+		c.ipFamily = ipFamily
+	}
+}
+
+// WithProtocol sets the HTTP protocol version the requests are sent with. An empty protocol negotiates it.
+func WithProtocol(protocol string) ClientOption {
+	return func(c *client) {
+		c.protocol = protocol
+	}
+}
+
+// timeoutContextKey is the context key of the timeout overriding the client timeout.
+type timeoutContextKey struct{}
+
+// ContextWithTimeout returns a copy of the context overriding the client timeout for the requests sent with it.
+func ContextWithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutContextKey{}, timeout)
+}
+
+// TimeoutFromContext returns the timeout overriding the client timeout set on the context, if any.
+func TimeoutFromContext(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(timeoutContextKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// redactedURLContextKey is the context key of the URL recorded in place of the sent URL.
+type redactedURLContextKey struct{}
+
+// ContextWithRedactedURL returns a copy of the context recording the given URL, with its secret values redacted,
+// in the details of the requests sent with it instead of the sent URL. An empty URL records the sent URL.
+func ContextWithRedactedURL(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, redactedURLContextKey{}, url)
+}
+
+// recordedURL returns the URL recorded in the details of a request sent to url with the context.
+func recordedURL(ctx context.Context, url string) string {
+	redacted, _ := ctx.Value(redactedURLContextKey{}).(string)
+	return cmp.Or(redacted, url)
 }
 
 type HttpResponse struct {
 	Body       string              `json:"body"`
 	Headers    map[string][]string `json:"headers"`
+	Trailers   map[string][]string `json:"trailers,omitempty"`
 	StatusCode int                 `json:"statusCode"`
+	StatusText string              `json:"statusText,omitempty"`
+	Timing     common.Timing       `json:"-"`
 }
 
 // Ensure HttpResponse implements interfaces.HTTPResponse
@@ -66,6 +182,16 @@ func (r *HttpResponse) GetHeaders() map[string][]string {
 	return r.Headers
 }
 
+// GetTrailers returns the response trailers.
+func (r *HttpResponse) GetTrailers() map[string][]string {
+	return r.Trailers
+}
+
+// GetStatusText returns the reason phrase of the response status line.
+func (r *HttpResponse) GetStatusText() string {
+	return r.StatusText
+}
+
 type Data struct {
 	Encrypted interface{} // Data containing encrypted data -> to be shown at the status
 	Decrypted interface{} // Data containing sensitive data -> to be sent
@@ -85,14 +211,12 @@ type HttpDetails struct {
 
 // SendRequest sends an HTTP request with optional TLS configuration.
 func (hc *client) SendRequest(ctx context.Context, method string, url string, body Data, headers Data, tlsConfigData *TLSConfigData) (details HttpDetails, err error) {
-	requestBody := []byte(body.Decrypted.(string))
-
 	// request contains the HTTP request that will be sent.
-	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
+	request, requestBody, err := newRequest(ctx, method, url, body)
 
 	// requestDetails contains the request details that will be logged.
 	requestDetails := HttpRequest{
-		URL:     url,
+		URL:     recordedURL(ctx, url),
 		Body:    body.Encrypted.(string),
 		Headers: headers.Encrypted.(map[string][]string),
 		Method:  method,
@@ -104,58 +228,129 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 		}, err
 	}
 
-	for key, values := range headers.Decrypted.(map[string][]string) {
-		for _, value := range values {
+	if hc.addressGuard != nil {
+		if err := hc.addressGuard.checkURL(request.URL); err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, err
+		}
+	}
+
+	release, err := hc.acquireHost(ctx, request.URL.Host)
+	if err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, classifyError(ctx, err)
+	}
+	defer release()
+
+	// Headers are added in a deterministic order, the values of a header keep their order.
+	decryptedHeaders := headers.Decrypted.(map[string][]string)
+	for _, key := range sortedKeys(decryptedHeaders) {
+		for _, value := range decryptedHeaders[key] {
 			request.Header.Add(key, value)
 		}
 	}
 
+	if _, exists := request.Header[userAgentKey]; !exists {
+		request.Header[userAgentKey] = []string{hc.userAgent}
+	}
+
 	// Add the authorization token to the request if it doesn't already exist.
-	if _, exists := request.Header[authKey]; !exists && hc.authorizationToken != "" {
-		request.Header[authKey] = []string{hc.authorizationToken}
+	if _, exists := request.Header[authKey]; !exists {
+		authorization, err := hc.authorization()
+		if err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, err
+		}
+		if authorization != "" {
+			request.Header[authKey] = []string{authorization}
+		}
 	}
 
 	// Build TLS configuration
-	tlsConfig, err := buildTLSConfig(tlsConfigData)
+	tlsConfig, err := buildTLSConfig(tlsConfigData.forHost(request.URL.Hostname()))
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
 		}, fmt.Errorf("failed to build TLS config: %w", err)
 	}
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: tlsConfig,
-			Proxy:           http.ProxyFromEnvironment, // Use proxy settings from environment
-		},
-		Timeout: hc.timeout,
+	// The exchanges that are not plain HTTP requests need the whole body in memory.
+	_, streamed := body.Decrypted.(*BodyStream)
+	if _, events := EventStreamFromContext(ctx); streamed && (isWebSocketURL(request.URL) || events) {
+		if requestBody, err = bufferBody(request); err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, err
+		}
 	}
 
-	response, err := client.Do(request)
-	if err != nil {
+	// Only the round trip to the host counts for its circuit, the local failures above say nothing about it.
+	breaker := hc.hostCircuitBreaker(request.URL.Host)
+	if err := breaker.allow(); err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
 		}, err
 	}
 
-	responsebody, err := io.ReadAll(response.Body)
-	if err != nil {
+	// ws and wss URLs exchange a single message over a WebSocket instead of sending an HTTP request.
+	if isWebSocketURL(request.URL) {
+		response, err := hc.sendWebSocket(ctx, request, requestBody, tlsConfig)
+		breaker.record(response.StatusCode, err)
+		if err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, classifyError(ctx, err)
+		}
+
+		hc.log.Info(fmt.Sprint("websocket message sent: ", toJSON(requestDetails)))
+
 		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
+			HttpResponse: response,
+			HttpRequest:  requestDetails,
+		}, nil
 	}
 
-	beautifiedResponse := HttpResponse{
-		Body:       string(responsebody),
-		Headers:    response.Header,
-		StatusCode: response.StatusCode,
+	protocol := hc.protocol
+	if hc.ntlm != nil {
+		// NTLM authenticates a connection, which HTTP/2 multiplexes.
+		protocol = common.ProtocolHTTP1
 	}
 
-	err = response.Body.Close()
+	expectContinueTimeout := hc.expectContinue(request)
+	client := &http.Client{
+		Transport: newSigningTransport(newNTLMTransport(&http.Transport{
+			TLSClientConfig:       tlsConfig,
+			Proxy:                 hc.proxy(),
+			DialContext:           newDialContext(hc.ipFamily, hc.addressGuard, hc.hostAliases),
+			Protocols:             protocols(protocol),
+			ExpectContinueTimeout: expectContinueTimeout,
+		}, hc.ntlm), hc.signers),
+		Timeout:       hc.requestTimeout(ctx),
+		CheckRedirect: checkRedirect(hc.disallowBodyRedirects, tlsConfigData),
+	}
+
+	timer := newRequestTimer()
+	request = request.WithContext(httptrace.WithClientTrace(request.Context(), timer.trace()))
+
+	response, responsebody, err := send(ctx, client, request, requestBody)
+	breaker.record(responseStatusCode(response), err)
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
-		}, err
+		}, classifyError(ctx, err)
+	}
+	timer.finish()
+
+	beautifiedResponse := HttpResponse{
+		Body:       hc.decodeResponseBody(responsebody),
+		Headers:    canonicalHeaders(response.Header),
+		Trailers:   canonicalHeaders(trailers(response.Trailer)),
+		StatusCode: response.StatusCode,
+		StatusText: statusText(response),
+		Timing:     timer.timing(),
 	}
 
 	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(requestDetails)))
@@ -167,12 +362,153 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 }
 
 // NewClient returns a new Http Client
-func NewClient(log logging.Logger, timeout time.Duration, authorizationToken string) (Client, error) {
-	return &client{
+func NewClient(log logging.Logger, timeout time.Duration, authorizationToken string, opts ...ClientOption) (Client, error) {
+	c := &client{
 		log:                log,
 		timeout:            timeout,
 		authorizationToken: authorizationToken,
-	}, nil
+		userAgent:          DefaultUserAgent(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// requestTimeout returns the timeout of a request sent with the context, the maximum duration of a streamed
+// response or event stream if any, the timeout set on the context if any, or the client timeout.
+func (hc *client) requestTimeout(ctx context.Context) time.Duration {
+	if stream, ok := StreamFromContext(ctx); ok {
+		return stream.MaxDuration
+	}
+
+	if events, ok := EventStreamFromContext(ctx); ok {
+		return events.MaxDuration
+	}
+
+	if timeout, ok := TimeoutFromContext(ctx); ok {
+		return timeout
+	}
+
+	return hc.timeout
+}
+
+// proxy returns the proxy settings from the environment, or nil if the client has an address guard or host
+// aliases. Through a proxy, the client only dials the proxy, so neither the guard nor the aliases would apply to
+// the host of the request.
+func (hc *client) proxy() func(*http.Request) (*url.URL, error) {
+	if hc.addressGuard != nil || len(hc.hostAliases) > 0 {
+		return nil
+	}
+
+	return http.ProxyFromEnvironment
+}
+
+// send sends the request and reads its response body, as server-sent events if the context sets event stream
+// options. The body of the returned response is closed.
+func send(ctx context.Context, client *http.Client, request *http.Request, requestBody []byte) (*http.Response, []byte, error) {
+	if events, ok := EventStreamFromContext(ctx); ok {
+		return sendEventStream(client, request, requestBody, events)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Trailers are only populated once the body has been read to EOF.
+	responseBody, err := readBody(ctx, response.Body)
+	if err != nil {
+		// Closing the body stops a stream that is still being sent.
+		_ = response.Body.Close()
+		return nil, nil, err
+	}
+
+	return response, responseBody, response.Body.Close()
+}
+
+// readBody reads the response body, as a stream of lines if the context sets stream options, or entirely.
+func readBody(ctx context.Context, body io.Reader) ([]byte, error) {
+	if stream, ok := StreamFromContext(ctx); ok {
+		return readStream(body, stream)
+	}
+
+	return io.ReadAll(body)
+}
+
+// authorization returns the Authorization header value of the client, a Bearer access token from the token source
+// if set, or the authorization token.
+func (hc *client) authorization() (string, error) {
+	if hc.tokenSource == nil {
+		return hc.authorizationToken, nil
+	}
+
+	token, err := hc.tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", errAcquireOAuth2Token, err)
+	}
+
+	return token.Type() + " " + token.AccessToken, nil
+}
+
+// checkRedirect returns the redirect policy of the client.
+// It keeps the default limit of 10 redirects and, if body redirects are disallowed, rejects
+// 307 and 308 redirects that would resubmit the request body. Redirects to a host verified with another TLS host
+// policy than the host of the request are rejected, since the connection keeps the policy of the request.
+func checkRedirect(disallowBodyRedirects bool, tlsConfigData *TLSConfigData) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		if tlsConfigData.hostPolicy(req.URL.Hostname()) != tlsConfigData.hostPolicy(via[0].URL.Hostname()) {
+			return fmt.Errorf("%s: redirect to %s", errTLSHostPolicyRedirect, req.URL)
+		}
+
+		if disallowBodyRedirects && isBodyRedirect(req) {
+			return fmt.Errorf("%s: %d redirect to %s would resubmit the request body", errBodyRedirectDisallowed, req.Response.StatusCode, req.URL)
+		}
+
+		return nil
+	}
+}
+
+// isBodyRedirect checks if the redirected request resubmits a body, which only happens for 307 and 308.
+func isBodyRedirect(req *http.Request) bool {
+	if req.Response == nil || req.Body == nil || req.Body == http.NoBody {
+		return false
+	}
+
+	return req.Response.StatusCode == http.StatusTemporaryRedirect || req.Response.StatusCode == http.StatusPermanentRedirect
+}
+
+// trailers returns the trailers that were actually sent by the server.
+// Trailer keys announced in the headers but never sent are present with nil values, they are dropped.
+func trailers(trailer http.Header) map[string][]string {
+	if len(trailer) == 0 {
+		return nil
+	}
+
+	sent := make(map[string][]string, len(trailer))
+	for key, values := range trailer {
+		if len(values) > 0 {
+			sent[key] = values
+		}
+	}
+
+	if len(sent) == 0 {
+		return nil
+	}
+
+	return sent
+}
+
+// statusText returns the reason phrase of the status line of the response, e.g. "Not Found" for "404 Not Found".
+// It is empty if the server sent none.
+func statusText(response *http.Response) string {
+	return strings.TrimSpace(strings.TrimPrefix(response.Status, strconv.Itoa(response.StatusCode)))
 }
 
 // toJSON converts the request to a JSON string.
@@ -195,6 +531,9 @@ func buildTLSConfig(data *TLSConfigData) (*tls.Config, error) {
 	tlsConfig := &tls.Config{
 		// #nosec G402 - InsecureSkipVerify is configurable by the user
 		InsecureSkipVerify: data.InsecureSkipVerify,
+		// #nosec G402 - MinVersion is configurable by the user and defaults to the minimum version of Go
+		MinVersion:   data.MinVersion,
+		CipherSuites: data.CipherSuites,
 	}
 
 	// Load CA bundle if provided
@@ -15,6 +15,8 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
 )
 
 const (
@@ -635,14 +637,300 @@ func TestSendRequestIntegration(t *testing.T) {
 	})
 }
 
+func TestSendRequestTLSHandshakeSettings(t *testing.T) {
+	// The server only offers TLS 1.2 with a single cipher suite.
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	cases := map[string]struct {
+		tlsConfig *common.TLSConfig
+		wantErr   string
+	}{
+		"DefaultsAcceptServer": {
+			tlsConfig: &common.TLSConfig{InsecureSkipVerify: true},
+		},
+		"AllowedVersionAndCipherSuite": {
+			tlsConfig: &common.TLSConfig{
+				InsecureSkipVerify: true,
+				TLSMinVersion:      "1.2",
+				CipherSuites:       []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			},
+		},
+		"DisallowedVersion": {
+			tlsConfig: &common.TLSConfig{InsecureSkipVerify: true, TLSMinVersion: "1.3"},
+			wantErr:   "protocol version not supported",
+		},
+		"DisallowedCipherSuite": {
+			tlsConfig: &common.TLSConfig{
+				InsecureSkipVerify: true,
+				CipherSuites:       []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+			},
+			wantErr: "handshake failure",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tlsConfigData, err := LoadTLSConfig(context.Background(), nil, tc.tlsConfig)
+			if err != nil {
+				t.Fatalf("LoadTLSConfig(...): unexpected error: %v", err)
+			}
+
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, "")
+			headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, headers, tlsConfigData)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("SendRequest(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSendRequestTrailers(t *testing.T) {
+	cases := map[string]struct {
+		handler http.HandlerFunc
+		want    map[string][]string
+	}{
+		"TrailersSentAfterBody": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"result": "success"}`))
+				w.Header().Set("Grpc-Status", "0")
+				w.Header().Set("Grpc-Message", "OK")
+			},
+			want: map[string][]string{
+				"Grpc-Status":  {"0"},
+				"Grpc-Message": {"OK"},
+			},
+		},
+		"AnnouncedTrailerNotSent": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Trailer", "Grpc-Status")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"result": "success"}`))
+			},
+			want: nil,
+		},
+		"NoTrailers": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"result": "success"}`))
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			result, err := c.SendRequest(
+				context.Background(),
+				http.MethodGet,
+				server.URL,
+				Data{Encrypted: "", Decrypted: ""},
+				Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+				&TLSConfigData{},
+			)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, result.HttpResponse.Trailers); diff != "" {
+				t.Errorf("SendRequest(...): -want trailers, +got trailers: %s", diff)
+			}
+		})
+	}
+}
+
+// rawStatusLine returns a handler writing the given status line itself, since the server only sends the standard
+// reason phrases.
+func rawStatusLine(statusLine string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf.WriteString(statusLine + "\r\nContent-Length: 0\r\nConnection: close\r\n\r\n")
+		buf.Flush()
+	}
+}
+
+func TestSendRequestStatusText(t *testing.T) {
+	cases := map[string]struct {
+		reason         string
+		handler        http.HandlerFunc
+		wantStatusCode int
+		wantStatusText string
+	}{
+		"StandardReasonPhrase": {
+			reason: "Should record the standard reason phrase of the status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantStatusText: "Not Found",
+		},
+		"CustomReasonPhrase": {
+			reason:         "Should record the reason phrase sent by the server rather than the standard one",
+			handler:        rawStatusLine("HTTP/1.1 409 Resource Locked By Another Job"),
+			wantStatusCode: http.StatusConflict,
+			wantStatusText: "Resource Locked By Another Job",
+		},
+		"NoReasonPhrase": {
+			reason:         "Should record an empty reason phrase when the server sent none",
+			handler:        rawStatusLine("HTTP/1.1 599"),
+			wantStatusCode: 599,
+			wantStatusText: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			result, err := c.SendRequest(
+				context.Background(),
+				http.MethodGet,
+				server.URL,
+				Data{Encrypted: "", Decrypted: ""},
+				Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+				&TLSConfigData{},
+			)
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if result.HttpResponse.StatusCode != tc.wantStatusCode {
+				t.Errorf("\n%s\nSendRequest(...): want status code %d, got %d", tc.reason, tc.wantStatusCode, result.HttpResponse.StatusCode)
+			}
+			if result.HttpResponse.StatusText != tc.wantStatusText {
+				t.Errorf("\n%s\nSendRequest(...): want status text %q, got %q", tc.reason, tc.wantStatusText, result.HttpResponse.StatusText)
+			}
+		})
+	}
+}
+
+func TestSendRequestUserAgent(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		userAgent string
+		headers   map[string][]string
+		want      string
+	}{
+		"Default": {
+			reason: "Should send the default user agent of the provider",
+			want:   DefaultUserAgent(),
+		},
+		"Override": {
+			reason:    "Should send the user agent of the resource",
+			userAgent: "billing-sync/1.2",
+			want:      "billing-sync/1.2",
+		},
+		"ExplicitHeader": {
+			reason:    "Should send the User-Agent header set by the request",
+			userAgent: "billing-sync/1.2",
+			headers:   map[string][]string{"user-agent": {"curl/8.0"}},
+			want:      "curl/8.0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("User-Agent")
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "", WithUserAgent(tc.userAgent))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			headers := tc.headers
+			if headers == nil {
+				headers = map[string][]string{}
+			}
+			if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: headers, Decrypted: headers}, &TLSConfigData{}); err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("\n%s\nSendRequest(...): want User-Agent %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSendRequestRedactedURL(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+
+	ctx := ContextWithRedactedURL(context.Background(), server.URL+"?token=****")
+	details, err := c.SendRequest(ctx, http.MethodGet, server.URL+"?token=s3cr3t", Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+
+	if gotQuery != "token=s3cr3t" {
+		t.Errorf("SendRequest(...): want the request sent with the real URL, got the query %q", gotQuery)
+	}
+	if want := server.URL + "?token=****"; details.HttpRequest.URL != want {
+		t.Errorf("SendRequest(...): want the redacted URL %q recorded, got %q", want, details.HttpRequest.URL)
+	}
+}
+
 func TestNewClient(t *testing.T) {
 	type args struct {
 		timeout            time.Duration
 		authorizationToken string
+		opts               []ClientOption
+	}
+
+	type want struct {
+		ipFamily string
 	}
 
 	cases := map[string]struct {
 		args args
+		want want
 	}{
 		"ClientWithDefaultValues": {
 			args: args{
@@ -662,11 +950,20 @@ func TestNewClient(t *testing.T) {
 				authorizationToken: "Bearer test-token",
 			},
 		},
+		"ClientWithIPFamily": {
+			args: args{
+				timeout: 30 * time.Second,
+				opts:    []ClientOption{WithIPFamily(common.IPFamilyIPv4)},
+			},
+			want: want{
+				ipFamily: common.IPFamilyIPv4,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, err := NewClient(logging.NewNopLogger(), tc.args.timeout, tc.args.authorizationToken)
+			got, err := NewClient(logging.NewNopLogger(), tc.args.timeout, tc.args.authorizationToken, tc.args.opts...)
 
 			if err != nil {
 				t.Fatalf("NewClient(...): unexpected error: %v", err)
@@ -688,6 +985,10 @@ func TestNewClient(t *testing.T) {
 			if c.authorizationToken != tc.args.authorizationToken {
 				t.Errorf("NewClient(...): authorizationToken = %v, want %v", c.authorizationToken, tc.args.authorizationToken)
 			}
+
+			if c.ipFamily != tc.want.ipFamily {
+				t.Errorf("NewClient(...): ipFamily = %v, want %v", c.ipFamily, tc.want.ipFamily)
+			}
 		})
 	}
 }
@@ -1008,6 +1309,32 @@ func TestHTTPClientConfiguration(t *testing.T) {
 			t.Error("SendRequest(...): expected timeout error, got nil")
 		}
 	})
+
+	t.Run("ContextTimeoutOverridesClientTimeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(500 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		c, err := NewClient(logging.NewNopLogger(), 100*time.Millisecond, "")
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %v", err)
+		}
+
+		_, err = c.SendRequest(
+			ContextWithTimeout(context.Background(), 5*time.Second),
+			http.MethodGet,
+			server.URL,
+			Data{Encrypted: "", Decrypted: ""},
+			Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+			&TLSConfigData{},
+		)
+
+		if err != nil {
+			t.Errorf("SendRequest(...): unexpected error: %v", err)
+		}
+	})
 }
 
 func TestTLSConfigWithSystemCertPool(t *testing.T) {
@@ -1116,3 +1443,77 @@ func TestTLSConfigInsecureSkipVerifyFlag(t *testing.T) {
 		}
 	})
 }
+
+func TestSendRequestBodyRedirect(t *testing.T) {
+	cases := map[string]struct {
+		disallowBodyRedirects bool
+		redirectStatus        int
+		wantBody              string
+		wantErr               bool
+	}{
+		"TemporaryRedirectResubmitsBody": {
+			redirectStatus: http.StatusTemporaryRedirect,
+			wantBody:       `{"name": "test"}`,
+		},
+		"PermanentRedirectResubmitsBody": {
+			redirectStatus: http.StatusPermanentRedirect,
+			wantBody:       `{"name": "test"}`,
+		},
+		"BodyRedirectDisallowed": {
+			disallowBodyRedirects: true,
+			redirectStatus:        http.StatusTemporaryRedirect,
+			wantErr:               true,
+		},
+		"FoundRedirectAllowedWhenBodyRedirectsDisallowed": {
+			disallowBodyRedirects: true,
+			redirectStatus:        http.StatusFound,
+			wantBody:              "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/new", tc.redirectStatus)
+			})
+			mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusOK)
+				w.Write(body)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "", WithDisallowBodyRedirects(tc.disallowBodyRedirects))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			body := `{"name": "test"}`
+			result, err := c.SendRequest(
+				context.Background(),
+				http.MethodPost,
+				server.URL+"/old",
+				Data{Encrypted: body, Decrypted: body},
+				Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+				&TLSConfigData{},
+			)
+
+			if tc.wantErr {
+				if err == nil || !strings.Contains(err.Error(), errBodyRedirectDisallowed) {
+					t.Fatalf("SendRequest(...): expected body redirect error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, result.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	// defaultCredentialsTTL is how long fetched credentials are reused by default.
+	defaultCredentialsTTL = time.Minute
+
+	// credentialsEndpointTimeout bounds the requests sent to a credentials endpoint.
+	credentialsEndpointTimeout = 30 * time.Second
+
+	// maxCredentialsSize bounds the size of a response of a credentials endpoint.
+	maxCredentialsSize = 1 << 20
+
+	errCredentialsStatus = "credentials endpoint answered with status code %d"
+	errEmptyCredentials  = "credentials endpoint returned empty credentials"
+)
+
+// endpointCredentials caches the credentials fetched per endpoint, so they are shared across reconciles and
+// resources instead of being fetched on every connect.
+var endpointCredentials = newCredentialsCache()
+
+// credentialsCache is a concurrency safe cache of the credentials fetched from endpoints.
+type credentialsCache struct {
+	mu      sync.Mutex
+	entries map[string]*cachedCredentials
+	client  *http.Client
+	now     func() time.Time
+}
+
+// cachedCredentials are credentials fetched from an endpoint, reused until they expire. Its mutex is held while
+// they are fetched, so a slow endpoint only delays the requests using its credentials.
+type cachedCredentials struct {
+	mu     sync.Mutex
+	value  string
+	expiry time.Time
+}
+
+func newCredentialsCache() *credentialsCache {
+	return &credentialsCache{
+		entries: map[string]*cachedCredentials{},
+		client:  &http.Client{Timeout: credentialsEndpointTimeout},
+		now:     time.Now,
+	}
+}
+
+// FetchEndpointCredentials returns the credentials fetched from the endpoint, reusing the ones fetched for the same
+// endpoint until their TTL expires. Failures are not cached, the next call fetches the credentials again.
+func FetchEndpointCredentials(ctx context.Context, config *common.CredentialsEndpointConfig) (string, error) {
+	if config == nil {
+		return "", fmt.Errorf("no credentials endpoint configured")
+	}
+
+	return endpointCredentials.get(ctx, config)
+}
+
+// get returns the cached credentials of the endpoint, fetching them if they are missing or expired. Concurrent
+// calls for the same endpoint wait for a single fetch, while the other endpoints are not blocked by it.
+func (c *credentialsCache) get(ctx context.Context, config *common.CredentialsEndpointConfig) (string, error) {
+	cached := c.entry(config.URL + "\x00" + config.ResponseJQ)
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	if cached.value != "" && c.now().Before(cached.expiry) {
+		return cached.value, nil
+	}
+
+	value, err := c.fetch(ctx, config)
+	if err != nil {
+		cached.value, cached.expiry = "", time.Time{}
+		return "", err
+	}
+
+	cached.value, cached.expiry = value, c.now().Add(credentialsTTL(config))
+	return value, nil
+}
+
+// entry returns the cached credentials of the key, creating an empty entry if there is none yet.
+func (c *credentialsCache) entry(key string) *cachedCredentials {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok {
+		cached = &cachedCredentials{}
+		c.entries[key] = cached
+	}
+
+	return cached
+}
+
+// fetch sends a GET request to the endpoint and extracts the credentials from its response.
+func (c *credentialsCache) fetch(ctx context.Context, config *common.CredentialsEndpointConfig) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, config.URL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return "", fmt.Errorf(errCredentialsStatus, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxCredentialsSize))
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSpace(string(body))
+	if config.ResponseJQ != "" {
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			return "", fmt.Errorf("credentials endpoint returned invalid JSON: %w", err)
+		}
+		if value, err = jq.ParseString(config.ResponseJQ, data); err != nil {
+			return "", err
+		}
+	}
+
+	if value == "" {
+		return "", fmt.Errorf(errEmptyCredentials)
+	}
+
+	return value, nil
+}
+
+// credentialsTTL returns how long the credentials fetched from the endpoint are reused.
+func credentialsTTL(config *common.CredentialsEndpointConfig) time.Duration {
+	if config.TTL == nil || config.TTL.Duration <= 0 {
+		return defaultCredentialsTTL
+	}
+
+	return config.TTL.Duration
+}
@@ -0,0 +1,185 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// newCredentialsServer returns a credentials endpoint minting numbered tokens, replacing %d in format with the
+// number of the request, or failing with the status code while it is set. The number of requests it received is
+// returned as well.
+func newCredentialsServer(t *testing.T, format string, status *int32) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&received, 1)
+		if code := atomic.LoadInt32(status); code != 0 {
+			w.WriteHeader(int(code))
+			return
+		}
+		fmt.Fprint(w, strings.ReplaceAll(format, "%d", fmt.Sprint(n)))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &received
+}
+
+func TestFetchEndpointCredentials(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		format     string
+		responseJQ string
+		want       string
+	}{
+		"PlainBody": {
+			reason: "Should use the whole body without its surrounding white space",
+			format: "token-%d\n",
+			want:   "token-1",
+		},
+		"JSONBody": {
+			reason:     "Should extract the credentials from a JSON response with responseJQ",
+			format:     `{"token":"token-%d","expiresIn":300}`,
+			responseJQ: ".token",
+			want:       "token-1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var status int32
+			server, _ := newCredentialsServer(t, tc.format, &status)
+
+			got, err := newCredentialsCache().get(context.Background(), &common.CredentialsEndpointConfig{URL: server.URL, ResponseJQ: tc.responseJQ})
+			if err != nil {
+				t.Fatalf("\n%s\nget(...): unexpected error: %v", tc.reason, err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nget(...): want %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFetchEndpointCredentialsCache(t *testing.T) {
+	var status int32
+	server, received := newCredentialsServer(t, "token-%d", &status)
+	config := &common.CredentialsEndpointConfig{URL: server.URL, TTL: &metav1.Duration{Duration: 10 * time.Second}}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cache := newCredentialsCache()
+	cache.now = func() time.Time { return now }
+
+	steps := []struct {
+		reason       string
+		advance      time.Duration
+		status       int32
+		want         string
+		wantErr      string
+		wantReceived int32
+	}{
+		{reason: "Should fetch the credentials on the first call", want: "token-1", wantReceived: 1},
+		{reason: "Should reuse the credentials until their TTL expires", advance: 9 * time.Second, want: "token-1", wantReceived: 1},
+		{reason: "Should fetch the credentials again once their TTL expired", advance: time.Second, want: "token-2", wantReceived: 2},
+		{reason: "Should fail when the endpoint fails once the TTL expired", advance: 10 * time.Second, status: http.StatusServiceUnavailable, wantErr: "credentials endpoint answered with status code 503", wantReceived: 3},
+		{reason: "Should not cache a failure", want: "token-4", wantReceived: 4},
+	}
+
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		atomic.StoreInt32(&status, s.status)
+
+		got, err := cache.get(context.Background(), config)
+		if s.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), s.wantErr) {
+				t.Errorf("\n%s\nget(...) #%d: want error containing %q, got %v", s.reason, i, s.wantErr, err)
+			}
+		} else if err != nil {
+			t.Errorf("\n%s\nget(...) #%d: unexpected error: %v", s.reason, i, err)
+		}
+		if got != s.want {
+			t.Errorf("\n%s\nget(...) #%d: want %q, got %q", s.reason, i, s.want, got)
+		}
+		if n := atomic.LoadInt32(received); n != s.wantReceived {
+			t.Errorf("\n%s\nget(...) #%d: want %d requests to the endpoint, got %d", s.reason, i, s.wantReceived, n)
+		}
+	}
+}
+
+func TestFetchEndpointCredentialsErrors(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		format     string
+		responseJQ string
+		wantErr    string
+	}{
+		"Empty": {
+			reason:  "Should fail when the endpoint returns no credentials",
+			format:  "  \n",
+			wantErr: errEmptyCredentials,
+		},
+		"NotJSON": {
+			reason:     "Should fail when responseJQ is set and the response is not JSON",
+			format:     "token-%d",
+			responseJQ: ".token",
+			wantErr:    "credentials endpoint returned invalid JSON",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var status int32
+			server, _ := newCredentialsServer(t, tc.format, &status)
+
+			_, err := newCredentialsCache().get(context.Background(), &common.CredentialsEndpointConfig{URL: server.URL, ResponseJQ: tc.responseJQ})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("\n%s\nget(...): want error containing %q, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFetchEndpointCredentialsSlowEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fmt.Fprint(w, "slow-token")
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+
+	var status int32
+	fast, _ := newCredentialsServer(t, "token-%d", &status)
+
+	cache := newCredentialsCache()
+	go func() {
+		_, _ = cache.get(context.Background(), &common.CredentialsEndpointConfig{URL: slow.URL})
+	}()
+
+	// The fetch from the slow endpoint is in flight while the credentials of the other endpoint are fetched.
+	time.Sleep(50 * time.Millisecond)
+	fetched := make(chan string, 1)
+	go func() {
+		got, _ := cache.get(context.Background(), &common.CredentialsEndpointConfig{URL: fast.URL})
+		fetched <- got
+	}()
+
+	select {
+	case got := <-fetched:
+		if got != "token-1" {
+			t.Errorf("get(...): want %q, got %q", "token-1", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("get(...): want the credentials of an endpoint fetched while another endpoint is slow")
+	}
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// dialContextFunc is the signature of http.Transport's DialContext.
+type dialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// newDialContext returns a DialContext honoring the given host aliases, address family preference and address
+// guard. A nil function is returned when none is set, so the transport keeps its default dialer.
+func newDialContext(ipFamily string, guard *AddressGuard, aliases HostAliases) dialContextFunc {
+	dial := familyDialContext(ipFamily, guard)
+	if len(aliases) == 0 {
+		return dial
+	}
+
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	return aliases.dialContext(dial)
+}
+
+// familyDialContext returns a DialContext honoring the given address family preference and address guard, or nil
+// when neither is set.
+func familyDialContext(ipFamily string, guard *AddressGuard) dialContextFunc {
+	dialer := guardedDialer(guard)
+
+	switch ipFamily {
+	case common.IPFamilyIPv4:
+		return restrictedDialContext(dialer, "tcp4")
+	case common.IPFamilyIPv6:
+		return restrictedDialContext(dialer, "tcp6")
+	case common.IPFamilyPreferIPv4:
+		return preferredDialContext(dialer, net.DefaultResolver, true)
+	case common.IPFamilyPreferIPv6:
+		return preferredDialContext(dialer, net.DefaultResolver, false)
+	default:
+		if guard != nil {
+			return dialer.DialContext
+		}
+		return nil
+	}
+}
+
+// restrictedDialContext only dials addresses of the given tcp network.
+func restrictedDialContext(dialer *net.Dialer, network string) dialContextFunc {
+	return func(ctx context.Context, _, address string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
+// preferredDialContext resolves the host and dials the addresses of the preferred family first.
+func preferredDialContext(dialer *net.Dialer, resolver *net.Resolver, preferIPv4 bool) dialContextFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		addrs, err := resolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		sortByFamily(addrs, preferIPv4)
+
+		var lastErr error
+		for _, addr := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.IP.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no addresses found for host %s", host)
+		}
+
+		return nil, lastErr
+	}
+}
+
+// sortByFamily moves the addresses of the preferred family to the front, keeping the resolver order otherwise.
+func sortByFamily(addrs []net.IPAddr, preferIPv4 bool) {
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return isIPv4(addrs[i]) == preferIPv4 && isIPv4(addrs[j]) != preferIPv4
+	})
+}
+
+func isIPv4(addr net.IPAddr) bool {
+	return addr.IP.To4() != nil
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+func TestNewDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("url.Parse(...): unexpected error: %v", err)
+	}
+
+	cases := map[string]struct {
+		ipFamily string
+		wantNil  bool
+		wantErr  bool
+	}{
+		"NoPreferenceKeepsDefaultDialer": {
+			ipFamily: "",
+			wantNil:  true,
+		},
+		"IPv4DialsIPv4Server": {
+			ipFamily: common.IPFamilyIPv4,
+		},
+		"IPv6RejectsIPv4Server": {
+			ipFamily: common.IPFamilyIPv6,
+			wantErr:  true,
+		},
+		"PreferIPv6DialsIPLiteral": {
+			ipFamily: common.IPFamilyPreferIPv6,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dial := newDialContext(tc.ipFamily, nil, nil)
+			if tc.wantNil {
+				if dial != nil {
+					t.Fatalf("newDialContext(%q): expected nil dialer", tc.ipFamily)
+				}
+				return
+			}
+
+			conn, err := dial(context.Background(), "tcp", serverURL.Host)
+			if tc.wantErr {
+				if err == nil {
+					conn.Close()
+					t.Fatalf("newDialContext(%q): expected dial error, got nil", tc.ipFamily)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("newDialContext(%q): unexpected dial error: %v", tc.ipFamily, err)
+			}
+			conn.Close()
+		})
+	}
+}
+
+func TestSortByFamily(t *testing.T) {
+	v4a := net.IPAddr{IP: net.ParseIP("10.0.0.1")}
+	v4b := net.IPAddr{IP: net.ParseIP("10.0.0.2")}
+	v6a := net.IPAddr{IP: net.ParseIP("fd00::1")}
+	v6b := net.IPAddr{IP: net.ParseIP("fd00::2")}
+
+	cases := map[string]struct {
+		addrs      []net.IPAddr
+		preferIPv4 bool
+		want       []net.IPAddr
+	}{
+		"PreferIPv4": {
+			addrs:      []net.IPAddr{v6a, v4a, v6b, v4b},
+			preferIPv4: true,
+			want:       []net.IPAddr{v4a, v4b, v6a, v6b},
+		},
+		"PreferIPv6": {
+			addrs:      []net.IPAddr{v4a, v6a, v4b, v6b},
+			preferIPv4: false,
+			want:       []net.IPAddr{v6a, v6b, v4a, v4b},
+		},
+		"SingleFamilyKeepsOrder": {
+			addrs:      []net.IPAddr{v4b, v4a},
+			preferIPv4: false,
+			want:       []net.IPAddr{v4b, v4a},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sortByFamily(tc.addrs, tc.preferIPv4)
+			if diff := cmp.Diff(tc.want, tc.addrs); diff != "" {
+				t.Errorf("sortByFamily(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// CanceledError reports a request interrupted by its context, canceled or past its deadline, e.g. because the
+// reconcile timed out, rather than by the server.
+type CanceledError struct {
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return "request canceled: " + e.Err.Error()
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError reports a request the server did not answer within the request timeout.
+type TimeoutError struct {
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return "request timed out: " + e.Err.Error()
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// DNSError reports a request whose host does not resolve, e.g. because its URL is mistyped.
+type DNSError struct {
+	Err error
+}
+
+func (e *DNSError) Error() string {
+	return "DNS resolution failed: " + e.Err.Error()
+}
+
+func (e *DNSError) Unwrap() error {
+	return e.Err
+}
+
+// CircuitOpenError reports a request that was not sent because the circuit breaker of its host is open, after
+// consecutive failures of the host.
+type CircuitOpenError struct {
+	Host string
+	// RetryIn is the remaining cooldown of the circuit, zero while a probe request is in flight.
+	RetryIn time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e.RetryIn > 0 {
+		return fmt.Sprintf("circuit breaker of host %s is open, retrying in %s", e.Host, e.RetryIn.Round(time.Second))
+	}
+	return fmt.Sprintf("circuit breaker of host %s is open, probing the host", e.Host)
+}
+
+// IsCanceled checks whether the request failed because its context was canceled or its deadline exceeded.
+// Such a failure says nothing about the server and should not count as a failed attempt.
+func IsCanceled(err error) bool {
+	var canceled *CanceledError
+	return errors.As(err, &canceled)
+}
+
+// IsTimeout checks whether the request failed because the server did not answer within the request timeout.
+func IsTimeout(err error) bool {
+	var timeout *TimeoutError
+	return errors.As(err, &timeout)
+}
+
+// IsDNSFailure checks whether the request failed because its host does not resolve.
+// Retrying such a failure only delays it, unless the name is about to be registered.
+func IsDNSFailure(err error) bool {
+	var dnsErr *DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// IsCircuitOpen checks whether the request was not sent because the circuit breaker of its host is open.
+func IsCircuitOpen(err error) bool {
+	var circuitOpen *CircuitOpenError
+	return errors.As(err, &circuitOpen)
+}
+
+// classifyError wraps the error of a request sent with the context into a CanceledError when the context is done,
+// a TimeoutError when the request timed out, or a DNSError when its host does not resolve. Other errors are
+// returned unchanged.
+func classifyError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return &CanceledError{Err: err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{Err: err}
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return &DNSError{Err: err}
+	}
+
+	return err
+}
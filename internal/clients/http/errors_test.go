@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestSendRequestErrorClassification(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		timeout      time.Duration
+		ctx          func() (context.Context, context.CancelFunc)
+		wantCanceled bool
+		wantTimeout  bool
+		wantIs       error
+	}{
+		"ContextCanceled": {
+			reason:  "Should report a request canceled with its context as canceled",
+			timeout: time.Minute,
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			wantCanceled: true,
+			wantIs:       context.Canceled,
+		},
+		"ContextDeadlineExceeded": {
+			reason:  "Should report a request interrupted by the deadline of its context as canceled",
+			timeout: time.Minute,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			wantCanceled: true,
+			wantIs:       context.DeadlineExceeded,
+		},
+		"SlowServer": {
+			reason:  "Should report a server not answering within the request timeout as timed out",
+			timeout: 20 * time.Millisecond,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			wantTimeout: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+			}))
+			defer server.Close()
+			defer close(release)
+
+			c, err := NewClient(logging.NewNopLogger(), tc.timeout, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			ctx, cancel := tc.ctx()
+			defer cancel()
+
+			_, err = c.SendRequest(ctx, http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+			if err == nil {
+				t.Fatalf("\n%s\nSendRequest(...): want error, got nil", tc.reason)
+			}
+			if got := IsCanceled(err); got != tc.wantCanceled {
+				t.Errorf("\n%s\nIsCanceled(%v): want %t, got %t", tc.reason, err, tc.wantCanceled, got)
+			}
+			if got := IsTimeout(err); got != tc.wantTimeout {
+				t.Errorf("\n%s\nIsTimeout(%v): want %t, got %t", tc.reason, err, tc.wantTimeout, got)
+			}
+			if tc.wantIs != nil && !errors.Is(err, tc.wantIs) {
+				t.Errorf("\n%s\nSendRequest(...): want error wrapping %v, got %v", tc.reason, tc.wantIs, err)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorDNSFailure(t *testing.T) {
+	cases := map[string]struct {
+		reason         string
+		err            error
+		wantDNSFailure bool
+		wantTimeout    bool
+	}{
+		"NoSuchHost": {
+			reason:         "Should report a host that does not resolve as a DNS failure",
+			err:            &url.Error{Op: "Get", URL: "http://typo.example", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "typo.example", IsNotFound: true}}},
+			wantDNSFailure: true,
+		},
+		"ResolverTimeout": {
+			reason:      "Should report a resolver not answering as timed out rather than as a DNS failure",
+			err:         &url.Error{Op: "Get", URL: "http://slow.example", Err: &net.DNSError{Err: "i/o timeout", Name: "slow.example", IsTimeout: true}},
+			wantTimeout: true,
+		},
+		"TemporaryResolverFailure": {
+			reason: "Should not report a failure of the resolver itself as a DNS failure",
+			err:    &url.Error{Op: "Get", URL: "http://flaky.example", Err: &net.DNSError{Err: "server misbehaving", Name: "flaky.example", IsTemporary: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := classifyError(context.Background(), tc.err)
+			if got := IsDNSFailure(err); got != tc.wantDNSFailure {
+				t.Errorf("\n%s\nIsDNSFailure(%v): want %t, got %t", tc.reason, err, tc.wantDNSFailure, got)
+			}
+			if got := IsTimeout(err); got != tc.wantTimeout {
+				t.Errorf("\n%s\nIsTimeout(%v): want %t, got %t", tc.reason, err, tc.wantTimeout, got)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Errorf("\n%s\nclassifyError(...): want error wrapping %v, got %v", tc.reason, tc.err, err)
+			}
+		})
+	}
+}
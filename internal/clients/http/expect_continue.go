@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	expectKey           = "Expect"
+	expectContinueValue = "100-continue"
+)
+
+// WithExpectContinueTimeout makes the client send the requests bearing a body with an Expect: 100-continue header,
+// and wait up to the timeout for the interim response of the server before sending the body. A final response
+// received first, e.g. a 401 or a 417, completes the request without the body being sent, so a rejected upload does
+// not stream its whole body. A server not answering within the timeout receives the body anyway. A timeout of zero
+// or less disables the header.
+func WithExpectContinueTimeout(timeout time.Duration) ClientOption {
+	return func(c *client) {
+		c.expectContinueTimeout = timeout
+	}
+}
+
+// expectContinue adds the Expect: 100-continue header to the request if the client sends it and the request bears a
+// body, and returns the time the transport waits for the interim response.
+func (hc *client) expectContinue(request *http.Request) time.Duration {
+	if hc.expectContinueTimeout <= 0 || request.Body == nil || request.Body == http.NoBody {
+		return 0
+	}
+
+	request.Header.Set(expectKey, expectContinueValue)
+
+	return hc.expectContinueTimeout
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// countingReader counts the bytes read from it.
+type countingReader struct {
+	io.Reader
+	read *atomic.Int64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read.Add(int64(n))
+	return n, err
+}
+
+func (r countingReader) Close() error {
+	return nil
+}
+
+func TestSendRequestExpectContinue(t *testing.T) {
+	const bodySize = 8 << 20
+
+	cases := map[string]struct {
+		reason     string
+		timeout    time.Duration
+		status     int
+		readBody   bool
+		wantExpect string
+		wantSent   bool
+	}{
+		"Unauthorized": {
+			reason:     "Should not send the body when the server rejects the expectation with a 401",
+			timeout:    10 * time.Second,
+			status:     http.StatusUnauthorized,
+			wantExpect: expectContinueValue,
+		},
+		"ExpectationFailed": {
+			reason:     "Should not send the body when the server rejects the expectation with a 417",
+			timeout:    10 * time.Second,
+			status:     http.StatusExpectationFailed,
+			wantExpect: expectContinueValue,
+		},
+		"Continue": {
+			reason:     "Should send the body once the server answers with a 100 Continue",
+			timeout:    10 * time.Second,
+			status:     http.StatusOK,
+			readBody:   true,
+			wantExpect: expectContinueValue,
+			wantSent:   true,
+		},
+		"Disabled": {
+			reason:   "Should send the body without the expectation when it is disabled",
+			status:   http.StatusOK,
+			readBody: true,
+			wantSent: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotExpect string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotExpect = r.Header.Get(expectKey)
+				if tc.readBody {
+					// Reading the body makes the server answer the expectation with a 100 Continue.
+					_, _ = io.Copy(io.Discard, r.Body)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			var read atomic.Int64
+			stream := &BodyStream{
+				Open: func() (io.ReadCloser, error) {
+					return countingReader{Reader: strings.NewReader(strings.Repeat("x", bodySize)), read: &read}, nil
+				},
+				Size: bodySize,
+			}
+
+			client, err := NewClient(logging.NewNopLogger(), 30*time.Second, "", WithExpectContinueTimeout(tc.timeout))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			details, err := client.SendRequest(context.Background(), http.MethodPut, server.URL,
+				Data{Encrypted: "<streamed>", Decrypted: stream},
+				Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+				&TLSConfigData{})
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			if details.HttpResponse.StatusCode != tc.status {
+				t.Errorf("\n%s\nSendRequest(...): want status %d, got %d", tc.reason, tc.status, details.HttpResponse.StatusCode)
+			}
+			if gotExpect != tc.wantExpect {
+				t.Errorf("\n%s\nSendRequest(...): want Expect header %q, got %q", tc.reason, tc.wantExpect, gotExpect)
+			}
+			if sent := read.Load() == bodySize; sent != tc.wantSent {
+				t.Errorf("\n%s\nSendRequest(...): want body sent %t, got %d of %d bytes read", tc.reason, tc.wantSent, read.Load(), bodySize)
+			}
+		})
+	}
+}
+
+func TestExpectContinueWithoutBody(t *testing.T) {
+	hc := &client{expectContinueTimeout: time.Second}
+	request := httptest.NewRequest(http.MethodGet, "http://example.com", http.NoBody)
+
+	if got := hc.expectContinue(request); got != 0 || request.Header.Get(expectKey) != "" {
+		t.Errorf("expectContinue(...): want no expectation for a request without a body, got %s and %q", got, request.Header.Get(expectKey))
+	}
+}
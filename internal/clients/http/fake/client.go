@@ -0,0 +1,206 @@
+// Package fake provides an in-memory Http client for tests, answering the requests it receives with scripted
+// responses and recording them for assertions.
+package fake
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const errNoResponse = "no scripted response left for %s %s"
+
+// Response is a scripted answer to a request.
+type Response struct {
+	// StatusCode, Headers and Body make up the response, unless Err is set.
+	StatusCode int
+	Headers    map[string][]string
+	Body       string
+
+	// Err is returned instead of a response, e.g. to simulate a connection failure.
+	Err error
+
+	// Latency delays the answer. The request fails with a CanceledError wrapping the error of its context if it is
+	// done first.
+	Latency time.Duration
+}
+
+// Respond returns a response with the status code and body.
+func Respond(statusCode int, body string) Response {
+	return Response{StatusCode: statusCode, Body: body}
+}
+
+// Fail returns a response failing the request with the error.
+func Fail(err error) Response {
+	return Response{Err: err}
+}
+
+// WithHeaders returns a copy of the response with the headers.
+func (r Response) WithHeaders(headers map[string][]string) Response {
+	r.Headers = headers
+	return r
+}
+
+// WithLatency returns a copy of the response answered after the latency.
+func (r Response) WithLatency(latency time.Duration) Response {
+	r.Latency = latency
+	return r
+}
+
+// Call is a request received by the client, with the body and headers that were sent, i.e. with secrets injected.
+type Call struct {
+	Method  string
+	URL     string
+	Body    string
+	Headers map[string][]string
+}
+
+// Client is an Http client answering the requests it receives with the queued responses, in order. Once the queue
+// is empty, it answers with the fallback response if one is set, and fails otherwise. It is safe for concurrent use.
+type Client struct {
+	mu        sync.Mutex
+	responses []Response
+	fallback  *Response
+	calls     []Call
+}
+
+var _ httpClient.Client = &Client{}
+
+// NewClient returns a client answering with the responses, in order.
+func NewClient(responses ...Response) *Client {
+	return &Client{responses: responses}
+}
+
+// Queue appends responses answering the next requests.
+func (c *Client) Queue(responses ...Response) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses = append(c.responses, responses...)
+	return c
+}
+
+// Always answers every request with the response once the queued responses are used up.
+func (c *Client) Always(response Response) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fallback = &response
+	return c
+}
+
+// SendRequest records the request and answers it with the next scripted response.
+func (c *Client) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+	request := httpClient.HttpRequest{
+		Method:  method,
+		URL:     url,
+		Body:    stringData(body.Encrypted),
+		Headers: headersData(headers.Encrypted),
+	}
+
+	response, ok := c.record(Call{
+		Method:  method,
+		URL:     url,
+		Body:    stringData(body.Decrypted),
+		Headers: headersData(headers.Decrypted),
+	})
+	if !ok {
+		return httpClient.HttpDetails{HttpRequest: request}, errors.Errorf(errNoResponse, method, url)
+	}
+
+	if response.Latency > 0 {
+		timer := time.NewTimer(response.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return httpClient.HttpDetails{HttpRequest: request}, &httpClient.CanceledError{Err: ctx.Err()}
+		}
+	}
+
+	if response.Err != nil {
+		return httpClient.HttpDetails{HttpRequest: request}, response.Err
+	}
+
+	return httpClient.HttpDetails{
+		HttpRequest: request,
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: response.StatusCode,
+			Headers:    response.Headers,
+			Body:       response.Body,
+		},
+	}, nil
+}
+
+// record records the call and returns the response answering it, if any.
+func (c *Client) record(call Call) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, call)
+	if len(c.responses) > 0 {
+		response := c.responses[0]
+		c.responses = c.responses[1:]
+		return response, true
+	}
+
+	if c.fallback != nil {
+		return *c.fallback, true
+	}
+
+	return Response{}, false
+}
+
+// Calls returns the requests received so far, in the order they were received.
+func (c *Client) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Call(nil), c.calls...)
+}
+
+// Pending returns the number of queued responses that did not answer a request yet.
+func (c *Client) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.responses)
+}
+
+// AssertCalls fails the test if the requests received so far differ from the wanted ones. Headers are only compared
+// when a wanted call sets them.
+func (c *Client) AssertCalls(t testing.TB, want ...Call) {
+	t.Helper()
+
+	got := c.Calls()
+	if len(want) == len(got) {
+		for i := range want {
+			if want[i].Headers == nil {
+				got[i].Headers = nil
+			}
+		}
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fake.Client: -want calls, +got calls:\n%s", diff)
+	}
+}
+
+// stringData returns the string the data holds, or an empty string.
+func stringData(data interface{}) string {
+	s, _ := data.(string)
+	return s
+}
+
+// headersData returns the headers the data holds, or nil.
+func headersData(data interface{}) map[string][]string {
+	headers, _ := data.(map[string][]string)
+	return headers
+}
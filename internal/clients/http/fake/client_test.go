@@ -0,0 +1,88 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func data(body string, headers map[string][]string) (httpClient.Data, httpClient.Data) {
+	return httpClient.Data{Encrypted: body, Decrypted: body}, httpClient.Data{Encrypted: headers, Decrypted: headers}
+}
+
+func TestClientScriptedResponses(t *testing.T) {
+	errConnRefused := errors.New("connection refused")
+	c := NewClient(
+		Respond(http.StatusCreated, `{"id":"42"}`).WithHeaders(map[string][]string{"Location": {"/users/42"}}),
+		Fail(errConnRefused),
+	).Queue(Respond(http.StatusOK, `{"id":"42","name":"ada"}`))
+
+	body, headers := data(`{"name":"ada"}`, map[string][]string{"Content-Type": {"application/json"}})
+	details, err := c.SendRequest(context.Background(), http.MethodPost, "https://api.example.com/users", body, headers, nil)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+	want := httpClient.HttpResponse{StatusCode: http.StatusCreated, Body: `{"id":"42"}`, Headers: map[string][]string{"Location": {"/users/42"}}}
+	if diff := cmp.Diff(want, details.HttpResponse); diff != "" {
+		t.Errorf("SendRequest(...): -want response, +got response:\n%s", diff)
+	}
+
+	body, headers = data("", nil)
+	if _, err := c.SendRequest(context.Background(), http.MethodGet, "https://api.example.com/users/42", body, headers, nil); !errors.Is(err, errConnRefused) {
+		t.Errorf("SendRequest(...): want the scripted error, got %v", err)
+	}
+
+	details, err = c.SendRequest(context.Background(), http.MethodGet, "https://api.example.com/users/42", body, headers, nil)
+	if err != nil || details.HttpResponse.StatusCode != http.StatusOK {
+		t.Errorf("SendRequest(...): want the queued 200 response, got %d, %v", details.HttpResponse.StatusCode, err)
+	}
+
+	if _, err := c.SendRequest(context.Background(), http.MethodDelete, "https://api.example.com/users/42", body, headers, nil); err == nil || !strings.Contains(err.Error(), "no scripted response left") {
+		t.Errorf("SendRequest(...): want an error once the responses are used up, got %v", err)
+	}
+
+	c.AssertCalls(t,
+		Call{Method: http.MethodPost, URL: "https://api.example.com/users", Body: `{"name":"ada"}`, Headers: map[string][]string{"Content-Type": {"application/json"}}},
+		Call{Method: http.MethodGet, URL: "https://api.example.com/users/42"},
+		Call{Method: http.MethodGet, URL: "https://api.example.com/users/42"},
+		Call{Method: http.MethodDelete, URL: "https://api.example.com/users/42"},
+	)
+	if got := c.Pending(); got != 0 {
+		t.Errorf("Pending(): want 0, got %d", got)
+	}
+}
+
+func TestClientRecordsSentData(t *testing.T) {
+	c := NewClient().Always(Respond(http.StatusOK, ""))
+
+	body := httpClient.Data{Encrypted: `{"token":"{{ api:default:token }}"}`, Decrypted: `{"token":"s3cr3t"}`}
+	headers := httpClient.Data{Encrypted: map[string][]string{"Authorization": {"****"}}, Decrypted: map[string][]string{"Authorization": {"Bearer s3cr3t"}}}
+	details, err := c.SendRequest(context.Background(), http.MethodPost, "https://api.example.com/login", body, headers, nil)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(httpClient.HttpRequest{Method: http.MethodPost, URL: "https://api.example.com/login", Body: `{"token":"{{ api:default:token }}"}`, Headers: map[string][]string{"Authorization": {"****"}}}, details.HttpRequest); diff != "" {
+		t.Errorf("SendRequest(...): want the logged request to hold the encrypted data, -want, +got:\n%s", diff)
+	}
+	c.AssertCalls(t, Call{Method: http.MethodPost, URL: "https://api.example.com/login", Body: `{"token":"s3cr3t"}`, Headers: map[string][]string{"Authorization": {"Bearer s3cr3t"}}})
+}
+
+func TestClientLatency(t *testing.T) {
+	c := NewClient(Respond(http.StatusOK, "").WithLatency(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	body, headers := data("", nil)
+	if _, err := c.SendRequest(ctx, http.MethodGet, "https://api.example.com/slow", body, headers, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendRequest(...): want the context error while waiting for a slow response, got %v", err)
+	}
+}
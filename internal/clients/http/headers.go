@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// canonicalHeaders returns the headers keyed by their canonical MIME form, e.g. "etag" and "ETag" are both stored
+// as "Etag", so filters can rely on the exact keys. The values of keys differing only by case are merged, in the
+// order of the sorted keys.
+func canonicalHeaders(headers map[string][]string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	canonical := make(map[string][]string, len(headers))
+	for _, key := range sortedKeys(headers) {
+		canonicalKey := textproto.CanonicalMIMEHeaderKey(key)
+		canonical[canonicalKey] = append(canonical[canonicalKey], headers[key]...)
+	}
+
+	return canonical
+}
+
+// sortedKeys returns the header names in lexical order.
+func sortedKeys(headers map[string][]string) []string {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// HeaderValues returns the values of the header, looked up case-insensitively.
+func HeaderValues(headers map[string][]string, key string) []string {
+	if values, ok := headers[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+		return values
+	}
+
+	for name, values := range headers {
+		if strings.EqualFold(name, key) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+// HeaderValue returns the first value of the header, looked up case-insensitively, or an empty string.
+func HeaderValue(headers map[string][]string, key string) string {
+	if values := HeaderValues(headers, key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// MergeDefaultHeaders returns the headers with the default headers they do not set, e.g. the default headers of a
+// ProviderConfig merged into the headers of a resource. Header names are compared case-insensitively, so a header
+// of the resource takes precedence over a default one differing only by case.
+func MergeDefaultHeaders(defaults, headers map[string][]string) map[string][]string {
+	if len(defaults) == 0 {
+		return headers
+	}
+
+	merged := make(map[string][]string, len(defaults)+len(headers))
+	for key, values := range headers {
+		merged[key] = values
+	}
+	for _, key := range sortedKeys(defaults) {
+		if !hasHeader(merged, key) {
+			merged[key] = defaults[key]
+		}
+	}
+
+	return merged
+}
+
+// hasHeader checks whether the headers set the header, looked up case-insensitively.
+func hasHeader(headers map[string][]string, key string) bool {
+	for name := range headers {
+		if strings.EqualFold(name, key) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHeaderValues(t *testing.T) {
+	headers := canonicalHeaders(map[string][]string{
+		"etag":         {`"v1"`},
+		"content-type": {"application/json"},
+	})
+
+	cases := map[string]struct {
+		key  string
+		want []string
+	}{
+		"LowerCase":     {key: "etag", want: []string{`"v1"`}},
+		"ServerCase":    {key: "ETag", want: []string{`"v1"`}},
+		"CanonicalCase": {key: "Etag", want: []string{`"v1"`}},
+		"Missing":       {key: "Location"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, HeaderValues(headers, tc.key)); diff != "" {
+				t.Errorf("HeaderValues(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHeaderValuesNonCanonicalKeys(t *testing.T) {
+	// Headers stored before canonicalization may use any casing.
+	headers := map[string][]string{"ETag": {`"v1"`}}
+
+	for _, key := range []string{"etag", "ETag", "Etag"} {
+		if got := HeaderValue(headers, key); got != `"v1"` {
+			t.Errorf("HeaderValue(%q): want %q, got %q", key, `"v1"`, got)
+		}
+	}
+}
+
+func TestMergeDefaultHeaders(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		defaults map[string][]string
+		headers  map[string][]string
+		want     map[string][]string
+	}{
+		"NoDefaults": {
+			reason:  "Should keep the headers without default headers",
+			headers: map[string][]string{"Accept": {"text/plain"}},
+			want:    map[string][]string{"Accept": {"text/plain"}},
+		},
+		"Merged": {
+			reason:   "Should add the default headers the headers do not set",
+			defaults: map[string][]string{"Accept": {"application/json"}, "X-Org-Id": {"42"}},
+			headers:  map[string][]string{"Authorization": {"Bearer token"}},
+			want:     map[string][]string{"Accept": {"application/json"}, "X-Org-Id": {"42"}, "Authorization": {"Bearer token"}},
+		},
+		"HeaderOverridesDefault": {
+			reason:   "Should keep the header of the resource over a default header with the same name",
+			defaults: map[string][]string{"Accept": {"application/json"}, "X-Org-Id": {"42"}},
+			headers:  map[string][]string{"Accept": {"text/csv"}},
+			want:     map[string][]string{"Accept": {"text/csv"}, "X-Org-Id": {"42"}},
+		},
+		"HeaderOverridesDefaultIgnoringCase": {
+			reason:   "Should compare the header names case-insensitively",
+			defaults: map[string][]string{"X-Org-Id": {"42"}},
+			headers:  map[string][]string{"x-org-id": {"7"}},
+			want:     map[string][]string{"x-org-id": {"7"}},
+		},
+		"NoHeaders": {
+			reason:   "Should return the default headers when the resource sets none",
+			defaults: map[string][]string{"Accept": {"application/json"}},
+			want:     map[string][]string{"Accept": {"application/json"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, MergeDefaultHeaders(tc.defaults, tc.headers)); diff != "" {
+				t.Errorf("\n%s\nMergeDefaultHeaders(...): -want, +got: %s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCanonicalHeaders(t *testing.T) {
+	got := canonicalHeaders(map[string][]string{
+		"x-request-id": {"a"},
+		"X-Request-ID": {"b"},
+		"ETag":         {`"v1"`},
+	})
+
+	// The values of keys differing by case are merged in the order of the sorted keys.
+	want := map[string][]string{
+		"X-Request-Id": {"b", "a"},
+		"Etag":         {`"v1"`},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("canonicalHeaders(...): -want, +got: %s", diff)
+	}
+}
+
+func TestSendRequestCanonicalHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Set the keys as is, bypassing the canonicalization of Header.Set.
+		w.Header()["etag"] = []string{`"v1"`}
+		w.Header()["x-rate-limit"] = []string{"10"}
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(logging.NewNopLogger(), 0, "")
+	headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+	details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, headers, nil)
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"Etag", "X-Rate-Limit"} {
+		if _, ok := details.HttpResponse.Headers[key]; !ok {
+			t.Errorf("SendRequest(...): want header %s in %v", key, details.HttpResponse.Headers)
+		}
+	}
+}
+
+func TestSendRequestHeaderOrder(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Values("X-Tag")
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(logging.NewNopLogger(), 0, "")
+	requestHeaders := map[string][]string{"x-tag": {"c"}, "X-Tag": {"a", "b"}, "X-TAG": {"d"}}
+	headers := Data{Encrypted: requestHeaders, Decrypted: requestHeaders}
+
+	for i := 0; i < 10; i++ {
+		if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, headers, nil); err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"d", "a", "b", "c"}, got); diff != "" {
+			t.Fatalf("SendRequest(...): -want header values, +got header values: %s", diff)
+		}
+	}
+}
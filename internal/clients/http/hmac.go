@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	defaultHMACHeader = "X-Signature"
+)
+
+// hmacSigner signs requests with an HMAC placed in a header.
+type hmacSigner struct {
+	key     []byte
+	newHash func() hash.Hash
+	header  string
+	prefix  string
+	payload string
+}
+
+// LoadHMACSigner loads the signing key from its secret and returns a signer signing requests with it.
+func LoadHMACSigner(ctx context.Context, kubeClient kube.Client, hmacConfig *common.HMACSigningConfig) (RequestSigner, error) {
+	if hmacConfig == nil {
+		return nil, nil
+	}
+
+	key, err := loadSecretData(ctx, kubeClient, &hmacConfig.KeySecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load HMAC signing key from secret: %w", err)
+	}
+
+	signer := &hmacSigner{
+		key:     key,
+		newHash: sha256.New,
+		header:  hmacConfig.Header,
+		prefix:  hmacConfig.Prefix,
+		payload: hmacConfig.Payload,
+	}
+
+	if hmacConfig.Algorithm == common.HMACAlgorithmSHA512 {
+		signer.newHash = sha512.New
+	}
+
+	if signer.header == "" {
+		signer.header = defaultHMACHeader
+	}
+
+	return signer, nil
+}
+
+// SignRequest sets the signature header of the request.
+func (s *hmacSigner) SignRequest(req *http.Request, body []byte, _ time.Time) error {
+	mac := hmac.New(s.newHash, s.key)
+	mac.Write(s.signedPayload(req, body))
+	req.Header.Set(s.header, s.prefix+hex.EncodeToString(mac.Sum(nil)))
+
+	return nil
+}
+
+// signedPayload returns the bytes signed for the request.
+func (s *hmacSigner) signedPayload(req *http.Request, body []byte) []byte {
+	if s.payload != common.HMACPayloadMethodPathBody {
+		return body
+	}
+
+	return []byte(strings.Join([]string{req.Method, req.URL.RequestURI(), string(body)}, "\n"))
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testHMACKey  = "webhook-shared-key"
+	testHMACBody = `{"event":"user.created","id":42}`
+)
+
+// hmacSecretClient returns a client serving the HMAC signing key secret.
+func hmacSecretClient() kube.Client {
+	return &test.MockClient{
+		MockGet: func(ctx context.Context, key kube.ObjectKey, obj kube.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Data = map[string][]byte{"key": []byte(testHMACKey)}
+			}
+			return nil
+		},
+	}
+}
+
+// expectedHMAC independently computes the hex encoded HMAC of the payload.
+func expectedHMAC(newHash func() hash.Hash, payload string) string {
+	mac := hmac.New(newHash, []byte(testHMACKey))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func testHMACConfig(algorithm, header, prefix, payload string) *common.HMACSigningConfig {
+	return &common.HMACSigningConfig{
+		KeySecretRef: xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "webhook", Namespace: "default"},
+			Key:             "key",
+		},
+		Algorithm: algorithm,
+		Header:    header,
+		Prefix:    prefix,
+		Payload:   payload,
+	}
+}
+
+func TestHMACSignRequest(t *testing.T) {
+	cases := map[string]struct {
+		config     *common.HMACSigningConfig
+		wantHeader string
+		wantValue  string
+	}{
+		"DefaultsSignBodyWithSHA256": {
+			config:     testHMACConfig("", "", "", ""),
+			wantHeader: "X-Signature",
+			wantValue:  expectedHMAC(sha256.New, testHMACBody),
+		},
+		"SHA512WithPrefix": {
+			config:     testHMACConfig(common.HMACAlgorithmSHA512, "X-Hub-Signature-512", "sha512=", common.HMACPayloadBody),
+			wantHeader: "X-Hub-Signature-512",
+			wantValue:  "sha512=" + expectedHMAC(sha512.New, testHMACBody),
+		},
+		"MethodPathBody": {
+			config:     testHMACConfig(common.HMACAlgorithmSHA256, "X-Signature", "", common.HMACPayloadMethodPathBody),
+			wantHeader: "X-Signature",
+			wantValue:  expectedHMAC(sha256.New, "POST\n/hooks/users?tenant=a\n"+testHMACBody),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			signer, err := LoadHMACSigner(context.Background(), hmacSecretClient(), tc.config)
+			if err != nil {
+				t.Fatalf("LoadHMACSigner(...): unexpected error: %v", err)
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "https://example.com/hooks/users?tenant=a", strings.NewReader(testHMACBody))
+			if err := signer.SignRequest(req, []byte(testHMACBody), time.Now()); err != nil {
+				t.Fatalf("SignRequest(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantValue, req.Header.Get(tc.wantHeader)); diff != "" {
+				t.Errorf("SignRequest(...): -want %s, +got %s: %s", tc.wantHeader, tc.wantHeader, diff)
+			}
+		})
+	}
+}
+
+func TestLoadHMACSignerNilConfig(t *testing.T) {
+	signer, err := LoadHMACSigner(context.Background(), nil, nil)
+	if err != nil || signer != nil {
+		t.Errorf("LoadHMACSigner(...): want no signer and no error, got %v, %v", signer, err)
+	}
+}
+
+func TestSendRequestHMACSignedOnRedirect(t *testing.T) {
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("X-Signature"))
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusPermanentRedirect)
+		}
+	}))
+	defer server.Close()
+
+	signer, err := LoadHMACSigner(context.Background(), hmacSecretClient(), testHMACConfig("", "", "", common.HMACPayloadMethodPathBody))
+	if err != nil {
+		t.Fatalf("LoadHMACSigner(...): unexpected error: %v", err)
+	}
+
+	c, _ := NewClient(logging.NewNopLogger(), 0, "", WithRequestSigners(signer))
+	headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+	if _, err := c.SendRequest(context.Background(), http.MethodPost, server.URL+"/old", Data{Encrypted: testHMACBody, Decrypted: testHMACBody}, headers, nil); err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+
+	want := []string{
+		expectedHMAC(sha256.New, "POST\n/old\n"+testHMACBody),
+		expectedHMAC(sha256.New, "POST\n/new\n"+testHMACBody),
+	}
+	if diff := cmp.Diff(want, signatures); diff != "" {
+		t.Errorf("SendRequest(...): -want signatures, +got signatures: %s", diff)
+	}
+}
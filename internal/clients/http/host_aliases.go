@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+const (
+	errInvalidHostAlias = "invalid hostAliases[%q]: %q is not an IP address"
+)
+
+// HostAliases resolves host names statically to IP addresses, like the entries of /etc/hosts, e.g. to send the
+// requests to a staging server without touching the cluster DNS.
+type HostAliases map[string]netip.Addr
+
+// NewHostAliases parses the IP addresses of the host aliases, keyed by host name, or returns nil if there are
+// none.
+func NewHostAliases(aliases map[string]string) (HostAliases, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(HostAliases, len(aliases))
+	for host, ip := range aliases {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidHostAlias, host, ip)
+		}
+		parsed[strings.ToLower(host)] = addr.Unmap()
+	}
+
+	return parsed, nil
+}
+
+// WithHostAliases makes the client connect to the IP address of the aliased hosts instead of resolving them. The
+// requests keep their host in the Host header and as the TLS server name, so virtual hosting and certificate
+// verification work as if the host had been resolved to that address.
+func WithHostAliases(aliases HostAliases) ClientOption {
+	return func(c *client) {
+		c.hostAliases = aliases
+	}
+}
+
+// dialContext returns a DialContext dialing the IP address of the aliased hosts instead of their name, and every
+// other address unchanged, with the given DialContext.
+func (a HostAliases) dialContext(dial dialContextFunc) dialContextFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		return dial(ctx, network, a.resolve(address))
+	}
+}
+
+// resolve replaces the host of the address with its IP address if it is aliased, matching host names
+// case-insensitively.
+func (a HostAliases) resolve(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	addr, ok := a[strings.ToLower(host)]
+	if !ok {
+		return address
+	}
+
+	return net.JoinHostPort(addr.String(), port)
+}
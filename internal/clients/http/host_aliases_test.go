@@ -0,0 +1,75 @@
+package http
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestSendRequestHostAliases(t *testing.T) {
+	// The certificate of the server is valid for example.com, which does not resolve to the server.
+	var gotHost, gotServerName string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		gotServerName = r.TLS.ServerName
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	aliasedURL := strings.Replace(server.URL, "127.0.0.1", "example.com", 1)
+
+	aliases, err := NewHostAliases(map[string]string{"example.com": "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("NewHostAliases(...): unexpected error: %v", err)
+	}
+	client, err := NewClient(logging.NewNopLogger(), 5*time.Second, "", WithHostAliases(aliases))
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+
+	details, err := client.SendRequest(context.Background(), http.MethodGet, aliasedURL,
+		Data{Encrypted: "", Decrypted: ""},
+		Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+		&TLSConfigData{CABundle: caBundle})
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+	if details.HttpResponse.StatusCode != http.StatusOK {
+		t.Errorf("SendRequest(...): want status %d, got %d", http.StatusOK, details.HttpResponse.StatusCode)
+	}
+
+	// The certificate is verified against the aliased host, which is sent as Host and server name.
+	if wantHost := strings.TrimPrefix(aliasedURL, "https://"); gotHost != wantHost || gotServerName != "example.com" {
+		t.Errorf("SendRequest(...): want Host %q and server name %q, got %q and %q", wantHost, "example.com", gotHost, gotServerName)
+	}
+}
+
+func TestNewHostAliases(t *testing.T) {
+	aliases, err := NewHostAliases(map[string]string{"API.example.com": "::ffff:10.0.0.1", "v6.example.com": "fd00::1"})
+	if err != nil {
+		t.Fatalf("NewHostAliases(...): unexpected error: %v", err)
+	}
+	if got := aliases.resolve("api.example.com:443"); got != "10.0.0.1:443" {
+		t.Errorf("resolve(...): want %q, got %q", "10.0.0.1:443", got)
+	}
+	if got := aliases.resolve("v6.example.com:8443"); got != "[fd00::1]:8443" {
+		t.Errorf("resolve(...): want %q, got %q", "[fd00::1]:8443", got)
+	}
+	if got := aliases.resolve("other.example.com:443"); got != "other.example.com:443" {
+		t.Errorf("resolve(...): want the address of a host that is not aliased unchanged, got %q", got)
+	}
+
+	if _, err := NewHostAliases(map[string]string{"api.example.com": "staging"}); err == nil {
+		t.Errorf("NewHostAliases(...): want an error for an alias that is not an IP address")
+	}
+	if aliases, err := NewHostAliases(nil); aliases != nil || err != nil {
+		t.Errorf("NewHostAliases(nil): want no aliases, got %v and %v", aliases, err)
+	}
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	errWaitHostSlot = "canceled while waiting for a free slot of the host concurrency limit"
+
+	// hostLimiterIdleTimeout is how long the limiter of a host is kept once no request was sent to the host.
+	hostLimiterIdleTimeout = 10 * time.Minute
+)
+
+// hostLimiterNow returns the current time, replaced by the tests.
+var hostLimiterNow = time.Now
+
+var (
+	hostLimitersMu sync.Mutex
+	hostLimiters   = map[string]*hostLimiter{}
+	// hostLimitersSweptAt is when the idle host limiters were last evicted.
+	hostLimitersSweptAt time.Time
+)
+
+// hostLimiter counts the requests in flight to a host across all the clients.
+type hostLimiter struct {
+	mu       sync.Mutex
+	inFlight int
+	// released is closed, and replaced, when a request in flight completes, to wake up the waiting requests.
+	released chan struct{}
+	// usedAt is when a request to the host was last sent or completed.
+	usedAt time.Time
+}
+
+// WithMaxConcurrentPerHost bounds the number of requests in flight to each host, counted across all the clients. A
+// request waits until fewer requests than the bound of its client are in flight to the host, or for its context
+// to be done. Clients with different bounds share the same count, so a changed bound applies to the requests
+// already in flight. A bound of zero or less disables the limit.
+func WithMaxConcurrentPerHost(max int) ClientOption {
+	return func(c *client) {
+		c.maxConcurrentPerHost = max
+	}
+}
+
+// hostLimiterFor returns the limiter of the host.
+func hostLimiterFor(host string) *hostLimiter {
+	hostLimitersMu.Lock()
+	defer hostLimitersMu.Unlock()
+
+	now := hostLimiterNow()
+	evictIdleHostLimiters(now)
+
+	limiter, ok := hostLimiters[host]
+	if !ok {
+		limiter = &hostLimiter{released: make(chan struct{})}
+		hostLimiters[host] = limiter
+	}
+
+	limiter.mu.Lock()
+	limiter.usedAt = now
+	limiter.mu.Unlock()
+
+	return limiter
+}
+
+// evictIdleHostLimiters removes the limiters of the hosts no request was in flight to for hostLimiterIdleTimeout,
+// at most once per hostLimiterIdleTimeout. The caller must hold hostLimitersMu.
+func evictIdleHostLimiters(now time.Time) {
+	if now.Sub(hostLimitersSweptAt) < hostLimiterIdleTimeout {
+		return
+	}
+	hostLimitersSweptAt = now
+
+	for host, limiter := range hostLimiters {
+		if limiter.idle(now) {
+			delete(hostLimiters, host)
+		}
+	}
+}
+
+// idle checks whether no request is in flight to the host and none was sent for hostLimiterIdleTimeout.
+func (l *hostLimiter) idle(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.inFlight == 0 && now.Sub(l.usedAt) >= hostLimiterIdleTimeout
+}
+
+// acquire waits until fewer than max requests are in flight to the host and returns the function releasing the
+// slot taken. It fails if the context is done first.
+func (l *hostLimiter) acquire(ctx context.Context, max int) (func(), error) {
+	for {
+		l.mu.Lock()
+		if l.inFlight < max {
+			l.inFlight++
+			l.mu.Unlock()
+			return l.release, nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s: %w", errWaitHostSlot, ctx.Err())
+		}
+	}
+}
+
+// release frees a slot and wakes up the waiting requests.
+func (l *hostLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+	l.usedAt = hostLimiterNow()
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// acquireHost waits for a free slot of the concurrency limit of the host and returns the function releasing it.
+// It fails if the context is done first.
+func (hc *client) acquireHost(ctx context.Context, host string) (func(), error) {
+	if hc.maxConcurrentPerHost <= 0 {
+		return func() {}, nil
+	}
+
+	return hostLimiterFor(host).acquire(ctx, hc.maxConcurrentPerHost)
+}
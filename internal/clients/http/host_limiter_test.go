@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestSendRequestMaxConcurrentPerHost(t *testing.T) {
+	const max = 2
+
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			previous := atomic.LoadInt32(&peak)
+			if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	// Several clients share the limit of the host.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		c, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithMaxConcurrentPerHost(max))
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %v", err)
+		}
+
+		for j := 0; j < 3; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{}); err != nil {
+					t.Errorf("SendRequest(...): unexpected error: %v", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&peak); got > max {
+		t.Errorf("SendRequest(...): want at most %d requests in flight to the host, got %d", max, got)
+	}
+}
+
+func TestSendRequestMaxConcurrentPerHostCanceled(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithMaxConcurrentPerHost(1))
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+	}()
+	<-received
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.SendRequest(ctx, http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendRequest(...): want the wait for the host to end with the context, got %v", err)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestSendRequestMaxConcurrentPerHostDifferentBounds(t *testing.T) {
+	release := make(chan struct{})
+	received := make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		first := false
+		once.Do(func() { first = true })
+		if first {
+			close(received)
+			<-release
+		}
+	}))
+	defer server.Close()
+
+	wide, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithMaxConcurrentPerHost(2))
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+	narrow, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithMaxConcurrentPerHost(1))
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = wide.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+	}()
+	<-received
+
+	// The request in flight of the other client counts toward the bound of the narrow one.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = narrow.SendRequest(ctx, http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendRequest(...): want the request to wait for the request in flight of the other client, got %v", err)
+	}
+
+	// The wide client still has a free slot.
+	if _, err := wide.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{}); err != nil {
+		t.Errorf("SendRequest(...): unexpected error: %v", err)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestEvictIdleHostLimiters(t *testing.T) {
+	now := time.Now()
+	hostLimiterNow = func() time.Time { return now }
+	defer func() { hostLimiterNow = time.Now }()
+	hostLimitersMu.Lock()
+	hostLimitersSweptAt = time.Time{}
+	hostLimitersMu.Unlock()
+
+	limiter := hostLimiterFor("evicted.example.com")
+	release, err := limiter.acquire(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("acquire(...): unexpected error: %v", err)
+	}
+
+	now = now.Add(2 * hostLimiterIdleTimeout)
+	if got := hostLimiterFor("evicted.example.com"); got != limiter {
+		t.Errorf("hostLimiterFor(...): want a limiter with a request in flight kept")
+	}
+
+	release()
+	now = now.Add(2 * hostLimiterIdleTimeout)
+	if got := hostLimiterFor("evicted.example.com"); got == limiter {
+		t.Errorf("hostLimiterFor(...): want an idle limiter evicted")
+	}
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// md4 rounds: the order the words of a block are read in and the rotations of each round.
+var (
+	md4Round2Order = [16]int{0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15}
+	md4Round3Order = [16]int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15}
+	md4Shifts      = [3][4]int{{3, 7, 11, 19}, {3, 5, 9, 13}, {3, 9, 11, 15}}
+)
+
+// md4Sum returns the MD4 digest of the data (RFC 1320). MD4 is broken, it is only used to derive the NT hash of a
+// password, which NTLM requires and the standard library does not provide.
+func md4Sum(data []byte) [16]byte {
+	// The message is padded to 56 bytes modulo 64, followed by its length in bits.
+	msg := make([]byte, 0, len(data)+72)
+	msg = append(msg, data...)
+	msg = append(msg, 0x80)
+	for len(msg)%64 != 56 {
+		msg = append(msg, 0)
+	}
+	msg = binary.LittleEndian.AppendUint64(msg, uint64(len(data))<<3)
+
+	v := [4]uint32{0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476}
+	for block := msg; len(block) > 0; block = block[64:] {
+		var x [16]uint32
+		for i := range x {
+			x[i] = binary.LittleEndian.Uint32(block[i*4:])
+		}
+
+		saved := v
+		for i := range 48 {
+			// Each step updates a, d, c and b in turn from the other three words.
+			t := (4 - i%4) % 4
+			b, c, d := v[(t+1)%4], v[(t+2)%4], v[(t+3)%4]
+
+			var f uint32
+			var k int
+			switch round := i / 16; round {
+			case 0:
+				f, k = (b&c)|(^b&d), i%16
+			case 1:
+				f, k = ((b&c)|(b&d)|(c&d))+0x5a827999, md4Round2Order[i%16]
+			default:
+				f, k = (b^c^d)+0x6ed9eba1, md4Round3Order[i%16]
+			}
+			v[t] = bits.RotateLeft32(v[t]+f+x[k], md4Shifts[i/16][i%4])
+		}
+
+		for i := range v {
+			v[i] += saved[i]
+		}
+	}
+
+	var sum [16]byte
+	for i, word := range v {
+		binary.LittleEndian.PutUint32(sum[i*4:], word)
+	}
+	return sum
+}
@@ -0,0 +1,304 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5" // #nosec G501 -- NTLMv2 is defined with HMAC-MD5
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	ntlmScheme    = "NTLM"
+	ntlmSignature = "NTLMSSP\x00"
+
+	ntlmNegotiateMessage    = 1
+	ntlmChallengeMessage    = 2
+	ntlmAuthenticateMessage = 3
+
+	// The negotiate flags requested: Unicode strings, the target info of the server, and NTLMv2 responses.
+	ntlmFlagUnicode            = 0x00000001
+	ntlmFlagRequestTarget      = 0x00000004
+	ntlmFlagNTLM               = 0x00000200
+	ntlmFlagAlwaysSign         = 0x00008000
+	ntlmFlagExtendedSecurity   = 0x00080000
+	ntlmFlagTargetInfo         = 0x00800000
+	ntlmFlag128                = 0x20000000
+	ntlmFlag56                 = 0x80000000
+	ntlmNegotiateFlags         = ntlmFlagUnicode | ntlmFlagRequestTarget | ntlmFlagNTLM | ntlmFlagAlwaysSign | ntlmFlagExtendedSecurity | ntlmFlagTargetInfo | ntlmFlag128 | ntlmFlag56
+	ntlmAvTimestamp            = 7
+	ntlmAuthenticateHeaderSize = 64
+
+	// windowsEpochOffset is the number of 100ns intervals between 1601-01-01, the epoch of Windows FILETIME, and
+	// the Unix epoch.
+	windowsEpochOffset = 116444736000000000
+
+	errInvalidNTLMChallenge = "invalid NTLM challenge"
+)
+
+// NTLMCredentials are the credentials of an account authenticated with NTLM.
+type NTLMCredentials struct {
+	Domain   string
+	Username string
+	Password string
+}
+
+// LoadNTLMCredentials loads the NTLM credentials from secrets.
+func LoadNTLMCredentials(ctx context.Context, kubeClient kube.Client, ntlmConfig *common.NTLMConfig) (*NTLMCredentials, error) {
+	if ntlmConfig == nil {
+		return nil, nil
+	}
+
+	username, err := loadSecretData(ctx, kubeClient, &ntlmConfig.UsernameSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load NTLM username from secret: %w", err)
+	}
+
+	password, err := loadSecretData(ctx, kubeClient, &ntlmConfig.PasswordSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load NTLM password from secret: %w", err)
+	}
+
+	return &NTLMCredentials{
+		Domain:   ntlmConfig.Domain,
+		Username: strings.TrimSpace(string(username)),
+		Password: strings.TrimRight(string(password), "\r\n"),
+	}, nil
+}
+
+// WithNTLM makes the client authenticate the requests that do not set the Authorization header with NTLM. A nil
+// credentials does not.
+func WithNTLM(credentials *NTLMCredentials) ClientOption {
+	return func(c *client) {
+		c.ntlm = credentials
+	}
+}
+
+// ntlmTransport authenticates requests with the NTLM handshake: a negotiate message answered by a challenge, and
+// an authenticate message answering the challenge. NTLM authenticates the connection rather than the request, so
+// both messages must be sent over the same keep-alive connection. The response to the negotiate message is fully
+// read before the authenticate message is sent, which returns its connection to the idle connections of the base
+// transport, sending HTTP/1.1 requests, to be reused right away.
+type ntlmTransport struct {
+	base        http.RoundTripper
+	credentials *NTLMCredentials
+	now         func() time.Time
+}
+
+// newNTLMTransport wraps the base transport so requests are authenticated with NTLM, or returns it if there are no
+// credentials.
+func newNTLMTransport(base http.RoundTripper, credentials *NTLMCredentials) http.RoundTripper {
+	if credentials == nil {
+		return base
+	}
+
+	return &ntlmTransport{base: base, credentials: credentials, now: time.Now}
+}
+
+// RoundTrip sends the request after negotiating NTLM authentication, unless it sets the Authorization header or
+// the server does not challenge it.
+func (t *ntlmTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(authKey) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	negotiate := withAuthorization(req, body, ntlmScheme+" "+base64.StdEncoding.EncodeToString(ntlmNegotiate()))
+	resp, err := t.base.RoundTrip(negotiate)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedChallenge, ok := ntlmChallengeHeader(resp)
+	if resp.StatusCode != http.StatusUnauthorized || !ok {
+		return resp, nil
+	}
+
+	// The response is drained so the connection is reused for the authenticate message.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	challenge, err := base64.StdEncoding.DecodeString(encodedChallenge)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errInvalidNTLMChallenge, err)
+	}
+
+	authenticate, err := ntlmAuthenticate(challenge, t.credentials, t.now(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(withAuthorization(req, body, ntlmScheme+" "+base64.StdEncoding.EncodeToString(authenticate)))
+}
+
+// withAuthorization returns a copy of the request sending the body with the Authorization header.
+func withAuthorization(req *http.Request, body []byte, authorization string) *http.Request {
+	// A RoundTripper must not modify the request it is given.
+	authorized := req.Clone(req.Context())
+	if body != nil {
+		authorized.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	authorized.Header.Set(authKey, authorization)
+
+	return authorized
+}
+
+// ntlmChallengeHeader returns the challenge message sent in the WWW-Authenticate header of the response, if any.
+func ntlmChallengeHeader(resp *http.Response) (string, bool) {
+	for _, value := range resp.Header.Values("WWW-Authenticate") {
+		if challenge, ok := strings.CutPrefix(value, ntlmScheme+" "); ok {
+			return strings.TrimSpace(challenge), true
+		}
+	}
+
+	return "", false
+}
+
+// ntlmNegotiate returns the negotiate message, opening the handshake without naming the domain or workstation.
+func ntlmNegotiate() []byte {
+	msg := make([]byte, 32)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], ntlmNegotiateMessage)
+	binary.LittleEndian.PutUint32(msg[12:], ntlmNegotiateFlags)
+
+	return msg
+}
+
+// ntlmChallenge is the challenge message of the server.
+type ntlmChallenge struct {
+	serverChallenge []byte
+	targetInfo      []byte
+}
+
+// parseNTLMChallenge parses the challenge message of the server.
+func parseNTLMChallenge(msg []byte) (ntlmChallenge, error) {
+	if len(msg) < 32 || string(msg[:8]) != ntlmSignature || binary.LittleEndian.Uint32(msg[8:]) != ntlmChallengeMessage {
+		return ntlmChallenge{}, errors.New(errInvalidNTLMChallenge)
+	}
+
+	challenge := ntlmChallenge{serverChallenge: msg[24:32]}
+	if len(msg) >= 48 {
+		length := int(binary.LittleEndian.Uint16(msg[40:]))
+		offset := int(binary.LittleEndian.Uint32(msg[44:]))
+		if offset+length > len(msg) {
+			return ntlmChallenge{}, errors.New(errInvalidNTLMChallenge)
+		}
+		challenge.targetInfo = msg[offset : offset+length]
+	}
+
+	return challenge, nil
+}
+
+// ntlmAuthenticate returns the authenticate message answering the challenge message with an NTLMv2 response.
+func ntlmAuthenticate(challengeMsg []byte, credentials *NTLMCredentials, now time.Time, random io.Reader) ([]byte, error) {
+	challenge, err := parseNTLMChallenge(challengeMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	clientChallenge := make([]byte, 8)
+	if _, err := io.ReadFull(random, clientChallenge); err != nil {
+		return nil, err
+	}
+
+	// The timestamp of the server is preferred, and then replaces the LMv2 response.
+	timestamp, serverTime := ntlmTimestamp(challenge.targetInfo)
+	if !serverTime {
+		timestamp = binary.LittleEndian.AppendUint64(nil, uint64(now.UnixNano()/100+windowsEpochOffset))
+	}
+
+	key := ntowfv2(credentials.Username, credentials.Password, credentials.Domain)
+	ntResponse := ntlmv2Response(key, challenge.serverChallenge, clientChallenge, timestamp, challenge.targetInfo)
+	lmResponse := make([]byte, 24)
+	if !serverTime {
+		lmResponse = append(hmacMD5(key, challenge.serverChallenge, clientChallenge), clientChallenge...)
+	}
+
+	payloads := [][]byte{lmResponse, ntResponse, utf16le(credentials.Domain), utf16le(credentials.Username), nil, nil}
+
+	msg := make([]byte, ntlmAuthenticateHeaderSize)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], ntlmAuthenticateMessage)
+	// The fields of the payloads follow each other from offset 12, each with its length and offset.
+	for i, payload := range payloads {
+		field := msg[12+8*i:]
+		binary.LittleEndian.PutUint16(field, uint16(len(payload)))
+		binary.LittleEndian.PutUint16(field[2:], uint16(len(payload)))
+		binary.LittleEndian.PutUint32(field[4:], uint32(len(msg)))
+		msg = append(msg, payload...)
+	}
+	binary.LittleEndian.PutUint32(msg[60:], ntlmNegotiateFlags)
+
+	return msg, nil
+}
+
+// ntlmTimestamp returns the timestamp of the target info of the server, if it sends one.
+func ntlmTimestamp(targetInfo []byte) ([]byte, bool) {
+	for len(targetInfo) >= 4 {
+		id := binary.LittleEndian.Uint16(targetInfo)
+		length := int(binary.LittleEndian.Uint16(targetInfo[2:]))
+		if len(targetInfo) < 4+length {
+			break
+		}
+		if id == ntlmAvTimestamp && length == 8 {
+			return targetInfo[4:12], true
+		}
+		targetInfo = targetInfo[4+length:]
+	}
+
+	return nil, false
+}
+
+// ntowfv2 returns the NTLMv2 key of the account, derived from its NT hash, the MD4 digest of the password.
+func ntowfv2(username, password, domain string) []byte {
+	ntHash := md4Sum(utf16le(password))
+	return hmacMD5(ntHash[:], utf16le(strings.ToUpper(username)+domain))
+}
+
+// ntlmv2Response returns the NTLMv2 response proving the knowledge of the key for the challenges.
+func ntlmv2Response(key, serverChallenge, clientChallenge, timestamp, targetInfo []byte) []byte {
+	blob := []byte{1, 1, 0, 0, 0, 0, 0, 0}
+	blob = append(blob, timestamp...)
+	blob = append(blob, clientChallenge...)
+	blob = append(blob, 0, 0, 0, 0)
+	blob = append(blob, targetInfo...)
+	blob = append(blob, 0, 0, 0, 0)
+
+	return append(hmacMD5(key, serverChallenge, blob), blob...)
+}
+
+// hmacMD5 returns the HMAC-MD5 of the concatenated data.
+func hmacMD5(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(md5.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// utf16le encodes the string in UTF-16 little-endian, the encoding of the strings of NTLM.
+func utf16le(s string) []byte {
+	encoded := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(encoded))
+	for i, r := range encoded {
+		binary.LittleEndian.PutUint16(b[2*i:], r)
+	}
+	return b
+}
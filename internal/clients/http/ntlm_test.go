@@ -0,0 +1,218 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+const (
+	testNTLMDomain   = "CORP"
+	testNTLMUsername = "svc-provider"
+	testNTLMPassword = "s3cr3t!"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q): %v", s, err)
+	}
+	return b
+}
+
+func TestNTLMv2Response(t *testing.T) {
+	// Test vectors of MS-NLMP 4.2.4.
+	key := ntowfv2("User", "Password", "Domain")
+	if got, want := hex.EncodeToString(key), "0c868a403bfd7a93a3001ef22ef02e3f"; got != want {
+		t.Fatalf("ntowfv2(...): want %s, got %s", want, got)
+	}
+
+	targetInfo := append(append([]byte{2, 0, 12, 0}, utf16le("Domain")...), append(append([]byte{1, 0, 12, 0}, utf16le("Server")...), 0, 0, 0, 0)...)
+	response := ntlmv2Response(key, mustDecodeHex(t, "0123456789abcdef"), mustDecodeHex(t, "aaaaaaaaaaaaaaaa"), make([]byte, 8), targetInfo)
+	if got, want := hex.EncodeToString(response[:16]), "68cd0ab851e51c96aabc927bebef6a1c"; got != want {
+		t.Errorf("ntlmv2Response(...): want NTProofStr %s, got %s", want, got)
+	}
+}
+
+// ntlmStub is a server requiring NTLM authentication of the account with the password. It only accepts the
+// authenticate message answering its challenge over the connection the challenge was sent over.
+type ntlmStub struct {
+	password string
+
+	mu         sync.Mutex
+	challenges map[net.Conn][]byte
+}
+
+type ntlmConnKey struct{}
+
+func newNTLMStub(t *testing.T, password string) *httptest.Server {
+	t.Helper()
+
+	stub := &ntlmStub{password: password, challenges: map[net.Conn][]byte{}}
+	server := httptest.NewUnstartedServer(stub)
+	server.Config.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
+		return context.WithValue(ctx, ntlmConnKey{}, c)
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func (s *ntlmStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	conn := r.Context().Value(ntlmConnKey{}).(net.Conn)
+
+	authorization := r.Header.Get("Authorization")
+	if strings.HasPrefix(authorization, "Bearer ") {
+		_, _ = w.Write([]byte("bearer"))
+		return
+	}
+
+	msg, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(authorization, "NTLM "))
+	if !strings.HasPrefix(authorization, "NTLM ") || err != nil || len(msg) < 12 || string(msg[:8]) != ntlmSignature {
+		w.Header().Set("WWW-Authenticate", "NTLM")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch binary.LittleEndian.Uint32(msg[8:]) {
+	case ntlmNegotiateMessage:
+		serverChallenge := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+		s.mu.Lock()
+		s.challenges[conn] = serverChallenge
+		s.mu.Unlock()
+
+		targetInfo := append([]byte{ntlmAvTimestamp, 0, 8, 0}, binary.LittleEndian.AppendUint64(nil, 133000000000000000)...)
+		targetInfo = append(targetInfo, 0, 0, 0, 0)
+		challenge := make([]byte, 48)
+		copy(challenge, ntlmSignature)
+		binary.LittleEndian.PutUint32(challenge[8:], ntlmChallengeMessage)
+		binary.LittleEndian.PutUint32(challenge[20:], ntlmNegotiateFlags)
+		copy(challenge[24:], serverChallenge)
+		binary.LittleEndian.PutUint16(challenge[40:], uint16(len(targetInfo)))
+		binary.LittleEndian.PutUint16(challenge[42:], uint16(len(targetInfo)))
+		binary.LittleEndian.PutUint32(challenge[44:], 48)
+		challenge = append(challenge, targetInfo...)
+
+		w.Header().Set("WWW-Authenticate", "NTLM "+base64.StdEncoding.EncodeToString(challenge))
+		w.WriteHeader(http.StatusUnauthorized)
+	case ntlmAuthenticateMessage:
+		s.mu.Lock()
+		serverChallenge, ok := s.challenges[conn]
+		s.mu.Unlock()
+
+		if !ok || !s.verify(msg, serverChallenge) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(append([]byte("authenticated:"), body...))
+	}
+}
+
+// verify checks the NTLMv2 response of the authenticate message, for the account named by the message.
+func (s *ntlmStub) verify(msg, serverChallenge []byte) bool {
+	field := func(i int) []byte {
+		length := int(binary.LittleEndian.Uint16(msg[12+8*i:]))
+		offset := int(binary.LittleEndian.Uint32(msg[16+8*i:]))
+		return msg[offset : offset+length]
+	}
+	ntResponse, domain, username := field(1), field(2), field(3)
+	if !bytes.Equal(domain, utf16le(testNTLMDomain)) || !bytes.Equal(username, utf16le(testNTLMUsername)) || len(ntResponse) < 16 {
+		return false
+	}
+
+	key := ntowfv2(testNTLMUsername, s.password, testNTLMDomain)
+	return bytes.Equal(ntResponse[:16], hmacMD5(key, serverChallenge, ntResponse[16:]))
+}
+
+func TestSendRequestNTLM(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		password   string
+		headers    map[string][]string
+		wantStatus int
+		wantBody   string
+	}{
+		"Authenticated": {
+			reason:     "Should answer the challenge of the server over the connection of the handshake",
+			password:   testNTLMPassword,
+			wantStatus: http.StatusOK,
+			wantBody:   "authenticated:{\"name\":\"x\"}",
+		},
+		"WrongPassword": {
+			reason:     "Should fail the authentication with another password",
+			password:   "guess",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"AuthorizationSet": {
+			reason:     "Should send a request setting the Authorization header as is",
+			password:   testNTLMPassword,
+			headers:    map[string][]string{"Authorization": {"Bearer token"}},
+			wantStatus: http.StatusOK,
+			wantBody:   "bearer",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newNTLMStub(t, testNTLMPassword)
+
+			client, err := NewClient(logging.NewNopLogger(), 10*time.Second, "",
+				WithNTLM(&NTLMCredentials{Domain: testNTLMDomain, Username: testNTLMUsername, Password: tc.password}))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			headers := tc.headers
+			if headers == nil {
+				headers = map[string][]string{}
+			}
+			details, err := client.SendRequest(context.Background(), http.MethodPost, server.URL,
+				Data{Encrypted: `{"name":"x"}`, Decrypted: `{"name":"x"}`},
+				Data{Encrypted: headers, Decrypted: headers},
+				&TLSConfigData{})
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			if details.HttpResponse.StatusCode != tc.wantStatus {
+				t.Errorf("\n%s\nSendRequest(...): want status %d, got %d", tc.reason, tc.wantStatus, details.HttpResponse.StatusCode)
+			}
+			if tc.wantBody != "" && details.HttpResponse.Body != tc.wantBody {
+				t.Errorf("\n%s\nSendRequest(...): want body %q, got %q", tc.reason, tc.wantBody, details.HttpResponse.Body)
+			}
+		})
+	}
+}
+
+func TestNTLMTransportNoChallenge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("open"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(logging.NewNopLogger(), 10*time.Second, "", WithNTLM(&NTLMCredentials{Username: testNTLMUsername, Password: testNTLMPassword}))
+	details, err := client.SendRequest(context.Background(), http.MethodGet, server.URL,
+		Data{Encrypted: "", Decrypted: ""},
+		Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+		&TLSConfigData{})
+	if err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+	if details.HttpResponse.StatusCode != http.StatusOK || details.HttpResponse.Body != "open" {
+		t.Errorf("SendRequest(...): want the response of a server not challenging the request, got %d %q", details.HttpResponse.StatusCode, details.HttpResponse.Body)
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	// oauth2RefreshWindow is how long before its expiry a cached access token is refreshed.
+	oauth2RefreshWindow = 30 * time.Second
+
+	// oauth2TokenTimeout bounds the requests sent to the token endpoint.
+	oauth2TokenTimeout = 30 * time.Second
+)
+
+// oauth2TokenSources caches a token source per set of client credentials, so the access token is shared
+// across reconciles and resources instead of being acquired on every connect.
+var oauth2TokenSources = &tokenSourceCache{sources: map[string]oauth2.TokenSource{}}
+
+// tokenSourceCache is a concurrency safe cache of token sources.
+type tokenSourceCache struct {
+	mu      sync.Mutex
+	sources map[string]oauth2.TokenSource
+}
+
+// get returns the cached token source for the client credentials, creating it if needed.
+func (c *tokenSourceCache) get(config *clientcredentials.Config) oauth2.TokenSource {
+	key := tokenSourceKey(config)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+
+	// The token source outlives the reconcile that created it, it must not use the reconcile context.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: oauth2TokenTimeout})
+	source := oauth2.ReuseTokenSourceWithExpiry(nil, &clientCredentialsSource{ctx: ctx, config: config}, oauth2RefreshWindow)
+	c.sources[key] = source
+
+	return source
+}
+
+// clientCredentialsSource acquires a new access token on every call, caching is left to the caller.
+type clientCredentialsSource struct {
+	ctx    context.Context
+	config *clientcredentials.Config
+}
+
+// Token requests a new access token from the token endpoint.
+func (s *clientCredentialsSource) Token() (*oauth2.Token, error) {
+	return s.config.Token(s.ctx)
+}
+
+// tokenSourceKey identifies the client credentials without keeping the client secret in memory in clear.
+func tokenSourceKey(config *clientcredentials.Config) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		config.TokenURL, config.ClientID, config.ClientSecret, strings.Join(config.Scopes, " "),
+	}, "\x00")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// LoadOAuth2TokenSource loads the OAuth2 client credentials from secrets and returns a token source sharing its
+// cached access token with every client using the same credentials. A token is acquired, or reused from the cache,
+// so that invalid credentials are reported right away.
+func LoadOAuth2TokenSource(ctx context.Context, kubeClient kube.Client, oauth2Config *common.OAuth2Config) (oauth2.TokenSource, error) {
+	if oauth2Config == nil {
+		return nil, nil
+	}
+
+	clientID, err := loadSecretData(ctx, kubeClient, &oauth2Config.ClientIDSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OAuth2 client id from secret: %w", err)
+	}
+
+	clientSecret, err := loadSecretData(ctx, kubeClient, &oauth2Config.ClientSecretSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OAuth2 client secret from secret: %w", err)
+	}
+
+	source := oauth2TokenSources.get(&clientcredentials.Config{
+		ClientID:     strings.TrimSpace(string(clientID)),
+		ClientSecret: strings.TrimSpace(string(clientSecret)),
+		TokenURL:     oauth2Config.TokenURL,
+		Scopes:       oauth2Config.Scopes,
+	})
+
+	if _, err := source.Token(); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newTokenServer returns a token endpoint issuing numbered access tokens expiring after expiresIn seconds,
+// and the number of tokens it issued.
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if clientID, clientSecret, ok := r.BasicAuth(); !ok || clientID != "client-id" || clientSecret != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+// oauth2SecretClient returns a client serving the OAuth2 client credentials secret.
+func oauth2SecretClient(clientSecret string) kube.Client {
+	return &test.MockClient{
+		MockGet: func(ctx context.Context, key kube.ObjectKey, obj kube.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Data = map[string][]byte{
+					"client-id":     []byte("client-id"),
+					"client-secret": []byte(clientSecret),
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func testOAuth2Config(tokenURL string) *common.OAuth2Config {
+	ref := func(key string) xpv1.SecretKeySelector {
+		return xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "oauth2", Namespace: "default"},
+			Key:             key,
+		}
+	}
+
+	return &common.OAuth2Config{
+		TokenURL:              tokenURL,
+		ClientIDSecretRef:     ref("client-id"),
+		ClientSecretSecretRef: ref("client-secret"),
+		Scopes:                []string{"read", "write"},
+	}
+}
+
+func TestLoadOAuth2TokenSource(t *testing.T) {
+	cases := map[string]struct {
+		expiresIn    int
+		clientSecret string
+		loads        int
+		wantToken    string
+		wantIssued   int32
+		wantErr      bool
+	}{
+		"InitialFetch": {
+			expiresIn:    3600,
+			clientSecret: "client-secret",
+			loads:        1,
+			wantToken:    "token-1",
+			wantIssued:   1,
+		},
+		"CacheHit": {
+			expiresIn:    3600,
+			clientSecret: "client-secret",
+			loads:        3,
+			wantToken:    "token-1",
+			wantIssued:   1,
+		},
+		"RefreshNearExpiry": {
+			// The token expires within the refresh window, so a new one is acquired every time it is used,
+			// once when loading the token source and once when getting its token.
+			expiresIn:    5,
+			clientSecret: "client-secret",
+			loads:        2,
+			wantToken:    "token-4",
+			wantIssued:   4,
+		},
+		"InvalidCredentials": {
+			expiresIn:    3600,
+			clientSecret: "wrong-secret",
+			loads:        1,
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server, issued := newTokenServer(t, tc.expiresIn)
+			config := testOAuth2Config(server.URL)
+
+			var err error
+			var accessToken string
+			for i := 0; i < tc.loads; i++ {
+				tokenSource, loadErr := LoadOAuth2TokenSource(context.Background(), oauth2SecretClient(tc.clientSecret), config)
+				if err = loadErr; err != nil {
+					break
+				}
+
+				token, tokenErr := tokenSource.Token()
+				if tokenErr != nil {
+					t.Fatalf("Token(): unexpected error: %v", tokenErr)
+				}
+				accessToken = token.AccessToken
+			}
+
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("LoadOAuth2TokenSource(...): want error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if accessToken != tc.wantToken {
+				t.Errorf("LoadOAuth2TokenSource(...): want access token %q, got %q", tc.wantToken, accessToken)
+			}
+			if got := atomic.LoadInt32(issued); got != tc.wantIssued {
+				t.Errorf("LoadOAuth2TokenSource(...): want %d tokens issued, got %d", tc.wantIssued, got)
+			}
+		})
+	}
+}
+
+func TestLoadOAuth2TokenSourceNilConfig(t *testing.T) {
+	source, err := LoadOAuth2TokenSource(context.Background(), nil, nil)
+	if err != nil || source != nil {
+		t.Errorf("LoadOAuth2TokenSource(...): want no token source and no error, got %v, %v", source, err)
+	}
+}
+
+func TestSendRequestOAuth2Token(t *testing.T) {
+	tokenServer, _ := newTokenServer(t, 3600)
+
+	var authorization []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get(authKey))
+	}))
+	defer server.Close()
+
+	tokenSource, err := LoadOAuth2TokenSource(context.Background(), oauth2SecretClient("client-secret"), testOAuth2Config(tokenServer.URL))
+	if err != nil {
+		t.Fatalf("LoadOAuth2TokenSource(...): unexpected error: %v", err)
+	}
+
+	c, _ := NewClient(logging.NewNopLogger(), 0, "static-token", WithTokenSource(tokenSource))
+	body := Data{Encrypted: "", Decrypted: ""}
+	for _, headers := range []map[string][]string{{}, {authKey: {"Basic explicit"}}} {
+		if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, body, Data{Encrypted: headers, Decrypted: headers}, nil); err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %v", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"Bearer token-1", "Basic explicit"}, authorization); diff != "" {
+		t.Errorf("SendRequest(...): -want Authorization headers, +got Authorization headers: %s", diff)
+	}
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Prober sends readiness probes.
+type Prober interface {
+	Probe(ctx context.Context, url string, tlsConfigData *TLSConfigData) (int, error)
+}
+
+// Probe sends a GET request to the URL and returns the status code of its response, e.g. to check that an endpoint
+// a request depends on is ready. The probe goes through a bare client only carrying the TLS configuration, the
+// address guard and the host aliases: it sends neither the credentials nor the signatures of the client, and is
+// not counted by the circuit breakers and the host limiters.
+func (hc *client) Probe(ctx context.Context, url string, tlsConfigData *TLSConfigData) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if hc.addressGuard != nil {
+		if err := hc.addressGuard.checkURL(request.URL); err != nil {
+			return 0, err
+		}
+	}
+
+	tlsConfig, err := buildTLSConfig(tlsConfigData.forHost(request.URL.Hostname()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build TLS config: %w", err)
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+			Proxy:           hc.proxy(),
+			DialContext:     newDialContext(hc.ipFamily, hc.addressGuard, hc.hostAliases),
+		},
+		Timeout:       hc.timeout,
+		CheckRedirect: checkRedirect(hc.disallowBodyRedirects, tlsConfigData),
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, classifyError(ctx, err)
+	}
+
+	return response.StatusCode, response.Body.Close()
+}
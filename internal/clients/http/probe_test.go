@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+func TestProbe(t *testing.T) {
+	var authorization []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Values(authKey)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "s3cr3t")
+	if err != nil {
+		t.Fatalf("NewClient(...): unexpected error: %v", err)
+	}
+
+	got, err := c.(Prober).Probe(context.Background(), server.URL, &TLSConfigData{})
+	if err != nil {
+		t.Fatalf("Probe(...): unexpected error: %v", err)
+	}
+
+	if got != http.StatusServiceUnavailable {
+		t.Errorf("Probe(...): want status code %d, got %d", http.StatusServiceUnavailable, got)
+	}
+	if len(authorization) != 0 {
+		t.Errorf("Probe(...): want no credentials sent, got the Authorization header %v", authorization)
+	}
+}
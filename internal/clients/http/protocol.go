@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// protocols returns the HTTP protocol versions a transport may use for the protocol setting. The requests keep
+// using HTTP/1.1 by default, HTTP/2 is only used with h2, negotiated with ALPN over TLS, or with h2c, which also
+// sends cleartext requests with HTTP/2 assuming the server supports it.
+func protocols(protocol string) *http.Protocols {
+	p := &http.Protocols{}
+	switch protocol {
+	case common.ProtocolH2:
+		p.SetHTTP2(true)
+	case common.ProtocolH2C:
+		p.SetHTTP2(true)
+		p.SetUnencryptedHTTP2(true)
+	default:
+		p.SetHTTP1(true)
+	}
+
+	return p
+}
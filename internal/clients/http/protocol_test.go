@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// newProtocolServer returns a server answering with the protocol of the request, supporting HTTP/1.1 and HTTP/2
+// over TLS, or HTTP/1.1 and HTTP/2 with prior knowledge over cleartext.
+func newProtocolServer(t *testing.T, tls bool) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	if tls {
+		server.EnableHTTP2 = true
+		server.StartTLS()
+	} else {
+		server.Config.Protocols = &http.Protocols{}
+		server.Config.Protocols.SetHTTP1(true)
+		server.Config.Protocols.SetUnencryptedHTTP2(true)
+		server.Start()
+	}
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSendRequestProtocol(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		protocol string
+		tls      bool
+		want     string
+	}{
+		"AutoTLS": {
+			reason: "Should keep using HTTP/1.1 with a server offering HTTP/2 over TLS by default",
+			tls:    true,
+			want:   "HTTP/1.1",
+		},
+		"AutoCleartext": {
+			reason:   "Should use HTTP/1.1 over cleartext with auto",
+			protocol: common.ProtocolAuto,
+			want:     "HTTP/1.1",
+		},
+		"HTTP1TLS": {
+			reason:   "Should use HTTP/1.1 with a server offering HTTP/2 with http1",
+			protocol: common.ProtocolHTTP1,
+			tls:      true,
+			want:     "HTTP/1.1",
+		},
+		"H2TLS": {
+			reason:   "Should use HTTP/2 over TLS with h2",
+			protocol: common.ProtocolH2,
+			tls:      true,
+			want:     "HTTP/2.0",
+		},
+		"H2CCleartext": {
+			reason:   "Should use HTTP/2 with prior knowledge over cleartext with h2c",
+			protocol: common.ProtocolH2C,
+			want:     "HTTP/2.0",
+		},
+		"H2CTLS": {
+			reason:   "Should use HTTP/2 over TLS with h2c",
+			protocol: common.ProtocolH2C,
+			tls:      true,
+			want:     "HTTP/2.0",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := newProtocolServer(t, tc.tls)
+
+			client, err := NewClient(logging.NewNopLogger(), 10*time.Second, "", WithProtocol(tc.protocol))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			details, err := client.SendRequest(context.Background(), http.MethodGet, server.URL,
+				Data{Encrypted: "", Decrypted: ""},
+				Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}},
+				&TLSConfigData{InsecureSkipVerify: true})
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			if details.HttpResponse.Body != tc.want {
+				t.Errorf("\n%s\nSendRequest(...): want protocol %s, got %s", tc.reason, tc.want, details.HttpResponse.Body)
+			}
+		})
+	}
+}
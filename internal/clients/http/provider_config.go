@@ -0,0 +1,119 @@
+package http
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+const (
+	errNewHttpClient        = "cannot create new Http client"
+	errExtractCredentials   = "cannot extract credentials"
+	errFetchCredentials     = "cannot fetch credentials from endpoint"
+	errLoadOAuth2Token      = "cannot acquire OAuth2 token"
+	errLoadSigV4Credentials = "cannot load SigV4 credentials"
+	errLoadNTLMCredentials  = "cannot load NTLM credentials"
+	errInvalidSSRFGuard     = "invalid SSRF guard"
+	errInvalidHostAliases   = "invalid host aliases"
+)
+
+// NewClientFn creates a client, e.g. NewClient.
+type NewClientFn func(log logging.Logger, timeout time.Duration, creds string, opts ...ClientOption) (Client, error)
+
+// ResourceClientConfig holds the settings of a resource the client of its provider config is created with.
+type ResourceClientConfig struct {
+	// Timeout of the requests.
+	Timeout time.Duration
+
+	// ResponseFormat the response bodies are converted from.
+	ResponseFormat string
+
+	// UserAgent sent with the requests.
+	UserAgent string
+
+	// Protocol overriding the protocol of the provider config, if set.
+	Protocol string
+
+	// ExpectContinueTimeout is the time waited for a 100 Continue before sending a body.
+	ExpectContinueTimeout time.Duration
+
+	// Signers sign the requests before the provider config credentials do.
+	Signers []RequestSigner
+}
+
+// NewProviderConfigClient creates the client authenticating with the credentials of the provider config, with the
+// settings of the resource sending the requests.
+func NewProviderConfigClient(ctx context.Context, kubeClient kube.Client, log logging.Logger, pc *apisv1alpha1.ProviderConfig, config ResourceClientConfig, newClient NewClientFn) (Client, error) {
+	creds := ""
+	switch pc.Spec.Credentials.Source {
+	case xpv1.CredentialsSourceSecret:
+		data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, kubeClient, pc.Spec.Credentials.CommonCredentialSelectors)
+		if err != nil {
+			return nil, errors.Wrap(err, errExtractCredentials)
+		}
+
+		creds = string(data)
+	case apisv1alpha1.CredentialsSourceEndpoint:
+		data, err := FetchEndpointCredentials(ctx, pc.Spec.Credentials.Endpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, errFetchCredentials)
+		}
+
+		creds = data
+	}
+
+	tokenSource, err := LoadOAuth2TokenSource(ctx, kubeClient, pc.Spec.OAuth2)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadOAuth2Token)
+	}
+
+	sigV4Signer, err := LoadSigV4Signer(ctx, kubeClient, pc.Spec.SigV4)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadSigV4Credentials)
+	}
+
+	ntlmCredentials, err := LoadNTLMCredentials(ctx, kubeClient, pc.Spec.NTLM)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadNTLMCredentials)
+	}
+
+	addressGuard, err := NewAddressGuard(pc.Spec.SSRFGuard)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidSSRFGuard)
+	}
+
+	hostAliases, err := NewHostAliases(pc.Spec.HostAliases)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidHostAliases)
+	}
+
+	signers := append(append([]RequestSigner{}, config.Signers...), sigV4Signer)
+	h, err := newClient(log, config.Timeout, creds,
+		WithIPFamily(pc.Spec.IPFamily),
+		WithDisallowBodyRedirects(pc.Spec.DisallowBodyRedirects),
+		WithMaxConcurrentPerHost(pc.Spec.MaxConcurrentPerHost),
+		WithCircuitBreaker(pc.GetName(), pc.Spec.CircuitBreaker),
+		WithTokenSource(tokenSource),
+		WithNTLM(ntlmCredentials),
+		WithRequestSigners(signers...),
+		WithAddressGuard(addressGuard),
+		WithHostAliases(hostAliases),
+		WithResponseFormat(config.ResponseFormat),
+		WithUserAgent(config.UserAgent),
+		WithProtocol(cmp.Or(config.Protocol, pc.Spec.Protocol)),
+		WithExpectContinueTimeout(config.ExpectContinueTimeout),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+
+	return h, nil
+}
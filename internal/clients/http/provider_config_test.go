@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+func TestNewProviderConfigClient(t *testing.T) {
+	secretCredentials := apisv1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "creds", Namespace: "default"},
+				Key:             "token",
+			},
+		},
+	}
+
+	type want struct {
+		creds    string
+		timeout  time.Duration
+		protocol string
+		err      string
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   apisv1alpha1.ProviderConfigSpec
+		config ResourceClientConfig
+		want   want
+	}{
+		"SecretCredentials": {
+			reason: "The client should authenticate with the credentials of the Secret and use the resource settings",
+			spec:   apisv1alpha1.ProviderConfigSpec{Credentials: secretCredentials, Protocol: common.ProtocolH2},
+			config: ResourceClientConfig{Timeout: 5 * time.Second, Protocol: common.ProtocolHTTP1},
+			want: want{
+				creds:    "s3cr3t",
+				timeout:  5 * time.Second,
+				protocol: common.ProtocolHTTP1,
+			},
+		},
+		"ProviderConfigProtocol": {
+			reason: "The protocol of the provider config should be used when the resource sets none",
+			spec:   apisv1alpha1.ProviderConfigSpec{Protocol: common.ProtocolH2},
+			want: want{
+				protocol: common.ProtocolH2,
+			},
+		},
+		"InvalidSSRFGuard": {
+			reason: "An invalid SSRF guard should fail the creation of the client",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				SSRFGuard: &common.SSRFGuardConfig{AllowedCIDRs: []string{"not-a-cidr"}},
+			},
+			want: want{
+				err: errInvalidSSRFGuard,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kubeClient := &test.MockClient{
+				MockGet: func(_ context.Context, key kube.ObjectKey, obj kube.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("s3cr3t")}
+					return nil
+				},
+			}
+
+			var got want
+			newClient := func(log logging.Logger, timeout time.Duration, creds string, opts ...ClientOption) (Client, error) {
+				c := &client{}
+				for _, opt := range opts {
+					opt(c)
+				}
+				got.creds, got.timeout, got.protocol = creds, timeout, c.protocol
+				return c, nil
+			}
+
+			pc := &apisv1alpha1.ProviderConfig{Spec: tc.spec}
+			_, err := NewProviderConfigClient(context.Background(), kubeClient, logging.NewNopLogger(), pc, tc.config, newClient)
+			if tc.want.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.want.err) {
+					t.Fatalf("\n%s\nNewProviderConfigClient(...): want error containing %q, got %v", tc.reason, tc.want.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nNewProviderConfigClient(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nNewProviderConfigClient(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	errReadBodyToSign = "failed to read the request body to sign"
+	errSignRequest    = "failed to sign the request"
+)
+
+// RequestSigner signs a request right before it is sent, once the body and headers are final.
+type RequestSigner interface {
+	// SignRequest adds the signature of the request to its headers. The body is the request body, which must not be
+	// read from the request itself.
+	SignRequest(req *http.Request, body []byte, now time.Time) error
+}
+
+// WithRequestSigners makes the client sign every request it sends with the signers, in order.
+// Nil signers are ignored.
+func WithRequestSigners(signers ...RequestSigner) ClientOption {
+	return func(c *client) {
+		for _, signer := range signers {
+			if signer != nil {
+				c.signers = append(c.signers, signer)
+			}
+		}
+	}
+}
+
+// signingTransport signs the requests before sending them with the base transport. Since every redirected
+// request goes through the transport, the signature is recomputed with a fresh timestamp for each of them.
+type signingTransport struct {
+	base    http.RoundTripper
+	signers []RequestSigner
+}
+
+// signingNow returns the signing time of the requests.
+var signingNow = time.Now
+
+// newSigningTransport wraps the base transport so requests are signed, or returns it if there are no signers.
+func newSigningTransport(base http.RoundTripper, signers []RequestSigner) http.RoundTripper {
+	if len(signers) == 0 {
+		return base
+	}
+
+	return &signingTransport{base: base, signers: signers}
+}
+
+// RoundTrip signs a copy of the request and sends it.
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errReadBodyToSign, err)
+	}
+
+	// A RoundTripper must not modify the request it is given.
+	signed := req.Clone(req.Context())
+	if body != nil {
+		signed.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	now := signingNow()
+	for _, signer := range t.signers {
+		if err := signer.SignRequest(signed, body, now); err != nil {
+			return nil, fmt.Errorf("%s: %w", errSignRequest, err)
+		}
+	}
+
+	return t.base.RoundTrip(signed)
+}
+
+// requestBody returns the body of the request, without consuming the body of the request if it can be replayed.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody == nil {
+		defer func() { _ = req.Body.Close() }()
+		return io.ReadAll(req.Body)
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+
+	return io.ReadAll(body)
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	sigV4Algorithm      = "AWS4-HMAC-SHA256"
+	sigV4Request        = "aws4_request"
+	sigV4DateFormat     = "20060102"
+	sigV4DateTimeFormat = "20060102T150405Z"
+	sigV4S3Service      = "s3"
+
+	headerAmzDate          = "X-Amz-Date"
+	headerAmzSecurityToken = "X-Amz-Security-Token"
+	headerAmzContentSHA256 = "X-Amz-Content-Sha256"
+)
+
+// sigV4UnsignedHeaders are the headers left out of the signature, since they may be changed on the way to the server.
+var sigV4UnsignedHeaders = map[string]bool{
+	"authorization":   true,
+	"user-agent":      true,
+	"x-amzn-trace-id": true,
+	"expect":          true,
+}
+
+// sigV4Signer signs requests with AWS Signature Version 4.
+type sigV4Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	region          string
+	service         string
+}
+
+// LoadSigV4Signer loads the AWS credentials from secrets and returns a signer signing requests with them.
+func LoadSigV4Signer(ctx context.Context, kubeClient kube.Client, sigV4Config *common.SigV4Config) (RequestSigner, error) {
+	if sigV4Config == nil {
+		return nil, nil
+	}
+
+	accessKeyID, err := loadSecretData(ctx, kubeClient, &sigV4Config.AccessKeyIDSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS access key id from secret: %w", err)
+	}
+
+	secretAccessKey, err := loadSecretData(ctx, kubeClient, &sigV4Config.SecretAccessKeySecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS secret access key from secret: %w", err)
+	}
+
+	sessionToken, err := loadSecretData(ctx, kubeClient, sigV4Config.SessionTokenSecretRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS session token from secret: %w", err)
+	}
+
+	return &sigV4Signer{
+		accessKeyID:     strings.TrimSpace(string(accessKeyID)),
+		secretAccessKey: strings.TrimSpace(string(secretAccessKey)),
+		sessionToken:    strings.TrimSpace(string(sessionToken)),
+		region:          sigV4Config.Region,
+		service:         sigV4Config.Service,
+	}, nil
+}
+
+// SignRequest sets the X-Amz-Date and Authorization headers of the request, and the X-Amz-Security-Token
+// header when using temporary credentials.
+func (s *sigV4Signer) SignRequest(req *http.Request, body []byte, now time.Time) error {
+	now = now.UTC()
+	req.Header.Set(headerAmzDate, now.Format(sigV4DateTimeFormat))
+	if s.sessionToken != "" {
+		req.Header.Set(headerAmzSecurityToken, s.sessionToken)
+	}
+
+	payloadHash := sha256Hex(body)
+	if s.service == sigV4S3Service {
+		req.Header.Set(headerAmzContentSHA256, payloadHash)
+	}
+
+	canonicalRequest, signedHeaders := s.canonicalRequest(req, payloadHash)
+	scope := strings.Join([]string{now.Format(sigV4DateFormat), s.region, s.service, sigV4Request}, "/")
+	stringToSign := strings.Join([]string{sigV4Algorithm, now.Format(sigV4DateTimeFormat), scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(s.signingKey(now), stringToSign))
+
+	req.Header.Set(authKey, fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.accessKeyID, scope, signedHeaders, signature))
+
+	return nil
+}
+
+// canonicalRequest returns the canonical form of the request and the list of its signed headers.
+func (s *sigV4Signer) canonicalRequest(req *http.Request, payloadHash string) (string, string) {
+	headers, signedHeaders := sigV4CanonicalHeaders(req)
+
+	return strings.Join([]string{
+		req.Method,
+		s.canonicalURI(req.URL),
+		sigV4CanonicalQuery(req.URL),
+		headers,
+		signedHeaders,
+		payloadHash,
+	}, "\n"), signedHeaders
+}
+
+// canonicalURI returns the URI-encoded path of the URL. Every service but S3 expects the path to be encoded twice.
+func (s *sigV4Signer) canonicalURI(u *url.URL) string {
+	path := u.Path
+	if path == "" {
+		return "/"
+	}
+
+	encoded := sigV4Encode(path, false)
+	if s.service != sigV4S3Service {
+		encoded = sigV4Encode(encoded, false)
+	}
+
+	return encoded
+}
+
+// signingKey derives the key signing the requests of the day.
+func (s *sigV4Signer) signingKey(now time.Time) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format(sigV4DateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	return hmacSHA256(key, sigV4Request)
+}
+
+// sigV4CanonicalQuery returns the query parameters of the URL sorted by name then value, URI-encoded.
+func sigV4CanonicalQuery(u *url.URL) string {
+	query := u.Query()
+	params := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, value := range values {
+			params = append(params, sigV4Encode(key, true)+"="+sigV4Encode(value, true))
+		}
+	}
+	sort.Strings(params)
+
+	return strings.Join(params, "&")
+}
+
+// sigV4CanonicalHeaders returns the canonical headers of the request, host included, and the list of their names.
+func sigV4CanonicalHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	values := map[string][]string{"host": {host}}
+	for key, v := range req.Header {
+		name := strings.ToLower(key)
+		if sigV4UnsignedHeaders[name] {
+			continue
+		}
+		values[name] = append(values[name], v...)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		trimmed := make([]string, len(values[name]))
+		for i, value := range values[name] {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+		canonical.WriteString(name + ":" + strings.Join(trimmed, ",") + "\n")
+	}
+
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// sigV4Encode URI-encodes every byte except the unreserved characters, and the slashes unless encodeSlash is set.
+func sigV4Encode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			encoded.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			encoded.WriteByte(c)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+
+	return encoded.String()
+}
+
+// sha256Hex returns the hex encoded SHA-256 hash of the data.
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
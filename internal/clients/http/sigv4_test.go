@@ -0,0 +1,230 @@
+package http
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testAccessKeyID     = "AKIDEXAMPLE"
+	testSecretAccessKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+)
+
+// testSigV4Time is the signing time of the AWS Signature Version 4 test suite.
+var testSigV4Time = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+func TestSigV4SignRequest(t *testing.T) {
+	type want struct {
+		canonicalRequest string
+		authorization    string
+	}
+
+	cases := map[string]struct {
+		method  string
+		url     string
+		service string
+		headers map[string]string
+		body    string
+		want    want
+	}{
+		// Test vectors from the AWS Signature Version 4 test suite.
+		"GetVanilla": {
+			method:  http.MethodGet,
+			url:     "https://example.amazonaws.com/",
+			service: "service",
+			want: want{
+				canonicalRequest: "GET\n/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" +
+					"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+					"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+			},
+		},
+		"GetVanillaQueryOrderKeyCase": {
+			method:  http.MethodGet,
+			url:     "https://example.amazonaws.com/?Param2=value2&Param1=value1",
+			service: "service",
+			want: want{
+				canonicalRequest: "GET\n/\nParam1=value1&Param2=value2\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" +
+					"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+					"SignedHeaders=host;x-amz-date, Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+			},
+		},
+		"PostVanilla": {
+			method:  http.MethodPost,
+			url:     "https://example.amazonaws.com/",
+			service: "service",
+			want: want{
+				canonicalRequest: "POST\n/\n\nhost:example.amazonaws.com\nx-amz-date:20150830T123600Z\n\nhost;x-amz-date\n" +
+					"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+					"SignedHeaders=host;x-amz-date, Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+			},
+		},
+		// Example from the AWS documentation on creating a signed request.
+		"IAMListUsers": {
+			method:  http.MethodGet,
+			url:     "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08",
+			service: "iam",
+			headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded; charset=utf-8"},
+			want: want{
+				canonicalRequest: "GET\n/\nAction=ListUsers&Version=2010-05-08\n" +
+					"content-type:application/x-www-form-urlencoded; charset=utf-8\nhost:iam.amazonaws.com\nx-amz-date:20150830T123600Z\n\n" +
+					"content-type;host;x-amz-date\ne3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				authorization: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+					"SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			signer := &sigV4Signer{
+				accessKeyID:     testAccessKeyID,
+				secretAccessKey: testSecretAccessKey,
+				region:          "us-east-1",
+				service:         tc.service,
+			}
+
+			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("http.NewRequest(...): unexpected error: %v", err)
+			}
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			if err := signer.SignRequest(req, []byte(tc.body), testSigV4Time); err != nil {
+				t.Fatalf("SignRequest(...): unexpected error: %v", err)
+			}
+
+			canonicalRequest, _ := signer.canonicalRequest(req, sha256Hex([]byte(tc.body)))
+			if diff := cmp.Diff(tc.want.canonicalRequest, canonicalRequest); diff != "" {
+				t.Errorf("canonicalRequest(...): -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.authorization, req.Header.Get(authKey)); diff != "" {
+				t.Errorf("SignRequest(...): -want Authorization, +got Authorization: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSigV4SigningKey(t *testing.T) {
+	signer := &sigV4Signer{secretAccessKey: testSecretAccessKey, region: "us-east-1", service: "iam"}
+
+	want := "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9"
+	if got := hex.EncodeToString(signer.signingKey(testSigV4Time)); got != want {
+		t.Errorf("signingKey(...): want %s, got %s", want, got)
+	}
+}
+
+func TestSigV4SessionToken(t *testing.T) {
+	signer := &sigV4Signer{
+		accessKeyID:     testAccessKeyID,
+		secretAccessKey: testSecretAccessKey,
+		sessionToken:    "session-token",
+		region:          "us-east-1",
+		service:         "execute-api",
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://abc.execute-api.us-east-1.amazonaws.com/prod/users", strings.NewReader(`{"name":"john"}`))
+	if err := signer.SignRequest(req, []byte(`{"name":"john"}`), testSigV4Time); err != nil {
+		t.Fatalf("SignRequest(...): unexpected error: %v", err)
+	}
+
+	if got := req.Header.Get(headerAmzSecurityToken); got != "session-token" {
+		t.Errorf("SignRequest(...): want X-Amz-Security-Token session-token, got %q", got)
+	}
+	if got := req.Header.Get(authKey); !strings.Contains(got, "SignedHeaders=host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("SignRequest(...): want the session token to be signed, got %q", got)
+	}
+}
+
+func TestLoadSigV4Signer(t *testing.T) {
+	ref := func(key string) xpv1.SecretKeySelector {
+		return xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "aws", Namespace: "default"}, Key: key}
+	}
+	sessionTokenRef := ref("session-token")
+	kubeClient := &test.MockClient{
+		MockGet: func(ctx context.Context, key kube.ObjectKey, obj kube.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Data = map[string][]byte{
+					"access-key-id":     []byte(testAccessKeyID + "\n"),
+					"secret-access-key": []byte(testSecretAccessKey),
+					"session-token":     []byte("session-token"),
+				}
+			}
+			return nil
+		},
+	}
+
+	signer, err := LoadSigV4Signer(context.Background(), kubeClient, &common.SigV4Config{
+		Region:                   "eu-west-1",
+		Service:                  "execute-api",
+		AccessKeyIDSecretRef:     ref("access-key-id"),
+		SecretAccessKeySecretRef: ref("secret-access-key"),
+		SessionTokenSecretRef:    &sessionTokenRef,
+	})
+	if err != nil {
+		t.Fatalf("LoadSigV4Signer(...): unexpected error: %v", err)
+	}
+
+	want := &sigV4Signer{
+		accessKeyID:     testAccessKeyID,
+		secretAccessKey: testSecretAccessKey,
+		sessionToken:    "session-token",
+		region:          "eu-west-1",
+		service:         "execute-api",
+	}
+	if diff := cmp.Diff(want, signer, cmp.AllowUnexported(sigV4Signer{})); diff != "" {
+		t.Errorf("LoadSigV4Signer(...): -want, +got: %s", diff)
+	}
+
+	if signer, err := LoadSigV4Signer(context.Background(), kubeClient, nil); signer != nil || err != nil {
+		t.Errorf("LoadSigV4Signer(...): want no signer and no error without config, got %v, %v", signer, err)
+	}
+}
+
+func TestSendRequestSignedOnRedirect(t *testing.T) {
+	var dates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dates = append(dates, r.Header.Get(headerAmzDate))
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer server.Close()
+
+	signer := &sigV4Signer{accessKeyID: testAccessKeyID, secretAccessKey: testSecretAccessKey, region: "us-east-1", service: "execute-api"}
+	c, _ := NewClient(logging.NewNopLogger(), 0, "", WithRequestSigners(signer))
+
+	// Every request sent by the transport is signed one second after the previous one.
+	clock := testSigV4Time
+	signingNow = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	defer func() { signingNow = time.Now }()
+
+	headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+	if _, err := c.SendRequest(context.Background(), http.MethodPost, server.URL+"/old", Data{Encrypted: "{}", Decrypted: "{}"}, headers, nil); err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"20150830T123601Z", "20150830T123602Z"}, dates); diff != "" {
+		t.Errorf("SendRequest(...): -want signing dates, +got signing dates: %s", diff)
+	}
+}
@@ -0,0 +1,179 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+
+	errEventStreamMaxDuration = "event stream exceeded %s without a matching event"
+	errEventStreamEnded       = "event stream ended after %d reconnects without a matching event"
+)
+
+// Event is a server-sent event.
+type Event struct {
+	// ID is the last event ID set by the stream.
+	ID string
+
+	// Type is the event type, message if the event sets none.
+	Type string
+
+	// Data is the data of the event, with the lines of multi-line data joined by newlines.
+	Data string
+}
+
+// EventStreamOptions configures reading a response as a stream of server-sent events.
+type EventStreamOptions struct {
+	// Match checks if an event completes the stream.
+	Match func(event Event) bool
+
+	// MaxDuration is the maximum time the request and stream take, reconnections included. It replaces the timeout
+	// of the request.
+	MaxDuration time.Duration
+
+	// MaxReconnects is the maximum number of times the request is sent again after the stream ended or was
+	// interrupted without a matching event.
+	MaxReconnects int
+}
+
+// eventStreamContextKey is the context key of the event stream options of a request.
+type eventStreamContextKey struct{}
+
+// ContextWithEventStream returns a copy of the context making the requests sent with it read their response as a
+// stream of server-sent events, until an event matches.
+func ContextWithEventStream(ctx context.Context, options EventStreamOptions) context.Context {
+	return context.WithValue(ctx, eventStreamContextKey{}, options)
+}
+
+// EventStreamFromContext returns the event stream options set on the context, if any.
+func EventStreamFromContext(ctx context.Context) (EventStreamOptions, bool) {
+	options, ok := ctx.Value(eventStreamContextKey{}).(EventStreamOptions)
+	return options, ok
+}
+
+// sendEventStream sends the request and reads its response as server-sent events until one matches the options.
+// The matching event becomes the body of the response. When the stream ends or is interrupted first, the request
+// is sent again with the ID of the last received event in the Last-Event-ID header, after the reconnection delay
+// requested by the server, if any. Responses other than 200 are returned as is.
+func sendEventStream(client *http.Client, request *http.Request, body []byte, options EventStreamOptions) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(request.Context(), options.MaxDuration)
+	defer cancel()
+
+	var lastEventID string
+	var retry time.Duration
+	for reconnects := 0; ; reconnects++ {
+		attempt := request.Clone(ctx)
+		attempt.Body = io.NopCloser(bytes.NewReader(body))
+		attempt.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		if lastEventID != "" {
+			attempt.Header.Set(lastEventIDHeader, lastEventID)
+		}
+
+		response, err := client.Do(attempt)
+		if err != nil {
+			if isTimeout(err) || ctx.Err() != nil {
+				return nil, nil, fmt.Errorf("%s: %w", fmt.Sprintf(errEventStreamMaxDuration, options.MaxDuration), err)
+			}
+			return nil, nil, err
+		}
+
+		if response.StatusCode != http.StatusOK {
+			responseBody, err := io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			return response, responseBody, err
+		}
+
+		stream := eventReader{reader: bufio.NewReader(response.Body), lastEventID: lastEventID, retry: retry}
+		event, matched, err := stream.readUntil(options.Match)
+		_ = response.Body.Close()
+		lastEventID, retry = stream.lastEventID, stream.retry
+
+		if matched {
+			return response, []byte(event.Data), nil
+		}
+		if ctx.Err() != nil || (err != nil && isTimeout(err)) {
+			return nil, nil, fmt.Errorf(errEventStreamMaxDuration, options.MaxDuration)
+		}
+		if reconnects >= options.MaxReconnects {
+			return nil, nil, fmt.Errorf(errEventStreamEnded, reconnects)
+		}
+
+		select {
+		case <-time.After(retry):
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf(errEventStreamMaxDuration, options.MaxDuration)
+		}
+	}
+}
+
+// eventReader parses server-sent events from a stream.
+type eventReader struct {
+	reader *bufio.Reader
+
+	// lastEventID is the ID of the last event, kept across events and reconnections as the specification requires.
+	lastEventID string
+
+	// retry is the reconnection delay last requested by the server.
+	retry time.Duration
+}
+
+// readUntil reads events until one matches, or the stream ends or fails.
+func (r *eventReader) readUntil(match func(event Event) bool) (Event, bool, error) {
+	var eventType string
+	var data []string
+	for {
+		line, err := r.reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Event{}, false, err
+		}
+
+		// An event that is not terminated by an empty line before the stream ends is discarded.
+		if err != nil {
+			return Event{}, false, nil
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if len(data) > 0 {
+				event := Event{ID: r.lastEventID, Type: eventType, Data: strings.Join(data, "\n")}
+				if event.Type == "" {
+					event.Type = "message"
+				}
+				if match == nil || match(event) {
+					return event, true, nil
+				}
+			}
+			eventType, data = "", nil
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				r.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				r.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+// jobEventServer streams the events of a job. Every connection sends the events following the Last-Event-ID of
+// the request, up to perConnection events, then closes the stream, or keeps it open if keepOpen is set.
+// The Last-Event-ID headers received are recorded.
+type jobEventServer struct {
+	events        []string
+	perConnection int
+	keepOpen      bool
+	status        int
+
+	mu           sync.Mutex
+	lastEventIDs []string
+}
+
+func (s *jobEventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.lastEventIDs = append(s.lastEventIDs, r.Header.Get(lastEventIDHeader))
+	s.mu.Unlock()
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		fmt.Fprint(w, `{"error":"unavailable"}`)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	flusher := w.(http.Flusher)
+	fmt.Fprint(w, ": connected\nretry: 10\n\n")
+
+	start := 0
+	if id := r.Header.Get(lastEventIDHeader); id != "" {
+		_, _ = fmt.Sscanf(id, "%d", &start)
+	}
+
+	for i := start; i < len(s.events) && i < start+s.perConnection; i++ {
+		fmt.Fprintf(w, "id: %d\nevent: status\n%s\n\n", i+1, s.events[i])
+		flusher.Flush()
+	}
+
+	if s.keepOpen {
+		<-r.Context().Done()
+	}
+}
+
+// matchComplete matches the completion event of the job.
+func matchComplete(event Event) bool {
+	return strings.Contains(event.Data, `"status":"complete"`)
+}
+
+func TestSendRequestEventStream(t *testing.T) {
+	jobEvents := []string{
+		`data: {"status":"running"}`,
+		`data: {"status":"running",` + "\n" + `data: "progress":50}`,
+		`data: {"status":"complete","result":"ok"}`,
+	}
+
+	cases := map[string]struct {
+		server           *jobEventServer
+		options          EventStreamOptions
+		wantBody         string
+		wantStatusCode   int
+		wantLastEventIDs []string
+		wantErr          string
+	}{
+		"CompletesOnMatchingEvent": {
+			server:           &jobEventServer{events: jobEvents, perConnection: 3, keepOpen: true},
+			options:          EventStreamOptions{Match: matchComplete, MaxDuration: 5 * time.Second, MaxReconnects: 3},
+			wantBody:         `{"status":"complete","result":"ok"}`,
+			wantStatusCode:   http.StatusOK,
+			wantLastEventIDs: []string{""},
+		},
+		"ReconnectsWithLastEventID": {
+			server:           &jobEventServer{events: jobEvents, perConnection: 1},
+			options:          EventStreamOptions{Match: matchComplete, MaxDuration: 5 * time.Second, MaxReconnects: 3},
+			wantBody:         `{"status":"complete","result":"ok"}`,
+			wantStatusCode:   http.StatusOK,
+			wantLastEventIDs: []string{"", "1", "2"},
+		},
+		"ReconnectsExhausted": {
+			server:           &jobEventServer{events: jobEvents, perConnection: 1},
+			options:          EventStreamOptions{Match: matchComplete, MaxDuration: 5 * time.Second, MaxReconnects: 1},
+			wantErr:          "event stream ended after 1 reconnects without a matching event",
+			wantLastEventIDs: []string{"", "1"},
+		},
+		"MaxDurationExceeded": {
+			server:           &jobEventServer{events: jobEvents[:2], perConnection: 3, keepOpen: true},
+			options:          EventStreamOptions{Match: matchComplete, MaxDuration: 200 * time.Millisecond, MaxReconnects: 3},
+			wantErr:          "event stream exceeded 200ms without a matching event",
+			wantLastEventIDs: []string{""},
+		},
+		"ErrorResponseReturnedAsIs": {
+			server:           &jobEventServer{status: http.StatusServiceUnavailable},
+			options:          EventStreamOptions{Match: matchComplete, MaxDuration: 5 * time.Second, MaxReconnects: 3},
+			wantBody:         `{"error":"unavailable"}`,
+			wantStatusCode:   http.StatusServiceUnavailable,
+			wantLastEventIDs: []string{""},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.server)
+			defer server.Close()
+
+			c, _ := NewClient(logging.NewNopLogger(), time.Millisecond, "")
+			headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+			ctx := ContextWithEventStream(context.Background(), tc.options)
+			details, err := c.SendRequest(ctx, http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, headers, nil)
+
+			tc.server.mu.Lock()
+			lastEventIDs := tc.server.lastEventIDs
+			tc.server.mu.Unlock()
+			if diff := cmp.Diff(tc.wantLastEventIDs, lastEventIDs); diff != "" {
+				t.Errorf("SendRequest(...): -want Last-Event-ID headers, +got Last-Event-ID headers: %s", diff)
+			}
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("SendRequest(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, details.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStatusCode, details.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+		})
+	}
+}
+
+func TestEventReaderMultiLineData(t *testing.T) {
+	stream := "event: progress\ndata: first\r\ndata: second\n\n"
+	reader := eventReader{reader: bufio.NewReader(strings.NewReader(stream))}
+
+	event, matched, err := reader.readUntil(nil)
+	if err != nil || !matched {
+		t.Fatalf("readUntil(...): want matching event, got matched %t, error %v", matched, err)
+	}
+
+	if diff := cmp.Diff(Event{Type: "progress", Data: "first\nsecond"}, event); diff != "" {
+		t.Errorf("readUntil(...): -want event, +got event: %s", diff)
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	errStreamMaxBytes    = "stream exceeded %d bytes without a matching line"
+	errStreamMaxDuration = "stream exceeded %s without a matching line"
+)
+
+// StreamOptions configures reading a response as a stream of lines.
+type StreamOptions struct {
+	// Match checks if a line completes the stream.
+	Match func(line []byte) bool
+
+	// MaxDuration is the maximum time the request and stream take, it replaces the timeout of the request.
+	MaxDuration time.Duration
+
+	// MaxBytes is the maximum number of bytes read from the stream.
+	MaxBytes int64
+}
+
+// streamContextKey is the context key of the stream options of a request.
+type streamContextKey struct{}
+
+// ContextWithStream returns a copy of the context making the requests sent with it read their response as a stream
+// of lines, until a line matches or the stream ends.
+func ContextWithStream(ctx context.Context, options StreamOptions) context.Context {
+	return context.WithValue(ctx, streamContextKey{}, options)
+}
+
+// StreamFromContext returns the stream options set on the context, if any.
+func StreamFromContext(ctx context.Context) (StreamOptions, bool) {
+	options, ok := ctx.Value(streamContextKey{}).(StreamOptions)
+	return options, ok
+}
+
+// readStream reads the body line by line and returns the first line matching the options, or the last non-empty
+// line if the stream ends first. It fails once more than MaxBytes were read, or if the stream outlasts MaxDuration.
+func readStream(body io.Reader, options StreamOptions) ([]byte, error) {
+	reader := bufio.NewReader(io.LimitReader(body, options.MaxBytes+1))
+
+	var read int64
+	var last []byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		read += int64(len(line))
+		if read > options.MaxBytes {
+			return nil, fmt.Errorf(errStreamMaxBytes, options.MaxBytes)
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if options.Match != nil && options.Match(line) {
+				return line, nil
+			}
+			last = line
+		}
+
+		if errors.Is(err, io.EOF) {
+			return last, nil
+		}
+		if err != nil {
+			if isTimeout(err) {
+				return nil, fmt.Errorf("%s: %w", fmt.Sprintf(errStreamMaxDuration, options.MaxDuration), err)
+			}
+			return nil, err
+		}
+	}
+}
+
+// isTimeout checks if reading failed because the request timed out.
+func isTimeout(err error) bool {
+	var timeout interface{ Timeout() bool }
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &timeout) && timeout.Timeout())
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+// ndjsonProgressServer streams NDJSON progress lines followed by a final status line, then keeps the stream open
+// until the client goes away, like a job API that never closes the connection on its own.
+func ndjsonProgressServer(progress int, final string, keepOpen bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher := w.(http.Flusher)
+
+		for i := 1; i <= progress; i++ {
+			fmt.Fprintf(w, "{\"status\":\"running\",\"progress\":%d}\n", i*10)
+			flusher.Flush()
+		}
+		if final != "" {
+			fmt.Fprintln(w, final)
+			flusher.Flush()
+		}
+
+		if keepOpen {
+			<-r.Context().Done()
+		}
+	}))
+}
+
+// matchDone matches the final status line of the progress stream.
+func matchDone(line []byte) bool {
+	return bytes.Contains(line, []byte(`"status":"done"`))
+}
+
+func TestSendRequestStream(t *testing.T) {
+	cases := map[string]struct {
+		server   *httptest.Server
+		options  StreamOptions
+		wantBody string
+		wantErr  string
+	}{
+		"CompletesOnMatchingLine": {
+			server:   ndjsonProgressServer(3, `{"status":"done","result":"ok"}`, true),
+			options:  StreamOptions{Match: matchDone, MaxDuration: 5 * time.Second, MaxBytes: 1024},
+			wantBody: `{"status":"done","result":"ok"}`,
+		},
+		"ReturnsLastLineWhenStreamEnds": {
+			server:   ndjsonProgressServer(2, "", false),
+			options:  StreamOptions{Match: matchDone, MaxDuration: 5 * time.Second, MaxBytes: 1024},
+			wantBody: `{"status":"running","progress":20}`,
+		},
+		"MaxBytesExceeded": {
+			server:  ndjsonProgressServer(100, `{"status":"done"}`, true),
+			options: StreamOptions{Match: matchDone, MaxDuration: 5 * time.Second, MaxBytes: 200},
+			wantErr: "stream exceeded 200 bytes without a matching line",
+		},
+		"MaxDurationExceeded": {
+			server:  ndjsonProgressServer(1, "", true),
+			options: StreamOptions{Match: matchDone, MaxDuration: 200 * time.Millisecond, MaxBytes: 1024},
+			wantErr: "stream exceeded 200ms without a matching line",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer tc.server.Close()
+
+			c, _ := NewClient(logging.NewNopLogger(), time.Millisecond, "")
+			headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+			ctx := ContextWithStream(context.Background(), tc.options)
+			details, err := c.SendRequest(ctx, http.MethodGet, tc.server.URL, Data{Encrypted: "", Decrypted: ""}, headers, nil)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("SendRequest(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, details.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+// requestTimer collects the latency breakdown of a single HTTP request using httptrace hooks.
+type requestTimer struct {
+	mu sync.Mutex
+
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+	done         time.Time
+}
+
+// newRequestTimer returns a requestTimer whose clock starts now.
+func newRequestTimer() *requestTimer {
+	return &requestTimer{start: time.Now()}
+}
+
+// trace returns the httptrace hooks recording the timer's phases.
+func (t *requestTimer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.record(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.record(&t.dnsDone) },
+		// ConnectStart and ConnectDone may be called several times when dialing multiple addresses,
+		// the first start and the last successful done are kept.
+		ConnectStart: func(string, string) { t.recordOnce(&t.connectStart) },
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				t.record(&t.connectDone)
+			}
+		},
+		TLSHandshakeStart:    func() { t.record(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.record(&t.tlsDone) },
+		GotFirstResponseByte: func() { t.record(&t.firstByte) },
+	}
+}
+
+// finish stops the timer.
+func (t *requestTimer) finish() {
+	t.record(&t.done)
+}
+
+// timing returns the recorded phases, phases that did not happen are left zero.
+func (t *requestTimer) timing() common.Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return common.Timing{
+		DNSMs:          elapsedMs(t.dnsStart, t.dnsDone),
+		ConnectMs:      elapsedMs(t.connectStart, t.connectDone),
+		TLSHandshakeMs: elapsedMs(t.tlsStart, t.tlsDone),
+		TTFBMs:         elapsedMs(t.start, t.firstByte),
+		TotalMs:        elapsedMs(t.start, t.done),
+	}
+}
+
+func (t *requestTimer) record(field *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*field = time.Now()
+}
+
+func (t *requestTimer) recordOnce(field *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if field.IsZero() {
+		*field = time.Now()
+	}
+}
+
+// elapsedMs returns the milliseconds between from and to, or zero if either is unset.
+func elapsedMs(from, to time.Time) int64 {
+	if from.IsZero() || to.IsZero() {
+		return 0
+	}
+	return to.Sub(from).Milliseconds()
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+func TestSendRequestTiming(t *testing.T) {
+	emptyBody := Data{Encrypted: "", Decrypted: ""}
+	emptyHeaders := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+
+	t.Run("TimingPopulatedOnSuccess", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(logging.NewNopLogger(), 30*time.Second, "")
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %v", err)
+		}
+
+		got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, emptyBody, emptyHeaders, &TLSConfigData{})
+		if err != nil {
+			t.Fatalf("SendRequest(...): unexpected error: %v", err)
+		}
+
+		timing := got.HttpResponse.Timing
+		if timing.TTFBMs < 20 {
+			t.Errorf("SendRequest(...): TTFBMs = %d, want at least 20", timing.TTFBMs)
+		}
+		if timing.TotalMs < timing.TTFBMs {
+			t.Errorf("SendRequest(...): TotalMs = %d, want at least TTFBMs %d", timing.TotalMs, timing.TTFBMs)
+		}
+	})
+
+	t.Run("TimingZeroOnConnectFailure", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("net.Listen(...): unexpected error: %v", err)
+		}
+		url := "http://" + listener.Addr().String()
+		listener.Close()
+
+		c, err := NewClient(logging.NewNopLogger(), 5*time.Second, "")
+		if err != nil {
+			t.Fatalf("NewClient(...): unexpected error: %v", err)
+		}
+
+		got, err := c.SendRequest(context.Background(), http.MethodGet, url, emptyBody, emptyHeaders, &TLSConfigData{})
+		if err == nil {
+			t.Fatalf("SendRequest(...): expected connection error, got nil")
+		}
+
+		if diff := cmp.Diff(common.Timing{}, got.HttpResponse.Timing); diff != "" {
+			t.Errorf("SendRequest(...): -want timing, +got timing: %s", diff)
+		}
+	})
+}
+
+func TestElapsedMs(t *testing.T) {
+	start := time.Now()
+
+	cases := map[string]struct {
+		from time.Time
+		to   time.Time
+		want int64
+	}{
+		"BothSet": {
+			from: start,
+			to:   start.Add(150 * time.Millisecond),
+			want: 150,
+		},
+		"FromUnset": {
+			to:   start,
+			want: 0,
+		},
+		"ToUnset": {
+			from: start,
+			want: 0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := elapsedMs(tc.from, tc.to); got != tc.want {
+				t.Errorf("elapsedMs(...) = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
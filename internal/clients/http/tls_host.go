@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kube "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	errTLSHostPolicyRedirect = "redirects to a host with another TLS host policy are disallowed"
+)
+
+// HostTLSPolicy is the verification of the certificate of the servers of a host, loaded from a TLSHostPolicy.
+type HostTLSPolicy struct {
+	// Host is a host name or IP address, or a wildcard matching the subdomains of a host name
+	Host string
+	// InsecureSkipVerify skips the verification of the certificate of the host
+	InsecureSkipVerify bool
+	// CABundle contains the PEM encoded CA certificates of the host, empty for the default ones
+	CABundle []byte
+}
+
+// LoadTLSHostPolicies loads the CA bundles of the TLS host policies from their secrets.
+func LoadTLSHostPolicies(ctx context.Context, kubeClient kube.Client, policies []common.TLSHostPolicy) ([]HostTLSPolicy, error) {
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	loaded := make([]HostTLSPolicy, 0, len(policies))
+	for _, policy := range policies {
+		hostPolicy := HostTLSPolicy{
+			Host:               policy.Host,
+			InsecureSkipVerify: policy.InsecureSkipVerify,
+			CABundle:           policy.CABundle,
+		}
+
+		if len(hostPolicy.CABundle) == 0 && policy.CACertSecretRef != nil {
+			caData, err := loadSecretData(ctx, kubeClient, policy.CACertSecretRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load CA certificate of host %s from secret: %w", policy.Host, err)
+			}
+			hostPolicy.CABundle = caData
+		}
+
+		loaded = append(loaded, hostPolicy)
+	}
+
+	return loaded, nil
+}
+
+// hostPolicy returns the first policy matching the host, or nil if none does.
+func (data *TLSConfigData) hostPolicy(host string) *HostTLSPolicy {
+	if data == nil {
+		return nil
+	}
+
+	for i := range data.HostPolicies {
+		if matchesHost(data.HostPolicies[i].Host, host) {
+			return &data.HostPolicies[i]
+		}
+	}
+
+	return nil
+}
+
+// forHost returns the TLS configuration of the requests to the host, whose verification settings are replaced by
+// the ones of its policy if any.
+func (data *TLSConfigData) forHost(host string) *TLSConfigData {
+	policy := data.hostPolicy(host)
+	if policy == nil {
+		return data
+	}
+
+	hostData := *data
+	hostData.InsecureSkipVerify = policy.InsecureSkipVerify
+	if len(policy.CABundle) > 0 {
+		hostData.CABundle = policy.CABundle
+	}
+
+	return &hostData
+}
+
+// matchesHost checks whether the host matches the pattern of a policy, a host name or IP address, or a wildcard
+// matching the subdomains of a host name, case-insensitively.
+func matchesHost(pattern, host string) bool {
+	pattern, host = strings.ToLower(pattern), strings.ToLower(host)
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+
+	return pattern == host
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+func TestSendRequestTLSHostPolicies(t *testing.T) {
+	// The certificate of the server is valid for 127.0.0.1, not for localhost, and signed by an unknown authority.
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, server.URL, http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	internalURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+	// One config skipping the verification of the internal host and trusting the CA of the other host.
+	policies := []common.TLSHostPolicy{
+		{Host: "localhost", InsecureSkipVerify: true},
+		{Host: "127.0.0.1", CABundle: caBundle},
+	}
+
+	cases := map[string]struct {
+		reason   string
+		url      string
+		policies []common.TLSHostPolicy
+		wantErr  string
+	}{
+		"SkippedHost": {
+			reason:   "Should not verify the certificate of a host whose policy skips the verification",
+			url:      internalURL,
+			policies: policies,
+		},
+		"VerifiedHost": {
+			reason:   "Should verify the certificate of a host with the CA bundle of its policy",
+			url:      server.URL,
+			policies: policies,
+		},
+		"VerifiedHostWildcard": {
+			reason:   "Should apply the first policy matching the host",
+			url:      server.URL,
+			policies: []common.TLSHostPolicy{{Host: "*.example.com", InsecureSkipVerify: true}, {Host: "127.0.0.1", CABundle: caBundle}},
+		},
+		"HostWithoutPolicy": {
+			reason:   "Should verify the certificate of a host without a policy with the default roots",
+			url:      server.URL,
+			policies: []common.TLSHostPolicy{{Host: "localhost", InsecureSkipVerify: true}},
+			wantErr:  "certificate signed by unknown authority",
+		},
+		"RedirectToOtherPolicy": {
+			reason:   "Should not follow a redirect to a host with another policy",
+			url:      internalURL + "/redirect",
+			policies: policies,
+			wantErr:  errTLSHostPolicyRedirect,
+		},
+		"HostNameMismatch": {
+			reason:   "Should verify the host name of the certificate with the CA bundle of the policy",
+			url:      internalURL,
+			policies: []common.TLSHostPolicy{{Host: "localhost", CABundle: caBundle}},
+			wantErr:  "certificate is valid for",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hostPolicies, err := LoadTLSHostPolicies(context.Background(), nil, tc.policies)
+			if err != nil {
+				t.Fatalf("LoadTLSHostPolicies(...): unexpected error: %v", err)
+			}
+
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, "")
+			headers := Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, tc.url, Data{Encrypted: "", Decrypted: ""}, headers, &TLSConfigData{HostPolicies: hostPolicies})
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("\n%s\nSendRequest(...): want error containing %q, got %v", tc.reason, tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestMatchesHost(t *testing.T) {
+	cases := map[string]struct {
+		pattern string
+		host    string
+		want    bool
+	}{
+		"Exact":             {pattern: "api.example.com", host: "api.example.com", want: true},
+		"CaseInsensitive":   {pattern: "API.example.com", host: "api.EXAMPLE.com", want: true},
+		"OtherHost":         {pattern: "api.example.com", host: "web.example.com"},
+		"Wildcard":          {pattern: "*.example.com", host: "api.internal.example.com", want: true},
+		"WildcardApexHost":  {pattern: "*.example.com", host: "example.com"},
+		"WildcardOtherHost": {pattern: "*.example.com", host: "api.example.org"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := matchesHost(tc.pattern, tc.host); got != tc.want {
+				t.Errorf("matchesHost(%q, %q): want %t, got %t", tc.pattern, tc.host, tc.want, got)
+			}
+		})
+	}
+}
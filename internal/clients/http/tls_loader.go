@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
@@ -21,6 +22,18 @@ func LoadTLSConfig(ctx context.Context, kubeClient kube.Client, tlsConfig *commo
 		InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
 	}
 
+	minVersion, err := parseTLSVersion(tlsConfig.TLSMinVersion)
+	if err != nil {
+		return nil, err
+	}
+	data.MinVersion = minVersion
+
+	cipherSuites, err := parseCipherSuites(tlsConfig.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	data.CipherSuites = cipherSuites
+
 	// Load CA bundle from inline or secret
 	if len(tlsConfig.CABundle) > 0 {
 		data.CABundle = tlsConfig.CABundle
@@ -53,6 +66,48 @@ func LoadTLSConfig(ctx context.Context, kubeClient kube.Client, tlsConfig *commo
 	return data, nil
 }
 
+// parseTLSVersion returns the TLS version of the given name, e.g. 1.2, or zero for the default of Go if empty.
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "":
+		return 0, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS minimum version %q, supported versions are 1.0, 1.1, 1.2 and 1.3", version)
+	}
+}
+
+// parseCipherSuites returns the IDs of the cipher suites of the given IANA names, or nil for the defaults of Go if
+// none is given.
+func parseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]uint16)
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		known[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown TLS cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 // loadSecretData loads data from a Kubernetes secret
 func loadSecretData(ctx context.Context, kubeClient kube.Client, secretRef *xpv1.SecretKeySelector) ([]byte, error) {
 	if secretRef == nil {
@@ -99,6 +154,7 @@ func MergeTLSConfigs(resourceTLS *common.TLSConfig, providerTLS *common.TLSConfi
 
 	mergeCABundle(merged, resourceTLS, providerTLS)
 	mergeSecretRefs(merged, resourceTLS, providerTLS)
+	mergeHandshakeSettings(merged, resourceTLS, providerTLS)
 
 	return merged
 }
@@ -132,3 +188,18 @@ func mergeSecretRefs(merged, resourceTLS, providerTLS *common.TLSConfig) {
 		merged.ClientKeySecretRef = providerTLS.ClientKeySecretRef
 	}
 }
+
+// mergeHandshakeSettings merges the minimum TLS version and cipher suites
+func mergeHandshakeSettings(merged, resourceTLS, providerTLS *common.TLSConfig) {
+	if resourceTLS.TLSMinVersion != "" {
+		merged.TLSMinVersion = resourceTLS.TLSMinVersion
+	} else {
+		merged.TLSMinVersion = providerTLS.TLSMinVersion
+	}
+
+	if len(resourceTLS.CipherSuites) > 0 {
+		merged.CipherSuites = resourceTLS.CipherSuites
+	} else {
+		merged.CipherSuites = providerTLS.CipherSuites
+	}
+}
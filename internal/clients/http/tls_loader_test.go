@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
@@ -70,6 +71,46 @@ func TestLoadTLSConfig(t *testing.T) {
 				err: nil,
 			},
 		},
+		"MinVersionAndCipherSuites": {
+			args: args{
+				kubeClient: nil,
+				tlsConfig: &common.TLSConfig{
+					TLSMinVersion: "1.2",
+					CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+				},
+			},
+			want: want{
+				result: &TLSConfigData{
+					MinVersion:   tls.VersionTLS12,
+					CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384},
+				},
+				err: nil,
+			},
+		},
+		"UnknownCipherSuite": {
+			args: args{
+				kubeClient: nil,
+				tlsConfig: &common.TLSConfig{
+					CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_NOTHING"},
+				},
+			},
+			want: want{
+				result: nil,
+				err:    errors.New(`unknown TLS cipher suite "TLS_RSA_WITH_NOTHING"`),
+			},
+		},
+		"UnsupportedMinVersion": {
+			args: args{
+				kubeClient: nil,
+				tlsConfig: &common.TLSConfig{
+					TLSMinVersion: "1.4",
+				},
+			},
+			want: want{
+				result: nil,
+				err:    errors.New(`unsupported TLS minimum version "1.4", supported versions are 1.0, 1.1, 1.2 and 1.3`),
+			},
+		},
 		"CACertFromSecret": {
 			args: args{
 				kubeClient: &test.MockClient{
@@ -598,6 +639,23 @@ func TestMergeTLSConfigs(t *testing.T) {
 				},
 			},
 		},
+		"ResourceHandshakeSettingsOverrideProvider": {
+			args: args{
+				resourceTLS: &common.TLSConfig{
+					TLSMinVersion: "1.3",
+				},
+				providerTLS: &common.TLSConfig{
+					TLSMinVersion: "1.2",
+					CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+			want: want{
+				result: &common.TLSConfig{
+					TLSMinVersion: "1.3",
+					CipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				},
+			},
+		},
 		"ResourceSecretRefsOverrideProviderSecretRefs": {
 			args: args{
 				resourceTLS: &common.TLSConfig{
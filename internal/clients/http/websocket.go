@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	schemeWS  = "ws"
+	schemeWSS = "wss"
+
+	errWebSocketDial      = "failed to connect to the websocket server"
+	errWebSocketHandshake = "failed to upgrade the connection to a websocket"
+	errWebSocketSend      = "failed to send the websocket message"
+	errWebSocketReceive   = "failed to receive the websocket reply"
+)
+
+// isWebSocketURL checks if the URL selects a WebSocket exchange rather than an HTTP request.
+func isWebSocketURL(u *url.URL) bool {
+	return u.Scheme == schemeWS || u.Scheme == schemeWSS
+}
+
+// sendWebSocket upgrades a connection to the URL of the request to a WebSocket, sends the body as a single message
+// and waits for a single reply message, within the timeout of the request.
+// Bodies that are valid UTF-8 are sent as a text frame, others as a binary frame. The reply is returned as the body
+// of a 101 Switching Protocols response.
+func (hc *client) sendWebSocket(ctx context.Context, request *http.Request, body []byte, tlsConfig *tls.Config) (HttpResponse, error) {
+	if timeout := hc.requestTimeout(ctx); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	conn, err := hc.dialWebSocket(ctx, request.URL, tlsConfig)
+	if err != nil {
+		return HttpResponse{}, fmt.Errorf("%s: %w", errWebSocketDial, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return HttpResponse{}, err
+		}
+	}
+
+	config, err := websocket.NewConfig(request.URL.String(), webSocketOrigin(request))
+	if err != nil {
+		return HttpResponse{}, fmt.Errorf("%s: %w", errWebSocketHandshake, err)
+	}
+	config.Header = request.Header.Clone()
+	config.Header.Del("Origin")
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		return HttpResponse{}, fmt.Errorf("%s: %w", errWebSocketHandshake, err)
+	}
+
+	var message interface{} = body
+	if utf8.Valid(body) {
+		message = string(body)
+	}
+	if err := websocket.Message.Send(ws, message); err != nil {
+		return HttpResponse{}, fmt.Errorf("%s: %w", errWebSocketSend, err)
+	}
+
+	var reply string
+	if err := websocket.Message.Receive(ws, &reply); err != nil {
+		return HttpResponse{}, fmt.Errorf("%s: %w", errWebSocketReceive, err)
+	}
+
+	return HttpResponse{
+		Body:       reply,
+		Headers:    map[string][]string{},
+		StatusCode: http.StatusSwitchingProtocols,
+	}, nil
+}
+
+// dialWebSocket opens the connection to the WebSocket server, honoring the host aliases, address family preference
+// and address guard of the client. wss URLs are connected to over TLS.
+func (hc *client) dialWebSocket(ctx context.Context, u *url.URL, tlsConfig *tls.Config) (net.Conn, error) {
+	dial := newDialContext(hc.ipFamily, hc.addressGuard, hc.hostAliases)
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+
+	conn, err := dial(ctx, "tcp", webSocketAddress(u))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != schemeWSS {
+		return conn, nil
+	}
+
+	config := tlsConfig.Clone()
+	if config.ServerName == "" {
+		config.ServerName = u.Hostname()
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// webSocketAddress returns the host and port of the URL, with the default port of its scheme if it sets none.
+func webSocketAddress(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return net.JoinHostPort(u.Hostname(), port)
+	}
+
+	if u.Scheme == schemeWSS {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+// webSocketOrigin returns the Origin header of the request, or the HTTP origin of its URL if it sets none.
+func webSocketOrigin(request *http.Request) string {
+	if origin := request.Header.Get("Origin"); origin != "" {
+		return origin
+	}
+
+	scheme := "http"
+	if request.URL.Scheme == schemeWSS {
+		scheme = "https"
+	}
+
+	return (&url.URL{Scheme: scheme, Host: request.URL.Host}).String()
+}
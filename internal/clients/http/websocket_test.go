@@ -0,0 +1,106 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/websocket"
+)
+
+// webSocketEchoServer replies to every message with the message prefixed with the frame type it was received as,
+// and the Authorization header of the handshake. Without reply, it keeps the connection open without answering.
+func webSocketEchoServer(reply bool) *httptest.Server {
+	return httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var message []byte
+		var payloadType byte
+		receive := websocket.Codec{Unmarshal: func(msg []byte, t byte, _ interface{}) error {
+			message, payloadType = msg, t
+			return nil
+		}}
+		if err := receive.Receive(ws, nil); err != nil {
+			return
+		}
+
+		if !reply {
+			<-ws.Request().Context().Done()
+			return
+		}
+
+		frame := "binary"
+		if payloadType == websocket.TextFrame {
+			frame = "text"
+		}
+		_ = websocket.Message.Send(ws, frame+":"+ws.Request().Header.Get(authKey)+":"+string(message))
+	}))
+}
+
+func TestSendRequestWebSocket(t *testing.T) {
+	cases := map[string]struct {
+		reply    bool
+		timeout  time.Duration
+		body     string
+		headers  map[string][]string
+		wantBody string
+		wantErr  string
+	}{
+		"TextMessageEchoed": {
+			reply:    true,
+			timeout:  5 * time.Second,
+			body:     `{"op":"ping"}`,
+			headers:  map[string][]string{"Authorization": {"Bearer token"}},
+			wantBody: `text:Bearer token:{"op":"ping"}`,
+		},
+		"BinaryMessageEchoed": {
+			reply:    true,
+			timeout:  5 * time.Second,
+			body:     "\xff\xfe",
+			headers:  map[string][]string{},
+			wantBody: "binary::\xff\xfe",
+		},
+		"ReplyTimesOut": {
+			reply:   false,
+			timeout: 200 * time.Millisecond,
+			body:    `{"op":"ping"}`,
+			headers: map[string][]string{},
+			wantErr: errWebSocketReceive,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := webSocketEchoServer(tc.reply)
+			defer server.Close()
+
+			c, _ := NewClient(logging.NewNopLogger(), tc.timeout, "")
+			url := "ws" + strings.TrimPrefix(server.URL, "http")
+			headers := Data{Encrypted: tc.headers, Decrypted: tc.headers}
+			details, err := c.SendRequest(context.Background(), http.MethodPost, url, Data{Encrypted: tc.body, Decrypted: tc.body}, headers, nil)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("SendRequest(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, details.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(http.StatusSwitchingProtocols, details.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(url, details.HttpRequest.URL); diff != "" {
+				t.Errorf("SendRequest(...): -want request URL, +got request URL: %s", diff)
+			}
+		})
+	}
+}
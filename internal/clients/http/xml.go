@@ -0,0 +1,146 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const (
+	xmlAttributePrefix = "@"
+	xmlTextKey         = "#text"
+
+	errNoXMLRootElement = "no XML root element"
+)
+
+// WithResponseFormat makes the client convert the response bodies from the format to JSON, so jq filters work
+// against them. Only xml is converted, json and empty formats keep the bodies unchanged.
+func WithResponseFormat(format string) ClientOption {
+	return func(c *client) {
+		c.responseFormat = format
+	}
+}
+
+// decodeResponseBody converts the response body from the response format of the client to JSON. A body that is not
+// in the response format, e.g. an HTML error page, is kept unchanged.
+func (hc *client) decodeResponseBody(body []byte) string {
+	if hc.responseFormat != common.ResponseFormatXML {
+		return string(body)
+	}
+
+	converted, err := XMLToJSON(body)
+	if err != nil {
+		hc.log.Debug("response body is not XML, keeping it unchanged", "error", err)
+		return string(body)
+	}
+
+	return converted
+}
+
+// xmlElement is an XML element being decoded.
+type xmlElement struct {
+	name   string
+	fields map[string]interface{}
+	text   strings.Builder
+}
+
+// XMLToJSON converts an XML document to a JSON object with the root element as its only key, as XMLToMap does.
+func XMLToJSON(body []byte) (string, error) {
+	root, err := XMLToMap(body)
+	if err != nil {
+		return "", err
+	}
+
+	converted, err := json.Marshal(root)
+	if err != nil {
+		return "", err
+	}
+
+	return string(converted), nil
+}
+
+// XMLToMap converts an XML document to a JSON-compatible map with the root element as its only key. Elements become
+// keys holding the text of the element, or a map if it has attributes or child elements. Attributes become keys
+// prefixed with @, and the text of an element with attributes or child elements the #text key. Repeated elements
+// become arrays. Namespace prefixes are dropped from the names.
+func XMLToMap(body []byte) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+
+	var root map[string]interface{}
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local, fields: map[string]interface{}{}}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				element.fields[xmlAttributePrefix+attr.Name.Local] = attr.Value
+			}
+			stack = append(stack, element)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			element := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = map[string]interface{}{element.name: element.value()}
+				continue
+			}
+			addXMLField(stack[len(stack)-1].fields, element.name, element.value())
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New(errNoXMLRootElement)
+	}
+
+	return root, nil
+}
+
+// value returns the text of the element if it has neither attributes nor child elements, or its fields with its
+// non-blank text under #text.
+func (e *xmlElement) value() interface{} {
+	text := strings.TrimSpace(e.text.String())
+	if len(e.fields) == 0 {
+		return text
+	}
+
+	if text != "" {
+		e.fields[xmlTextKey] = text
+	}
+	return e.fields
+}
+
+// addXMLField adds the value of a child element to the fields of its parent, turning repeated elements into arrays.
+func addXMLField(fields map[string]interface{}, name string, value interface{}) {
+	existing, ok := fields[name]
+	if !ok {
+		fields[name] = value
+		return
+	}
+
+	if values, ok := existing.([]interface{}); ok {
+		fields[name] = append(values, value)
+		return
+	}
+
+	fields[name] = []interface{}{existing, value}
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const orderXML = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+  <soap:Body>
+    <order id="42" status="open">
+      <customer>ACME</customer>
+      <item sku="a">first</item>
+      <item sku="b">second</item>
+      <note/>
+    </order>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestXMLToJSON(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		body    string
+		query   string
+		want    interface{}
+		wantErr bool
+	}{
+		"NestedElement": {
+			reason: "Should expose nested elements as nested keys, without namespace prefixes",
+			body:   orderXML,
+			query:  ".Envelope.Body.order.customer",
+			want:   "ACME",
+		},
+		"Attribute": {
+			reason: "Should expose attributes as keys prefixed with @",
+			body:   orderXML,
+			query:  `.Envelope.Body.order["@id"]`,
+			want:   "42",
+		},
+		"RepeatedElements": {
+			reason: "Should expose repeated elements as an array, with the text of elements with attributes under #text",
+			body:   orderXML,
+			query:  `[.Envelope.Body.order.item[] | {sku: .["@sku"], text: .["#text"]}]`,
+			want: []interface{}{
+				map[string]interface{}{"sku": "a", "text": "first"},
+				map[string]interface{}{"sku": "b", "text": "second"},
+			},
+		},
+		"EmptyElement": {
+			reason: "Should expose an empty element as an empty string",
+			body:   orderXML,
+			query:  ".Envelope.Body.order.note",
+			want:   "",
+		},
+		"NotXML": {
+			reason:  "Should fail to convert a body that is not XML",
+			body:    `{"id": 42}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := XMLToJSON([]byte(tc.body))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("\n%s\nXMLToJSON(...): want error, got %s", tc.reason, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nXMLToJSON(...): unexpected error: %v", tc.reason, err)
+			}
+
+			value, err := jq.Parse(tc.query, json_util.JsonStringToMap(got))
+			if err != nil {
+				t.Fatalf("\n%s\njq.Parse(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, value); diff != "" {
+				t.Errorf("\n%s\njq.Parse(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestXMLToMap(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		body    string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		"AttributesAndRepeatedElements": {
+			reason: "Should convert attributes, repeated elements and the text of elements with attributes",
+			body:   `<batch id="42"><item code="200">created</item><item code="409">conflict</item><note>done</note></batch>`,
+			want: map[string]interface{}{
+				"batch": map[string]interface{}{
+					"@id": "42",
+					"item": []interface{}{
+						map[string]interface{}{"@code": "200", "#text": "created"},
+						map[string]interface{}{"@code": "409", "#text": "conflict"},
+					},
+					"note": "done",
+				},
+			},
+		},
+		"Malformed": {
+			reason:  "Should fail to convert a malformed document",
+			body:    `<batch><item></batch>`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := XMLToMap([]byte(tc.body))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nXMLToMap(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nXMLToMap(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSendRequestResponseFormat(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		format string
+		body   string
+		want   string
+	}{
+		"XML": {
+			reason: "Should convert an XML response body to JSON",
+			format: common.ResponseFormatXML,
+			body:   `<user id="7"><name>Ada</name></user>`,
+			want:   `{"user":{"@id":"7","name":"Ada"}}`,
+		},
+		"NotXML": {
+			reason: "Should keep a response body that is not XML unchanged",
+			format: common.ResponseFormatXML,
+			body:   "Internal Server Error",
+			want:   "Internal Server Error",
+		},
+		"JSON": {
+			reason: "Should keep the response body unchanged by default",
+			body:   `<user id="7"><name>Ada</name></user>`,
+			want:   `<user id="7"><name>Ada</name></user>`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			c, err := NewClient(logging.NewNopLogger(), time.Minute, "", WithResponseFormat(tc.format))
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+
+			details, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, Data{Encrypted: "", Decrypted: ""}, Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, &TLSConfigData{})
+			if err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, details.HttpResponse.Body); diff != "" {
+				t.Errorf("\n%s\nSendRequest(...): -want body, +got body:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
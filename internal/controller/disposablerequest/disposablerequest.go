@@ -27,9 +27,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -38,6 +38,7 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/metrics"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/service/disposablerequest"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
@@ -46,10 +47,9 @@ import (
 const (
 	errNotDisposableRequest                = "managed resource is not a DisposableRequest custom resource"
 	errTrackPCUsage                        = "cannot track ProviderConfig usage"
-	errNewHttpClient                       = "cannot create new Http client"
 	errProviderNotRetrieved                = "provider could not be retrieved"
 	errFailedToSendHttpDisposableRequest   = "failed to send http request"
-	errExtractCredentials                  = "cannot extract credentials"
+	errLoadHMACSigningKey                  = "cannot load HMAC signing key"
 	errResponseDoesntMatchExpectedCriteria = "response does not match expected criteria"
 )
 
@@ -94,7 +94,7 @@ type connector struct {
 	logger          logging.Logger
 	kube            client.Client
 	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration, creds string) (httpClient.Client, error)
+	newHttpClientFn httpClient.NewClientFn
 }
 
 // Connect returns a new ExternalClient.
@@ -116,20 +116,24 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
 
-	creds := ""
-	if pc.Spec.Credentials.Source == xpv1.CredentialsSourceSecret {
-		data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
-		if err != nil {
-			return nil, errors.Wrap(err, errExtractCredentials)
-		}
-
-		creds = string(data)
+	hmacSigner, err := httpClient.LoadHMACSigner(ctx, c.kube, cr.Spec.ForProvider.HMACSigning)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadHMACSigningKey)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), creds)
+	timeout := disposablerequest.AttemptTimeout(cr, &cr.Spec.ForProvider, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), time.Now())
+	h, err := httpClient.NewProviderConfigClient(ctx, c.kube, l, pc, httpClient.ResourceClientConfig{
+		Timeout:               timeout,
+		ResponseFormat:        cr.Spec.ForProvider.ResponseFormat,
+		UserAgent:             cr.Spec.ForProvider.UserAgent,
+		Protocol:              cr.Spec.ForProvider.Protocol,
+		ExpectContinueTimeout: cr.Spec.ForProvider.GetExpectContinueTimeout(),
+		Signers:               []httpClient.RequestSigner{hmacSigner},
+	}, c.newHttpClientFn)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewHttpClient)
+		return nil, err
 	}
+	h = metrics.InstrumentClient(h, v1alpha2.DisposableRequestKind, cr.GetLabels())
 
 	// Merge TLS configs: resource-level overrides provider-level
 	mergedTLSConfig := httpClient.MergeTLSConfigs(cr.Spec.ForProvider.TLSConfig, pc.Spec.TLS)
@@ -148,19 +152,26 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, "failed to load TLS configuration")
 	}
 
+	tlsConfigData.HostPolicies, err = httpClient.LoadTLSHostPolicies(ctx, c.kube, pc.Spec.TLSHostPolicies)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load TLS host policies")
+	}
+
 	return &external{
-		localKube:     c.kube,
-		logger:        l,
-		http:          h,
-		tlsConfigData: tlsConfigData,
+		localKube:      c.kube,
+		logger:         l,
+		http:           h,
+		tlsConfigData:  tlsConfigData,
+		defaultHeaders: pc.Spec.DefaultHeaders,
 	}, nil
 }
 
 type external struct {
-	localKube     client.Client
-	logger        logging.Logger
-	http          httpClient.Client
-	tlsConfigData *httpClient.TLSConfigData
+	localKube      client.Client
+	logger         logging.Logger
+	http           httpClient.Client
+	tlsConfigData  *httpClient.TLSConfigData
+	defaultHeaders map[string][]string
 }
 
 // Observe checks the state of the DisposableRequest resource and updates its status accordingly.
@@ -170,17 +181,45 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotDisposableRequest)
 	}
 
+	crCtx := service.NewDisposableRequestCRContext(cr)
+	if !meta.WasDeleted(cr) && disposablerequest.IsRetriesExhausted(crCtx) {
+		return c.observeRetriesExhausted(ctx, crCtx)
+	}
+
 	isUpToDate := !(utils.ShouldRetry(cr.Spec.ForProvider.RollbackRetriesLimit, cr.Status.Failed) && !utils.RetriesLimitReached(cr.Status.Failed, cr.Spec.ForProvider.RollbackRetriesLimit))
 	isAvailable := isUpToDate
 
+	// The request is not sent again before the delay requested by the Retry-After header of the last response.
+	if _, ok := retryAfterWait(cr, time.Now()); ok {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	// A failing request backing off is not sent again before its next attempt is due.
+	if _, ok := failureBackoffWait(cr, time.Now()); ok {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	// A synced request triggered since it was last sent, or with a next page to fetch, is sent again.
+	if cr.Status.Synced && (disposablerequest.RerunRequested(cr, cr.Status.LastReconcileTime.Time) || disposablerequest.HasContinuation(cr)) {
+		if err := disposablerequest.Rerun(ctx, crCtx, c.localKube); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
 	if !cr.Status.Synced {
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
-	crCtx := service.NewDisposableRequestCRContext(cr)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithDefaultHeaders(c.defaultHeaders)
 	isExpected, storedResponse, err := disposablerequest.ValidateStoredResponse(svcCtx, crCtx)
 	if err != nil {
 		return managed.ExternalObservation{}, err
@@ -208,6 +247,23 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// observeRetriesExhausted reports a request whose retries are exhausted as up to date with a Failed condition, so it
+// is not sent nor requeued anymore, unless it is rearmed by a spec change or the force-retry-after annotation.
+func (c *external) observeRetriesExhausted(ctx context.Context, crCtx *service.DisposableRequestCRContext) (managed.ExternalObservation, error) {
+	if disposablerequest.ShouldRearm(crCtx.GetCR(), time.Now()) {
+		if err := disposablerequest.Rearm(ctx, crCtx, c.localKube); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	disposablerequest.MarkRetriesExhausted(crCtx)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha2.DisposableRequest)
 	if !ok {
@@ -218,7 +274,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, err
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithDefaultHeaders(c.defaultHeaders)
 	crCtx := service.NewDisposableRequestCRContext(cr)
 	return managed.ExternalCreation{}, errors.Wrap(disposablerequest.DeployAction(svcCtx, crCtx), errFailedToSendHttpDisposableRequest)
 }
@@ -233,7 +289,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithDefaultHeaders(c.defaultHeaders)
 	crCtx := service.NewDisposableRequestCRContext(cr)
 	return managed.ExternalUpdate{}, errors.Wrap(disposablerequest.DeployAction(svcCtx, crCtx), errFailedToSendHttpDisposableRequest)
 }
@@ -248,32 +304,48 @@ func (c *external) Disconnect(_ context.Context) error {
 }
 
 // WithCustomPollIntervalHook returns a managed.ReconcilerOption that sets a custom poll interval based on the DisposableRequest spec.
+// The poll jitter is added to it.
 func WithCustomPollIntervalHook() managed.ReconcilerOption {
-	return managed.WithPollIntervalHook(func(mg resource.Managed, pollInterval time.Duration) time.Duration {
-		defaultPollInterval := 30 * time.Second
-
-		cr, ok := mg.(*v1alpha2.DisposableRequest)
-		if !ok {
-			return defaultPollInterval
-		}
+	return managed.WithPollIntervalHook(utils.WithPollJitter(customPollInterval))
+}
 
-		if cr.Spec.ForProvider.NextReconcile == nil {
-			return defaultPollInterval
-		}
+// customPollInterval returns the poll interval of the DisposableRequest according to its spec and status.
+func customPollInterval(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	defaultPollInterval := 30 * time.Second
 
-		// Calculate next reconcile time based on NextReconcile duration
-		nextReconcileDuration := cr.Spec.ForProvider.NextReconcile.Duration
-		lastReconcileTime := cr.Status.LastReconcileTime.Time
-		nextReconcileTime := lastReconcileTime.Add(nextReconcileDuration)
+	cr, ok := mg.(*v1alpha2.DisposableRequest)
+	if !ok {
+		return defaultPollInterval
+	}
 
-		// Determine if the current time is past the next reconcile time
-		now := time.Now()
-		if now.Before(nextReconcileTime) {
-			// If not yet time to reconcile, calculate remaining time
-			return nextReconcileTime.Sub(now)
+	// Requests whose retries are exhausted are only reconciled again on a spec change, or for a forced retry.
+	if disposablerequest.IsRetriesExhausted(service.NewDisposableRequestCRContext(cr)) {
+		if wait, ok := disposablerequest.NextForcedRetry(cr, time.Now()); ok {
+			return wait
 		}
+		return 0
+	}
 
-		// Default poll interval if the next reconcile time is in the past
+	if wait, ok := retryAfterWait(cr, time.Now()); ok {
+		return wait
+	}
+
+	interval, ok := loopInterval(cr)
+	if !ok {
 		return defaultPollInterval
-	})
+	}
+
+	// Calculate next reconcile time based on the NextReconcile duration, backed off for failures and jittered
+	lastReconcileTime := cr.Status.LastReconcileTime.Time
+	nextReconcileTime := lastReconcileTime.Add(interval)
+
+	// Determine if the current time is past the next reconcile time
+	now := time.Now()
+	if now.Before(nextReconcileTime) {
+		// If not yet time to reconcile, calculate remaining time
+		return nextReconcileTime.Sub(now)
+	}
+
+	// Default poll interval if the next reconcile time is in the past
+	return defaultPollInterval
 }
@@ -342,6 +342,34 @@ func Test_httpExternal_Observe(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "RetriesExhaustedNotSentAgain",
+			args: args{
+				http: &MockHttpClient{},
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				mg: &v1alpha2.DisposableRequest{
+					Spec: v1alpha2.DisposableRequestSpec{
+						ForProvider: v1alpha2.DisposableRequestParameters{
+							URL:                  testURL,
+							Method:               testMethod,
+							RollbackRetriesLimit: func() *int32 { l := int32(2); return &l }(),
+						},
+					},
+					Status: v1alpha2.DisposableRequestStatus{
+						Failed: 2,
+					},
+				},
+			},
+			want: want{
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
 		{
 			name: "ResourceSyncedAndUpToDate",
 			args: args{
@@ -504,7 +532,7 @@ func Test_deployAction(t *testing.T) {
 				},
 			},
 			want: want{
-				err:           errors.Errorf(utils.ErrStatusCode, testMethod, strconv.Itoa(400)),
+				err:           errors.Errorf(utils.ErrStatusCode+`, response: {"key1":"value1"}`, testMethod, strconv.Itoa(400)),
 				failuresIndex: 1,
 				statusCode:    400,
 			},
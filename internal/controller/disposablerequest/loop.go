@@ -0,0 +1,75 @@
+package disposablerequest
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+)
+
+// loopInterval returns the interval between the last request and the next one: NextReconcile, backed off for the
+// consecutive failures, plus the jitter. It returns false if the spec sets no NextReconcile.
+func loopInterval(cr *v1alpha2.DisposableRequest) (time.Duration, bool) {
+	params := cr.Spec.ForProvider
+	if params.NextReconcile == nil {
+		return 0, false
+	}
+
+	interval := params.NextReconcile.Duration
+	if params.MaxFailureBackoff != nil {
+		interval = failureBackoff(cr.Status.Failed, interval, params.MaxFailureBackoff.Duration)
+	}
+
+	return interval + loopJitter(cr), true
+}
+
+// failureBackoffWait returns the time left before a failing request backing off is sent again, if it is not over
+// yet.
+func failureBackoffWait(cr *v1alpha2.DisposableRequest, now time.Time) (time.Duration, bool) {
+	if cr.Spec.ForProvider.MaxFailureBackoff == nil || cr.Status.Failed == 0 || cr.Status.LastReconcileTime.IsZero() {
+		return 0, false
+	}
+
+	interval, ok := loopInterval(cr)
+	if !ok {
+		return 0, false
+	}
+
+	wait := cr.Status.LastReconcileTime.Add(interval).Sub(now)
+	return wait, wait > 0
+}
+
+// failureBackoff returns base without failures, otherwise base doubled for each consecutive failure after the first
+// one, capped at max.
+func failureBackoff(failures int32, base, max time.Duration) time.Duration {
+	if failures <= 1 || max <= 0 || base <= 0 {
+		return base
+	}
+
+	interval := base
+	for i := int32(1); i < failures && interval < max; i++ {
+		interval *= 2
+	}
+
+	if interval > max {
+		return max
+	}
+	return interval
+}
+
+// loopJitter returns the random delay added to the interval of the request, up to its NextReconcileJitter. It is
+// derived from the resource and its last request, so it does not change between the reconciles waiting for the next
+// request.
+func loopJitter(cr *v1alpha2.DisposableRequest) time.Duration {
+	jitter := cr.Spec.ForProvider.NextReconcileJitter
+	if jitter == nil || jitter.Duration <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(string(cr.GetUID())))
+	_, _ = h.Write([]byte(strconv.FormatInt(cr.Status.LastReconcileTime.UnixNano(), 10)))
+
+	return time.Duration(h.Sum64() % uint64(jitter.Duration))
+}
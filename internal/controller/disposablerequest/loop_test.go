@@ -0,0 +1,123 @@
+package disposablerequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+)
+
+// withLoop sets the cadence of the loop of the request, and its consecutive failures.
+func withLoop(nextReconcile, maxFailureBackoff time.Duration, failed int32) httpDisposableRequestModifier {
+	return func(cr *v1alpha2.DisposableRequest) {
+		cr.Spec.ForProvider.ShouldLoopInfinitely = true
+		cr.Spec.ForProvider.NextReconcile = &metav1.Duration{Duration: nextReconcile}
+		if maxFailureBackoff != 0 {
+			cr.Spec.ForProvider.MaxFailureBackoff = &metav1.Duration{Duration: maxFailureBackoff}
+		}
+		cr.Status.Failed = failed
+		cr.Status.LastReconcileTime = metav1.Now()
+	}
+}
+
+func TestLoopInterval(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha2.DisposableRequest
+		want   time.Duration
+	}{
+		"Succeeding": {
+			reason: "Should loop at NextReconcile without failures",
+			cr:     httpDisposableRequest(withLoop(time.Minute, time.Hour, 0)),
+			want:   time.Minute,
+		},
+		"FirstFailure": {
+			reason: "Should retry a first failure after NextReconcile",
+			cr:     httpDisposableRequest(withLoop(time.Minute, time.Hour, 1)),
+			want:   time.Minute,
+		},
+		"ConsecutiveFailures": {
+			reason: "Should double NextReconcile for each consecutive failure after the first one",
+			cr:     httpDisposableRequest(withLoop(time.Minute, time.Hour, 4)),
+			want:   8 * time.Minute,
+		},
+		"Capped": {
+			reason: "Should cap the backoff at maxFailureBackoff",
+			cr:     httpDisposableRequest(withLoop(time.Minute, 10*time.Minute, 20)),
+			want:   10 * time.Minute,
+		},
+		"NoBackoff": {
+			reason: "Should keep NextReconcile for failures without maxFailureBackoff",
+			cr:     httpDisposableRequest(withLoop(time.Minute, 0, 4)),
+			want:   time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := loopInterval(tc.cr)
+			if !ok || got != tc.want {
+				t.Errorf("\n%s\nloopInterval(...): want %s, got %s (%t)", tc.reason, tc.want, got, ok)
+			}
+		})
+	}
+}
+
+func TestLoopIntervalJitter(t *testing.T) {
+	jittered := 0
+	for i := range 20 {
+		cr := httpDisposableRequest(withLoop(time.Minute, 0, 0), func(cr *v1alpha2.DisposableRequest) {
+			cr.UID = types.UID("uid-" + string(rune('a'+i)))
+			cr.Spec.ForProvider.NextReconcileJitter = &metav1.Duration{Duration: 30 * time.Second}
+		})
+
+		got, _ := loopInterval(cr)
+		if got < time.Minute || got >= time.Minute+30*time.Second {
+			t.Fatalf("loopInterval(...): want an interval within the jitter of NextReconcile, got %s", got)
+		}
+		if again, _ := loopInterval(cr); again != got {
+			t.Errorf("loopInterval(...): want the same jitter until the next request, got %s then %s", got, again)
+		}
+		if got != time.Minute {
+			jittered++
+		}
+	}
+
+	if jittered == 0 {
+		t.Errorf("loopInterval(...): want the intervals of different resources jittered")
+	}
+}
+
+func TestFailureBackoff(t *testing.T) {
+	cr := httpDisposableRequest(withLoop(time.Minute, time.Hour, 3))
+
+	// A failing request backing off is not sent, and is polled again when its next attempt is due.
+	got, err := (&external{logger: logging.NewNopLogger()}).Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if !got.ResourceExists || !got.ResourceUpToDate {
+		t.Errorf("Observe(...): want a request backing off not sent, got %+v", got)
+	}
+	if wait := customPollInterval(cr, time.Minute); absDuration(wait-4*time.Minute) > 2*time.Second {
+		t.Errorf("customPollInterval(...): want the backoff of 3 failures, got %s", wait)
+	}
+
+	// Once the backoff is over, the request is sent again.
+	cr.Status.LastReconcileTime = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	if _, ok := failureBackoffWait(cr, time.Now()); ok {
+		t.Errorf("failureBackoffWait(...): want the backoff over after 5 minutes")
+	}
+
+	// A success resets the failures, and the cadence returns to NextReconcile.
+	cr.SetSynced(true)
+	cr.Status.LastReconcileTime = metav1.Now()
+	if wait := customPollInterval(cr, time.Minute); absDuration(wait-time.Minute) > 2*time.Second {
+		t.Errorf("customPollInterval(...): want NextReconcile after a success, got %s", wait)
+	}
+}
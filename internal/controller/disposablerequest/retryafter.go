@@ -0,0 +1,14 @@
+package disposablerequest
+
+import (
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+)
+
+// retryAfterWait returns the time left to wait before the request is sent again, as requested by the Retry-After
+// header of the last response, if it is not over yet.
+func retryAfterWait(cr *v1alpha2.DisposableRequest, now time.Time) (time.Duration, bool) {
+	wait := cr.GetRetryAfterWait(now)
+	return wait, wait > 0
+}
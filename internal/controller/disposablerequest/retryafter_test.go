@@ -0,0 +1,95 @@
+package disposablerequest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/clients/http/fake"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/disposablerequest"
+)
+
+func TestRetryAfterRequeue(t *testing.T) {
+	cases := map[string]struct {
+		reason        string
+		retryAfter    string
+		maxRetryAfter *metav1.Duration
+		want          time.Duration
+	}{
+		"DeltaSeconds": {
+			reason:     "Should requeue after the delta-seconds of the Retry-After header",
+			retryAfter: "120",
+			want:       2 * time.Minute,
+		},
+		"HTTPDate": {
+			reason:     "Should requeue at the HTTP date of the Retry-After header",
+			retryAfter: time.Now().Add(5 * time.Minute).UTC().Format(http.TimeFormat),
+			want:       5 * time.Minute,
+		},
+		"Capped": {
+			reason:        "Should cap the requeue at maxRetryAfter",
+			retryAfter:    "3600",
+			maxRetryAfter: &metav1.Duration{Duration: time.Minute},
+			want:          time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpDisposableRequest(func(cr *v1alpha2.DisposableRequest) {
+				cr.Spec.ForProvider.MaxRetryAfter = tc.maxRetryAfter
+			})
+			mockHTTP := fake.NewClient(fake.Respond(429, "").WithHeaders(map[string][]string{"Retry-After": {tc.retryAfter}}))
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), mockHTTP, nil)
+
+			if err := disposablerequest.DeployAction(svcCtx, service.NewDisposableRequestCRContext(cr)); err == nil {
+				t.Fatalf("\n%s\nDeployAction(...): want an error for the 429 response", tc.reason)
+			}
+			if cr.Status.RetryAfter == nil || absDuration(cr.Status.RetryAfter.Duration-tc.want) > 2*time.Second {
+				t.Fatalf("\n%s\nDeployAction(...): want status.retryAfter %v, got %v", tc.reason, tc.want, cr.Status.RetryAfter)
+			}
+
+			if got := customPollInterval(cr, time.Minute); absDuration(got-tc.want) > 2*time.Second {
+				t.Errorf("\n%s\ncustomPollInterval(...): want %v, got %v", tc.reason, tc.want, got)
+			}
+
+			e := external{localKube: localKube, logger: logging.NewNopLogger(), http: mockHTTP}
+			obs, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): unexpected error: %v", tc.reason, err)
+			}
+			if !obs.ResourceExists || !obs.ResourceUpToDate {
+				t.Errorf("\n%s\nObserve(...): want the request not to be sent again before the Retry-After delay, got %+v", tc.reason, obs)
+			}
+		})
+	}
+}
+
+func TestRetryAfterElapsed(t *testing.T) {
+	cr := httpDisposableRequest(func(cr *v1alpha2.DisposableRequest) {
+		cr.Status.RetryAfter = &metav1.Duration{Duration: time.Minute}
+		cr.Status.LastReconcileTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	})
+
+	if wait, ok := retryAfterWait(cr, time.Now()); ok {
+		t.Errorf("retryAfterWait(...): want no wait once the delay elapsed, got %v", wait)
+	}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,65 @@
+package request
+
+import (
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+var (
+	observeBackoffMu   sync.RWMutex
+	observeBackoffBase = time.Minute
+	observeBackoffMax  = 30 * time.Minute
+)
+
+// SetObserveBackoff sets the base and the cap of the backoff applied to the poll interval of Requests failing
+// consecutively. A cap of zero disables the backoff, failing Requests are then polled at the poll interval.
+func SetObserveBackoff(base, max time.Duration) {
+	observeBackoffMu.Lock()
+	defer observeBackoffMu.Unlock()
+
+	observeBackoffBase, observeBackoffMax = base, max
+}
+
+// withObserveBackoff returns a managed.ReconcilerOption polling a Request failing consecutively with an
+// exponential backoff, derived from its status.failed counter, instead of the poll interval. The poll interval is
+// used again once a request succeeds and resets the counter. The poll jitter is added to either.
+func withObserveBackoff() managed.ReconcilerOption {
+	return managed.WithPollIntervalHook(utils.WithPollJitter(observeBackoffHook))
+}
+
+// observeBackoffHook returns the poll interval of the Request according to its consecutive failures.
+func observeBackoffHook(mg resource.Managed, pollInterval time.Duration) time.Duration {
+	cr, ok := mg.(*v1alpha2.Request)
+	if !ok {
+		return pollInterval
+	}
+
+	observeBackoffMu.RLock()
+	defer observeBackoffMu.RUnlock()
+
+	return backoffInterval(cr.Status.Failed, pollInterval, observeBackoffBase, observeBackoffMax)
+}
+
+// backoffInterval returns the poll interval without failures, otherwise base doubled for each consecutive failure
+// after the first one, capped at max.
+func backoffInterval(failures int32, pollInterval, base, max time.Duration) time.Duration {
+	if failures <= 0 || max <= 0 || base <= 0 {
+		return pollInterval
+	}
+
+	interval := base
+	for i := int32(1); i < failures && interval < max; i++ {
+		interval *= 2
+	}
+
+	if interval > max {
+		return max
+	}
+	return interval
+}
@@ -0,0 +1,53 @@
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func TestBackoffInterval(t *testing.T) {
+	cases := map[string]struct {
+		failures int32
+		max      time.Duration
+		want     time.Duration
+	}{
+		"NoFailureUsesPollInterval": {failures: 0, max: 30 * time.Minute, want: 5 * time.Minute},
+		"FirstFailureUsesBase":      {failures: 1, max: 30 * time.Minute, want: time.Minute},
+		"SecondFailureDoubles":      {failures: 2, max: 30 * time.Minute, want: 2 * time.Minute},
+		"FifthFailure":              {failures: 5, max: 30 * time.Minute, want: 16 * time.Minute},
+		"CappedAtMax":               {failures: 6, max: 30 * time.Minute, want: 30 * time.Minute},
+		"ManyFailuresCappedAtMax":   {failures: 1000, max: 30 * time.Minute, want: 30 * time.Minute},
+		"DisabledUsesPollInterval":  {failures: 3, max: 0, want: 5 * time.Minute},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := backoffInterval(tc.failures, 5*time.Minute, time.Minute, tc.max); got != tc.want {
+				t.Errorf("backoffInterval(%d, ...): want %s, got %s", tc.failures, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestObserveBackoffGrowsWithFailures(t *testing.T) {
+	SetObserveBackoff(10*time.Second, time.Hour)
+	defer SetObserveBackoff(time.Minute, 30*time.Minute)
+
+	cr := httpRequest()
+	previous := time.Duration(0)
+	for failures := int32(1); failures <= 8; failures++ {
+		cr.Status.Failed = failures
+		got := observeBackoffHook(cr, time.Minute)
+		if got <= previous {
+			t.Fatalf("poll interval after %d failures: want more than %s, got %s", failures, previous, got)
+		}
+		previous = got
+	}
+
+	cr.Status = v1alpha2.RequestStatus{}
+	if got := observeBackoffHook(cr, time.Minute); got != time.Minute {
+		t.Errorf("poll interval after a success: want %s, got %s", time.Minute, got)
+	}
+}
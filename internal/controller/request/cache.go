@@ -0,0 +1,49 @@
+package request
+
+import (
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// isCacheFresh checks if the OBSERVE request of the Request can be skipped, because its last one found the ready
+// resource up to date for the current spec less than cacheTTL ago, and no refresh was requested since. A deleted
+// Request is always observed, to find out when the resource is removed.
+func isCacheFresh(cr *v1alpha2.Request, now time.Time) bool {
+	ttl := cr.Spec.ForProvider.GetCacheTTL()
+	if ttl <= 0 || meta.WasDeleted(cr) {
+		return false
+	}
+
+	if cr.Status.Cache.ObservedGeneration == 0 || cr.Status.Cache.ObservedGeneration != cr.GetGeneration() {
+		return false
+	}
+
+	if cr.Status.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return false
+	}
+
+	lastUpdated, err := time.Parse(time.RFC3339, cr.Status.Cache.LastUpdated)
+	if err != nil || now.Sub(lastUpdated) >= ttl {
+		return false
+	}
+
+	return !refreshRequested(cr, lastUpdated)
+}
+
+// refreshRequested checks if the refresh-requested-at annotation holds a time later than the given time the cached
+// response was received at.
+func refreshRequested(cr *v1alpha2.Request, since time.Time) bool {
+	value, ok := cr.GetAnnotations()[common.AnnotationKeyRefreshRequestedAt]
+	if !ok {
+		return false
+	}
+
+	requestedAt, err := time.Parse(time.RFC3339, value)
+	return err == nil && requestedAt.After(since)
+}
@@ -0,0 +1,139 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func Test_httpExternal_ObserveCacheTTL(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		// statusCode of the response to the first OBSERVE request.
+		statusCode int
+		cacheTTL   time.Duration
+		// between modifies the Request between the two observations.
+		between  func(r *v1alpha2.Request)
+		wantSent int
+	}{
+		"FreshCache": {
+			reason:     "Should not send the OBSERVE request again while the cached response is fresh",
+			statusCode: http.StatusOK,
+			cacheTTL:   time.Hour,
+			wantSent:   1,
+		},
+		"NoCacheTTL": {
+			reason:     "Should send every OBSERVE request without a cache TTL",
+			statusCode: http.StatusOK,
+			wantSent:   2,
+		},
+		"Expired": {
+			reason:     "Should send the OBSERVE request again once the cached response expired",
+			statusCode: http.StatusOK,
+			cacheTTL:   time.Hour,
+			between: func(r *v1alpha2.Request) {
+				r.Status.Cache.LastUpdated = time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+			},
+			wantSent: 2,
+		},
+		"SpecChanged": {
+			reason:     "Should send the OBSERVE request again once the spec changed",
+			statusCode: http.StatusOK,
+			cacheTTL:   time.Hour,
+			between: func(r *v1alpha2.Request) {
+				r.Generation++
+			},
+			wantSent: 2,
+		},
+		"RefreshRequested": {
+			reason:     "Should send the OBSERVE request again when a refresh was requested after the cached response",
+			statusCode: http.StatusOK,
+			cacheTTL:   time.Hour,
+			between: func(r *v1alpha2.Request) {
+				r.SetAnnotations(map[string]string{
+					common.AnnotationKeyRefreshRequestedAt: time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+				})
+			},
+			wantSent: 2,
+		},
+		"RefreshRequestedBefore": {
+			reason:     "Should not send the OBSERVE request again for a refresh requested before the cached response",
+			statusCode: http.StatusOK,
+			cacheTTL:   time.Hour,
+			between: func(r *v1alpha2.Request) {
+				r.SetAnnotations(map[string]string{
+					common.AnnotationKeyRefreshRequestedAt: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+				})
+			},
+			wantSent: 1,
+		},
+		"NotUpToDate": {
+			reason:     "Should send the OBSERVE request again when the last one did not find the resource up to date",
+			statusCode: http.StatusInternalServerError,
+			cacheTTL:   time.Hour,
+			wantSent:   2,
+		},
+		"Deleted": {
+			reason:     "Should send the OBSERVE request of a deleted Request",
+			statusCode: http.StatusOK,
+			cacheTTL:   time.Hour,
+			between: func(r *v1alpha2.Request) {
+				now := v1.Now()
+				r.SetDeletionTimestamp(&now)
+			},
+			wantSent: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := 0
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+						sent++
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.statusCode, Body: `{"id": "123"}`}}, nil
+					},
+				},
+			}
+
+			mg := httpRequest(func(r *v1alpha2.Request) {
+				r.Generation = 1
+				r.Spec.ForProvider.Payload.Body = ""
+				r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testGetMapping}
+				r.Spec.ForProvider.CacheTTL = &v1.Duration{Duration: tc.cacheTTL}
+			})
+			if _, err := e.Observe(context.Background(), mg); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if tc.between != nil {
+				tc.between(mg)
+			}
+
+			got, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if sent != tc.wantSent {
+				t.Errorf("\n%s\ne.Observe(...): want %d OBSERVE requests sent, got %d", tc.reason, tc.wantSent, sent)
+			}
+			if tc.wantSent == 1 && (!got.ResourceExists || !got.ResourceUpToDate) {
+				t.Errorf("\n%s\ne.Observe(...): want the resource up to date from the cache, got %+v", tc.reason, got)
+			}
+		})
+	}
+}
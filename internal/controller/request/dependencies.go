@@ -0,0 +1,92 @@
+package request
+
+import (
+	"context"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errGetDependency       = "cannot get dependency %s %s"
+	errReadDependency      = "cannot read the conditions of dependency %s %s"
+	errNoProber            = "the HTTP client cannot send readiness probes"
+	msgDependencyNotFound  = "%s %s not found"
+	msgDependencyNotReady  = "%s %s is not ready"
+	msgProbeFailed         = "readiness probe %s failed: %s"
+	msgProbeNotReady       = "readiness probe %s responded with status code %d"
+	msgWaitingDependencies = "Waiting for dependencies: %s"
+)
+
+// waitsForDependencies checks if the dependencies of the Request must still be checked before it is created. They
+// are only checked until a first response was received, and never once the Request was deleted.
+func waitsForDependencies(cr *v1alpha2.Request) bool {
+	return cr.Spec.ForProvider.DependsOn != nil && cr.Status.Response.StatusCode == 0 && !meta.WasDeleted(cr)
+}
+
+// unreadyDependency returns the reason why a dependency of the Request is not ready yet, or an empty string once
+// they all are. A missing resource or a failed readiness probe is only a reason to wait, while a resource that
+// cannot be read, e.g. because the provider is not allowed to, fails.
+func (c *external) unreadyDependency(ctx context.Context, dependsOn *v1alpha2.DependsOn) (string, error) {
+	for _, ref := range dependsOn.Resources {
+		reason, err := unreadyResource(ctx, c.localKube, ref)
+		if err != nil || reason != "" {
+			return reason, err
+		}
+	}
+
+	if dependsOn.ReadinessProbeURL == "" {
+		return "", nil
+	}
+
+	if c.prober == nil {
+		return "", errors.New(errNoProber)
+	}
+
+	// The probe URL may point anywhere, so it is not sent the credentials of the provider config.
+	statusCode, err := c.prober.Probe(ctx, dependsOn.ReadinessProbeURL, c.tlsConfigData)
+	if err != nil {
+		return fmt.Sprintf(msgProbeFailed, dependsOn.ReadinessProbeURL, err.Error()), nil
+	}
+	if !utils.IsHTTPSuccess(statusCode) {
+		return fmt.Sprintf(msgProbeNotReady, dependsOn.ReadinessProbeURL, statusCode), nil
+	}
+
+	return "", nil
+}
+
+// unreadyResource returns the reason why the referenced resource is not ready yet, or an empty string if its Ready
+// condition is true.
+func unreadyResource(ctx context.Context, kube client.Client, ref v1alpha2.DependencyReference) (string, error) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(ref.APIVersion)
+	u.SetKind(ref.Kind)
+
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, u); err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Sprintf(msgDependencyNotFound, ref.Kind, ref.Name), nil
+		}
+		return "", errors.Wrapf(err, errGetDependency, ref.Kind, ref.Name)
+	}
+
+	status := xpv1.ConditionedStatus{}
+	if err := fieldpath.Pave(u.Object).GetValueInto("status", &status); err != nil && !fieldpath.IsNotFound(err) {
+		return "", errors.Wrapf(err, errReadDependency, ref.Kind, ref.Name)
+	}
+	if status.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return fmt.Sprintf(msgDependencyNotReady, ref.Kind, ref.Name), nil
+	}
+
+	return "", nil
+}
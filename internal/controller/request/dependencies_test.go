@@ -0,0 +1,162 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const testProbeURL = "https://api.example.com/healthz"
+
+// mockProber sends readiness probes with a function.
+type mockProber func(ctx context.Context, url string, tlsConfigData *httpClient.TLSConfigData) (int, error)
+
+func (p mockProber) Probe(ctx context.Context, url string, tlsConfigData *httpClient.TLSConfigData) (int, error) {
+	return p(ctx, url, tlsConfigData)
+}
+
+// withDependency makes the Request depend on a Request named database and on the readiness probe, before any
+// response was received.
+func withDependency(r *v1alpha2.Request) {
+	r.Spec.ForProvider.DependsOn = &v1alpha2.DependsOn{
+		Resources: []v1alpha2.DependencyReference{{
+			APIVersion: v1alpha2.SchemeGroupVersion.String(),
+			Kind:       v1alpha2.RequestKind,
+			Name:       "database",
+		}},
+		ReadinessProbeURL: testProbeURL,
+	}
+	r.Status.Response = v1alpha2.Response{}
+}
+
+// dependencyGetFn returns the database dependency with the given Ready condition status, or a NotFound error if
+// it is empty.
+func dependencyGetFn(ready string) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return nil
+		}
+		if ready == "" {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "requests"}, key.Name)
+		}
+		u.Object["status"] = map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": ready}},
+		}
+		return nil
+	}
+}
+
+func Test_httpExternal_ObserveDependsOn(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		mod         httpRequestModifier
+		ready       string
+		probeStatus int
+		want        managed.ExternalObservation
+		wantWaiting bool
+	}{
+		"ResourceNotFound": {
+			reason:      "Should wait while the dependency does not exist",
+			mod:         withDependency,
+			probeStatus: http.StatusOK,
+			want:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantWaiting: true,
+		},
+		"ResourceNotReady": {
+			reason:      "Should wait while the Ready condition of the dependency is not true",
+			mod:         withDependency,
+			ready:       "False",
+			probeStatus: http.StatusOK,
+			want:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantWaiting: true,
+		},
+		"ProbeNotReady": {
+			reason:      "Should wait while the readiness probe does not respond with a 2xx status code",
+			mod:         withDependency,
+			ready:       "True",
+			probeStatus: http.StatusServiceUnavailable,
+			want:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantWaiting: true,
+		},
+		"Ready": {
+			reason:      "Should report the resource as not existing once its dependencies are ready, so it is created",
+			mod:         withDependency,
+			ready:       "True",
+			probeStatus: http.StatusOK,
+			want:        managed.ExternalObservation{ResourceExists: false},
+		},
+		"AlreadyCreated": {
+			reason: "Should not check the dependencies of a resource once a response was received, even if it is gone",
+			mod: func(r *v1alpha2.Request) {
+				withDependency(r)
+				r.Status.Response = v1alpha2.Response{StatusCode: http.StatusOK, Body: `{"id": "123"}`}
+			},
+			probeStatus: http.StatusServiceUnavailable,
+			want:        managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet:          dependencyGetFn(tc.ready),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound}}, nil
+					},
+				},
+				prober: mockProber(func(_ context.Context, url string, _ *httpClient.TLSConfigData) (int, error) {
+					if url != testProbeURL {
+						return 0, errors.Errorf("unexpected probe URL %s", url)
+					}
+					return tc.probeStatus, nil
+				}),
+			}
+
+			mg := httpRequest(tc.mod)
+			got, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got: %s", tc.reason, diff)
+			}
+
+			waiting := mg.Status.GetCondition(xpv1.TypeReady).Reason == common.ReasonWaitingForDependencies
+			if waiting != tc.wantWaiting {
+				t.Errorf("\n%s\ne.Observe(...): want waiting for dependencies %t, got condition %v", tc.reason, tc.wantWaiting, mg.Status.GetCondition(xpv1.TypeReady))
+			}
+		})
+	}
+}
+
+func Test_httpExternal_ObserveDependsOnGetFails(t *testing.T) {
+	e := &external{
+		localKube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+		logger:    logging.NewNopLogger(),
+	}
+
+	if _, err := e.Observe(context.Background(), httpRequest(withDependency)); err == nil {
+		t.Errorf("e.Observe(...): want an error when the dependency cannot be read, got none")
+	}
+}
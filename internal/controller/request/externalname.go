@@ -0,0 +1,32 @@
+package request
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+// externalNameInitializer defaults the external name of a Request to its name, like the default initializer of the
+// managed reconciler, unless the external name is extracted from the response of the CREATE request.
+type externalNameInitializer struct {
+	nameAsExternalName managed.Initializer
+}
+
+// withExternalNameInitializer returns a managed.ReconcilerOption initializing Requests with an
+// externalNameInitializer.
+func withExternalNameInitializer(kube client.Client) managed.ReconcilerOption {
+	return managed.WithInitializers(&externalNameInitializer{nameAsExternalName: managed.NewNameAsExternalName(kube)})
+}
+
+// Initialize defaults the external name of the Request to its name, unless it is set by externalNameFrom.
+func (i *externalNameInitializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	if cr, ok := mg.(*v1alpha2.Request); ok && cr.Spec.ForProvider.ExternalNameFrom != "" {
+		return nil
+	}
+
+	return i.nameAsExternalName.Initialize(ctx, mg)
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func TestExternalNameInitializer(t *testing.T) {
+	cases := map[string]struct {
+		reason           string
+		externalNameFrom string
+		want             string
+	}{
+		"DefaultsToName": {
+			reason: "Should default the external name to the name of the Request",
+			want:   testRequestName,
+		},
+		"SetFromResponse": {
+			reason:           "Should leave the external name unset when it is extracted from the response",
+			externalNameFrom: ".body.id",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.ExternalNameFrom = tc.externalNameFrom
+			})
+			i := &externalNameInitializer{nameAsExternalName: managed.NewNameAsExternalName(&test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)})}
+
+			if err := i.Initialize(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\nInitialize(...): unexpected error: %v", tc.reason, err)
+			}
+			if got := meta.GetExternalName(cr); got != tc.want {
+				t.Errorf("\n%s\nInitialize(...): want external name %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -18,10 +18,12 @@ package request
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -38,6 +40,7 @@ import (
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/metrics"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/service/request"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/observe"
@@ -48,7 +51,6 @@ import (
 const (
 	errNotRequest                   = "managed resource is not a Request custom resource"
 	errTrackPCUsage                 = "cannot track ProviderConfig usage"
-	errNewHttpClient                = "cannot create new Http client"
 	errProviderNotRetrieved         = "provider could not be retrieved"
 	errFailedToSendHttpRequest      = "something went wrong"
 	errFailedToCheckIfUpToDate      = "failed to check if request is up to date"
@@ -56,7 +58,10 @@ const (
 	errFailedUpdateStatusConditions = "failed updating status conditions"
 	errPatchDataToSecret            = "Warning, couldn't patch data from request to secret %s:%s:%s, error: %s"
 	errGetLatestVersion             = "failed to get the latest version of the resource"
-	errExtractCredentials           = "cannot extract credentials"
+	errFailedToRenderDryRun         = "failed to render the request of the dry run"
+	errLoadHMACSigningKey           = "cannot load HMAC signing key"
+	errFailedToConfirmDeletion      = "failed to confirm deletion"
+	errDeletionNotConfirmed         = "external resource still exists after removal, deletion not confirmed yet"
 )
 
 // Setup adds a controller that reconciles Request managed resources.
@@ -73,6 +78,8 @@ func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		withObserveBackoff(),
+		withExternalNameInitializer(mgr.GetClient()),
 		managed.WithTimeout(timeout),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
@@ -101,7 +108,7 @@ type connector struct {
 	logger          logging.Logger
 	kube            client.Client
 	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration, creds string) (httpClient.Client, error)
+	newHttpClientFn httpClient.NewClientFn
 }
 
 // Connect creates a new external client using the provider config.
@@ -123,20 +130,25 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errProviderNotRetrieved)
 	}
 
-	creds := ""
-	if pc.Spec.Credentials.Source == xpv1.CredentialsSourceSecret {
-		data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
-		if err != nil {
-			return nil, errors.Wrap(err, errExtractCredentials)
-		}
-
-		creds = string(data)
+	hmacSigner, err := httpClient.LoadHMACSigner(ctx, c.kube, cr.Spec.ForProvider.HMACSigning)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadHMACSigningKey)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout), creds)
+	h, err := httpClient.NewProviderConfigClient(ctx, c.kube, l, pc, httpClient.ResourceClientConfig{
+		Timeout:               utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout),
+		ResponseFormat:        cr.Spec.ForProvider.ResponseFormat,
+		UserAgent:             cr.Spec.ForProvider.UserAgent,
+		Protocol:              cr.Spec.ForProvider.Protocol,
+		ExpectContinueTimeout: cr.Spec.ForProvider.GetExpectContinueTimeout(),
+		Signers:               []httpClient.RequestSigner{hmacSigner},
+	}, c.newHttpClientFn)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewHttpClient)
+		return nil, err
 	}
+	// The readiness probes bypass the credentials, the signatures and the metrics of the client.
+	prober, _ := h.(httpClient.Prober)
+	h = metrics.InstrumentClient(h, v1alpha2.RequestKind, cr.GetLabels())
 
 	// Merge TLS configs: resource-level overrides provider-level
 	mergedTLSConfig := httpClient.MergeTLSConfigs(cr.Spec.ForProvider.TLSConfig, pc.Spec.TLS)
@@ -155,21 +167,32 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, "failed to load TLS configuration")
 	}
 
+	tlsConfigData.HostPolicies, err = httpClient.LoadTLSHostPolicies(ctx, c.kube, pc.Spec.TLSHostPolicies)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load TLS host policies")
+	}
+
 	return &external{
-		localKube:     c.kube,
-		logger:        l,
-		http:          h,
-		tlsConfigData: tlsConfigData,
+		localKube:      c.kube,
+		logger:         l,
+		http:           h,
+		prober:         prober,
+		tlsConfigData:  tlsConfigData,
+		baseURL:        pc.Spec.BaseURL,
+		defaultHeaders: pc.Spec.DefaultHeaders,
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	localKube     client.Client
-	logger        logging.Logger
-	http          httpClient.Client
-	tlsConfigData *httpClient.TLSConfigData
+	localKube      client.Client
+	logger         logging.Logger
+	http           httpClient.Client
+	prober         httpClient.Prober
+	tlsConfigData  *httpClient.TLSConfigData
+	baseURL        string
+	defaultHeaders map[string][]string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -178,12 +201,47 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRequest)
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithBaseURL(c.baseURL).WithDefaultHeaders(c.defaultHeaders)
 	crCtx := service.NewRequestCRContext(cr)
+	if cr.Spec.ForProvider.DryRun {
+		return observeDryRun(svcCtx, crCtx, cr)
+	}
+
+	if waitsForDependencies(cr) {
+		reason, err := c.unreadyDependency(ctx, cr.Spec.ForProvider.DependsOn)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if reason != "" {
+			// The resource is reported as existing and up to date, so it is not
+			// created before it is observed again at the next poll.
+			cr.Status.SetConditions(common.WaitingForDependencies(fmt.Sprintf(msgWaitingDependencies, reason)))
+			return managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+			}, nil
+		}
+	}
+
+	if isCacheFresh(cr, time.Now()) {
+		// The resource was found up to date for this spec recently enough, its
+		// OBSERVE request is not sent again until the cached response expires.
+		cr.Status.SetConditions(readyCondition(cr))
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	// The status handler reads the latest version of the resource, whose spec may
+	// have changed since it was observed.
+	generation := cr.GetGeneration()
 	observeRequestDetails, err := request.IsUpToDate(svcCtx, crCtx)
 	if err != nil && err.Error() == observe.ErrObjectNotFound {
+		// A resource assumed to exist is updated instead of being created, unless
+		// it is being deleted: it is then gone once its REMOVE request succeeded.
 		return managed.ExternalObservation{
-			ResourceExists: false,
+			ResourceExists: request.AssumesExists(crCtx.Spec()) && !meta.WasDeleted(cr),
 		}, nil
 	}
 
@@ -200,12 +258,17 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if synced {
 		statusHandler.ResetFailures()
 	}
+	statusHandler.SetFailedCheck(observeRequestDetails.FailedCheck)
+	statusHandler.SetDrift(observeRequestDetails.Drift)
+	statusHandler.CountSuccess()
+	statusHandler.SetCacheObservedGeneration(observedGeneration(generation, synced))
+	statusHandler.RecordAttempt(v1alpha2.ActionObserve)
 
-	cr.Status.SetConditions(xpv1.Available())
 	err = statusHandler.SetRequestStatus()
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, " failed updating status")
 	}
+	cr.Status.SetConditions(readyCondition(cr))
 
 	return managed.ExternalObservation{
 		ResourceExists:    true,
@@ -214,6 +277,43 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// observedGeneration returns the generation of the spec the resource was found up to date for, zero if it was not.
+func observedGeneration(generation int64, synced bool) int64 {
+	if !synced {
+		return 0
+	}
+
+	return generation
+}
+
+// readyCondition returns the Ready condition of a resource after it was observed. It is only available once it was
+// successfully observed readyAfterSuccesses times in a row.
+func readyCondition(cr *v1alpha2.Request) xpv1.Condition {
+	if required := cr.Spec.ForProvider.ReadyAfterSuccesses; cr.Status.ConsecutiveSuccesses < required {
+		return common.Stabilizing(cr.Status.ConsecutiveSuccesses, required)
+	}
+
+	return xpv1.Available()
+}
+
+// observeDryRun renders the CREATE request into the status without sending any request, and reports the resource
+// as existing and up to date so it is never created, updated or deleted. A deleted resource is reported as gone.
+func observeDryRun(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, cr *v1alpha2.Request) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if err := request.DryRun(svcCtx, crCtx, v1alpha2.ActionCreate); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToRenderDryRun)
+	}
+
+	cr.Status.SetConditions(common.DryRun())
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha2.Request)
 	if !ok {
@@ -225,7 +325,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, errGetLatestVersion)
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithBaseURL(c.baseURL).WithDefaultHeaders(c.defaultHeaders)
 	crCtx := service.NewRequestCRContext(cr)
 	return managed.ExternalCreation{}, errors.Wrap(request.DeployAction(svcCtx, crCtx, v1alpha2.ActionCreate), errFailedToSendHttpRequest)
 }
@@ -241,7 +341,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetLatestVersion)
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithBaseURL(c.baseURL).WithDefaultHeaders(c.defaultHeaders)
 	crCtx := service.NewRequestCRContext(cr)
 	return managed.ExternalUpdate{}, errors.Wrap(request.DeployAction(svcCtx, crCtx, v1alpha2.ActionUpdate), errFailedToSendHttpRequest)
 }
@@ -257,9 +357,32 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.Wrap(err, errGetLatestVersion)
 	}
 
-	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData)
+	svcCtx := service.NewServiceContext(ctx, c.localKube, c.logger, c.http, c.tlsConfigData).WithBaseURL(c.baseURL).WithDefaultHeaders(c.defaultHeaders)
 	crCtx := service.NewRequestCRContext(cr)
-	return managed.ExternalDelete{}, errors.Wrap(request.DeployAction(svcCtx, crCtx, v1alpha2.ActionRemove), errFailedToSendHttpRequest)
+	if err := request.DeployAction(svcCtx, crCtx, v1alpha2.ActionRemove); err != nil {
+		return managed.ExternalDelete{}, errors.Wrap(err, errFailedToSendHttpRequest)
+	}
+
+	if !cr.Spec.ForProvider.ConfirmDeletion {
+		return managed.ExternalDelete{}, nil
+	}
+
+	return managed.ExternalDelete{}, confirmDeletion(svcCtx, crCtx)
+}
+
+// confirmDeletion observes the external resource after removal and returns an error, causing a requeue,
+// as long as the is-removed check does not pass.
+func confirmDeletion(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext) error {
+	removed, err := request.IsRemoved(svcCtx, crCtx)
+	if err != nil {
+		return errors.Wrap(err, errFailedToConfirmDeletion)
+	}
+
+	if !removed {
+		return errors.New(errDeletionNotConfirmed)
+	}
+
+	return nil
 }
 
 // Disconnect does nothing. It never returns an error.
@@ -2,11 +2,16 @@ package request
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -222,6 +227,414 @@ func Test_httpExternal_Create(t *testing.T) {
 	}
 }
 
+func Test_httpExternal_CreateWithProviderConfigBaseURL(t *testing.T) {
+	var gotURL string
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+				gotURL = url
+				return httpClient.HttpDetails{}, nil
+			},
+		},
+		baseURL: "https://api.example.com/v2",
+	}
+
+	mg := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+			{Method: "POST", Body: "{ name: .payload.body.username }", URL: `"\(.providerConfig.baseURL)/things"`},
+		}
+	})
+	if _, err := e.Create(context.Background(), mg); err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff("https://api.example.com/v2/things", gotURL); diff != "" {
+		t.Errorf("e.Create(...): -want URL, +got URL: %s", diff)
+	}
+}
+
+func Test_httpExternal_ObserveDryRun(t *testing.T) {
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				if secret, ok := obj.(*corev1.Secret); ok {
+					secret.Data = map[string][]byte{"token": []byte("s3cr3t")}
+				}
+				return nil
+			}),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+				t.Errorf("SendRequest(...): unexpected request %s %s during a dry run", method, url)
+				return httpClient.HttpDetails{}, nil
+			},
+		},
+	}
+
+	mg := httpRequest(func(r *v1alpha2.Request) {
+		r.Spec.ForProvider.DryRun = true
+		r.Spec.ForProvider.Headers = map[string][]string{
+			"Authorization": {"Bearer {{auth:testns:token}}"},
+		}
+	})
+	got, err := e.Observe(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, got); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got: %s", diff)
+	}
+
+	wantDetails := v1alpha2.Mapping{
+		Method:  http.MethodPost,
+		URL:     "https://api.example.com/users",
+		Body:    `{"email":"john.doe@example.com","username":"john_doe"}`,
+		Headers: map[string][]string{"Authorization": {"Bearer {{auth:testns:token}}"}},
+	}
+	if diff := cmp.Diff(wantDetails, mg.Status.RequestDetails); diff != "" {
+		t.Errorf("e.Observe(...): -want request details, +got request details: %s", diff)
+	}
+	if strings.Contains(mg.Status.RequestDetails.Headers["Authorization"][0], "s3cr3t") {
+		t.Errorf("e.Observe(...): request details contain the secret value")
+	}
+
+	if got := mg.Status.GetCondition(common.TypeDryRun).Reason; got != common.ReasonRendered {
+		t.Errorf("e.Observe(...): want %s condition reason %s, got %s", common.TypeDryRun, common.ReasonRendered, got)
+	}
+}
+
+func Test_httpExternal_ObserveAssumeExists(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		assumeExists bool
+		getMapping   v1alpha2.Mapping
+		statusCode   int
+		want         managed.ExternalObservation
+	}{
+		"NotTemplated": {
+			reason:       "Should report a resource assumed to exist as existing when the OBSERVE request cannot be templated",
+			assumeExists: true,
+			getMapping:   testGetMapping,
+			want:         managed.ExternalObservation{ResourceExists: true},
+		},
+		"NotFound": {
+			reason:       "Should report a resource assumed to exist as existing when the OBSERVE request does not find it",
+			assumeExists: true,
+			getMapping:   v1alpha2.Mapping{Method: "GET", URL: "(.payload.baseUrl + \"/\" + .externalName)"},
+			statusCode:   404,
+			want:         managed.ExternalObservation{ResourceExists: true},
+		},
+		"CreatedByDefault": {
+			reason:     "Should report a resource not found as missing so it is created",
+			getMapping: v1alpha2.Mapping{Method: "GET", URL: "(.payload.baseUrl + \"/\" + .externalName)"},
+			statusCode: 404,
+			want:       managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodGet {
+							t.Errorf("\n%s\nSendRequest(...): unexpected %s request while observing", tc.reason, method)
+						}
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.statusCode}}, nil
+					},
+				},
+			}
+
+			mg := httpRequest(func(r *v1alpha2.Request) {
+				r.Annotations = map[string]string{"crossplane.io/external-name": "42"}
+				r.Status = v1alpha2.RequestStatus{}
+				r.Spec.ForProvider.AssumeExists = tc.assumeExists
+				r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{testPostMapping, tc.getMapping, testPutMapping}
+			})
+			got, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got: %s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_DeleteAssumeExists(t *testing.T) {
+	removed := false
+	e := &external{
+		localKube: &test.MockClient{
+			MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			MockGet:          test.NewMockGetFn(nil),
+		},
+		logger: logging.NewNopLogger(),
+		http: &MockHttpClient{
+			MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+				if method == http.MethodDelete {
+					removed = true
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}, nil
+				}
+				if removed {
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound}}, nil
+				}
+				return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id": "42"}`}}, nil
+			},
+		},
+	}
+
+	mg := httpRequest(func(r *v1alpha2.Request) {
+		now := v1.Now()
+		r.SetDeletionTimestamp(&now)
+		r.Annotations = map[string]string{"crossplane.io/external-name": "42"}
+		r.Spec.ForProvider.AssumeExists = true
+		r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+			{Method: "GET", URL: "(.payload.baseUrl + \"/\" + .externalName)"},
+			{Method: "DELETE", URL: "(.payload.baseUrl + \"/\" + .externalName)"},
+		}
+	})
+
+	if _, err := e.Delete(context.Background(), mg); err != nil {
+		t.Fatalf("e.Delete(...): unexpected error: %v", err)
+	}
+	got, err := e.Observe(context.Background(), mg)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	if got.ResourceExists {
+		t.Errorf("e.Observe(...): want a resource assumed to exist reported as missing once removed, so its finalizer is removed")
+	}
+}
+
+func Test_httpExternal_ObserveAdoptsExternalName(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		externalName string
+		statusCode   int
+		want         managed.ExternalObservation
+		wantErr      bool
+		wantRequests int
+	}{
+		"Adopted": {
+			reason:       "Should adopt the external resource identified by a pre-set external name instead of creating it",
+			externalName: "42",
+			statusCode:   http.StatusOK,
+			want:         managed.ExternalObservation{ResourceExists: true},
+			wantRequests: 1,
+		},
+		"NotFound": {
+			reason:       "Should create the resource when the external resource to adopt does not exist",
+			externalName: "42",
+			statusCode:   http.StatusNotFound,
+			want:         managed.ExternalObservation{ResourceExists: false},
+			wantRequests: 1,
+		},
+		"NotConfirmed": {
+			reason:       "Should fail rather than create the resource when the OBSERVE request cannot confirm whether it exists",
+			externalName: "42",
+			statusCode:   http.StatusServiceUnavailable,
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		"DefaultExternalName": {
+			reason:       "Should create the resource without observing it when the external name defaults to its name",
+			externalName: testRequestName,
+			want:         managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						requests++
+						if method != http.MethodGet {
+							t.Errorf("\n%s\nSendRequest(...): unexpected %s request while observing", tc.reason, method)
+						}
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.statusCode, Body: `{"username": "john_doe", "email": "john.doe@example.com"}`}}, nil
+					},
+				},
+			}
+
+			mg := httpRequest(func(r *v1alpha2.Request) {
+				r.Annotations = map[string]string{"crossplane.io/external-name": tc.externalName}
+				r.Status = v1alpha2.RequestStatus{}
+				// Creating first would duplicate the adopted resource.
+				r.Spec.ForProvider.ObserveBeforeCreate = ptr.To(false)
+				r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+					testPostMapping,
+					{Method: "GET", URL: "(.payload.baseUrl + \"/\" + .externalName)"},
+					testPutMapping,
+				}
+			})
+			got, err := e.Observe(context.Background(), mg)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\ne.Observe(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got: %s", tc.reason, diff)
+			}
+			if requests != tc.wantRequests {
+				t.Errorf("\n%s\ne.Observe(...): want %d OBSERVE requests, got %d", tc.reason, tc.wantRequests, requests)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_ObserveNotFoundStatusCodes(t *testing.T) {
+	cases := map[string]struct {
+		reason              string
+		notFoundStatusCodes []int
+		statusCode          int
+		want                managed.ExternalObservation
+	}{
+		"NotFound": {
+			reason:     "Should report the resource as missing on a 404 response, whatever isRemovedCheck evaluates to",
+			statusCode: http.StatusNotFound,
+			want:       managed.ExternalObservation{ResourceExists: false},
+		},
+		"Gone": {
+			reason:     "Should report the resource as missing on a 410 response by default",
+			statusCode: http.StatusGone,
+			want:       managed.ExternalObservation{ResourceExists: false},
+		},
+		"CustomStatusCodes": {
+			reason:              "Should report the resource as missing on a status code listed in notFoundStatusCodes",
+			notFoundStatusCodes: []int{http.StatusConflict},
+			statusCode:          http.StatusConflict,
+			want:                managed.ExternalObservation{ResourceExists: false},
+		},
+		"NotListed": {
+			reason:              "Should leave a status code that is not listed to isRemovedCheck",
+			notFoundStatusCodes: []int{},
+			statusCode:          http.StatusNotFound,
+			want:                managed.ExternalObservation{ResourceExists: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: tc.statusCode, Body: `{"id":"123"}`}}, nil
+					},
+				},
+			}
+
+			mg := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.NotFoundStatusCodes = tc.notFoundStatusCodes
+				r.Spec.ForProvider.IsRemovedCheck = v1alpha2.ExpectedResponseCheck{
+					Type:  v1alpha2.ExpectedResponseCheckTypeCustom,
+					Logic: "false",
+				}
+			})
+			got, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.ResourceExists, got.ResourceExists); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want ResourceExists, +got ResourceExists: %s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func Test_httpExternal_ObserveReadyAfterSuccesses(t *testing.T) {
+	type observation struct {
+		statusCode int
+		wantCount  int32
+		wantReady  corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason              string
+		readyAfterSuccesses int32
+		observations        []observation
+	}{
+		"ReadyOnceStable": {
+			reason:              "Should only report the resource ready once it was successfully observed enough times in a row, starting over on a failure",
+			readyAfterSuccesses: 3,
+			observations: []observation{
+				{statusCode: http.StatusOK, wantCount: 1, wantReady: corev1.ConditionFalse},
+				{statusCode: http.StatusOK, wantCount: 2, wantReady: corev1.ConditionFalse},
+				{statusCode: http.StatusServiceUnavailable, wantCount: 0, wantReady: corev1.ConditionFalse},
+				{statusCode: http.StatusOK, wantCount: 1, wantReady: corev1.ConditionFalse},
+				{statusCode: http.StatusOK, wantCount: 2, wantReady: corev1.ConditionFalse},
+				{statusCode: http.StatusOK, wantCount: 3, wantReady: corev1.ConditionTrue},
+				{statusCode: http.StatusOK, wantCount: 4, wantReady: corev1.ConditionTrue},
+				{statusCode: http.StatusServiceUnavailable, wantCount: 0, wantReady: corev1.ConditionFalse},
+			},
+		},
+		"ReadyByDefault": {
+			reason: "Should report the resource ready on the first successful observation without a threshold",
+			observations: []observation{
+				{statusCode: http.StatusOK, wantCount: 1, wantReady: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var statusCode int
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: statusCode, Body: `{"id": "123"}`}}, nil
+					},
+				},
+			}
+
+			mg := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.ReadyAfterSuccesses = tc.readyAfterSuccesses
+			})
+			for i, o := range tc.observations {
+				statusCode = o.statusCode
+				if _, err := e.Observe(context.Background(), mg); err != nil {
+					t.Fatalf("\n%s\ne.Observe(...) #%d: unexpected error: %v", tc.reason, i, err)
+				}
+				if mg.Status.ConsecutiveSuccesses != o.wantCount {
+					t.Errorf("\n%s\ne.Observe(...) #%d: want %d consecutive successes, got %d", tc.reason, i, o.wantCount, mg.Status.ConsecutiveSuccesses)
+				}
+				if got := mg.Status.GetCondition(xpv1.TypeReady).Status; got != o.wantReady {
+					t.Errorf("\n%s\ne.Observe(...) #%d: want Ready %s, got %s", tc.reason, i, o.wantReady, got)
+				}
+			}
+		})
+	}
+}
+
 func Test_httpExternal_Update(t *testing.T) {
 	type args struct {
 		http      httpClient.Client
@@ -362,6 +775,72 @@ func Test_httpExternal_Delete(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			name: "DeletionConfirmed",
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodGet {
+							return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound}}, nil
+						}
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ConfirmDeletion = true
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		{
+			name: "DeletionNotConfirmedYet",
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"id": "123"}`}}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ConfirmDeletion = true
+				}),
+			},
+			want: want{
+				err: errors.New(errDeletionNotConfirmed),
+			},
+		},
+		{
+			name: "ConfirmationRequestFailed",
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if method == http.MethodGet {
+							return httpClient.HttpDetails{}, errBoom
+						}
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ConfirmDeletion = true
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedToConfirmDeletion),
+			},
+		},
 	}
 	for _, tc := range cases {
 		tc := tc // Create local copies of loop variables
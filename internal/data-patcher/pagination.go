@@ -0,0 +1,197 @@
+package datapatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	errFetchPage       = "failed to fetch the page %s"
+	errInvalidNextPage = "invalid URL %s of the next page"
+	errAggregateValue  = "failed to aggregate the field %s across pages"
+
+	defaultMaxPages = 10
+)
+
+// Pages fetches the pages following a response.
+type Pages struct {
+	// URL is the URL of the first page, the relative URLs of the next pages are resolved against.
+	URL string
+
+	// Fetch sends the request of a page and returns its response.
+	Fetch func(url string) (*httpClient.HttpResponse, error)
+}
+
+// patchPagedResponseDataToSecret aggregates the values of the key mappings across the pages of the response and
+// patches them into a Kubernetes secret.
+func patchPagedResponseDataToSecret(ctx context.Context, localKube client.Client, logger logging.Logger, data, originalData *httpClient.HttpResponse, owner metav1.Object, secretConfig common.SecretInjectionConfig, pages *Pages) error {
+	dataMaps, err := collectPages(logger, originalData, secretConfig.Pagination, pages)
+	if err != nil {
+		return err
+	}
+
+	secret, err := kubehandler.GetOrCreateSecret(ctx, localKube, secretConfig.SecretRef.Name, secretConfig.SecretRef.Namespace, owner)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, mapping := range keyMappings(secretConfig) {
+		// Headers are not aggregated, the value of a header of the response itself is injected.
+		if mapping.FromHeader != nil {
+			valueToPatch := extractHeaderValue(originalData.Headers, mapping.FromHeader)
+			changed = updateSecretData(secret, mapping.SecretKey, valueToPatch, mapping.MissingFieldStrategy) || changed
+			replaceSensitiveValues(data, secret, mapping.SecretKey, valueToPatch)
+			continue
+		}
+
+		valueToPatch, elements, err := aggregateValue(dataMaps, mapping.ResponseJQ)
+		if err != nil {
+			return errors.Wrap(err, errPatchToReferencedSecret)
+		}
+
+		changed = updateSecretData(secret, mapping.SecretKey, valueToPatch, mapping.MissingFieldStrategy) || changed
+		for _, element := range elements {
+			replaceSensitiveValues(data, secret, mapping.SecretKey, &element)
+		}
+	}
+
+	// The secret is only written when its data changed.
+	if changed {
+		if err := kubehandler.UpdateSecret(ctx, localKube, secret); err != nil {
+			return errors.Wrap(err, errPatchToReferencedSecret)
+		}
+	}
+
+	return errors.Wrap(updateSecretLabelsAndAnnotations(ctx, localKube, logger, data, secret, secretConfig.Metadata.Labels, secretConfig.Metadata.Annotations), errPatchToReferencedSecret)
+}
+
+// collectPages returns the data maps of the response and of the pages following it, up to the maximum number of
+// pages. Pagination ends once a page has no next URL, or links back to a page that was already fetched.
+func collectPages(logger logging.Logger, response *httpClient.HttpResponse, pagination *common.Pagination, pages *Pages) ([]map[string]interface{}, error) {
+	maxPages := pagination.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+
+	var dataMaps []map[string]interface{}
+	visited := map[string]bool{}
+	currentURL := ""
+	if pages != nil {
+		currentURL = pages.URL
+		visited[currentURL] = true
+	}
+
+	for page := response; ; {
+		dataMap, err := prepareDataMap(page)
+		if err != nil {
+			return nil, err
+		}
+		dataMaps = append(dataMaps, dataMap)
+
+		if pages == nil || len(dataMaps) >= maxPages {
+			return dataMaps, nil
+		}
+
+		next, err := jq.ParseString(pagination.NextURLJQ, dataMap)
+		if err != nil || next == "" {
+			logger.Debug(fmt.Sprintf("No next page found with %s after %d pages", pagination.NextURLJQ, len(dataMaps)))
+			return dataMaps, nil
+		}
+
+		nextURL, err := resolvePageURL(currentURL, next)
+		if err != nil {
+			return nil, err
+		}
+		if visited[nextURL] {
+			return dataMaps, nil
+		}
+		visited[nextURL] = true
+
+		if page, err = pages.Fetch(nextURL); err != nil {
+			return nil, errors.Wrapf(err, errFetchPage, nextURL)
+		}
+		currentURL = nextURL
+	}
+}
+
+// resolvePageURL resolves the URL of the next page against the URL of the current page.
+func resolvePageURL(currentURL, next string) (string, error) {
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return "", errors.Wrapf(err, errInvalidNextPage, next)
+	}
+
+	base, err := url.Parse(currentURL)
+	if err != nil {
+		return "", errors.Wrapf(err, errInvalidNextPage, next)
+	}
+
+	return base.ResolveReference(nextURL).String(), nil
+}
+
+// aggregateValue runs the jq filter on every page and concatenates the results into a JSON array: an array result
+// is concatenated, any other result is appended, and a missing field is skipped. It also returns the string and
+// object elements of the array, which are replaced by a placeholder in the response. The value is nil if the field
+// is missing from every page.
+func aggregateValue(dataMaps []map[string]interface{}, responseJQ string) (*string, []string, error) {
+	var aggregated []interface{}
+	found := false
+	for _, dataMap := range dataMaps {
+		exists, err := jq.Exists(responseJQ, dataMap)
+		if err != nil || !exists {
+			continue
+		}
+
+		result, err := jq.Parse(responseJQ, dataMap)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errAggregateValue, responseJQ)
+		}
+
+		found = true
+		if items, ok := result.([]interface{}); ok {
+			aggregated = append(aggregated, items...)
+		} else if result != nil {
+			aggregated = append(aggregated, result)
+		}
+	}
+
+	if !found {
+		return nil, nil, nil
+	}
+
+	if aggregated == nil {
+		aggregated = []interface{}{}
+	}
+	value, err := json.Marshal(aggregated)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, errAggregateValue, responseJQ)
+	}
+
+	var elements []string
+	for _, item := range aggregated {
+		switch v := item.(type) {
+		case string:
+			elements = append(elements, v)
+		case map[string]interface{}:
+			if object, err := json.Marshal(v); err == nil {
+				elements = append(elements, string(object))
+			}
+		}
+	}
+
+	valueToPatch := string(value)
+	return &valueToPatch, elements, nil
+}
@@ -0,0 +1,111 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestApplyPagedResponseDataToSecrets(t *testing.T) {
+	listPages := map[string]string{
+		"https://api.example.com/members?page=2": `{"members": ["carol"], "next": "/members?page=3"}`,
+		"https://api.example.com/members?page=3": `{"members": ["dave"]}`,
+	}
+
+	cases := map[string]struct {
+		maxPages    int
+		withPages   bool
+		fetchErr    error
+		wantFetched []string
+		wantValue   string
+		wantUpdated bool
+	}{
+		"AggregatesAllPages": {
+			withPages:   true,
+			wantFetched: []string{"https://api.example.com/members?page=2", "https://api.example.com/members?page=3"},
+			wantValue:   `["alice","bob","carol","dave"]`,
+			wantUpdated: true,
+		},
+		"BoundedByMaxPages": {
+			maxPages:    2,
+			withPages:   true,
+			wantFetched: []string{"https://api.example.com/members?page=2"},
+			wantValue:   `["alice","bob","carol"]`,
+			wantUpdated: true,
+		},
+		"WithoutPagesAggregatesResponseOnly": {
+			wantValue:   `["alice","bob"]`,
+			wantUpdated: true,
+		},
+		"FailedPageLeavesSecretUnchanged": {
+			withPages:   true,
+			fetchErr:    errors.New("connection refused"),
+			wantFetched: []string{"https://api.example.com/members?page=2"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *corev1.Secret
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					obj.SetName(key.Name)
+					obj.SetNamespace(key.Namespace)
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					updated = obj.(*corev1.Secret).DeepCopy()
+					return nil
+				},
+			}
+
+			var fetched []string
+			var pages *Pages
+			if tc.withPages {
+				pages = &Pages{
+					URL: "https://api.example.com/members",
+					Fetch: func(url string) (*httpClient.HttpResponse, error) {
+						fetched = append(fetched, url)
+						if tc.fetchErr != nil {
+							return nil, tc.fetchErr
+						}
+						return &httpClient.HttpResponse{StatusCode: 200, Body: listPages[url]}, nil
+					},
+				}
+			}
+
+			response := &httpClient.HttpResponse{StatusCode: 200, Body: `{"members": ["alice", "bob"], "next": "/members?page=2"}`}
+			secretConfigs := []common.SecretInjectionConfig{{
+				SecretRef:   common.SecretRef{Name: "members", Namespace: "default"},
+				KeyMappings: []common.KeyInjection{{SecretKey: "names", ResponseJQ: ".body.members"}},
+				Pagination:  &common.Pagination{NextURLJQ: ".body.next", MaxPages: tc.maxPages},
+			}}
+			ApplyPagedResponseDataToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, secretConfigs, nil, pages)
+
+			if diff := cmp.Diff(tc.wantFetched, fetched); diff != "" {
+				t.Errorf("ApplyPagedResponseDataToSecrets(...): -want fetched pages, +got fetched pages: %s", diff)
+			}
+			if (updated != nil) != tc.wantUpdated {
+				t.Fatalf("ApplyPagedResponseDataToSecrets(...): want secret updated %t, got %t", tc.wantUpdated, updated != nil)
+			}
+			if updated == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantValue, string(updated.Data["names"])); diff != "" {
+				t.Errorf("ApplyPagedResponseDataToSecrets(...): -want secret value, +got secret value: %s", diff)
+			}
+			if diff := cmp.Diff(`{"members": ["{{members:default:names}}", "{{members:default:names}}"], "next": "/members?page=2"}`, response.Body); diff != "" {
+				t.Errorf("ApplyPagedResponseDataToSecrets(...): -want response body, +got response body: %s", diff)
+			}
+		})
+	}
+}
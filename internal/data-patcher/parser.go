@@ -31,6 +31,11 @@ func findPlaceholders(value string) []string {
 	return re.FindAllString(value, -1)
 }
 
+// ReplacePlaceholders replaces every placeholder in the provided string with the replacement.
+func ReplacePlaceholders(value, replacement string) string {
+	return re.ReplaceAllLiteralString(value, replacement)
+}
+
 // removeDuplicates removes duplicate strings from the given slice.
 func removeDuplicates(strSlice []string) []string {
 	unique := make(map[string]struct{})
@@ -85,6 +90,26 @@ func patchSecretsToValue(ctx context.Context, localKube client.Client, valueToHa
 
 }
 
+// secretValuesOf returns the values of the secrets referenced by the placeholders in the provided value.
+func secretValuesOf(ctx context.Context, localKube client.Client, value string, logger logging.Logger) ([]string, error) {
+	var values []string
+	for _, placeholder := range removeDuplicates(findPlaceholders(value)) {
+		name, namespace, key, ok := parsePlaceholder(placeholder)
+		if !ok {
+			continue
+		}
+		secret, err := kubehandler.GetSecret(ctx, localKube, name, namespace)
+		if err != nil {
+			logger.Info(fmt.Sprintf(errPatchFailed, err.Error()))
+			return nil, err
+		}
+
+		values = append(values, string(secret.Data[key]))
+	}
+
+	return values, nil
+}
+
 // patchSecretsInMap traverses a map and patches secrets into any string values.
 func patchSecretsInMap(ctx context.Context, localKube client.Client, data map[string]interface{}, logger logging.Logger) error {
 	for key, value := range data {
@@ -42,9 +42,36 @@ func PatchSecretsIntoResponse(ctx context.Context, localKube client.Client, resp
 		StatusCode: response.GetStatusCode(),
 		Body:       patchedBody,
 		Headers:    patchedHeaders,
+		Trailers:   response.GetTrailers(),
+		StatusText: response.GetStatusText(),
 	}, nil
 }
 
+// SecretValuesInResponse returns the values of the secrets referenced by placeholders in the body and headers of
+// the provided response, i.e. the values PatchSecretsIntoResponse patches into it.
+func SecretValuesInResponse(ctx context.Context, localKube client.Client, response interfaces.HTTPResponse, logger logging.Logger) ([]string, error) {
+	if response == nil {
+		return nil, nil
+	}
+
+	values, err := secretValuesOf(ctx, localKube, response.GetBody(), logger)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, headersList := range response.GetHeaders() {
+		for _, header := range headersList {
+			headerValues, err := secretValuesOf(ctx, localKube, header, logger)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, headerValues...)
+		}
+	}
+
+	return values, nil
+}
+
 // PatchSecretsIntoString patches secrets into the provided string.
 func PatchSecretsIntoString(ctx context.Context, localKube client.Client, str string, logger logging.Logger) (string, error) {
 	return patchSecretsToValue(ctx, localKube, str, logger)
@@ -98,30 +125,14 @@ func patchResponseDataToSecret(ctx context.Context, localKube client.Client, log
 
 // applySecretConfig applies the secret configuration to the secret.
 func applySecretConfig(ctx context.Context, localKube client.Client, logger logging.Logger, data *httpClient.HttpResponse, originalData *httpClient.HttpResponse, secretConfig common.SecretInjectionConfig, secret *v1.Secret) error {
-	var err error
-
-	if secretConfig.KeyMappings != nil {
-		for _, mapping := range secretConfig.KeyMappings {
-			err = updateSecretWithPatchedValue(ctx, localKube, logger, data, originalData, secret, mapping)
-			if err != nil {
-				return errors.Wrap(err, errPatchToReferencedSecret)
-			}
-		}
-	} else {
-		// Handle deprecated secretConfig fields
-		mapping := common.KeyInjection{
-			SecretKey:            secretConfig.SecretKey,
-			ResponseJQ:           secretConfig.ResponsePath,
-			MissingFieldStrategy: common.DeleteMissingField,
-		}
-
-		err = updateSecretWithPatchedValue(ctx, localKube, logger, data, originalData, secret, mapping)
+	for _, mapping := range keyMappings(secretConfig) {
+		err := updateSecretWithPatchedValue(ctx, localKube, logger, data, originalData, secret, mapping)
 		if err != nil {
 			return errors.Wrap(err, errPatchToReferencedSecret)
 		}
 	}
 
-	err = updateSecretLabelsAndAnnotations(ctx, localKube, logger, data, secret, secretConfig.Metadata.Labels, secretConfig.Metadata.Annotations)
+	err := updateSecretLabelsAndAnnotations(ctx, localKube, logger, data, secret, secretConfig.Metadata.Labels, secretConfig.Metadata.Annotations)
 	if err != nil {
 		return errors.Wrap(err, errPatchToReferencedSecret)
 	}
@@ -129,10 +140,31 @@ func applySecretConfig(ctx context.Context, localKube client.Client, logger logg
 	return nil
 }
 
+// keyMappings returns the key mappings of the secret configuration, or a single key mapping built from the
+// deprecated SecretKey and ResponsePath fields.
+func keyMappings(secretConfig common.SecretInjectionConfig) []common.KeyInjection {
+	if secretConfig.KeyMappings != nil {
+		return secretConfig.KeyMappings
+	}
+
+	return []common.KeyInjection{{
+		SecretKey:            secretConfig.SecretKey,
+		ResponseJQ:           secretConfig.ResponsePath,
+		MissingFieldStrategy: common.DeleteMissingField,
+	}}
+}
+
 // ApplyResponseDataToSecrets applies response data to Kubernetes Secrets as specified in the resource's SecretInjectionConfigs.
 // For each SecretInjectionConfig, it extracts a value from the HTTP response and patches it into the referenced Secret.
 // Ownership of the Secret is optionally set based on the configuration.
 func ApplyResponseDataToSecrets(ctx context.Context, localKube client.Client, logger logging.Logger, response *httpClient.HttpResponse, secretConfigs []common.SecretInjectionConfig, cr metav1.Object) {
+	ApplyPagedResponseDataToSecrets(ctx, localKube, logger, response, secretConfigs, cr, nil)
+}
+
+// ApplyPagedResponseDataToSecrets applies response data to Kubernetes Secrets like ApplyResponseDataToSecrets, and
+// follows the pages of the response with the given pages for the SecretInjectionConfigs setting pagination. Without
+// pages, only the response itself is aggregated for these configs.
+func ApplyPagedResponseDataToSecrets(ctx context.Context, localKube client.Client, logger logging.Logger, response *httpClient.HttpResponse, secretConfigs []common.SecretInjectionConfig, cr metav1.Object, pages *Pages) {
 	// Create a copy of the original response to use for data extraction (JQ queries)
 	// This ensures that each secret injection config extracts from the original response data
 	originalResponse := &httpClient.HttpResponse{
@@ -148,9 +180,24 @@ func ApplyResponseDataToSecrets(ctx context.Context, localKube client.Client, lo
 			owner = cr
 		}
 
+		inject, err := shouldInject(originalResponse, ref)
+		if err != nil {
+			logger.Info(fmt.Sprintf(errPatchDataToSecret, ref.SecretRef.Name, ref.SecretRef.Namespace, err.Error()))
+			continue
+		}
+		if !inject {
+			logger.Debug("Skipping secret injection since its when predicate is false", "secret", ref.SecretRef.Name, "namespace", ref.SecretRef.Namespace)
+			redactStoredValues(ctx, localKube, logger, response, originalResponse, ref)
+			continue
+		}
+
 		// Use the cumulative response for patching (gets updated with secret placeholders)
 		// and originalResponse for data extraction (remains unchanged)
-		err := patchResponseDataToSecret(ctx, localKube, logger, response, originalResponse, owner, ref)
+		if ref.Pagination != nil {
+			err = patchPagedResponseDataToSecret(ctx, localKube, logger, response, originalResponse, owner, ref, pages)
+		} else {
+			err = patchResponseDataToSecret(ctx, localKube, logger, response, originalResponse, owner, ref)
+		}
 		if err != nil {
 			logger.Info(fmt.Sprintf(errPatchDataToSecret, ref.SecretRef.Name, ref.SecretRef.Namespace, err.Error()))
 		}
@@ -21,6 +21,7 @@ import (
 const (
 	logUpdateSecretLabelsAndAnnotations = "Updating labels and annotations for Secret [%s/%s]"
 	logNoUpdatesRequired                = "No updates required for labels and annotations of Secret [%s/%s]"
+	logNoDataUpdateRequired             = "No update required for key %s of Secret [%s/%s], the value is unchanged"
 )
 
 // updateSecretLabelsAndAnnotations updates the labels and annotations of a Kubernetes Secret
@@ -58,6 +59,7 @@ func updateSecretLabelsAndAnnotations(ctx context.Context, kubeClient client.Cli
 // updateSecretWithPatchedValue extracts a specified value from an HTTP response,
 // transforms it if necessary, and patches it into a Kubernetes Secret. Additionally,
 // it replaces the sensitive value in the HTTP response body and headers with a placeholder.
+// The Secret is only written when the value changed, so an unchanged value does not bump its resourceVersion.
 func updateSecretWithPatchedValue(ctx context.Context, kubeClient client.Client, logger logging.Logger, data, originalData *httpClient.HttpResponse, secret *corev1.Secret, mapping common.KeyInjection) error {
 	// Step 1: Parse and prepare data
 	dataMap, err := prepareDataMap(originalData)
@@ -66,15 +68,24 @@ func updateSecretWithPatchedValue(ctx context.Context, kubeClient client.Client,
 	}
 
 	// Step 2: Extract the value to patch
-	valueToPatch := extractValueToPatch(logger, dataMap, mapping.ResponseJQ)
+	valueToPatch := extractMappingValue(logger, dataMap, originalData, mapping)
 
 	// Step 3: Update the secret data based on the missing strategy.
-	updateSecretData(secret, mapping.SecretKey, valueToPatch, mapping.MissingFieldStrategy)
+	changed := updateSecretData(secret, mapping.SecretKey, valueToPatch, mapping.MissingFieldStrategy)
 
 	// Step 4: Replace sensitive values in the HTTP response (only if the field was found).
 	replaceSensitiveValues(data, secret, mapping.SecretKey, valueToPatch)
+	if mapping.FromHeader != nil && mapping.FromHeader.Join != "" {
+		for _, value := range headerValues(originalData.Headers, mapping.FromHeader.Name) {
+			replaceSensitiveValues(data, secret, mapping.SecretKey, &value)
+		}
+	}
 
-	// Step 5: Save the updated secret to the Kubernetes API
+	// Step 5: Save the updated secret to the Kubernetes API, unless its data is unchanged
+	if !changed {
+		logger.Debug(fmt.Sprintf(logNoDataUpdateRequired, mapping.SecretKey, secret.Namespace, secret.Name))
+		return nil
+	}
 	return kubehandler.UpdateSecret(ctx, kubeClient, secret)
 }
 
@@ -88,6 +99,44 @@ func prepareDataMap(data *httpClient.HttpResponse) (map[string]interface{}, erro
 	return dataMap, nil
 }
 
+// extractMappingValue extracts the value of the key mapping from the response header it names, or else from the
+// data map of the response with its jq filter.
+func extractMappingValue(logger logging.Logger, dataMap map[string]interface{}, response *httpClient.HttpResponse, mapping common.KeyInjection) *string {
+	if mapping.FromHeader != nil {
+		return extractHeaderValue(response.Headers, mapping.FromHeader)
+	}
+
+	return extractValueToPatch(logger, dataMap, mapping.ResponseJQ)
+}
+
+// extractHeaderValue returns the first value of the response header, or all its values joined with the separator
+// of the source when it sets one. It returns nil when the response has no such header.
+func extractHeaderValue(headers map[string][]string, source *common.HeaderSource) *string {
+	values := headerValues(headers, source.Name)
+	if len(values) == 0 {
+		return nil
+	}
+
+	value := values[0]
+	if source.Join != "" {
+		value = strings.Join(values, source.Join)
+	}
+
+	return &value
+}
+
+// headerValues returns the values of the header, whose name is matched case-insensitively.
+func headerValues(headers map[string][]string, name string) []string {
+	var values []string
+	for key, headerValues := range headers {
+		if strings.EqualFold(key, name) {
+			values = append(values, headerValues...)
+		}
+	}
+
+	return values
+}
+
 // extractValueToPatch extracts a value from a data map based on the given field path.
 // If the field is a boolean or number, it converts it to a string.
 // If the field is a map[string]interface{}, it converts it to a JSON string.
@@ -118,9 +167,8 @@ func extractValueToPatch(logger logging.Logger, dataMap map[string]interface{},
 		logger.Debug(fmt.Sprintf("Failed to parse the field %s as a boolean: %s", requestFieldPath, boolErr))
 	}
 
-	// Attempt to parse the field as a number.
-	if numberResult, err := jq.ParseFloat(requestFieldPath, dataMap); err == nil {
-		numStr := strconv.FormatFloat(numberResult, 'f', -1, 64)
+	// Attempt to parse the field as a number, integers keep their exact digits.
+	if numStr, err := jq.ParseNumber(requestFieldPath, dataMap); err == nil {
 		return &numStr
 	} else {
 		logger.Debug(fmt.Sprintf("Failed to parse the field %s as a number: %s", requestFieldPath, err))
@@ -149,21 +197,36 @@ func extractValueToPatch(logger logging.Logger, dataMap map[string]interface{},
 //   - common.PreserveMissingField: does nothing
 //   - common.SetEmptyMissingField: sets the value to ""
 //   - common.DeleteMissingField: deletes the key from the secret if it exists.
-func updateSecretData(secret *corev1.Secret, secretKey string, valueToPatch *string, missingStrategy common.MissingFieldStrategy) {
+//
+// It returns whether the data of the secret changed.
+func updateSecretData(secret *corev1.Secret, secretKey string, valueToPatch *string, missingStrategy common.MissingFieldStrategy) bool {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
 	if valueToPatch != nil {
-		secret.Data[secretKey] = []byte(*valueToPatch)
-	} else {
-		switch missingStrategy {
-		case common.PreserveMissingField:
-		case common.SetEmptyMissingField:
-			secret.Data[secretKey] = []byte("")
-		case common.DeleteMissingField:
-			delete(secret.Data, secretKey)
-		}
+		return setSecretData(secret, secretKey, *valueToPatch)
 	}
+
+	switch missingStrategy {
+	case common.PreserveMissingField:
+	case common.SetEmptyMissingField:
+		return setSecretData(secret, secretKey, "")
+	case common.DeleteMissingField:
+		_, exists := secret.Data[secretKey]
+		delete(secret.Data, secretKey)
+		return exists
+	}
+	return false
+}
+
+// setSecretData sets the key of the secret to the value, and returns whether it changed.
+func setSecretData(secret *corev1.Secret, secretKey, value string) bool {
+	if isSecretDataUpToDate(secret, secretKey, value) {
+		return false
+	}
+
+	secret.Data[secretKey] = []byte(value)
+	return true
 }
 
 // replaceSensitiveValues replaces occurrences of sensitive values in the HTTP response body
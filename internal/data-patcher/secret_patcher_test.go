@@ -1,16 +1,20 @@
 package datapatcher
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestIsSecretDataUpToDate(t *testing.T) {
@@ -564,6 +568,16 @@ func TestExtractValueToPatch(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"ShouldExtractLargeIntegerWithAllItsDigits": {
+			args: args{
+				dataMap:          json_util.JsonStringToMap(`{"id": 10000000000000001}`),
+				requestFieldPath: ".id",
+			},
+			want: want{
+				result: ptr.To("10000000000000001"),
+				err:    nil,
+			},
+		},
 		"ShouldExtractNestedNumericValueAsString": {
 			args: args{
 				dataMap: map[string]interface{}{
@@ -847,3 +861,149 @@ func TestUpdateSecretLabelsAndAnnotationsEndToEnd(t *testing.T) {
 		t.Errorf("Annotations mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestApplyResponseDataToSecretsFromHeader(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		mappings    []common.KeyInjection
+		headers     map[string][]string
+		wantData    map[string][]byte
+		wantHeaders map[string][]string
+	}{
+		"FirstValue": {
+			reason:      "Should inject the first value of the header, matched case-insensitively, and redact it",
+			mappings:    []common.KeyInjection{{SecretKey: "token", FromHeader: &common.HeaderSource{Name: "x-api-token"}}},
+			headers:     map[string][]string{"X-Api-Token": {"s3cr3t", "other"}},
+			wantData:    map[string][]byte{"token": []byte("s3cr3t")},
+			wantHeaders: map[string][]string{"X-Api-Token": {"{{token:default:token}}", "other"}},
+		},
+		"Joined": {
+			reason:      "Should inject all the values of the header joined with the separator",
+			mappings:    []common.KeyInjection{{SecretKey: "scopes", FromHeader: &common.HeaderSource{Name: "X-Scopes", Join: ","}}},
+			headers:     map[string][]string{"X-Scopes": {"read", "write"}},
+			wantData:    map[string][]byte{"scopes": []byte("read,write")},
+			wantHeaders: map[string][]string{"X-Scopes": {"{{token:default:scopes}}", "{{token:default:scopes}}"}},
+		},
+		"WithBody": {
+			reason: "Should inject values from the header and the body into the same secret",
+			mappings: []common.KeyInjection{
+				{SecretKey: "token", FromHeader: &common.HeaderSource{Name: "X-Api-Token"}},
+				{SecretKey: "id", ResponseJQ: ".body.id"},
+			},
+			headers:     map[string][]string{"X-Api-Token": {"s3cr3t"}},
+			wantData:    map[string][]byte{"token": []byte("s3cr3t"), "id": []byte("42")},
+			wantHeaders: map[string][]string{"X-Api-Token": {"{{token:default:token}}"}},
+		},
+		"MissingHeader": {
+			reason:      "Should apply the missing field strategy when the response has no such header",
+			mappings:    []common.KeyInjection{{SecretKey: "token", FromHeader: &common.HeaderSource{Name: "X-Api-Token"}, MissingFieldStrategy: common.SetEmptyMissingField}},
+			headers:     map[string][]string{},
+			wantData:    map[string][]byte{"token": []byte("")},
+			wantHeaders: map[string][]string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data map[string][]byte
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					obj.SetName(key.Name)
+					obj.SetNamespace(key.Namespace)
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					data = obj.(*corev1.Secret).Data
+					return nil
+				},
+			}
+
+			response := &httpClient.HttpResponse{StatusCode: 200, Body: `{"id": "42"}`, Headers: tc.headers}
+			secretConfigs := []common.SecretInjectionConfig{{
+				SecretRef:   common.SecretRef{Name: "token", Namespace: "default"},
+				KeyMappings: tc.mappings,
+			}}
+			ApplyResponseDataToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, secretConfigs, nil)
+
+			if diff := cmp.Diff(tc.wantData, data); diff != "" {
+				t.Errorf("\n%s\nApplyResponseDataToSecrets(...): -want secret data, +got secret data: %s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantHeaders, response.Headers); diff != "" {
+				t.Errorf("\n%s\nApplyResponseDataToSecrets(...): -want response headers, +got response headers: %s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyResponseDataToSecretsUnchanged(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		mapping     common.KeyInjection
+		existing    map[string][]byte
+		wantUpdates int
+	}{
+		"Unchanged": {
+			reason:   "Should not write the secret when the extracted value is unchanged, even if the rest of the response changed",
+			mapping:  common.KeyInjection{SecretKey: "token", ResponseJQ: ".body.token"},
+			existing: map[string][]byte{"token": []byte("s3cr3t")},
+		},
+		"Changed": {
+			reason:      "Should write the secret when the extracted value changed",
+			mapping:     common.KeyInjection{SecretKey: "token", ResponseJQ: ".body.token"},
+			existing:    map[string][]byte{"token": []byte("expired")},
+			wantUpdates: 1,
+		},
+		"Added": {
+			reason:      "Should write the secret when it does not hold the key yet",
+			mapping:     common.KeyInjection{SecretKey: "token", ResponseJQ: ".body.token"},
+			wantUpdates: 1,
+		},
+		"MissingPreserved": {
+			reason:   "Should not write the secret when a missing field preserves its key",
+			mapping:  common.KeyInjection{SecretKey: "token", ResponseJQ: ".body.missing", MissingFieldStrategy: common.PreserveMissingField},
+			existing: map[string][]byte{"token": []byte("s3cr3t")},
+		},
+		"MissingDeleted": {
+			reason:      "Should write the secret when a missing field deletes its key",
+			mapping:     common.KeyInjection{SecretKey: "token", ResponseJQ: ".body.missing", MissingFieldStrategy: common.DeleteMissingField},
+			existing:    map[string][]byte{"token": []byte("s3cr3t")},
+			wantUpdates: 1,
+		},
+		"MissingAlreadyDeleted": {
+			reason:  "Should not write the secret when a missing field deletes a key it does not hold",
+			mapping: common.KeyInjection{SecretKey: "token", ResponseJQ: ".body.missing", MissingFieldStrategy: common.DeleteMissingField},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updates := 0
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					obj.SetName(key.Name)
+					obj.SetNamespace(key.Namespace)
+					obj.(*corev1.Secret).Data = tc.existing
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					updates++
+					return nil
+				},
+			}
+
+			response := &httpClient.HttpResponse{StatusCode: 200, Body: `{"token": "s3cr3t", "issuedAt": "2026-10-15T10:00:00Z"}`}
+			secretConfigs := []common.SecretInjectionConfig{{
+				SecretRef:   common.SecretRef{Name: "token", Namespace: "default"},
+				KeyMappings: []common.KeyInjection{tc.mapping},
+			}}
+			ApplyResponseDataToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, secretConfigs, nil)
+
+			if updates != tc.wantUpdates {
+				t.Errorf("\n%s\nApplyResponseDataToSecrets(...): want %d secret updates, got %d", tc.reason, tc.wantUpdates, updates)
+			}
+			if tc.mapping.ResponseJQ == ".body.token" && !strings.Contains(response.Body, "{{token:default:token}}") {
+				t.Errorf("\n%s\nApplyResponseDataToSecrets(...): want the value redacted from the response, got %s", tc.reason, response.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package datapatcher
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const errEvaluateWhen = "failed to evaluate the when predicate %s"
+
+// shouldInject checks whether the response data is injected into the secret of the configuration, i.e. the
+// configuration sets no when predicate or the predicate evaluates to true against the response.
+func shouldInject(response *httpClient.HttpResponse, secretConfig common.SecretInjectionConfig) (bool, error) {
+	if secretConfig.When == "" {
+		return true, nil
+	}
+
+	dataMap, err := prepareDataMap(response)
+	if err != nil {
+		return false, err
+	}
+
+	inject, err := jq.ParseBool(secretConfig.When, dataMap)
+	if err != nil {
+		return false, errors.Wrapf(err, errEvaluateWhen, secretConfig.When)
+	}
+
+	return inject, nil
+}
+
+// redactStoredValues replaces the values extracted from the response that the secret of the configuration already
+// holds with placeholders in the response, without updating the secret. A missing secret holds no value.
+func redactStoredValues(ctx context.Context, localKube client.Client, logger logging.Logger, data, originalData *httpClient.HttpResponse, secretConfig common.SecretInjectionConfig) {
+	secret, err := kubehandler.GetSecret(ctx, localKube, secretConfig.SecretRef.Name, secretConfig.SecretRef.Namespace)
+	if err != nil {
+		return
+	}
+
+	dataMap, err := prepareDataMap(originalData)
+	if err != nil {
+		return
+	}
+
+	for _, mapping := range keyMappings(secretConfig) {
+		value := extractMappingValue(logger, dataMap, originalData, mapping)
+		if value != nil && isSecretDataUpToDate(secret, mapping.SecretKey, *value) {
+			replaceSensitiveValues(data, secret, mapping.SecretKey, value)
+		}
+	}
+}
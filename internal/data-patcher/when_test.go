@@ -0,0 +1,94 @@
+package datapatcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestApplyResponseDataToSecretsWhen(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		when        string
+		statusCode  int
+		stored      string
+		wantUpdated bool
+		wantBody    string
+	}{
+		"NoPredicate": {
+			reason:      "Should inject the response data when no predicate is set",
+			statusCode:  200,
+			wantUpdated: true,
+			wantBody:    `{"token": "{{token:default:token}}"}`,
+		},
+		"PredicateTrue": {
+			reason:      "Should inject the response data when the predicate evaluates to true",
+			when:        ".statusCode == 201",
+			statusCode:  201,
+			wantUpdated: true,
+			wantBody:    `{"token": "{{token:default:token}}"}`,
+		},
+		"PredicateFalse": {
+			reason:     "Should leave the secret untouched when the predicate evaluates to false",
+			when:       ".statusCode == 201",
+			statusCode: 200,
+			wantBody:   `{"token": "s3cr3t"}`,
+		},
+		"PredicateFalseStoredValue": {
+			reason:     "Should still replace a value the secret already holds with a placeholder when skipping",
+			when:       ".statusCode == 201",
+			statusCode: 200,
+			stored:     "s3cr3t",
+			wantBody:   `{"token": "{{token:default:token}}"}`,
+		},
+		"PredicateNotBoolean": {
+			reason:     "Should leave the secret untouched when the predicate does not evaluate to a boolean",
+			when:       ".body.token",
+			statusCode: 201,
+			wantBody:   `{"token": "s3cr3t"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					obj.SetName(key.Name)
+					obj.SetNamespace(key.Namespace)
+					if tc.stored != "" {
+						obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte(tc.stored)}
+					}
+					return nil
+				},
+				MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+					updated = true
+					return nil
+				},
+			}
+
+			response := &httpClient.HttpResponse{StatusCode: tc.statusCode, Body: `{"token": "s3cr3t"}`}
+			secretConfigs := []common.SecretInjectionConfig{{
+				SecretRef:   common.SecretRef{Name: "token", Namespace: "default"},
+				KeyMappings: []common.KeyInjection{{SecretKey: "token", ResponseJQ: ".body.token"}},
+				When:        tc.when,
+			}}
+			ApplyResponseDataToSecrets(context.Background(), localKube, logging.NewNopLogger(), response, secretConfigs, nil)
+
+			if updated != tc.wantUpdated {
+				t.Errorf("\n%s\nApplyResponseDataToSecrets(...): want secret updated %t, got %t", tc.reason, tc.wantUpdated, updated)
+			}
+			if diff := cmp.Diff(tc.wantBody, response.Body); diff != "" {
+				t.Errorf("\n%s\nApplyResponseDataToSecrets(...): -want response body, +got response body: %s", tc.reason, diff)
+			}
+		})
+	}
+}
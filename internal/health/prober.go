@@ -0,0 +1,223 @@
+// Package health probes the canary endpoints of the ProviderConfigs to report whether the provider can reach the
+// APIs it talks to.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	// defaultInterval is the interval between two probes of a health check without one.
+	defaultInterval = time.Minute
+
+	// tick is how often the ProviderConfigs are listed to send the probes that are due.
+	tick = 10 * time.Second
+
+	errListProviderConfigs = "cannot list provider configs"
+	errLoadTLSConfig       = "cannot load TLS configuration"
+	errInvalidSSRFGuard    = "invalid SSRF guard"
+	errInvalidHostAliases  = "invalid host aliases"
+	errNewHttpClient       = "cannot create http client"
+	errUnexpectedStatus    = "want status code %d, got %d"
+	errProbesFailed        = "health checks failed: %s"
+)
+
+// result is the outcome of the last probe of a ProviderConfig.
+type result struct {
+	probed time.Time
+	err    error
+}
+
+// Prober periodically probes the canary endpoint of every ProviderConfig with a health check. It is a readiness
+// check failing while a probe fails, and a manager runnable sending the probes.
+type Prober struct {
+	kube    client.Client
+	logger  logging.Logger
+	timeout time.Duration
+
+	mu      sync.Mutex
+	results map[string]result
+}
+
+// NewProber returns a Prober reading the ProviderConfigs with the client and failing probes after the timeout.
+func NewProber(kube client.Client, l logging.Logger, timeout time.Duration) *Prober {
+	return &Prober{
+		kube:    kube,
+		logger:  l,
+		timeout: timeout,
+		results: map[string]result{},
+	}
+}
+
+// Start sends the probes that are due until the context is done.
+func (p *Prober) Start(ctx context.Context) error {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Probe(ctx, time.Now()); err != nil {
+			p.logger.Info("Cannot probe health checks", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection reports that every replica of the provider probes, since each one reports its own readiness.
+func (p *Prober) NeedLeaderElection() bool {
+	return false
+}
+
+// Probe sends the probes of the ProviderConfigs whose interval elapsed since their last probe, and forgets the
+// results of the ProviderConfigs that were deleted or no longer have a health check.
+func (p *Prober) Probe(ctx context.Context, now time.Time) error {
+	pcs := &apisv1alpha1.ProviderConfigList{}
+	if err := p.kube.List(ctx, pcs); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+
+	checked := map[string]bool{}
+	for i := range pcs.Items {
+		pc := &pcs.Items[i]
+		if pc.Spec.HealthCheck == nil {
+			continue
+		}
+		checked[pc.Name] = true
+
+		if !p.due(pc, now) {
+			continue
+		}
+
+		err := p.probe(ctx, pc)
+		if err != nil {
+			p.logger.Debug("Health check failed", "providerConfig", pc.Name, "url", pc.Spec.HealthCheck.URL, "error", err)
+		}
+		p.record(pc.Name, result{probed: now, err: err})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name := range p.results {
+		if !checked[name] {
+			delete(p.results, name)
+		}
+	}
+
+	return nil
+}
+
+// Check fails while the last probe of a ProviderConfig failed. ProviderConfigs that were not probed yet do not
+// fail it.
+func (p *Prober) Check(_ *http.Request) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var failed []string
+	for name, r := range p.results {
+		if r.err != nil {
+			failed = append(failed, name+": "+r.err.Error())
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	sort.Strings(failed)
+	return errors.Errorf(errProbesFailed, strings.Join(failed, "; "))
+}
+
+// due checks whether the interval of the health check of the ProviderConfig elapsed since its last probe.
+func (p *Prober) due(pc *apisv1alpha1.ProviderConfig, now time.Time) bool {
+	p.mu.Lock()
+	last, ok := p.results[pc.Name]
+	p.mu.Unlock()
+
+	return !ok || now.Sub(last.probed) >= interval(pc.Spec.HealthCheck)
+}
+
+// record records the result of the last probe of the ProviderConfig.
+func (p *Prober) record(name string, r result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.results[name] = r
+}
+
+// probe sends a GET request to the canary endpoint of the ProviderConfig, connecting as the Requests using it do,
+// and fails unless it is answered with the expected status code.
+func (p *Prober) probe(ctx context.Context, pc *apisv1alpha1.ProviderConfig) error {
+	tlsConfigData, err := httpClient.LoadTLSConfig(ctx, p.kube, pc.Spec.TLS)
+	if err != nil {
+		return errors.Wrap(err, errLoadTLSConfig)
+	}
+
+	tlsConfigData.HostPolicies, err = httpClient.LoadTLSHostPolicies(ctx, p.kube, pc.Spec.TLSHostPolicies)
+	if err != nil {
+		return errors.Wrap(err, errLoadTLSConfig)
+	}
+
+	addressGuard, err := httpClient.NewAddressGuard(pc.Spec.SSRFGuard)
+	if err != nil {
+		return errors.Wrap(err, errInvalidSSRFGuard)
+	}
+
+	hostAliases, err := httpClient.NewHostAliases(pc.Spec.HostAliases)
+	if err != nil {
+		return errors.Wrap(err, errInvalidHostAliases)
+	}
+
+	h, err := httpClient.NewClient(p.logger, p.timeout, "",
+		httpClient.WithIPFamily(pc.Spec.IPFamily),
+		httpClient.WithProtocol(pc.Spec.Protocol),
+		httpClient.WithAddressGuard(addressGuard),
+		httpClient.WithHostAliases(hostAliases),
+	)
+	if err != nil {
+		return errors.Wrap(err, errNewHttpClient)
+	}
+
+	details, err := h.SendRequest(ctx, http.MethodGet, pc.Spec.HealthCheck.URL, httpClient.Data{Encrypted: "", Decrypted: ""}, httpClient.Data{Encrypted: map[string][]string{}, Decrypted: map[string][]string{}}, tlsConfigData)
+	if err != nil {
+		return err
+	}
+
+	if want := expectedStatusCode(pc.Spec.HealthCheck); details.HttpResponse.StatusCode != want {
+		return errors.Errorf(errUnexpectedStatus, want, details.HttpResponse.StatusCode)
+	}
+
+	return nil
+}
+
+// interval returns the interval between two probes of the health check.
+func interval(hc *apisv1alpha1.HealthCheck) time.Duration {
+	if hc.Interval == nil || hc.Interval.Duration <= 0 {
+		return defaultInterval
+	}
+
+	return hc.Interval.Duration
+}
+
+// expectedStatusCode returns the status code of a passing probe of the health check.
+func expectedStatusCode(hc *apisv1alpha1.HealthCheck) int {
+	if hc.ExpectedStatusCode == 0 {
+		return http.StatusOK
+	}
+
+	return hc.ExpectedStatusCode
+}
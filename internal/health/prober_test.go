@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/crossplane-contrib/provider-http/apis/v1alpha1"
+)
+
+func providerConfigs(pcs ...apisv1alpha1.ProviderConfig) *test.MockClient {
+	return &test.MockClient{
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*apisv1alpha1.ProviderConfigList).Items = pcs
+			return nil
+		},
+	}
+}
+
+func providerConfig(name string, hc *apisv1alpha1.HealthCheck) apisv1alpha1.ProviderConfig {
+	return apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apisv1alpha1.ProviderConfigSpec{HealthCheck: hc},
+	}
+}
+
+func TestProberCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		reason  string
+		pcs     []apisv1alpha1.ProviderConfig
+		wantErr string
+	}{
+		"NoHealthCheck": {
+			reason: "Should be ready when no ProviderConfig has a health check",
+			pcs:    []apisv1alpha1.ProviderConfig{providerConfig("default", nil)},
+		},
+		"Passing": {
+			reason: "Should be ready when the canary endpoint answers with the expected status code",
+			pcs:    []apisv1alpha1.ProviderConfig{providerConfig("default", &apisv1alpha1.HealthCheck{URL: server.URL + "/up"})},
+		},
+		"ExpectedStatusCode": {
+			reason: "Should be ready when the canary endpoint answers with the configured status code",
+			pcs: []apisv1alpha1.ProviderConfig{providerConfig("default", &apisv1alpha1.HealthCheck{
+				URL:                server.URL + "/down",
+				ExpectedStatusCode: http.StatusServiceUnavailable,
+			})},
+		},
+		"UnexpectedStatusCode": {
+			reason:  "Should not be ready when the canary endpoint answers with another status code",
+			pcs:     []apisv1alpha1.ProviderConfig{providerConfig("default", &apisv1alpha1.HealthCheck{URL: server.URL + "/down"})},
+			wantErr: "default: want status code 200, got 503",
+		},
+		"Unreachable": {
+			reason:  "Should not be ready when the canary endpoint cannot be reached",
+			pcs:     []apisv1alpha1.ProviderConfig{providerConfig("egress", &apisv1alpha1.HealthCheck{URL: "http://127.0.0.1:1"})},
+			wantErr: "egress: ",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := NewProber(providerConfigs(tc.pcs...), logging.NewNopLogger(), 5*time.Second)
+			if err := p.Probe(context.Background(), time.Now()); err != nil {
+				t.Fatalf("\n%s\nProbe(...): unexpected error: %v", tc.reason, err)
+			}
+
+			err := p.Check(nil)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("\n%s\nCheck(...): unexpected error: %v", tc.reason, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("\n%s\nCheck(...): want error containing %q, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestProberInterval(t *testing.T) {
+	var probes, failing int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&probes, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	pc := providerConfig("default", &apisv1alpha1.HealthCheck{URL: server.URL, Interval: &metav1.Duration{Duration: time.Minute}})
+	p := NewProber(providerConfigs(pc), logging.NewNopLogger(), 5*time.Second)
+
+	now := time.Now()
+	for _, at := range []time.Duration{0, 30 * time.Second} {
+		if err := p.Probe(context.Background(), now.Add(at)); err != nil {
+			t.Fatalf("Probe(...): unexpected error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&probes); got != 1 {
+		t.Errorf("Probe(...): want 1 probe within the interval, got %d", got)
+	}
+
+	// The endpoint starts failing, which is only noticed once the interval elapsed.
+	atomic.StoreInt32(&failing, 1)
+	if err := p.Probe(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("Probe(...): unexpected error: %v", err)
+	}
+	if err := p.Check(nil); err == nil {
+		t.Errorf("Check(...): want the failing probe to mark the provider not ready")
+	}
+
+	// Forgetting the ProviderConfig forgets its failing probe.
+	p.kube = providerConfigs()
+	if err := p.Probe(context.Background(), now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("Probe(...): unexpected error: %v", err)
+	}
+	if err := p.Check(nil); err != nil {
+		t.Errorf("Check(...): unexpected error once the ProviderConfig is deleted: %v", err)
+	}
+}
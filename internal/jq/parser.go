@@ -2,6 +2,8 @@ package jq
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -12,8 +14,10 @@ import (
 const (
 	errStringParseFailed = "failed to parse string: %s"
 	errFloatParseFailed  = "failed to parse float: %s"
+	errNumberParseFailed = "failed to parse number: %s"
 	errResultParseFailed = "failed to parse result on jq query: %s"
 	errMapParseFailed    = "failed to parse map: %s"
+	errArrayParseFailed  = "failed to parse array: %s"
 	errQueryFailed       = "query should return at least one value, failed on: %s"
 	errInvalidQuery      = "failed to parse given mapping - %s jq error: %s"
 )
@@ -43,6 +47,11 @@ func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
 	return queryRes, nil
 }
 
+// Parse runs a jq query on a given object and returns the result as is.
+func Parse(jqQuery string, obj interface{}) (interface{}, error) {
+	return runJQQuery(jqQuery, obj)
+}
+
 // ParseString runs a jq query on a given object and returns the result as a string.
 func ParseString(jqQuery string, obj interface{}) (string, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
@@ -73,6 +82,26 @@ func ParseFloat(jqQuery string, obj interface{}) (float64, error) {
 	return floatVal, nil
 }
 
+// ParseNumber runs a jq query on a given object and returns the resulting number formatted as a string. Integers
+// are formatted with all their digits, whatever their size.
+func ParseNumber(jqQuery string, obj interface{}) (string, error) {
+	queryRes, err := runJQQuery(jqQuery, obj)
+	if err != nil {
+		return "", err
+	}
+
+	switch number := queryRes.(type) {
+	case int:
+		return strconv.Itoa(number), nil
+	case *big.Int:
+		return number.String(), nil
+	case float64:
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	}
+
+	return "", errors.Errorf(errNumberParseFailed, fmt.Sprint(queryRes))
+}
+
 // ParseBool runs a jq query on a given object and returns the result as a bool.
 func ParseBool(jqQuery string, obj interface{}) (bool, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
@@ -108,6 +137,21 @@ func ParseMapInterface(jqQuery string, obj interface{}) (map[string]interface{},
 	return nil, errors.Errorf(errMapParseFailed, fmt.Sprint(queryRes))
 }
 
+// ParseArray runs a jq query on a given object and returns the result as a []interface{}.
+func ParseArray(jqQuery string, obj interface{}) ([]interface{}, error) {
+	queryRes, err := runJQQuery(jqQuery, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	array, ok := queryRes.([]interface{})
+	if !ok {
+		return nil, errors.Errorf(errArrayParseFailed, fmt.Sprint(queryRes))
+	}
+
+	return array, nil
+}
+
 // ParseMapStrings runs a jq query on a given object and returns the result as a map[string][]string.
 func ParseMapStrings(keyToJQQueries map[string][]string, obj interface{}) (map[string][]string, error) {
 	result := make(map[string][]string, len(keyToJQQueries))
@@ -145,6 +189,29 @@ func IsJQQuery(query string) bool {
 	return err == nil
 }
 
+// Normalize returns the canonical form of a jq query, e.g. without whitespace between its indexes, or the query as
+// is if it cannot be parsed.
+func Normalize(query string) string {
+	parsed, err := gojq.Parse(query)
+	if err != nil {
+		return query
+	}
+
+	return parsed.String()
+}
+
+// Compile checks if a given string is a valid jq query and returns the reason it is not otherwise, e.g. a syntax
+// error or a call to an undefined function.
+func Compile(query string) error {
+	parsed, err := gojq.Parse(query)
+	if err != nil {
+		return err
+	}
+
+	_, err = gojq.Compile(parsed)
+	return err
+}
+
 // Exists checks if the given jq query returns a non-nil value from the object.
 // It returns true if the field exists, false otherwise.
 func Exists(jqQuery string, obj interface{}) (bool, error) {
@@ -1,8 +1,11 @@
 package jq
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/pkg/errors"
+
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 )
@@ -153,6 +156,70 @@ func Test_ParseFloat(t *testing.T) {
 	}
 }
 
+func Test_ParseNumber(t *testing.T) {
+	type args struct {
+		jqQuery string
+		obj     interface{}
+	}
+	type want struct {
+		result string
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Float": {
+			args: args{
+				jqQuery: `.payload.body.age`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: "30",
+			},
+		},
+		"LargeInteger": {
+			args: args{
+				jqQuery: `.id`,
+				obj:     map[string]any{"id": json.Number("10000000000000001")},
+			},
+			want: want{
+				result: "10000000000000001",
+			},
+		},
+		"BeyondInt64": {
+			args: args{
+				jqQuery: `.id + 1`,
+				obj:     map[string]any{"id": json.Number("123456789012345678901234567890")},
+			},
+			want: want{
+				result: "123456789012345678901234567891",
+			},
+		},
+		"NotNumber": {
+			args: args{
+				jqQuery: `.payload.body.username`,
+				obj:     testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errNumberParseFailed, "john_doe"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseNumber(tc.args.jqQuery, tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseNumber(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ParseNumber(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_ParseBool(t *testing.T) {
 	type args struct {
 		jqQuery string
@@ -238,6 +305,53 @@ func Test_ParseMapInterface(t *testing.T) {
 	}
 }
 
+func Test_ParseArray(t *testing.T) {
+	type args struct {
+		jqQuery string
+		obj     interface{}
+	}
+	type want struct {
+		result []interface{}
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Success": {
+			args: args{
+				jqQuery: `[.mappings[].method]`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: []any{"POST", "GET", "PUT", "DELETE"},
+				err:    nil,
+			},
+		},
+		"NotAnArray": {
+			args: args{
+				jqQuery: `.payload.baseUrl`,
+				obj:     testJQObject,
+			},
+			want: want{
+				err: errors.Errorf(errArrayParseFailed, "https://api.example.com/users"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseArray(tc.args.jqQuery, tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseArray(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ParseArray(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_ParseMapStrings(t *testing.T) {
 	// implemented on Test_ApplyJQOnMapStrings
 }
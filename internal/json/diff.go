@@ -0,0 +1,100 @@
+package json
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/maps"
+)
+
+// identifierRegex matches the keys that can follow a dot in a jq path.
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Diff returns the paths of the fields of containee that container does not contain, sorted, with the semantics of
+// Contains: nested objects are compared field by field, and other values, arrays included, as a whole.
+func Diff(container, containee map[string]interface{}) [][]string {
+	var paths [][]string
+	diff(container, containee, nil, &paths)
+	return paths
+}
+
+func diff(container, containee map[string]interface{}, prefix []string, paths *[][]string) {
+	keys := maps.Keys(containee)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		path := append(slices.Clone(prefix), key)
+		value := containee[key]
+		containerValue, exists := container[key]
+
+		nestedMap, isMap := value.(map[string]interface{})
+		containerNestedMap, containerIsMap := containerValue.(map[string]interface{})
+		switch {
+		case !exists:
+			*paths = append(*paths, path)
+		case isMap && containerIsMap:
+			diff(containerNestedMap, nestedMap, path, paths)
+		case isMap || !deepEqual(value, containerValue):
+			*paths = append(*paths, path)
+		}
+	}
+}
+
+// MergePatch returns the RFC 7386 merge patch turning original into desired, restricted to the fields of desired:
+// nested objects are compared field by field, and other values, arrays included, as a whole. The fields of original
+// missing from desired are left out rather than removed, so a field is only removed when desired sets it to null.
+func MergePatch(original, desired map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key, value := range desired {
+		originalValue, exists := original[key]
+
+		nestedMap, isMap := value.(map[string]interface{})
+		originalNestedMap, originalIsMap := originalValue.(map[string]interface{})
+		switch {
+		case isMap && originalIsMap:
+			if nestedPatch := MergePatch(originalNestedMap, nestedMap); len(nestedPatch) > 0 {
+				patch[key] = nestedPatch
+			}
+		case value == nil:
+			if exists && originalValue != nil {
+				patch[key] = nil
+			}
+		case !exists || isMap || !deepEqual(value, originalValue):
+			patch[key] = value
+		}
+	}
+
+	return patch
+}
+
+// ValueAt returns the value of the field at the path of nested objects, and whether it exists.
+func ValueAt(m map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = m
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// JQPath returns the jq path of the field at the path of nested objects, e.g. .spec.replicas.
+func JQPath(path []string) string {
+	var builder strings.Builder
+	for _, key := range path {
+		if identifierRegex.MatchString(key) {
+			builder.WriteString("." + key)
+			continue
+		}
+		builder.WriteString(".[" + strconv.Quote(key) + "]")
+	}
+
+	return builder.String()
+}
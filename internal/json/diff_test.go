@@ -0,0 +1,113 @@
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Diff(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		container string
+		containee string
+		want      [][]string
+	}{
+		"Contained": {
+			reason:    "Should find no difference when the container contains every field",
+			container: `{"name":"a","extra":true,"nested":{"x":1,"y":2}}`,
+			containee: `{"name":"a","nested":{"x":1}}`,
+		},
+		"Changed": {
+			reason:    "Should return the paths of the changed and missing fields, sorted",
+			container: `{"name":"b","nested":{"x":2}}`,
+			containee: `{"name":"a","nested":{"x":1,"y":2},"tags":["a"]}`,
+			want:      [][]string{{"name"}, {"nested", "x"}, {"nested", "y"}, {"tags"}},
+		},
+		"Arrays": {
+			reason:    "Should compare arrays as a whole",
+			container: `{"tags":["a","b"]}`,
+			containee: `{"tags":["a"]}`,
+			want:      [][]string{{"tags"}},
+		},
+		"ObjectReplacedByValue": {
+			reason:    "Should return the path of an object whose field is not an object in the container",
+			container: `{"nested":"x"}`,
+			containee: `{"nested":{"x":1}}`,
+			want:      [][]string{{"nested"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Diff(JsonStringToMap(tc.container), JsonStringToMap(tc.containee))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func Test_MergePatch(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		original string
+		desired  string
+		want     map[string]interface{}
+	}{
+		"ChangedFields": {
+			reason:   "Should only keep the changed and added fields, nested objects field by field",
+			original: `{"id":1,"name":"a","nested":{"x":1,"y":2}}`,
+			desired:  `{"name":"b","nested":{"x":1,"y":3},"tags":["a"]}`,
+			want:     map[string]interface{}{"name": "b", "nested": map[string]interface{}{"y": json.Number("3")}, "tags": []interface{}{"a"}},
+		},
+		"Arrays": {
+			reason:   "Should replace an array as a whole",
+			original: `{"tags":["a","b"]}`,
+			desired:  `{"tags":["a"]}`,
+			want:     map[string]interface{}{"tags": []interface{}{"a"}},
+		},
+		"Null": {
+			reason:   "Should remove a field set to null only if the original has it",
+			original: `{"a":1,"b":null}`,
+			desired:  `{"a":null,"b":null,"c":null}`,
+			want:     map[string]interface{}{"a": nil},
+		},
+		"ObjectReplacingValue": {
+			reason:   "Should keep a whole object replacing a field that is not an object",
+			original: `{"nested":"x"}`,
+			desired:  `{"nested":{"x":1}}`,
+			want:     map[string]interface{}{"nested": map[string]interface{}{"x": json.Number("1")}},
+		},
+		"NoOriginal": {
+			reason:  "Should keep every field without an original",
+			desired: `{"name":"a"}`,
+			want:    map[string]interface{}{"name": "a"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := MergePatch(JsonStringToMap(tc.original), JsonStringToMap(tc.desired))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMergePatch(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func Test_JQPath(t *testing.T) {
+	cases := map[string]struct {
+		path []string
+		want string
+	}{
+		"Identifiers": {path: []string{"spec", "max_replicas"}, want: ".spec.max_replicas"},
+		"QuotedKeys":  {path: []string{"labels", "app.kubernetes.io/name"}, want: `.labels.["app.kubernetes.io/name"]`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, JQPath(tc.path)); diff != "" {
+				t.Errorf("JQPath(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
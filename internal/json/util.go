@@ -3,6 +3,14 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	errTrailingData = errors.New("invalid character after top-level value")
+	errNotArray     = errors.New("not a JSON array")
 )
 
 // Contains checks if the containee map is contained within the container map, including nested JSON structures.
@@ -33,13 +41,59 @@ func IsJSONString(jsonStr string) bool {
 	return json.Unmarshal([]byte(jsonStr), &js) == nil
 }
 
-// JsonStringToMap converts a JSON string to a map.
+// JsonStringToMap converts a JSON string to a map. Integers keep their exact digits, see Unmarshal.
 func JsonStringToMap(jsonStr string) map[string]interface{} {
 	var jsonData map[string]interface{}
-	_ = json.Unmarshal([]byte(jsonStr), &jsonData)
+	_ = Unmarshal([]byte(jsonStr), &jsonData)
 	return jsonData
 }
 
+// Unmarshal parses JSON data into a map or an interface{} like json.Unmarshal, except that integers are decoded as
+// json.Number instead of float64, so integers beyond the precision of a float64, e.g. large IDs, keep their exact
+// digits through jq filters and when encoded again. Other numbers are decoded as float64.
+func Unmarshal(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errTrailingData
+	}
+
+	switch value := v.(type) {
+	case *map[string]interface{}:
+		normalizeNumbers(*value)
+	case *interface{}:
+		*value = normalizeNumbers(*value)
+	}
+
+	return nil
+}
+
+// normalizeNumbers converts the numbers decoded as json.Number that are not integers to float64, in place for
+// maps and arrays.
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			if f, err := v.Float64(); err == nil {
+				return f
+			}
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	}
+
+	return value
+}
+
 // ConvertJSONStringsToMaps converts JSON strings within a map to maps for JSON data processing.
 func ConvertJSONStringsToMaps(merged *map[string]interface{}) {
 	for key, value := range *merged {
@@ -68,6 +122,63 @@ func StructToMap(obj interface{}) (newMap map[string]interface{}, err error) {
 	return
 }
 
+// Canonicalize re-encodes a JSON object or array with the keys of every object sorted and without insignificant
+// whitespace, so the same document always serializes to the same bytes. Arrays keep their order and numbers are kept
+// as written. Other strings are returned unchanged.
+func Canonicalize(jsonStr string) string {
+	trimmed := bytes.TrimSpace([]byte(jsonStr))
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return jsonStr
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return jsonStr
+	}
+
+	canonical, err := json.Marshal(document)
+	if err != nil {
+		return jsonStr
+	}
+
+	return string(canonical)
+}
+
+// ToNDJSON re-encodes a JSON array as newline-delimited JSON, one canonical element per line, each line ending with
+// a newline. Numbers are kept as written. An empty array returns an empty string.
+func ToNDJSON(jsonStr string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(jsonStr))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return "", err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", errTrailingData
+	}
+
+	elements, ok := document.([]interface{})
+	if !ok {
+		return "", errNotArray
+	}
+
+	var lines strings.Builder
+	for _, element := range elements {
+		line, err := json.Marshal(element)
+		if err != nil {
+			return "", err
+		}
+		lines.Write(line)
+		lines.WriteByte('\n')
+	}
+
+	return lines.String(), nil
+}
+
 // ConvertMapToJson converts a map to a JSON string.
 func ConvertMapToJson(m map[string]interface{}) (string, error) {
 	jsonBytes, err := json.Marshal(m)
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
@@ -208,6 +209,14 @@ func Test_JsonStringToMap(t *testing.T) {
 				result: map[string]any{"email": "john.doe@example.com", "username": "john_doe"},
 			},
 		},
+		"Numbers": {
+			args: args{
+				jsonStr: `{"id":10000000000000001,"ratio":1.5,"items":[{"count":2}]}`,
+			},
+			want: want{
+				result: map[string]any{"id": json.Number("10000000000000001"), "ratio": 1.5, "items": []any{map[string]any{"count": json.Number("2")}}},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -338,3 +347,82 @@ func Test_StructToMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_Canonicalize(t *testing.T) {
+	cases := map[string]struct {
+		jsonStr string
+		want    string
+	}{
+		"NestedKeysSorted": {
+			jsonStr: `{"b": {"z": 1, "a": 2}, "a": "x"}`,
+			want:    `{"a":"x","b":{"a":2,"z":1}}`,
+		},
+		"ArraysKeepOrder": {
+			jsonStr: `[{"name": "second", "id": 2}, {"name": "first", "id": 1}]`,
+			want:    `[{"id":2,"name":"second"},{"id":1,"name":"first"}]`,
+		},
+		"NumbersKeptAsWritten": {
+			jsonStr: `{"big": 12345678901234567890, "exp": 1.0e3}`,
+			want:    `{"big":12345678901234567890,"exp":1.0e3}`,
+		},
+		"NotJSONUnchanged": {
+			jsonStr: "username=john_doe",
+			want:    "username=john_doe",
+		},
+		"ScalarUnchanged": {
+			jsonStr: `"a string"`,
+			want:    `"a string"`,
+		},
+		"TrailingDataUnchanged": {
+			jsonStr: `{"a": 1} {"b": 2}`,
+			want:    `{"a": 1} {"b": 2}`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Canonicalize(tc.jsonStr)); diff != "" {
+				t.Fatalf("Canonicalize(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ToNDJSON(t *testing.T) {
+	cases := map[string]struct {
+		jsonStr string
+		want    string
+		wantErr bool
+	}{
+		"OneElementPerLine": {
+			jsonStr: `[{"msg": "first", "level": "info"}, {"msg": "second", "level": "warn"}]`,
+			want:    "{\"level\":\"info\",\"msg\":\"first\"}\n{\"level\":\"warn\",\"msg\":\"second\"}\n",
+		},
+		"ScalarsAndNumbersKeptAsWritten": {
+			jsonStr: `[12345678901234567890, 1.0e3, "text"]`,
+			want:    "12345678901234567890\n1.0e3\n\"text\"\n",
+		},
+		"EmptyArray": {
+			jsonStr: `[]`,
+			want:    "",
+		},
+		"NotAnArray": {
+			jsonStr: `{"msg": "first"}`,
+			wantErr: true,
+		},
+		"TrailingData": {
+			jsonStr: `[] []`,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ToNDJSON(tc.jsonStr)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ToNDJSON(...): want error %t, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("ToNDJSON(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package jsonschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+)
+
+const (
+	errInvalidSchemaSource = "responseSchema must set exactly one of inline, secretKeyRef and configMapKeyRef"
+	errSchemaKeyNotFound   = "responseSchema key %s not found in %s %s:%s"
+	errInvalidSchema       = "invalid responseSchema"
+	errBodyNotJSON         = "body is not valid JSON"
+
+	rootName = "body"
+)
+
+// ValidationError is returned when a response body does not match its JSON Schema.
+type ValidationError struct {
+	// Path is the jq path of the first failing value of the body, e.g. '.items[0].id'.
+	Path string
+
+	// Message describes why the value at the path does not match the schema.
+	Message string
+
+	// Others is the number of other failing values.
+	Others int
+}
+
+// Error returns the failing path and why it does not match the schema.
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("response body does not match responseSchema at %s: %s", e.Path, e.Message)
+	if e.Others > 0 {
+		msg += fmt.Sprintf(" (and %d more)", e.Others)
+	}
+
+	return msg
+}
+
+// ValidateResponse validates the response body against the JSON Schema of the source. It returns a
+// *ValidationError if the body does not match, and another error if the schema cannot be loaded or is invalid.
+// A nil source accepts any body.
+func ValidateResponse(ctx context.Context, kubeClient client.Client, source *common.ResponseSchema, body string) error {
+	if source == nil {
+		return nil
+	}
+
+	schema, err := Load(ctx, kubeClient, source)
+	if err != nil {
+		return err
+	}
+
+	return Validate(schema, body)
+}
+
+// CheckResponse validates the response body against the response schema of the spec, if any. It returns a
+// *ValidationError if the body does not match, and another error if the schema cannot be loaded or is invalid.
+func CheckResponse(ctx context.Context, kubeClient client.Client, spec interface{}, body string) error {
+	aware, ok := spec.(interfaces.ResponseSchemaAware)
+	if !ok {
+		return nil
+	}
+
+	return ValidateResponse(ctx, kubeClient, aware.GetResponseSchema(), body)
+}
+
+// IsMismatch checks if the error reports a response body not matching its schema.
+func IsMismatch(err error) bool {
+	var mismatch *ValidationError
+	return errors.As(err, &mismatch)
+}
+
+// Load returns the JSON Schema of the source, inline or read from the referenced Secret or ConfigMap key.
+func Load(ctx context.Context, kubeClient client.Client, source *common.ResponseSchema) (string, error) {
+	switch {
+	case source.Inline != "" && source.SecretKeyRef == nil && source.ConfigMapKeyRef == nil:
+		return source.Inline, nil
+	case source.SecretKeyRef != nil && source.Inline == "" && source.ConfigMapKeyRef == nil:
+		ref := source.SecretKeyRef
+		secret, err := kubehandler.GetSecret(ctx, kubeClient, ref.Name, ref.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", errors.Errorf(errSchemaKeyNotFound, ref.Key, "secret", ref.Name, ref.Namespace)
+		}
+
+		return string(value), nil
+	case source.ConfigMapKeyRef != nil && source.Inline == "" && source.SecretKeyRef == nil:
+		ref := source.ConfigMapKeyRef
+		configMap, err := kubehandler.GetConfigMap(ctx, kubeClient, ref.Name, ref.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		if value, ok := configMap.Data[ref.Key]; ok {
+			return value, nil
+		}
+		if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return string(value), nil
+		}
+
+		return "", errors.Errorf(errSchemaKeyNotFound, ref.Key, "configmap", ref.Name, ref.Namespace)
+	default:
+		return "", errors.New(errInvalidSchemaSource)
+	}
+}
+
+// Validate validates the JSON body against the JSON Schema, written in JSON or YAML.
+func Validate(schema string, body string) error {
+	schemaJSON, err := yaml.YAMLToJSON([]byte(schema))
+	if err != nil {
+		return errors.Wrap(err, errInvalidSchema)
+	}
+
+	var parsedSchema spec.Schema
+	if err := json.Unmarshal(schemaJSON, &parsedSchema); err != nil {
+		return errors.Wrap(err, errInvalidSchema)
+	}
+
+	var document interface{}
+	if err := json.Unmarshal([]byte(body), &document); err != nil {
+		return &ValidationError{Path: ".", Message: errBodyNotJSON}
+	}
+
+	result := validate.NewSchemaValidator(&parsedSchema, nil, rootName, strfmt.Default).Validate(document)
+	if result.IsValid() {
+		return nil
+	}
+
+	failures := make([]ValidationError, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		failures = append(failures, toValidationError(err))
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Path != failures[j].Path {
+			return failures[i].Path < failures[j].Path
+		}
+		return failures[i].Message < failures[j].Message
+	})
+
+	first := failures[0]
+	first.Others = len(failures) - 1
+	return &first
+}
+
+// toValidationError converts a validation failure, whose message starts with the name of the failing value under
+// the root name, e.g. 'body.items[0].id in body is required', to its jq path and message.
+func toValidationError(err error) ValidationError {
+	message := err.Error()
+
+	name, rest, found := strings.Cut(message, " in body ")
+	if !found {
+		return ValidationError{Path: ".", Message: message}
+	}
+
+	return ValidationError{Path: jqPath(name), Message: rest}
+}
+
+// jqPath converts the name of a value under the root name to its jq path, e.g. 'body.items[0]' to '.items[0]'.
+func jqPath(name string) string {
+	path := strings.TrimPrefix(name, rootName)
+	if path == "" {
+		return "."
+	}
+	if strings.HasPrefix(path, "[") {
+		return "." + path
+	}
+
+	return path
+}
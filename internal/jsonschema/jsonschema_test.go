@@ -0,0 +1,244 @@
+package jsonschema
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+)
+
+const userSchema = `{
+  "type": "object",
+  "required": ["id", "roles"],
+  "properties": {
+    "id": {"type": "string"},
+    "roles": {"type": "array", "items": {"type": "string", "enum": ["admin", "viewer"]}}
+  }
+}`
+
+const userSchemaYAML = `
+type: object
+required: [id]
+properties:
+  id:
+    type: string
+`
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		schema  string
+		body    string
+		want    *ValidationError
+		wantErr string
+	}{
+		"BodyMatches": {
+			schema: userSchema,
+			body:   `{"id": "123", "roles": ["admin"], "name": "john"}`,
+		},
+		"YAMLSchema": {
+			schema: userSchemaYAML,
+			body:   `{"id": "123"}`,
+		},
+		"MissingRequiredField": {
+			schema: userSchema,
+			body:   `{"id": "123"}`,
+			want:   &ValidationError{Path: ".roles", Message: "is required"},
+		},
+		"WrongNestedType": {
+			schema: userSchema,
+			body:   `{"id": "123", "roles": ["admin", 7]}`,
+			want:   &ValidationError{Path: ".roles[1]", Message: `must be of type string: "number"`, Others: 1},
+		},
+		"SeveralFailures": {
+			schema: userSchema,
+			body:   `{"id": 123, "roles": ["owner"]}`,
+			want:   &ValidationError{Path: ".id", Message: `must be of type string: "number"`, Others: 1},
+		},
+		"WrongRootType": {
+			schema: userSchema,
+			body:   `["123"]`,
+			want:   &ValidationError{Path: ".", Message: `must be of type object: "array"`},
+		},
+		"BodyNotJSON": {
+			schema: userSchema,
+			body:   `<user id="123"/>`,
+			want:   &ValidationError{Path: ".", Message: "body is not valid JSON"},
+		},
+		"InvalidSchema": {
+			schema:  `{"type": `,
+			body:    `{}`,
+			wantErr: errInvalidSchema,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.schema, tc.body)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Validate(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("Validate(...): unexpected error: %v", err)
+				}
+				return
+			}
+
+			got, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Validate(...): want *ValidationError, got %T: %v", err, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Validate(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Path: ".roles[1]", Message: `must be of type string: "integer"`, Others: 2}
+
+	want := `response body does not match responseSchema at .roles[1]: must be of type string: "integer" (and 2 more)`
+	if diff := cmp.Diff(want, err.Error()); diff != "" {
+		t.Errorf("Error(): -want, +got: %s", diff)
+	}
+}
+
+// schemaSpec is a spec validating the body of its responses against a response schema.
+type schemaSpec struct {
+	schema *common.ResponseSchema
+}
+
+func (s schemaSpec) GetResponseSchema() *common.ResponseSchema {
+	return s.schema
+}
+
+func TestCheckResponse(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		spec         interface{}
+		body         string
+		wantErr      bool
+		wantMismatch bool
+	}{
+		"NotAware": {
+			reason: "Should accept any body when the spec does not support a response schema",
+			spec:   struct{}{},
+			body:   `{}`,
+		},
+		"NoSchema": {
+			reason: "Should accept any body when the spec sets no response schema",
+			spec:   schemaSpec{},
+			body:   `{}`,
+		},
+		"Match": {
+			reason: "Should accept a body matching the response schema",
+			spec:   schemaSpec{schema: &common.ResponseSchema{Inline: userSchema}},
+			body:   `{"id": "42", "roles": ["admin"]}`,
+		},
+		"Mismatch": {
+			reason:       "Should report a body not matching the response schema as a mismatch",
+			spec:         schemaSpec{schema: &common.ResponseSchema{Inline: userSchema}},
+			body:         `{"id": 42, "roles": []}`,
+			wantErr:      true,
+			wantMismatch: true,
+		},
+		"InvalidSchema": {
+			reason:  "Should not report an invalid response schema as a mismatch",
+			spec:    schemaSpec{schema: &common.ResponseSchema{Inline: `{"type": `}},
+			body:    `{}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckResponse(context.Background(), nil, tc.spec, tc.body)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nCheckResponse(...): unexpected error: %v", tc.reason, err)
+			}
+			if got := IsMismatch(err); got != tc.wantMismatch {
+				t.Errorf("\n%s\nIsMismatch(...): want %t, got %t", tc.reason, tc.wantMismatch, got)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	kubeClient := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"schema.json": []byte(userSchema)}
+			case *corev1.ConfigMap:
+				o.Data = map[string]string{"schema.yaml": userSchemaYAML}
+			}
+			return nil
+		},
+	}
+
+	cases := map[string]struct {
+		source  *common.ResponseSchema
+		want    string
+		wantErr string
+	}{
+		"Inline": {
+			source: &common.ResponseSchema{Inline: userSchema},
+			want:   userSchema,
+		},
+		"Secret": {
+			source: &common.ResponseSchema{SecretKeyRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "schemas", Namespace: "default"},
+				Key:             "schema.json",
+			}},
+			want: userSchema,
+		},
+		"ConfigMap": {
+			source: &common.ResponseSchema{ConfigMapKeyRef: &common.ConfigMapKeySelector{Name: "schemas", Namespace: "default", Key: "schema.yaml"}},
+			want:   userSchemaYAML,
+		},
+		"MissingKey": {
+			source:  &common.ResponseSchema{ConfigMapKeyRef: &common.ConfigMapKeySelector{Name: "schemas", Namespace: "default", Key: "other.yaml"}},
+			wantErr: "responseSchema key other.yaml not found in configmap schemas:default",
+		},
+		"SeveralSources": {
+			source: &common.ResponseSchema{Inline: userSchema, SecretKeyRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Name: "schemas", Namespace: "default"},
+				Key:             "schema.json",
+			}},
+			wantErr: errInvalidSchemaSource,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Load(context.Background(), kubeClient, tc.source)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("Load(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Load(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
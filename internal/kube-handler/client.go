@@ -16,6 +16,7 @@ import (
 const (
 	errCreateSecret      = "create secret failed"
 	errGetSecret         = "failed to get secret %s:%s"
+	errGetConfigMap      = "failed to get configmap %s:%s"
 	errUpdateFailed      = "update secret failed"
 	errSetOwnerReference = "could not set owner reference to secret"
 )
@@ -35,6 +36,21 @@ func GetSecret(ctx context.Context, kubeClient client.Client, name string, names
 	return secret, nil
 }
 
+// GetConfigMap retrieves a Kubernetes ConfigMap from the cluster.
+func GetConfigMap(ctx context.Context, kubeClient client.Client, name string, namespace string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	err := kubeClient.Get(ctx, client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, configMap)
+
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf(errGetConfigMap, name, namespace))
+	}
+
+	return configMap, nil
+}
+
 // GetOrCreateSecret retrieves a Kubernetes Secret from the cluster. If the secret does not exist, it creates a new one.
 // If the secret exists but has no owner reference, it sets the owner reference and updates the secret.
 func GetOrCreateSecret(ctx context.Context, kubeClient client.Client, name, namespace string, owner metav1.Object) (*corev1.Secret, error) {
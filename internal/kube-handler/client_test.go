@@ -109,6 +109,78 @@ func Test_GetSecret(t *testing.T) {
 	}
 }
 
+func Test_GetConfigMap(t *testing.T) {
+	type args struct {
+		localKube client.Client
+		name      string
+		namespace string
+	}
+	type want struct {
+		result *corev1.ConfigMap
+		err    error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"ShouldGetConfigMap": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						configMap, ok := obj.(*corev1.ConfigMap)
+						if !ok {
+							return errors.New("object is not a ConfigMap")
+						}
+
+						configMap.ObjectMeta = metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}
+						configMap.Data = map[string]string{"body": "payload"}
+						return nil
+					},
+				},
+				name:      "bodies",
+				namespace: "default",
+			},
+			want: want{
+				result: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "bodies",
+					},
+					Data: map[string]string{"body": "payload"},
+				},
+				err: nil,
+			},
+		},
+		"ShouldFail": {
+			args: args{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				name:      "bodies",
+				namespace: "default",
+			},
+			want: want{
+				result: nil,
+				err:    errorspkg.Wrap(errBoom, fmt.Sprintf(errGetConfigMap, "bodies", "default")),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetConfigMap(context.Background(), tc.args.localKube, tc.args.name, tc.args.namespace)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetConfigMap(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("GetConfigMap(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_GetOrCreateSecret(t *testing.T) {
 	type args struct {
 		localKube client.Client
@@ -0,0 +1,192 @@
+// Package metrics exposes Prometheus metrics about the HTTP requests sent by the provider.
+package metrics
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	// MaxResourceLabels is the maximum number of resource label keys that can be projected into metric labels.
+	MaxResourceLabels = 5
+
+	// maxLabelValues bounds the number of distinct values recorded for each projected resource label.
+	// Further values are recorded as overflowLabelValue.
+	maxLabelValues     = 100
+	overflowLabelValue = "__overflow__"
+
+	resourceLabelPrefix = "label_"
+	errorStatusCode     = "error"
+
+	errTooManyResourceLabels = "at most %d resource labels can be projected into metric labels, got %d"
+	errDuplicateLabel        = "resource labels %q and %q map to the same metric label %q"
+	errRegisterCollectors    = "cannot register request metrics"
+)
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Recorder records the HTTP requests sent on behalf of managed resources.
+type Recorder struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+
+	// resourceLabels are the resource label keys projected into metric labels, in metric label order.
+	resourceLabels []string
+
+	mu   sync.Mutex
+	seen []map[string]struct{}
+}
+
+// NewRecorder returns a Recorder projecting the given resource label keys into its metric labels.
+func NewRecorder(resourceLabels []string) (*Recorder, error) {
+	if len(resourceLabels) > MaxResourceLabels {
+		return nil, errors.Errorf(errTooManyResourceLabels, MaxResourceLabels, len(resourceLabels))
+	}
+
+	labelNames := []string{"kind", "method", "code"}
+	sources := map[string]string{}
+	for _, key := range resourceLabels {
+		name := metricLabelName(key)
+		if other, ok := sources[name]; ok {
+			return nil, errors.Errorf(errDuplicateLabel, other, key, name)
+		}
+		sources[name] = key
+		labelNames = append(labelNames, name)
+	}
+
+	seen := make([]map[string]struct{}, len(resourceLabels))
+	for i := range seen {
+		seen[i] = map[string]struct{}{}
+	}
+
+	return &Recorder{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "provider_http_requests_total",
+			Help: "Total number of HTTP requests sent, by resource kind, method and status code.",
+		}, labelNames),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "provider_http_request_duration_seconds",
+			Help:    "Duration of the HTTP requests sent, by resource kind, method and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, labelNames),
+		resourceLabels: resourceLabels,
+		seen:           seen,
+	}, nil
+}
+
+// Collectors returns the Prometheus collectors of the Recorder.
+func (r *Recorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{r.requests, r.duration}
+}
+
+// ObserveRequest records a request sent for a resource of the given kind and labels.
+func (r *Recorder) ObserveRequest(kind, method string, resourceLabels map[string]string, statusCode int, duration time.Duration) {
+	values := []string{kind, method, statusCodeLabel(statusCode)}
+	values = append(values, r.labelValues(resourceLabels)...)
+
+	r.requests.WithLabelValues(values...).Inc()
+	r.duration.WithLabelValues(values...).Observe(duration.Seconds())
+}
+
+// labelValues returns the values of the projected resource labels, capping the distinct values of each label.
+func (r *Recorder) labelValues(resourceLabels map[string]string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := make([]string, len(r.resourceLabels))
+	for i, key := range r.resourceLabels {
+		value := resourceLabels[key]
+		if _, ok := r.seen[i][value]; !ok {
+			if len(r.seen[i]) >= maxLabelValues {
+				value = overflowLabelValue
+			} else {
+				r.seen[i][value] = struct{}{}
+			}
+		}
+		values[i] = value
+	}
+
+	return values
+}
+
+var (
+	defaultRecorderMu sync.RWMutex
+	defaultRecorder   *Recorder
+)
+
+// Setup creates the provider Recorder projecting the given resource label keys and registers it.
+func Setup(registry prometheus.Registerer, resourceLabels []string) error {
+	r, err := NewRecorder(resourceLabels)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range r.Collectors() {
+		if err := registry.Register(c); err != nil {
+			return errors.Wrap(err, errRegisterCollectors)
+		}
+	}
+
+	defaultRecorderMu.Lock()
+	defer defaultRecorderMu.Unlock()
+	defaultRecorder = r
+
+	return nil
+}
+
+// InstrumentClient wraps the Http client so every request it sends is recorded for the given resource.
+// The client is returned unchanged when metrics were not set up.
+func InstrumentClient(client httpClient.Client, kind string, resourceLabels map[string]string) httpClient.Client {
+	defaultRecorderMu.RLock()
+	defer defaultRecorderMu.RUnlock()
+
+	if defaultRecorder == nil {
+		return client
+	}
+
+	return &instrumentedClient{
+		client:         client,
+		recorder:       defaultRecorder,
+		kind:           kind,
+		resourceLabels: resourceLabels,
+	}
+}
+
+// instrumentedClient is an Http client recording the requests it sends.
+type instrumentedClient struct {
+	client         httpClient.Client
+	recorder       *Recorder
+	kind           string
+	resourceLabels map[string]string
+}
+
+// SendRequest sends the request with the wrapped client and records it.
+func (c *instrumentedClient) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+	start := time.Now()
+	details, err := c.client.SendRequest(ctx, method, url, body, headers, tlsConfigData)
+	c.recorder.ObserveRequest(c.kind, method, c.resourceLabels, details.HttpResponse.StatusCode, time.Since(start))
+
+	return details, err
+}
+
+// metricLabelName converts a resource label key, such as app.kubernetes.io/team, to a valid metric label name.
+func metricLabelName(key string) string {
+	return resourceLabelPrefix + invalidLabelChars.ReplaceAllString(key, "_")
+}
+
+// statusCodeLabel returns the status code label value, requests that got no response are recorded as errors.
+func statusCodeLabel(statusCode int) string {
+	if statusCode == 0 {
+		return errorStatusCode
+	}
+
+	return strconv.Itoa(statusCode)
+}
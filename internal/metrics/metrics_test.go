@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+type mockHttpClient struct {
+	statusCode int
+	err        error
+}
+
+func (c *mockHttpClient) SendRequest(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+	return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: c.statusCode}}, c.err
+}
+
+func TestNewRecorder(t *testing.T) {
+	cases := map[string]struct {
+		resourceLabels []string
+		err            error
+	}{
+		"NoResourceLabels": {
+			resourceLabels: nil,
+		},
+		"WithinCap": {
+			resourceLabels: []string{"team", "env", "app.kubernetes.io/part-of"},
+		},
+		"TooManyResourceLabels": {
+			resourceLabels: []string{"a", "b", "c", "d", "e", "f"},
+			err:            errors.Errorf(errTooManyResourceLabels, MaxResourceLabels, 6),
+		},
+		"DuplicateMetricLabel": {
+			resourceLabels: []string{"team.name", "team/name"},
+			err:            errors.Errorf(errDuplicateLabel, "team.name", "team/name", "label_team_name"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRecorder(tc.resourceLabels)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("NewRecorder(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveRequest(t *testing.T) {
+	r, err := NewRecorder([]string{"team", "app.kubernetes.io/env"})
+	if err != nil {
+		t.Fatalf("NewRecorder(...): unexpected error: %v", err)
+	}
+
+	labels := map[string]string{"team": "payments", "app.kubernetes.io/env": "prod", "ignored": "value"}
+	r.ObserveRequest("Request", "GET", labels, 200, time.Second)
+	r.ObserveRequest("Request", "GET", labels, 200, time.Second)
+	r.ObserveRequest("Request", "POST", map[string]string{}, 0, time.Second)
+
+	if got := testutil.ToFloat64(r.requests.WithLabelValues("Request", "GET", "200", "payments", "prod")); got != 2 {
+		t.Errorf("ObserveRequest(...): requests with resource labels = %v, want 2", got)
+	}
+
+	if got := testutil.ToFloat64(r.requests.WithLabelValues("Request", "POST", errorStatusCode, "", "")); got != 1 {
+		t.Errorf("ObserveRequest(...): failed requests without resource labels = %v, want 1", got)
+	}
+}
+
+func TestLabelValuesCardinalityCap(t *testing.T) {
+	r, err := NewRecorder([]string{"team"})
+	if err != nil {
+		t.Fatalf("NewRecorder(...): unexpected error: %v", err)
+	}
+
+	for i := 0; i < maxLabelValues; i++ {
+		r.labelValues(map[string]string{"team": fmt.Sprintf("team-%d", i)})
+	}
+
+	cases := map[string]struct {
+		value string
+		want  []string
+	}{
+		"KnownValueKept": {
+			value: "team-0",
+			want:  []string{"team-0"},
+		},
+		"NewValueOverflows": {
+			value: "team-new",
+			want:  []string{overflowLabelValue},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := r.labelValues(map[string]string{"team": tc.value})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("labelValues(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestInstrumentClient(t *testing.T) {
+	if got := InstrumentClient(&mockHttpClient{}, "Request", nil); got == nil {
+		t.Fatalf("InstrumentClient(...): expected client, got nil")
+	} else if _, ok := got.(*instrumentedClient); ok {
+		t.Fatalf("InstrumentClient(...): expected unwrapped client when metrics are not set up")
+	}
+
+	registry := prometheus.NewRegistry()
+	if err := Setup(registry, []string{"team"}); err != nil {
+		t.Fatalf("Setup(...): unexpected error: %v", err)
+	}
+	defer func() { defaultRecorder = nil }()
+
+	errBoom := errors.New("boom")
+	c := InstrumentClient(&mockHttpClient{statusCode: 503, err: errBoom}, "DisposableRequest", map[string]string{"team": "payments"})
+	_, err := c.SendRequest(context.Background(), "POST", "http://example", httpClient.Data{}, httpClient.Data{}, nil)
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("SendRequest(...): -want error, +got error: %s", diff)
+	}
+
+	if got := testutil.ToFloat64(defaultRecorder.requests.WithLabelValues("DisposableRequest", "POST", "503", "payments")); got != 1 {
+		t.Errorf("SendRequest(...): recorded requests = %v, want 1", got)
+	}
+}
@@ -17,6 +17,16 @@ type ServiceContext struct {
 	Logger        logging.Logger
 	HTTP          httpClient.Client
 	TLSConfigData *httpClient.TLSConfigData
+
+	// BaseURL is the base URL of the ProviderConfig, exposed to the templates.
+	BaseURL string
+
+	// DefaultHeaders are the default headers of the ProviderConfig, merged into the headers of every request.
+	DefaultHeaders map[string][]string
+
+	// RequestRefs caches the statuses of the referenced Requests exposed to the templates by name, so each
+	// one is read once per reconciliation.
+	RequestRefs map[string]interface{}
 }
 
 // NewServiceContext creates a new ServiceContext with the provided dependencies.
@@ -29,3 +39,15 @@ func NewServiceContext(ctx context.Context, localKube client.Client, logger logg
 		TLSConfigData: tlsConfigData,
 	}
 }
+
+// WithBaseURL sets the base URL of the ProviderConfig exposed to the templates.
+func (s *ServiceContext) WithBaseURL(baseURL string) *ServiceContext {
+	s.BaseURL = baseURL
+	return s
+}
+
+// WithDefaultHeaders sets the default headers of the ProviderConfig merged into the headers of every request.
+func (s *ServiceContext) WithDefaultHeaders(headers map[string][]string) *ServiceContext {
+	s.DefaultHeaders = headers
+	return s
+}
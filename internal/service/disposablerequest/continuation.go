@@ -0,0 +1,117 @@
+package disposablerequest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+)
+
+const (
+	errExtractContinuation = "failed to extract the continuation token"
+)
+
+// continuationPlaceholder matches the {{ .continuation }} placeholders replaced with the continuation token.
+var continuationPlaceholder = regexp.MustCompile(`\{\{\s*\.continuation\s*\}\}`)
+
+// continuationOf returns the continuation token extracted from the last expected response of the request, or an
+// empty string.
+func continuationOf(cr interface{}) string {
+	if reader, ok := cr.(interfaces.ContinuationReader); ok {
+		return reader.GetContinuation()
+	}
+
+	return ""
+}
+
+// HasContinuation checks if the last expected response of the request returned a continuation token, so the
+// request is sent again to fetch the next page.
+func HasContinuation(cr interface{}) bool {
+	return continuationOf(cr) != ""
+}
+
+// injectContinuation replaces the {{ .continuation }} placeholders of the value with the token.
+func injectContinuation(value, token string) string {
+	return continuationPlaceholder.ReplaceAllLiteralString(value, token)
+}
+
+// continuationForBody returns the token to inject into the body, escaped as the content of a JSON string if the
+// body is JSON, so a token holding quotes or backslashes does not break the body.
+func continuationForBody(body, token string) string {
+	if !json.Valid([]byte(body)) {
+		return token
+	}
+
+	var escaped bytes.Buffer
+	encoder := json.NewEncoder(&escaped)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(token); err != nil {
+		return token
+	}
+
+	// The encoded token is quoted and followed by a newline.
+	encoded := strings.TrimSuffix(escaped.String(), "\n")
+	return encoded[1 : len(encoded)-1]
+}
+
+// injectContinuationIntoURL replaces the {{ .continuation }} placeholders of the URL with the escaped token, so
+// a token holding reserved characters can be sent as a query parameter.
+func injectContinuationIntoURL(rawURL, token string) string {
+	return injectContinuation(rawURL, url.QueryEscape(token))
+}
+
+// injectContinuationIntoHeaders returns a copy of the headers with the {{ .continuation }} placeholders of their
+// values replaced with the token.
+func injectContinuationIntoHeaders(headers map[string][]string, token string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	injected := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		injected[key] = make([]string, len(values))
+		for i, value := range values {
+			injected[key][i] = injectContinuation(value, token)
+		}
+	}
+
+	return injected
+}
+
+// extractContinuation evaluates the continuationJQ of the spec against the response, and returns the continuation
+// token, or an empty string when the filter returns null or an empty string. It returns false if the spec does not
+// extract a continuation token.
+func extractContinuation(spec interfaces.SimpleHTTPRequestSpec, res httpClient.HttpResponse, elapsed time.Duration) (string, bool, error) {
+	aware, ok := spec.(interfaces.ContinuationAware)
+	if !ok || aware.GetContinuationJQ() == "" {
+		return "", false, nil
+	}
+
+	responseMap, err := responseInput(spec, res, elapsed)
+	if err != nil {
+		return "", true, err
+	}
+
+	token, err := jq.Parse(aware.GetContinuationJQ(), responseMap)
+	if err != nil {
+		return "", true, errors.Wrap(err, errExtractContinuation)
+	}
+
+	switch token := token.(type) {
+	case nil:
+		return "", true, nil
+	case string:
+		return token, true, nil
+	default:
+		return fmt.Sprint(token), true, nil
+	}
+}
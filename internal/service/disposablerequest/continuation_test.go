@@ -0,0 +1,164 @@
+package disposablerequest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// sentRequest is a request received by the mock client.
+type sentRequest struct {
+	url    string
+	body   string
+	cursor string
+}
+
+func TestDeployActionContinuation(t *testing.T) {
+	pages := []string{`{"items": [1, 2], "next": "page 2+"}`, `{"items": [3], "next": 3}`, `{"items": [], "next": null}`}
+
+	var sent []sentRequest
+	client := &MockHttpClient{
+		MockSendRequest: func(_ context.Context, _ string, url string, body httpClient.Data, headers httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+			sent = append(sent, sentRequest{url: url, body: body.Decrypted.(string), cursor: headers.Decrypted.(map[string][]string)["X-Cursor"][0]})
+			return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: pages[len(sent)-1]}}, nil
+		},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), client, nil)
+
+	dr := disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+		dr.Spec.ForProvider.URL = "http://queue.example/messages?cursor={{ .continuation }}"
+		dr.Spec.ForProvider.Body = `{"after": "{{.continuation}}"}`
+		dr.Spec.ForProvider.Headers = map[string][]string{"X-Cursor": {"{{ .continuation }}"}}
+		dr.Spec.ForProvider.ContinuationJQ = ".body.next"
+	})
+
+	var gotContinuations []string
+	for range pages {
+		if err := DeployAction(svcCtx, service.NewDisposableRequestCRContext(dr)); err != nil {
+			t.Fatalf("DeployAction(...): unexpected error: %v", err)
+		}
+		gotContinuations = append(gotContinuations, dr.Status.Continuation)
+
+		// The request is sent again on the next reconcile while it has a next page to fetch.
+		if !HasContinuation(dr) {
+			break
+		}
+		dr.Status.Synced = false
+	}
+
+	want := []sentRequest{
+		{url: "http://queue.example/messages?cursor=", body: `{"after": ""}`, cursor: ""},
+		{url: "http://queue.example/messages?cursor=page+2%2B", body: `{"after": "page 2+"}`, cursor: "page 2+"},
+		{url: "http://queue.example/messages?cursor=3", body: `{"after": "3"}`, cursor: "3"},
+	}
+	if diff := cmp.Diff(want, sent, cmp.AllowUnexported(sentRequest{})); diff != "" {
+		t.Errorf("DeployAction(...): -want sent requests, +got sent requests:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"page 2+", "3", ""}, gotContinuations); diff != "" {
+		t.Errorf("DeployAction(...): -want continuations, +got continuations:\n%s", diff)
+	}
+	if dr.Spec.ForProvider.Headers["X-Cursor"][0] != "{{ .continuation }}" {
+		t.Errorf("DeployAction(...): want the headers of the spec untouched, got %v", dr.Spec.ForProvider.Headers)
+	}
+}
+
+func TestDeployActionContinuationInvalid(t *testing.T) {
+	client := &MockHttpClient{
+		MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+			return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"next": "abc"}`}}, nil
+		},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), client, nil)
+
+	dr := disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+		dr.Spec.ForProvider.ContinuationJQ = ".body.next | error"
+		dr.Status.Continuation = "previous"
+	})
+
+	// A response whose continuation token cannot be extracted counts as a failed attempt, and keeps the last token.
+	if err := DeployAction(svcCtx, service.NewDisposableRequestCRContext(dr)); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+	if dr.Status.Synced || dr.Status.Failed != 1 || dr.Status.Continuation != "previous" {
+		t.Errorf("DeployAction(...): want a failed attempt keeping the continuation, got synced %t, failed %d and continuation %q", dr.Status.Synced, dr.Status.Failed, dr.Status.Continuation)
+	}
+}
+
+func TestDeployActionContinuationNotPatched(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		continuation string
+		wantBody     string
+		wantCursor   string
+	}{
+		"SecretReference": {
+			reason:       "Should send a token holding a secret reference as is instead of the value of the secret",
+			continuation: "{{db-credentials:default:password}}",
+			wantBody:     `{"after": "{{db-credentials:default:password}}", "token": "s3cr3t"}`,
+			wantCursor:   "{{db-credentials:default:password}}",
+		},
+		"JSONEscaped": {
+			reason:       "Should escape a token holding quotes and backslashes in a JSON body",
+			continuation: `a"b\c<d>`,
+			wantBody:     `{"after": "a\"b\\c<d>", "token": "s3cr3t"}`,
+			wantCursor:   `a"b\c<d>`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var sent sentRequest
+			mockHttp := &MockHttpClient{
+				MockSendRequest: func(_ context.Context, _ string, url string, body httpClient.Data, headers httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+					sent = sentRequest{url: url, body: body.Decrypted.(string), cursor: headers.Decrypted.(map[string][]string)["X-Cursor"][0]}
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{}`}}, nil
+				},
+			}
+			localKube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if secret, ok := obj.(*corev1.Secret); ok {
+						secret.Data = map[string][]byte{"password": []byte("s3cr3t")}
+					}
+					return nil
+				}),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), mockHttp, nil)
+
+			dr := disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+				dr.Spec.ForProvider.Body = `{"after": "{{ .continuation }}", "token": "{{db-credentials:default:password}}"}`
+				dr.Spec.ForProvider.Headers = map[string][]string{"X-Cursor": {"{{ .continuation }}"}}
+				dr.Spec.ForProvider.ContinuationJQ = ".body.next"
+				dr.Status.Continuation = tc.continuation
+			})
+
+			if err := DeployAction(svcCtx, service.NewDisposableRequestCRContext(dr)); err != nil {
+				t.Fatalf("\n%s\nDeployAction(...): unexpected error: %v", tc.reason, err)
+			}
+			if sent.body != tc.wantBody {
+				t.Errorf("\n%s\nDeployAction(...): want the body %s, got %s", tc.reason, tc.wantBody, sent.body)
+			}
+			if sent.cursor != tc.wantCursor {
+				t.Errorf("\n%s\nDeployAction(...): want the header %q, got %q", tc.reason, tc.wantCursor, sent.cursor)
+			}
+		})
+	}
+}
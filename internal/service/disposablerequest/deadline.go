@@ -0,0 +1,50 @@
+package disposablerequest
+
+import (
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+// remainingDeadline returns the time left before the deadline of the attempts of the request passes, and whether
+// its spec sets one. The whole deadline is left before the request is first sent.
+func remainingDeadline(cr interface{}, spec interfaces.SimpleHTTPRequestSpec, now time.Time) (time.Duration, bool) {
+	aware, ok := spec.(interfaces.DeadlineAware)
+	if !ok || aware.GetDeadline() == 0 {
+		return 0, false
+	}
+
+	return aware.GetDeadline() - elapsedSince(cr, now), true
+}
+
+// AttemptTimeout returns the timeout of an attempt of the request, cut down to the time left before its deadline.
+// A deadline that already passed leaves the timeout unchanged, since no attempt is sent anymore, except for a
+// forced retry.
+func AttemptTimeout(cr interface{}, spec interfaces.SimpleHTTPRequestSpec, timeout time.Duration, now time.Time) time.Duration {
+	if remaining, ok := remainingDeadline(cr, spec, now); ok && remaining > 0 && remaining < timeout {
+		return remaining
+	}
+
+	return timeout
+}
+
+// isDeadlineExceeded checks if a failed request is not retried anymore because its deadline passed, or because the
+// delay requested by the Retry-After header of its last response ends after it.
+func isDeadlineExceeded(crCtx *service.DisposableRequestCRContext, now time.Time) bool {
+	if crCtx.Status().GetFailed() == 0 {
+		return false
+	}
+
+	remaining, ok := remainingDeadline(crCtx.GetCR(), crCtx.Spec(), now)
+	if !ok {
+		return false
+	}
+
+	var wait time.Duration
+	if reader, ok := crCtx.GetCR().(interfaces.RetryAfterReader); ok {
+		wait = reader.GetRetryAfterWait(now)
+	}
+
+	return remaining <= 0 || wait >= remaining
+}
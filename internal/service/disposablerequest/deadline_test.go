@@ -0,0 +1,160 @@
+package disposablerequest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// withDeadline sets the deadline of the request, and the time it was first sent if it was.
+func withDeadline(deadline time.Duration, sentAgo time.Duration, failed int32) func(*v1alpha2.DisposableRequest) {
+	return func(dr *v1alpha2.DisposableRequest) {
+		limit := int32(10)
+		dr.Spec.ForProvider.RollbackRetriesLimit = &limit
+		dr.Spec.ForProvider.Deadline = &v1.Duration{Duration: deadline}
+		dr.Status.Failed = failed
+		if sentAgo != 0 {
+			start := v1.NewTime(time.Now().Add(-sentAgo))
+			dr.Status.StartTime = &start
+			dr.Status.LastReconcileTime = v1.Now()
+		}
+	}
+}
+
+func TestDeployActionDeadline(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		dr       *v1alpha2.DisposableRequest
+		wantSent bool
+	}{
+		"FirstAttempt": {
+			reason:   "Should send the first attempt of a request with a deadline",
+			dr:       disposableRequest(withDeadline(time.Minute, 0, 0)),
+			wantSent: true,
+		},
+		"WithinDeadline": {
+			reason:   "Should retry a failed request before its deadline",
+			dr:       disposableRequest(withDeadline(time.Minute, 10*time.Second, 1)),
+			wantSent: true,
+		},
+		"DeadlinePassed": {
+			reason: "Should not retry a failed request once its deadline passed",
+			dr:     disposableRequest(withDeadline(time.Minute, 2*time.Minute, 1)),
+		},
+		"RetryAfterBeyondDeadline": {
+			reason: "Should not wait for a Retry-After delay ending after the deadline",
+			dr: disposableRequest(withDeadline(time.Minute, 10*time.Second, 1), func(dr *v1alpha2.DisposableRequest) {
+				dr.Status.RetryAfter = &v1.Duration{Duration: 5 * time.Minute}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := 0
+			client := &MockHttpClient{
+				MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+					sent++
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable}}, nil
+				},
+			}
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), client, nil)
+
+			// A failed attempt is reported as an error.
+			if err := DeployAction(svcCtx, service.NewDisposableRequestCRContext(tc.dr)); (err != nil) != tc.wantSent {
+				t.Fatalf("\n%s\nDeployAction(...): want a failed attempt %t, got error %v", tc.reason, tc.wantSent, err)
+			}
+			if (sent == 1) != tc.wantSent {
+				t.Errorf("\n%s\nDeployAction(...): want sent %t, got %d requests", tc.reason, tc.wantSent, sent)
+			}
+			if got := IsRetriesExhausted(service.NewDisposableRequestCRContext(tc.dr)); got == tc.wantSent {
+				t.Errorf("\n%s\nIsRetriesExhausted(...): want %t, got %t", tc.reason, !tc.wantSent, got)
+			}
+		})
+	}
+}
+
+func TestDeployActionDeadlineStopsRetries(t *testing.T) {
+	dr := disposableRequest(withDeadline(time.Minute, 0, 0))
+	sent := 0
+	client := &MockHttpClient{
+		MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+			sent++
+			return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusInternalServerError}}, nil
+		},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), client, nil)
+
+	// Every reconcile happens 25s after the previous one, so the fourth one is past the deadline of a minute.
+	for range 6 {
+		_ = DeployAction(svcCtx, service.NewDisposableRequestCRContext(dr))
+		start := v1.NewTime(dr.Status.StartTime.Add(-25 * time.Second))
+		dr.Status.StartTime = &start
+	}
+
+	if sent != 3 {
+		t.Errorf("DeployAction(...): want 3 attempts within the deadline, got %d", sent)
+	}
+	if dr.Status.Failed != 3 || !IsRetriesExhausted(service.NewDisposableRequestCRContext(dr)) {
+		t.Errorf("DeployAction(...): want the retries exhausted after 3 failures, got %d failures", dr.Status.Failed)
+	}
+}
+
+func TestAttemptTimeout(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		dr     *v1alpha2.DisposableRequest
+		want   time.Duration
+	}{
+		"NoDeadline": {
+			reason: "Should keep the timeout of a request without a deadline",
+			dr:     disposableRequest(),
+			want:   5 * time.Minute,
+		},
+		"NotSent": {
+			reason: "Should cut the timeout down to the deadline before the request is first sent",
+			dr:     disposableRequest(withDeadline(time.Minute, 0, 0)),
+			want:   time.Minute,
+		},
+		"TimeLeft": {
+			reason: "Should cut the timeout down to the time left before the deadline",
+			dr:     disposableRequest(withDeadline(time.Minute, 40*time.Second, 1)),
+			want:   20 * time.Second,
+		},
+		"DeadlineFarAway": {
+			reason: "Should keep a timeout shorter than the time left",
+			dr:     disposableRequest(withDeadline(time.Hour, 40*time.Second, 1)),
+			want:   5 * time.Minute,
+		},
+		"DeadlinePassed": {
+			reason: "Should keep the timeout once the deadline passed",
+			dr:     disposableRequest(withDeadline(time.Minute, 2*time.Minute, 1)),
+			want:   5 * time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := AttemptTimeout(tc.dr, &tc.dr.Spec.ForProvider, 5*time.Minute, time.Now())
+			if got.Round(time.Second) != tc.want {
+				t.Errorf("\n%s\nAttemptTimeout(...): want %s, got %s", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -2,11 +2,12 @@ package disposablerequest
 
 import (
 	"fmt"
-	"strconv"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jsonschema"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/pkg/errors"
@@ -35,7 +36,25 @@ func DeployAction(svcCtx *service.ServiceContext, crCtx *service.DisposableReque
 		return nil
 	}
 
-	details, httpRequestErr := sendHttpRequest(svcCtx, spec)
+	// Check if the last attempt failed with a status code that should not be retried
+	if isTerminalFailure(status, rollbackPolicy) {
+		svcCtx.Logger.Debug("Last response status code is not retryable, not retrying anymore")
+		return nil
+	}
+
+	// Check if the last attempt failed because the host does not resolve
+	if isDNSFailure(crCtx.GetCR()) {
+		svcCtx.Logger.Debug("Host of the last attempt does not resolve, not retrying anymore")
+		return nil
+	}
+
+	// Check if the deadline of the attempts passed
+	if isDeadlineExceeded(crCtx, time.Now()) {
+		svcCtx.Logger.Debug("Deadline passed, not retrying anymore")
+		return nil
+	}
+
+	details, httpRequestErr := sendHttpRequest(svcCtx, spec, continuationOf(crCtx.GetCR()))
 
 	resource, err := prepareRequestResource(svcCtx, crCtx, details)
 	if err != nil {
@@ -44,27 +63,43 @@ func DeployAction(svcCtx *service.ServiceContext, crCtx *service.DisposableReque
 
 	// Handle HTTP request errors first
 	if httpRequestErr != nil {
-		return handleHttpRequestError(resource, httpRequestErr)
+		return handleHttpRequestError(crCtx, resource, httpRequestErr)
 	}
 
 	return handleHttpResponse(svcCtx, crCtx, details.HttpResponse, resource)
 }
 
-// sendHttpRequest sends the HTTP request with sensitive data patched
-func sendHttpRequest(svcCtx *service.ServiceContext, spec interfaces.SimpleHTTPRequestSpec) (httpClient.HttpDetails, error) {
+// isTerminalFailure checks if the last attempt failed with an HTTP error status code that is not retryable.
+func isTerminalFailure(status interfaces.DisposableRequestStatusReader, rollbackPolicy interfaces.RollbackAware) bool {
+	response := status.GetResponse()
+	if status.GetFailed() == 0 || response == nil || !utils.IsHTTPError(response.GetStatusCode()) {
+		return false
+	}
+
+	return !utils.IsRetryableStatusCode(rollbackPolicy.GetRetryableStatusCodes(), response.GetStatusCode())
+}
+
+// sendHttpRequest sends the HTTP request with sensitive data patched, and the continuation token of the last
+// expected response in place of the {{ .continuation }} placeholders. The token is injected once the secrets are
+// patched, so a token returned by the server is never resolved as a secret reference.
+func sendHttpRequest(svcCtx *service.ServiceContext, spec interfaces.SimpleHTTPRequestSpec, continuation string) (httpClient.HttpDetails, error) {
 	sensitiveBody, err := datapatcher.PatchSecretsIntoString(svcCtx.Ctx, svcCtx.LocalKube, spec.GetBody(), svcCtx.Logger)
 	if err != nil {
 		return httpClient.HttpDetails{}, err
 	}
+	bodyToken := continuationForBody(spec.GetBody(), continuation)
+	body, sensitiveBody := injectContinuation(spec.GetBody(), bodyToken), injectContinuation(sensitiveBody, bodyToken)
 
-	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(svcCtx.Ctx, svcCtx.LocalKube, spec.GetHeaders(), svcCtx.Logger)
+	headers := httpClient.MergeDefaultHeaders(svcCtx.DefaultHeaders, spec.GetHeaders())
+	sensitiveHeaders, err := datapatcher.PatchSecretsIntoHeaders(svcCtx.Ctx, svcCtx.LocalKube, headers, svcCtx.Logger)
 	if err != nil {
 		return httpClient.HttpDetails{}, err
 	}
+	headers, sensitiveHeaders = injectContinuationIntoHeaders(headers, continuation), injectContinuationIntoHeaders(sensitiveHeaders, continuation)
 
-	bodyData := httpClient.Data{Encrypted: spec.GetBody(), Decrypted: sensitiveBody}
-	headersData := httpClient.Data{Encrypted: spec.GetHeaders(), Decrypted: sensitiveHeaders}
-	details, err := svcCtx.HTTP.SendRequest(svcCtx.Ctx, spec.GetMethod(), spec.GetURL(), bodyData, headersData, svcCtx.TLSConfigData)
+	bodyData := httpClient.Data{Encrypted: body, Decrypted: sensitiveBody}
+	headersData := httpClient.Data{Encrypted: headers, Decrypted: sensitiveHeaders}
+	details, err := svcCtx.HTTP.SendRequest(eventStreamContext(svcCtx.Ctx, spec), spec.GetMethod(), injectContinuationIntoURL(spec.GetURL(), continuation), bodyData, headersData, svcCtx.TLSConfigData)
 
 	return details, err
 }
@@ -79,6 +114,7 @@ func prepareRequestResource(svcCtx *service.ServiceContext, crCtx *service.Dispo
 		HttpResponse:   details.HttpResponse,
 		LocalClient:    svcCtx.LocalKube,
 		HttpRequest:    details.HttpRequest,
+		StoredHeaders:  utils.StoredHeadersConfig(crCtx.Spec()),
 	}
 
 	// Get the latest version of the resource before updating
@@ -105,36 +141,108 @@ func handleHttpResponse(svcCtx *service.ServiceContext, crCtx *service.Disposabl
 }
 
 // handleHttpRequestError handles cases where the HTTP request itself failed
-func handleHttpRequestError(resource *utils.RequestResource, httpRequestErr error) error {
-	setErr := resource.SetError(httpRequestErr)
-	if settingError := utils.SetRequestResourceStatus(*resource, setErr, resource.SetLastReconcileTime(), resource.SetRequestDetails()); settingError != nil {
+// A request canceled with the reconcile is retried on the next reconcile without counting as a failed attempt, and
+// a request whose host does not resolve is not retried anymore unless the spec retries DNS failures.
+func handleHttpRequestError(crCtx *service.DisposableRequestCRContext, resource *utils.RequestResource, httpRequestErr error) error {
+	if httpClient.IsCanceled(httpRequestErr) {
+		return httpRequestErr
+	}
+
+	statusFuncs := []utils.SetRequestStatusFunc{resource.SetError(httpRequestErr), resource.SetLastReconcileTime(), resource.SetStartTime(), resource.SetRequestDetails(), resource.ClearRetryAfter()}
+	if isTerminalDNSFailure(crCtx.Spec(), httpRequestErr) {
+		statusFuncs = append(statusFuncs, markDNSFailure(crCtx, httpRequestErr))
+	}
+
+	if settingError := utils.SetRequestResourceStatus(*resource, statusFuncs...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 	return httpRequestErr
 }
 
 // handleHttpErrorStatus handles HTTP error status codes
+// The error quotes the error body of the response, unless a possible clock skew explains the failure better.
 func handleHttpErrorStatus(spec interfaces.SimpleHTTPRequestSpec, resource *utils.RequestResource) error {
-	if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetRequestDetails(), resource.SetError(nil)); settingError != nil {
+	clockSkewErr := utils.DetectClockSkew(resource.HttpResponse, time.Now())
+	statusErr := utils.StatusCodeError(spec.GetMethod(), resource.HttpResponse)
+	failure := statusErr
+	if clockSkewErr != nil {
+		failure = clockSkewErr
+	}
+
+	if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetStartTime(), resource.SetHeaders(), resource.SetBody(), resource.SetTrailers(), resource.SetStatusText(), resource.SetTiming(), resource.SetRequestDetails(), resource.SetError(failure), resource.SetRetryAfter(maxRetryAfter(spec))); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
-	return errors.Errorf(utils.ErrStatusCode, spec.GetMethod(), strconv.Itoa(resource.HttpResponse.StatusCode))
+	if clockSkewErr != nil {
+		return errors.Wrap(clockSkewErr, statusErr.Error())
+	}
+
+	return statusErr
+}
+
+// maxRetryAfter returns the maximum delay waited for the Retry-After header of a response, or zero if the spec
+// does not cap it.
+func maxRetryAfter(spec interfaces.SimpleHTTPRequestSpec) time.Duration {
+	if aware, ok := spec.(interfaces.RetryAfterAware); ok {
+		return aware.GetMaxRetryAfter()
+	}
+
+	return 0
 }
 
 // handleResponseValidation validates the response and updates status accordingly
 func handleResponseValidation(svcCtx *service.ServiceContext, spec interfaces.SimpleHTTPRequestSpec, rollbackPolicy interfaces.RollbackAware, sensitiveResponse httpClient.HttpResponse, resource *utils.RequestResource, obj metav1.Object) error {
-	isExpectedResponse, err := IsResponseAsExpected(spec, sensitiveResponse)
+	if err := CheckResponseGuards(spec, sensitiveResponse); err != nil {
+		// A body exceeding maxBodyBytes is only recorded up to the limit, so it does not blow up the status.
+		resource.HttpResponse.Body = truncateBody(spec, resource.HttpResponse.Body)
+		return setUnexpectedResponseStatus(resource, err)
+	}
+
+	if err := jsonschema.CheckResponse(svcCtx.Ctx, svcCtx.LocalKube, spec, sensitiveResponse.Body); err != nil {
+		if !jsonschema.IsMismatch(err) {
+			return err
+		}
+		return setUnexpectedResponseStatus(resource, err)
+	}
+
+	multiStatus, err := EvaluateMultiStatus(spec, sensitiveResponse)
+	if err != nil {
+		return err
+	}
+
+	setMultiStatus := resource.SetMultiStatus(multiStatus)
+	if err := multiStatusError(multiStatus); err != nil {
+		return setUnexpectedResponseStatus(resource, err, setMultiStatus)
+	}
+
+	isExpectedResponse, err := IsResponseAsExpected(spec, sensitiveResponse, elapsedSince(obj, time.Now()))
 	if err != nil {
 		return err
 	}
 
 	if isExpectedResponse {
+		statusFuncs := []utils.SetRequestStatusFunc{resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetStartTime(), resource.SetHeaders(), resource.SetBody(), resource.SetTrailers(), resource.SetStatusText(), resource.SetTiming(), resource.SetSynced(), resource.SetRequestDetails(), setMultiStatus, resource.ClearRetryAfter()}
+
+		continuation, ok, err := extractContinuation(spec, sensitiveResponse, elapsedSince(obj, time.Now()))
+		if err != nil {
+			return setUnexpectedResponseStatus(resource, err, setMultiStatus)
+		}
+		if ok {
+			statusFuncs = append(statusFuncs, resource.SetContinuation(continuation))
+		}
+
 		datapatcher.ApplyResponseDataToSecrets(svcCtx.Ctx, svcCtx.LocalKube, svcCtx.Logger, &resource.HttpResponse, spec.GetSecretInjectionConfigs(), obj)
-		return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(), resource.SetSynced(), resource.SetRequestDetails())
+		return utils.SetRequestResourceStatus(*resource, statusFuncs...)
 	}
 
 	limit := utils.GetRollbackRetriesLimit(rollbackPolicy.GetRollbackRetriesLimit())
-	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetHeaders(), resource.SetBody(),
-		resource.SetError(errors.New(errResponseFormat+fmt.Sprint(limit))), resource.SetRequestDetails())
+	return setUnexpectedResponseStatus(resource, errors.New(errResponseFormat+fmt.Sprint(limit)), setMultiStatus)
+}
+
+// setUnexpectedResponseStatus records the response and counts the attempt as failed with the given reason.
+func setUnexpectedResponseStatus(resource *utils.RequestResource, reason error, extraStatusFuncs ...utils.SetRequestStatusFunc) error {
+	statusFuncs := []utils.SetRequestStatusFunc{resource.SetStatusCode(), resource.SetLastReconcileTime(), resource.SetStartTime(), resource.SetHeaders(), resource.SetBody(), resource.SetTrailers(), resource.SetStatusText(), resource.SetTiming(),
+		resource.SetError(reason), resource.SetRequestDetails(), resource.ClearRetryAfter()}
+
+	return utils.SetRequestResourceStatus(*resource, append(statusFuncs, extraStatusFuncs...)...)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/service"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -58,6 +60,7 @@ func disposableRequest(modifiers ...func(*v1alpha2.DisposableRequest)) *v1alpha2
 
 func TestDeployAction(t *testing.T) {
 	errBoom := errors.New("boom")
+	errCanceled := &httpClient.CanceledError{Err: context.DeadlineExceeded}
 
 	type args struct {
 		ctx        context.Context
@@ -111,6 +114,55 @@ func TestDeployAction(t *testing.T) {
 				err: nil,
 			},
 		},
+		"TerminalStatusCodeNotRetried": {
+			reason: "Should not send the request again when the last attempt failed with a non-retryable status code",
+			args: args{
+				ctx: context.Background(),
+				dr: disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+					limit := int32(3)
+					dr.Spec.ForProvider.RollbackRetriesLimit = &limit
+					dr.Spec.ForProvider.RetryableStatusCodes = []string{"500-599"}
+					dr.Status.Failed = 1
+					dr.Status.Response.StatusCode = 422
+				}),
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				httpClient: &MockHttpClient{},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"RetryableStatusCodeRetried": {
+			reason: "Should send the request again when the last attempt failed with a retryable status code",
+			args: args{
+				ctx: context.Background(),
+				dr: disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+					limit := int32(3)
+					dr.Spec.ForProvider.RollbackRetriesLimit = &limit
+					dr.Spec.ForProvider.RetryableStatusCodes = []string{"502-504"}
+					dr.Status.Failed = 1
+					dr.Status.Response.StatusCode = 503
+				}),
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				httpClient: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 503,
+							},
+						}, nil
+					},
+				},
+			},
+			want: want{
+				err: errors.New("HTTP POST request failed with status code: 503"),
+			},
+		},
 		"HttpRequestError": {
 			reason: "Should handle HTTP request error and update status",
 			args: args{
@@ -136,6 +188,25 @@ func TestDeployAction(t *testing.T) {
 				err: errBoom,
 			},
 		},
+		"HttpRequestCanceled": {
+			reason: "Should return a request canceled with the reconcile without counting it as a failed attempt",
+			args: args{
+				ctx: context.Background(),
+				dr:  disposableRequest(),
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+				},
+				httpClient: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errCanceled
+					},
+				},
+			},
+			want: want{
+				err: errCanceled,
+			},
+		},
 		"HttpErrorStatusCode": {
 			reason: "Should handle HTTP error status codes (4xx, 5xx) and still succeed",
 			args: args{
@@ -169,7 +240,36 @@ func TestDeployAction(t *testing.T) {
 				},
 			},
 			want: want{
-				err: errors.New("HTTP POST request failed with status code: 500"),
+				err: errors.New(`HTTP POST request failed with status code: 500, response: {"error":"internal server error"}`),
+			},
+		},
+		"HttpErrorStatusCodeWithClockSkew": {
+			reason: "Should surface a possible clock skew when an authentication failure reports an expired signature",
+			args: args{
+				ctx: context.Background(),
+				dr:  disposableRequest(),
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						if dr, ok := obj.(*v1alpha2.DisposableRequest); ok && dr.Status.Error != "possible clock skew: the server rejected the request timestamp, check the provider pod clock" {
+							return errors.Errorf("unexpected status error %q", dr.Status.Error)
+						}
+						return nil
+					}),
+				},
+				httpClient: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 403,
+								Body:       `{"message": "Signature expired"}`,
+							},
+						}, nil
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("possible clock skew: the server rejected the request timestamp, check the provider pod clock"), `HTTP POST request failed with status code: 403, response: {"message":"Signature expired"}`),
 			},
 		},
 		"ResponseValidationFailed": {
@@ -210,6 +310,37 @@ func TestDeployAction(t *testing.T) {
 				err: nil,
 			},
 		},
+		"BodyTooLarge": {
+			reason: "Should only record the body of a response exceeding maxBodyBytes up to the limit",
+			args: args{
+				ctx: context.Background(),
+				dr: disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+					dr.Spec.ForProvider.MaxBodyBytes = ptr.To[int64](8)
+				}),
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+						if dr, ok := obj.(*v1alpha2.DisposableRequest); ok && dr.Status.Response.Body != `{"status` {
+							return errors.Errorf("unexpected status body %q", dr.Status.Response.Body)
+						}
+						return nil
+					}),
+				},
+				httpClient: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Body:       `{"status": "success"}`,
+							},
+						}, nil
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"SuccessfulDeployment": {
 			reason: "Should successfully deploy and update status as synced",
 			args: args{
@@ -322,6 +453,35 @@ func TestDeployAction(t *testing.T) {
 	}
 }
 
+func TestDeployActionErrorBody(t *testing.T) {
+	dr := disposableRequest()
+	client := &MockHttpClient{
+		MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+			return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{
+				StatusCode: 422,
+				Body:       `{"errors": [{"field": "name", "message": "is required"}]}`,
+				Headers:    map[string][]string{"Content-Type": {"application/json"}},
+			}}, nil
+		},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), client, nil)
+
+	want := `HTTP POST request failed with status code: 422, response: {"errors":[{"field":"name","message":"is required"}]}`
+	if err := DeployAction(svcCtx, service.NewDisposableRequestCRContext(dr)); err == nil || err.Error() != want {
+		t.Errorf("DeployAction(...): want error %q, got %v", want, err)
+	}
+	if dr.Status.Error != want {
+		t.Errorf("DeployAction(...): want status error %q, got %q", want, dr.Status.Error)
+	}
+	if dr.Status.Response.StatusCode != 422 || dr.Status.Response.Body != `{"errors": [{"field": "name", "message": "is required"}]}` {
+		t.Errorf("DeployAction(...): want the error response captured, got %d %q", dr.Status.Response.StatusCode, dr.Status.Response.Body)
+	}
+}
+
 func TestSendHttpRequest(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -402,6 +562,7 @@ func TestSendHttpRequest(t *testing.T) {
 			details, err := sendHttpRequest(
 				svcCtx,
 				tc.args.spec,
+				"",
 			)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -415,6 +576,36 @@ func TestSendHttpRequest(t *testing.T) {
 	}
 }
 
+func TestSendHttpRequestDefaultHeaders(t *testing.T) {
+	var gotHeaders httpClient.Data
+	client := &MockHttpClient{
+		MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, headers httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+			gotHeaders = headers
+			return httpClient.HttpDetails{}, nil
+		},
+	}
+	spec := &v1alpha2.DisposableRequestParameters{
+		URL:     testURL,
+		Method:  "POST",
+		Body:    testBody,
+		Headers: map[string][]string{"Accept": {"text/csv"}},
+	}
+	svcCtx := service.NewServiceContext(context.Background(), &test.MockClient{}, logging.NewNopLogger(), client, nil).
+		WithDefaultHeaders(map[string][]string{"Accept": {"application/json"}, "X-Org-Id": {"42"}})
+
+	if _, err := sendHttpRequest(svcCtx, spec, ""); err != nil {
+		t.Fatalf("sendHttpRequest(...): unexpected error: %v", err)
+	}
+
+	want := map[string][]string{"Accept": {"text/csv"}, "X-Org-Id": {"42"}}
+	if diff := cmp.Diff(want, gotHeaders.Decrypted); diff != "" {
+		t.Errorf("sendHttpRequest(...): -want sent headers, +got sent headers:\n%s", diff)
+	}
+	if diff := cmp.Diff(want, gotHeaders.Encrypted); diff != "" {
+		t.Errorf("sendHttpRequest(...): -want status headers, +got status headers:\n%s", diff)
+	}
+}
+
 func TestPrepareRequestResource(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -513,7 +704,10 @@ func TestHandleHttpResponse(t *testing.T) {
 	}
 
 	type want struct {
-		err error
+		err         error
+		failed      int32
+		statusError string
+		multiStatus *common.MultiStatusResult
 	}
 
 	cases := map[string]struct {
@@ -547,11 +741,123 @@ func TestHandleHttpResponse(t *testing.T) {
 				err: nil,
 			},
 		},
+		"UnexpectedContentType": {
+			reason: "Should count a failed attempt without evaluating jq when the content type does not match",
+			args: args{
+				ctx: context.Background(),
+				spec: &v1alpha2.DisposableRequestParameters{
+					URL:                 testURL,
+					Method:              "POST",
+					ExpectedResponse:    ".body.status == \"success\"",
+					ExpectedContentType: "application/json",
+				},
+				rollbackPolicy: &v1alpha2.DisposableRequestParameters{},
+				sensitiveResponse: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `<html><body>Bad Gateway</body></html>`,
+					Headers:    map[string][]string{"Content-Type": {"text/html"}},
+				},
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				err:         nil,
+				failed:      1,
+				statusError: `response Content-Type "text/html" does not match the expected content type "application/json"`,
+			},
+		},
+		"ResponseSchemaMismatch": {
+			reason: "Should count a failed attempt naming the failing path when the body does not match the response schema",
+			args: args{
+				ctx: context.Background(),
+				spec: &v1alpha2.DisposableRequestParameters{
+					URL:            testURL,
+					Method:         "POST",
+					ResponseSchema: &common.ResponseSchema{Inline: `{"type": "object", "required": ["id"]}`},
+				},
+				rollbackPolicy: &v1alpha2.DisposableRequestParameters{},
+				sensitiveResponse: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"name": "john"}`,
+				},
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				err:         nil,
+				failed:      1,
+				statusError: "response body does not match responseSchema at .id: is required",
+			},
+		},
+		"MultiStatusPartialFailure": {
+			reason: "Should count a failed attempt and report the failed items when some multi-status items failed",
+			args: args{
+				ctx: context.Background(),
+				spec: &v1alpha2.DisposableRequestParameters{
+					URL:         testURL,
+					Method:      "POST",
+					MultiStatus: testMultiStatusCheck,
+				},
+				rollbackPolicy: &v1alpha2.DisposableRequestParameters{},
+				sensitiveResponse: httpClient.HttpResponse{
+					StatusCode: 207,
+					Body:       `{"results": [{"status": 201}, {"status": 409}, {"status": 200}]}`,
+				},
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				err:         nil,
+				failed:      1,
+				statusError: "multi-status response: 1 of 3 items failed, failed items: [1]",
+				multiStatus: &common.MultiStatusResult{Total: 3, Succeeded: 2, FailedItems: []int{1}},
+			},
+		},
+		"MultiStatusAllSucceeded": {
+			reason: "Should succeed when every multi-status item succeeded",
+			args: args{
+				ctx: context.Background(),
+				spec: &v1alpha2.DisposableRequestParameters{
+					URL:         testURL,
+					Method:      "POST",
+					MultiStatus: testMultiStatusCheck,
+				},
+				rollbackPolicy: &v1alpha2.DisposableRequestParameters{},
+				sensitiveResponse: httpClient.HttpResponse{
+					StatusCode: 207,
+					Body:       `{"results": [{"status": 201}, {"status": 200}]}`,
+				},
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						return nil
+					}),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+			},
+			want: want{
+				err:         nil,
+				multiStatus: &common.MultiStatusResult{Total: 2, Succeeded: 2},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			dr := disposableRequest()
+			dr := disposableRequest(func(dr *v1alpha2.DisposableRequest) {
+				dr.Spec.ForProvider = *tc.args.spec
+			})
 			svcCtx := service.NewServiceContext(
 				tc.args.ctx,
 				tc.args.localKube,
@@ -580,6 +886,18 @@ func TestHandleHttpResponse(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nhandleHttpResponse(...): -want error, +got error:\n%s", tc.reason, diff)
 			}
+
+			if diff := cmp.Diff(tc.want.failed, dr.Status.Failed); diff != "" {
+				t.Errorf("\n%s\nhandleHttpResponse(...): -want failed, +got failed:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.statusError, dr.Status.Error); diff != "" {
+				t.Errorf("\n%s\nhandleHttpResponse(...): -want status error, +got status error:\n%s", tc.reason, diff)
+			}
+
+			if diff := cmp.Diff(tc.want.multiStatus, dr.Status.MultiStatus); diff != "" {
+				t.Errorf("\n%s\nhandleHttpResponse(...): -want multi-status, +got multi-status:\n%s", tc.reason, diff)
+			}
 		})
 	}
 }
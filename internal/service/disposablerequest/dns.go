@@ -0,0 +1,51 @@
+package disposablerequest
+
+import (
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	msgDNSFailure = "host of the request does not resolve and is not retried anymore, fix the URL or set the %s annotation to retry it: %s"
+)
+
+// isTerminalDNSFailure checks if the request failed because its host does not resolve, and the spec does not retry
+// such failures.
+func isTerminalDNSFailure(spec interfaces.SimpleHTTPRequestSpec, err error) bool {
+	if !httpClient.IsDNSFailure(err) {
+		return false
+	}
+
+	aware, ok := spec.(interfaces.DNSFailureRetryAware)
+	return !ok || !aware.GetRetryDNSFailures()
+}
+
+// markDNSFailure returns a function setting the Failed condition of a request whose host does not resolve, so it is
+// not retried anymore. Like for exhausted retries, the condition records the generation of the failure, which
+// ShouldRearm compares against.
+func markDNSFailure(crCtx *service.DisposableRequestCRContext, err error) utils.SetRequestStatusFunc {
+	return func() {
+		obj := crCtx.GetCR()
+		cr, ok := obj.(conditioned)
+		if !ok {
+			return
+		}
+
+		message := fmt.Sprintf(msgDNSFailure, common.AnnotationKeyForceRetryAfter, err)
+		cr.SetConditions(xpv1.Unavailable(), common.DNSFailure(message).WithObservedGeneration(obj.GetGeneration()))
+	}
+}
+
+// isDNSFailure checks if the last attempt of the request failed because its host does not resolve.
+func isDNSFailure(obj client.Object) bool {
+	failed, ok := failedCondition(obj)
+	return ok && failed.Reason == common.ReasonDNSFailure
+}
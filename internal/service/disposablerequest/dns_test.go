@@ -0,0 +1,111 @@
+package disposablerequest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// withRetriesLimit sets the retries limit of the request.
+func withRetriesLimit(limit int32) func(*v1alpha2.DisposableRequest) {
+	return func(dr *v1alpha2.DisposableRequest) {
+		dr.Spec.ForProvider.RollbackRetriesLimit = &limit
+	}
+}
+
+func TestDeployActionDNSFailure(t *testing.T) {
+	errNoSuchHost := &httpClient.DNSError{Err: &net.DNSError{Err: "no such host", Name: "typo.example", IsNotFound: true}}
+
+	cases := map[string]struct {
+		reason           string
+		dr               *v1alpha2.DisposableRequest
+		err              error
+		wantSent         int
+		wantFailed       int32
+		wantDNSCondition bool
+	}{
+		"NoSuchHost": {
+			reason:           "Should send a request whose host does not resolve once, and not retry it",
+			dr:               disposableRequest(withRetriesLimit(5)),
+			err:              errNoSuchHost,
+			wantSent:         1,
+			wantFailed:       1,
+			wantDNSCondition: true,
+		},
+		"RetryDNSFailures": {
+			reason: "Should retry a request whose host does not resolve when the spec retries DNS failures",
+			dr: disposableRequest(withRetriesLimit(5), func(dr *v1alpha2.DisposableRequest) {
+				dr.Spec.ForProvider.RetryDNSFailures = true
+			}),
+			err:        errNoSuchHost,
+			wantSent:   3,
+			wantFailed: 3,
+		},
+		"OtherError": {
+			reason:     "Should retry a request failing with an error other than a DNS failure",
+			dr:         disposableRequest(withRetriesLimit(5)),
+			err:        &net.OpError{Op: "dial", Net: "tcp", Err: &net.AddrError{Err: "connection refused"}},
+			wantSent:   3,
+			wantFailed: 3,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := 0
+			client := &MockHttpClient{
+				MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+					sent++
+					return httpClient.HttpDetails{}, tc.err
+				},
+			}
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), client, nil)
+
+			for range 3 {
+				_ = DeployAction(svcCtx, service.NewDisposableRequestCRContext(tc.dr))
+			}
+
+			if sent != tc.wantSent {
+				t.Errorf("\n%s\nDeployAction(...): want %d requests sent, got %d", tc.reason, tc.wantSent, sent)
+			}
+			if tc.dr.Status.Failed != tc.wantFailed {
+				t.Errorf("\n%s\nDeployAction(...): want %d failures, got %d", tc.reason, tc.wantFailed, tc.dr.Status.Failed)
+			}
+
+			failed := tc.dr.GetCondition(common.TypeFailed)
+			if got := failed.Status == corev1.ConditionTrue && failed.Reason == common.ReasonDNSFailure; got != tc.wantDNSCondition {
+				t.Errorf("\n%s\nDeployAction(...): want a DNSFailure condition %t, got %+v", tc.reason, tc.wantDNSCondition, failed)
+			}
+			if got := IsRetriesExhausted(service.NewDisposableRequestCRContext(tc.dr)); got != tc.wantDNSCondition {
+				t.Errorf("\n%s\nIsRetriesExhausted(...): want %t, got %t", tc.reason, tc.wantDNSCondition, got)
+			}
+		})
+	}
+}
+
+func TestShouldRearmDNSFailure(t *testing.T) {
+	dr := disposableRequest(withRetriesLimit(5))
+	markDNSFailure(service.NewDisposableRequestCRContext(dr), &httpClient.DNSError{Err: &net.DNSError{Err: "no such host", IsNotFound: true}})()
+	dr.Status.Failed = 1
+
+	if ShouldRearm(dr, dr.GetCondition(common.TypeFailed).LastTransitionTime.Time) {
+		t.Errorf("ShouldRearm(...): want a DNS failure of the current generation not rearmed")
+	}
+
+	dr.Generation++
+	if !ShouldRearm(dr, dr.GetCondition(common.TypeFailed).LastTransitionTime.Time) {
+		t.Errorf("ShouldRearm(...): want a DNS failure rearmed once the spec changed")
+	}
+}
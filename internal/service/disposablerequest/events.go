@@ -0,0 +1,46 @@
+package disposablerequest
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+// eventStreamContext returns the context to send the request of the spec with, reading the response as
+// server-sent events until one matches the expected response if the spec consumes events.
+func eventStreamContext(ctx context.Context, spec interfaces.SimpleHTTPRequestSpec) context.Context {
+	aware, ok := spec.(interfaces.ServerSentEventsAware)
+	if !ok || aware.GetServerSentEventsMaxDuration() == nil {
+		return ctx
+	}
+
+	return httpClient.ContextWithEventStream(ctx, httpClient.EventStreamOptions{
+		Match:         matchExpectedEvent(spec.GetExpectedResponse()),
+		MaxDuration:   aware.GetServerSentEventsMaxDuration().Duration,
+		MaxReconnects: aware.GetServerSentEventsMaxReconnects(),
+	})
+}
+
+// matchExpectedEvent returns a predicate evaluating the expected response against an event, as the body of a
+// successful response. Without expected response, the first event matches. An event the filter cannot be evaluated
+// against, e.g. a progress event of another shape, does not match.
+func matchExpectedEvent(expectedResponse string) func(event httpClient.Event) bool {
+	return func(event httpClient.Event) bool {
+		if expectedResponse == "" {
+			return true
+		}
+
+		responseMap, err := json_util.StructToMap(httpClient.HttpResponse{StatusCode: http.StatusOK, Body: event.Data})
+		if err != nil {
+			return false
+		}
+		json_util.ConvertJSONStringsToMaps(&responseMap)
+
+		matched, err := jq.ParseBool(expectedResponse, responseMap)
+		return err == nil && matched
+	}
+}
@@ -0,0 +1,92 @@
+package disposablerequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_matchExpectedEvent(t *testing.T) {
+	cases := map[string]struct {
+		expectedResponse string
+		data             string
+		want             bool
+	}{
+		"MatchingEvent": {
+			expectedResponse: `.body.status == "complete"`,
+			data:             `{"status":"complete"}`,
+			want:             true,
+		},
+		"NotMatchingEvent": {
+			expectedResponse: `.body.status == "complete"`,
+			data:             `{"status":"running"}`,
+			want:             false,
+		},
+		"EventOfAnotherShape": {
+			expectedResponse: `.body.status == "complete"`,
+			data:             `heartbeat`,
+			want:             false,
+		},
+		"NoExpectedResponse": {
+			data: `{"status":"running"}`,
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := matchExpectedEvent(tc.expectedResponse)(httpClient.Event{Type: "message", Data: tc.data})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("matchExpectedEvent(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_eventStreamContext(t *testing.T) {
+	maxReconnects := int32(5)
+
+	cases := map[string]struct {
+		spec    *v1alpha2.DisposableRequestParameters
+		want    httpClient.EventStreamOptions
+		wantSet bool
+	}{
+		"NotConsumingEvents": {
+			spec: &v1alpha2.DisposableRequestParameters{},
+		},
+		"DefaultReconnects": {
+			spec: &v1alpha2.DisposableRequestParameters{
+				ServerSentEvents: &v1alpha2.ServerSentEvents{MaxDuration: metav1.Duration{Duration: time.Minute}},
+			},
+			want:    httpClient.EventStreamOptions{MaxDuration: time.Minute, MaxReconnects: 3},
+			wantSet: true,
+		},
+		"ConfiguredReconnects": {
+			spec: &v1alpha2.DisposableRequestParameters{
+				ServerSentEvents: &v1alpha2.ServerSentEvents{MaxDuration: metav1.Duration{Duration: time.Minute}, MaxReconnects: &maxReconnects},
+			},
+			want:    httpClient.EventStreamOptions{MaxDuration: time.Minute, MaxReconnects: 5},
+			wantSet: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := httpClient.EventStreamFromContext(eventStreamContext(context.Background(), tc.spec))
+			if diff := cmp.Diff(tc.wantSet, ok); diff != "" {
+				t.Fatalf("eventStreamContext(...): -want options set, +got options set: %s", diff)
+			}
+
+			got.Match = nil
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("eventStreamContext(...): -want options, +got options: %s", diff)
+			}
+		})
+	}
+}
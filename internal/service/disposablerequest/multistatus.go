@@ -0,0 +1,90 @@
+package disposablerequest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/pkg/errors"
+)
+
+const (
+	errMultiStatusItems   = "failed to select the multi-status items: %s"
+	errMultiStatusItem    = "failed to check multi-status item %d: %s"
+	errMultiStatusPartial = "multi-status response: %d of %d items failed, failed items: %v"
+	errParseXMLBody       = "failed to parse XML body"
+)
+
+// EvaluateMultiStatus evaluates every item of a 207 Multi-Status response with the multi-status check of the spec.
+// It returns nil if the response is not a 207 or the spec does not define a multi-status check.
+func EvaluateMultiStatus(spec interfaces.SimpleHTTPRequestSpec, res httpClient.HttpResponse) (*common.MultiStatusResult, error) {
+	multiStatus, ok := spec.(interfaces.MultiStatusAware)
+	if !ok || multiStatus.GetMultiStatusItems() == "" || res.StatusCode != http.StatusMultiStatus {
+		return nil, nil
+	}
+
+	responseMap, err := multiStatusResponseMap(res)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := jq.ParseArray(multiStatus.GetMultiStatusItems(), responseMap)
+	if err != nil {
+		return nil, errors.Errorf(errMultiStatusItems, err.Error())
+	}
+
+	result := &common.MultiStatusResult{Total: len(items)}
+	for i, item := range items {
+		succeeded, err := jq.ParseBool(multiStatus.GetMultiStatusItemSucceeded(), item)
+		if err != nil {
+			return nil, errors.Errorf(errMultiStatusItem, i, err.Error())
+		}
+
+		if succeeded {
+			result.Succeeded++
+		} else {
+			result.FailedItems = append(result.FailedItems, i)
+		}
+	}
+
+	return result, nil
+}
+
+// multiStatusError returns an error listing the failed items, or nil if every item succeeded.
+func multiStatusError(result *common.MultiStatusResult) error {
+	if result == nil || len(result.FailedItems) == 0 {
+		return nil
+	}
+
+	return errors.Errorf(errMultiStatusPartial, len(result.FailedItems), result.Total, result.FailedItems)
+}
+
+// multiStatusResponseMap converts the response to a map, parsing a JSON or XML body.
+func multiStatusResponseMap(res httpClient.HttpResponse) (map[string]interface{}, error) {
+	responseMap, err := json_util.StructToMap(res)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertResToMap)
+	}
+
+	body := strings.TrimSpace(res.Body)
+	if strings.HasPrefix(body, "<") {
+		parsed, err := httpClient.XMLToMap([]byte(body))
+		if err != nil {
+			return nil, errors.Wrap(err, errParseXMLBody)
+		}
+		responseMap["body"] = parsed
+		return responseMap, nil
+	}
+
+	var parsed interface{}
+	if json.Unmarshal([]byte(body), &parsed) == nil {
+		responseMap["body"] = parsed
+	}
+
+	return responseMap, nil
+}
@@ -0,0 +1,125 @@
+package disposablerequest
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+var testMultiStatusCheck = &v1alpha2.MultiStatusCheck{
+	Items:         ".body.results",
+	ItemSucceeded: ".status >= 200 and .status < 300",
+}
+
+const testWebDAVMultiStatus = `<?xml version="1.0" encoding="utf-8"?>
+<d:multistatus xmlns:d="DAV:">
+  <d:response>
+    <d:href>/files/a.txt</d:href>
+    <d:status>HTTP/1.1 200 OK</d:status>
+  </d:response>
+  <d:response>
+    <d:href>/files/b.txt</d:href>
+    <d:status>HTTP/1.1 423 Locked</d:status>
+  </d:response>
+</d:multistatus>`
+
+func TestEvaluateMultiStatus(t *testing.T) {
+	type args struct {
+		spec *v1alpha2.DisposableRequestParameters
+		res  httpClient.HttpResponse
+	}
+	type want struct {
+		result *common.MultiStatusResult
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotConfigured": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{},
+				res:  httpClient.HttpResponse{StatusCode: 207, Body: `{"results": [{"status": 500}]}`},
+			},
+			want: want{},
+		},
+		"NotMultiStatus": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{MultiStatus: testMultiStatusCheck},
+				res:  httpClient.HttpResponse{StatusCode: 200, Body: `{"results": [{"status": 500}]}`},
+			},
+			want: want{},
+		},
+		"JSONAllSucceeded": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{MultiStatus: testMultiStatusCheck},
+				res:  httpClient.HttpResponse{StatusCode: 207, Body: `{"results": [{"status": 200}, {"status": 204}]}`},
+			},
+			want: want{
+				result: &common.MultiStatusResult{Total: 2, Succeeded: 2},
+			},
+		},
+		"JSONArrayBody": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{MultiStatus: &v1alpha2.MultiStatusCheck{
+					Items:         ".body",
+					ItemSucceeded: ".ok",
+				}},
+				res: httpClient.HttpResponse{StatusCode: 207, Body: `[{"ok": true}, {"ok": false}, {"ok": false}]`},
+			},
+			want: want{
+				result: &common.MultiStatusResult{Total: 3, Succeeded: 1, FailedItems: []int{1, 2}},
+			},
+		},
+		"XMLPartialFailure": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{MultiStatus: &v1alpha2.MultiStatusCheck{
+					Items:         ".body.multistatus.response",
+					ItemSucceeded: `.status | test("^HTTP/1.1 2")`,
+				}},
+				res: httpClient.HttpResponse{StatusCode: 207, Body: testWebDAVMultiStatus},
+			},
+			want: want{
+				result: &common.MultiStatusResult{Total: 2, Succeeded: 1, FailedItems: []int{1}},
+			},
+		},
+		"ItemsNotAnArray": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{MultiStatus: testMultiStatusCheck},
+				res:  httpClient.HttpResponse{StatusCode: 207, Body: `{"results": "none"}`},
+			},
+			want: want{
+				err: errors.Errorf(errMultiStatusItems, "failed to parse array: none"),
+			},
+		},
+		"ItemCheckNotBoolean": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{MultiStatus: &v1alpha2.MultiStatusCheck{
+					Items:         ".body.results",
+					ItemSucceeded: ".status",
+				}},
+				res: httpClient.HttpResponse{StatusCode: 207, Body: `{"results": [{"status": 200}]}`},
+			},
+			want: want{
+				err: errors.Errorf(errMultiStatusItem, 0, "failed to parse string: 200"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := EvaluateMultiStatus(tc.args.spec, tc.args.res)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("EvaluateMultiStatus(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("EvaluateMultiStatus(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package disposablerequest
 
 import (
 	"context"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
@@ -38,10 +39,12 @@ func ValidateStoredResponse(svcCtx *service.ServiceContext, crCtx *service.Dispo
 	storedResponse := httpClient.HttpResponse{
 		StatusCode: response.GetStatusCode(),
 		Headers:    response.GetHeaders(),
+		Trailers:   response.GetTrailers(),
+		StatusText: response.GetStatusText(),
 		Body:       sensitiveBody,
 	}
 
-	isExpected, err := IsResponseAsExpected(spec, storedResponse)
+	isExpected, err := IsResponseAsExpected(spec, storedResponse, elapsedSince(crCtx.GetCR(), time.Now()))
 	if err != nil {
 		svcCtx.Logger.Debug("Setting error condition due to validation error", "error", err)
 		return false, httpClient.HttpResponse{}, errors.Wrap(err, errCheckExpectedResponse)
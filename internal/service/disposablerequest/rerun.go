@@ -0,0 +1,40 @@
+package disposablerequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+const (
+	errRerunRequest = "failed to reset the request to run it again"
+)
+
+// RerunRequested checks if the rerun-requested-at annotation, set by the trigger endpoint, holds a time later than
+// the given time the request was last sent or failed at.
+func RerunRequested(obj client.Object, since time.Time) bool {
+	value, ok := obj.GetAnnotations()[common.AnnotationKeyRerunRequestedAt]
+	if !ok {
+		return false
+	}
+
+	requestedAt, err := time.Parse(time.RFC3339, value)
+	return err == nil && requestedAt.After(since)
+}
+
+// Rerun resets a synced request, so it is sent again.
+func Rerun(ctx context.Context, crCtx *service.DisposableRequestCRContext, localKube client.Client) error {
+	obj := crCtx.GetCR()
+	if err := localKube.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj); err != nil {
+		return errors.Wrap(err, errGetLatestVersion)
+	}
+
+	crCtx.StatusWriter().SetSynced(false)
+	return errors.Wrap(localKube.Status().Update(ctx, obj), errRerunRequest)
+}
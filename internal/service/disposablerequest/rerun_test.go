@@ -0,0 +1,62 @@
+package disposablerequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func TestRerunRequested(t *testing.T) {
+	lastSent := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		annotations map[string]string
+		want        bool
+	}{
+		"NotTriggered": {},
+		"TriggeredSinceLastSent": {
+			annotations: map[string]string{common.AnnotationKeyRerunRequestedAt: lastSent.Add(time.Second).Format(time.RFC3339)},
+			want:        true,
+		},
+		"TriggeredBeforeLastSent": {
+			annotations: map[string]string{common.AnnotationKeyRerunRequestedAt: lastSent.Format(time.RFC3339)},
+		},
+		"InvalidTime": {
+			annotations: map[string]string{common.AnnotationKeyRerunRequestedAt: "now"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.DisposableRequest{ObjectMeta: v1.ObjectMeta{Annotations: tc.annotations}}
+			if got := RerunRequested(cr, lastSent); got != tc.want {
+				t.Errorf("RerunRequested(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRerun(t *testing.T) {
+	cr := &v1alpha2.DisposableRequest{
+		ObjectMeta: v1.ObjectMeta{Name: "test"},
+		Status:     v1alpha2.DisposableRequestStatus{Synced: true},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+
+	if err := Rerun(context.Background(), service.NewDisposableRequestCRContext(cr), localKube); err != nil {
+		t.Fatalf("Rerun(...): unexpected error: %v", err)
+	}
+	if cr.Status.Synced {
+		t.Errorf("Rerun(...): want the request not synced anymore, so it is sent again")
+	}
+}
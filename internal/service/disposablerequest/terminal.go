@@ -0,0 +1,135 @@
+package disposablerequest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errRearmFailedRequest = "failed to reset the failures of the request"
+
+	msgRetriesExhausted = "request failed %d times and is not retried anymore, change the spec or set the %s annotation to retry it"
+)
+
+// conditioned is a resource with status conditions.
+type conditioned interface {
+	GetCondition(ct xpv1.ConditionType) xpv1.Condition
+	SetConditions(c ...xpv1.Condition)
+}
+
+// IsRetriesExhausted checks if the request failed and is not retried anymore, because the retries limit was reached,
+// the last response status code is not retryable, the host of the last attempt does not resolve or the deadline of
+// the attempts passed.
+func IsRetriesExhausted(crCtx *service.DisposableRequestCRContext) bool {
+	status := crCtx.Status()
+	rollbackPolicy := crCtx.RollbackPolicy()
+	if status.GetSynced() || status.GetFailed() == 0 {
+		return false
+	}
+
+	limit := rollbackPolicy.GetRollbackRetriesLimit()
+	return (utils.RollBackEnabled(limit) && utils.RetriesLimitReached(status.GetFailed(), limit)) || isTerminalFailure(status, rollbackPolicy) ||
+		isDNSFailure(crCtx.GetCR()) || isDeadlineExceeded(crCtx, time.Now())
+}
+
+// MarkRetriesExhausted sets the Failed condition of a request whose retries are exhausted. The condition records
+// the generation and time of the failure, which ShouldRearm compares against.
+func MarkRetriesExhausted(crCtx *service.DisposableRequestCRContext) {
+	obj := crCtx.GetCR()
+	cr, ok := obj.(conditioned)
+	if !ok {
+		return
+	}
+
+	if failed := cr.GetCondition(common.TypeFailed); failed.Status == corev1.ConditionTrue {
+		return
+	}
+
+	message := fmt.Sprintf(msgRetriesExhausted, crCtx.Status().GetFailed(), common.AnnotationKeyForceRetryAfter)
+	cr.SetConditions(xpv1.Unavailable(), common.RetriesExhausted(message).WithObservedGeneration(obj.GetGeneration()))
+}
+
+// ShouldRearm checks if a request whose retries are exhausted is retried again, because its spec changed or it was
+// triggered since it failed, or because the force-retry-after annotation holds a time later than the failure which
+// has passed.
+func ShouldRearm(obj client.Object, now time.Time) bool {
+	failed, ok := failedCondition(obj)
+	if !ok {
+		return false
+	}
+
+	if failed.ObservedGeneration != obj.GetGeneration() || RerunRequested(obj, failed.LastTransitionTime.Time) {
+		return true
+	}
+
+	retryAfter, ok := forcedRetryTime(obj, failed)
+	return ok && !now.Before(retryAfter)
+}
+
+// NextForcedRetry returns the time left before a forced retry of a request whose retries are exhausted, if one is
+// scheduled by the force-retry-after annotation.
+func NextForcedRetry(obj client.Object, now time.Time) (time.Duration, bool) {
+	failed, ok := failedCondition(obj)
+	if !ok {
+		return 0, false
+	}
+
+	retryAfter, ok := forcedRetryTime(obj, failed)
+	if !ok || !now.Before(retryAfter) {
+		return 0, false
+	}
+
+	return retryAfter.Sub(now), true
+}
+
+// Rearm resets the failures of a request whose retries are exhausted, so it is sent again.
+func Rearm(ctx context.Context, crCtx *service.DisposableRequestCRContext, localKube client.Client) error {
+	obj := crCtx.GetCR()
+	if err := localKube.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj); err != nil {
+		return errors.Wrap(err, errGetLatestVersion)
+	}
+
+	crCtx.StatusWriter().SetFailed(0)
+	if cr, ok := obj.(conditioned); ok {
+		cr.SetConditions(common.RetryForced().WithObservedGeneration(obj.GetGeneration()))
+	}
+
+	return errors.Wrap(localKube.Status().Update(ctx, obj), errRearmFailedRequest)
+}
+
+// failedCondition returns the Failed condition of the resource, if it is set.
+func failedCondition(obj client.Object) (xpv1.Condition, bool) {
+	cr, ok := obj.(conditioned)
+	if !ok {
+		return xpv1.Condition{}, false
+	}
+
+	failed := cr.GetCondition(common.TypeFailed)
+	return failed, failed.Status == corev1.ConditionTrue
+}
+
+// forcedRetryTime returns the time of the force-retry-after annotation, if it is valid and later than the failure.
+func forcedRetryTime(obj client.Object, failed xpv1.Condition) (time.Time, bool) {
+	value, ok := obj.GetAnnotations()[common.AnnotationKeyForceRetryAfter]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	retryAfter, err := time.Parse(time.RFC3339, value)
+	if err != nil || !retryAfter.After(failed.LastTransitionTime.Time) {
+		return time.Time{}, false
+	}
+
+	return retryAfter, true
+}
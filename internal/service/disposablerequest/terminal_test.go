@@ -0,0 +1,189 @@
+package disposablerequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testFailureTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// failedDisposableRequest returns a request of the given generation which failed at generation 1.
+func failedDisposableRequest(generation int64, annotations map[string]string) *v1alpha2.DisposableRequest {
+	cr := &v1alpha2.DisposableRequest{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Generation:  generation,
+			Annotations: annotations,
+		},
+		Spec: v1alpha2.DisposableRequestSpec{
+			ForProvider: v1alpha2.DisposableRequestParameters{
+				RollbackRetriesLimit: func() *int32 { l := int32(3); return &l }(),
+			},
+		},
+		Status: v1alpha2.DisposableRequestStatus{Failed: 3},
+	}
+
+	failed := common.RetriesExhausted("failed").WithObservedGeneration(1)
+	failed.LastTransitionTime = v1.NewTime(testFailureTime)
+	cr.SetConditions(failed)
+	return cr
+}
+
+func TestIsRetriesExhausted(t *testing.T) {
+	cases := map[string]struct {
+		cr   *v1alpha2.DisposableRequest
+		want bool
+	}{
+		"LimitReached": {
+			cr:   failedDisposableRequest(1, nil),
+			want: true,
+		},
+		"LimitNotReached": {
+			cr: func() *v1alpha2.DisposableRequest {
+				cr := failedDisposableRequest(1, nil)
+				cr.Status.Failed = 1
+				return cr
+			}(),
+		},
+		"Synced": {
+			cr: func() *v1alpha2.DisposableRequest {
+				cr := failedDisposableRequest(1, nil)
+				cr.Status.Synced = true
+				return cr
+			}(),
+		},
+		"TerminalStatusCode": {
+			cr: func() *v1alpha2.DisposableRequest {
+				cr := failedDisposableRequest(1, nil)
+				cr.Status.Failed = 1
+				cr.Status.Response.StatusCode = 400
+				cr.Spec.ForProvider.RetryableStatusCodes = []string{"500-599"}
+				return cr
+			}(),
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsRetriesExhausted(service.NewDisposableRequestCRContext(tc.cr))
+			if got != tc.want {
+				t.Errorf("IsRetriesExhausted(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestShouldRearm(t *testing.T) {
+	now := testFailureTime.Add(time.Hour)
+
+	cases := map[string]struct {
+		cr       *v1alpha2.DisposableRequest
+		want     bool
+		wantWait time.Duration
+	}{
+		"NotRearmed": {
+			cr: failedDisposableRequest(1, nil),
+		},
+		"SpecChanged": {
+			cr:   failedDisposableRequest(2, nil),
+			want: true,
+		},
+		"ForcedRetryPassed": {
+			cr: failedDisposableRequest(1, map[string]string{
+				common.AnnotationKeyForceRetryAfter: testFailureTime.Add(time.Minute).Format(time.RFC3339),
+			}),
+			want: true,
+		},
+		"ForcedRetryScheduled": {
+			cr: failedDisposableRequest(1, map[string]string{
+				common.AnnotationKeyForceRetryAfter: now.Add(10 * time.Minute).Format(time.RFC3339),
+			}),
+			wantWait: 10 * time.Minute,
+		},
+		"ForcedRetryBeforeFailureIgnored": {
+			cr: failedDisposableRequest(1, map[string]string{
+				common.AnnotationKeyForceRetryAfter: testFailureTime.Add(-time.Minute).Format(time.RFC3339),
+			}),
+		},
+		"TriggeredAfterFailure": {
+			cr: failedDisposableRequest(1, map[string]string{
+				common.AnnotationKeyRerunRequestedAt: testFailureTime.Add(time.Minute).Format(time.RFC3339),
+			}),
+			want: true,
+		},
+		"TriggeredBeforeFailureIgnored": {
+			cr: failedDisposableRequest(1, map[string]string{
+				common.AnnotationKeyRerunRequestedAt: testFailureTime.Add(-time.Minute).Format(time.RFC3339),
+			}),
+		},
+		"InvalidForcedRetryIgnored": {
+			cr: failedDisposableRequest(1, map[string]string{
+				common.AnnotationKeyForceRetryAfter: "tomorrow",
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ShouldRearm(tc.cr, now); got != tc.want {
+				t.Errorf("ShouldRearm(...): want %t, got %t", tc.want, got)
+			}
+
+			wait, _ := NextForcedRetry(tc.cr, now)
+			if diff := cmp.Diff(tc.wantWait, wait); diff != "" {
+				t.Errorf("NextForcedRetry(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMarkRetriesExhausted(t *testing.T) {
+	cr := failedDisposableRequest(1, nil)
+	cr.SetConditions(xpv1.Condition{Type: common.TypeFailed, Status: corev1.ConditionFalse, Reason: common.ReasonRetryForced})
+
+	MarkRetriesExhausted(service.NewDisposableRequestCRContext(cr))
+
+	failed := cr.GetCondition(common.TypeFailed)
+	if failed.Status != corev1.ConditionTrue || failed.Reason != common.ReasonRetriesExhausted || failed.ObservedGeneration != 1 {
+		t.Errorf("MarkRetriesExhausted(...): unexpected Failed condition: %+v", failed)
+	}
+	if ready := cr.GetCondition(xpv1.TypeReady); ready.Reason != xpv1.ReasonUnavailable {
+		t.Errorf("MarkRetriesExhausted(...): want Unavailable, got %+v", ready)
+	}
+}
+
+func TestRearm(t *testing.T) {
+	var updated *v1alpha2.DisposableRequest
+	localKube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+		MockStatusUpdate: func(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			updated = obj.(*v1alpha2.DisposableRequest)
+			return nil
+		},
+	}
+
+	cr := failedDisposableRequest(2, nil)
+	if err := Rearm(context.Background(), service.NewDisposableRequestCRContext(cr), localKube); err != nil {
+		t.Fatalf("Rearm(...): unexpected error: %v", err)
+	}
+
+	if updated == nil || updated.Status.Failed != 0 {
+		t.Fatalf("Rearm(...): want failures reset in the status update, got %+v", updated)
+	}
+	if failed := updated.GetCondition(common.TypeFailed); failed.Status != corev1.ConditionFalse || failed.Reason != common.ReasonRetryForced {
+		t.Errorf("Rearm(...): unexpected Failed condition: %+v", failed)
+	}
+}
@@ -1,20 +1,38 @@
 package disposablerequest
 
 import (
+	"mime"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/jq"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 	"github.com/pkg/errors"
 )
 
 const (
 	ErrExpectedFormat  = "JQ filter should return a boolean, but returned error: %s"
 	errConvertResToMap = "failed to convert response to map"
+
+	errUnexpectedContentType = "response Content-Type %q does not match the expected content type %q"
+	errBodyTooLarge          = "response body of %d bytes exceeds the maximum of %d bytes"
+	headerContentType        = "Content-Type"
+
+	elapsedKey = "elapsed"
 )
 
-// IsResponseAsExpected checks if the response matches the expected criteria defined in the spec
-func IsResponseAsExpected(spec interfaces.SimpleHTTPRequestSpec, res httpClient.HttpResponse) (bool, error) {
+// IsResponseAsExpected checks if the response matches the expected criteria defined in the spec.
+// The seconds elapsed since the request of the current generation was first sent are exposed as .elapsed.
+func IsResponseAsExpected(spec interfaces.SimpleHTTPRequestSpec, res httpClient.HttpResponse, elapsed time.Duration) (bool, error) {
+	// Multi-status responses with failed items are never expected.
+	if multiStatus, err := EvaluateMultiStatus(spec, res); err != nil || multiStatusError(multiStatus) != nil {
+		return false, err
+	}
+
 	// If no expected response is defined, consider it as expected.
 	if spec.GetExpectedResponse() == "" {
 		return true, nil
@@ -24,12 +42,15 @@ func IsResponseAsExpected(spec interfaces.SimpleHTTPRequestSpec, res httpClient.
 		return false, nil
 	}
 
-	responseMap, err := json_util.StructToMap(res)
-	if err != nil {
-		return false, errors.Wrap(err, errConvertResToMap)
+	// Responses rejected by the guards are never fed to jq.
+	if CheckResponseGuards(spec, res) != nil {
+		return false, nil
 	}
 
-	json_util.ConvertJSONStringsToMaps(&responseMap)
+	responseMap, err := responseInput(spec, res, elapsed)
+	if err != nil {
+		return false, err
+	}
 
 	isExpected, err := jq.ParseBool(spec.GetExpectedResponse(), responseMap)
 	if err != nil {
@@ -38,3 +59,83 @@ func IsResponseAsExpected(spec interfaces.SimpleHTTPRequestSpec, res httpClient.
 
 	return isExpected, nil
 }
+
+// responseInput converts the response into the input of the jq filters evaluated against it, with the seconds
+// elapsed since the request of the current generation was first sent as .elapsed.
+func responseInput(spec interfaces.SimpleHTTPRequestSpec, res httpClient.HttpResponse, elapsed time.Duration) (map[string]interface{}, error) {
+	responseMap, err := json_util.StructToMap(res)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertResToMap)
+	}
+
+	if utils.IsTextResponseFormat(spec) {
+		responseMap[utils.BodyTextKey] = res.Body
+	} else {
+		json_util.ConvertJSONStringsToMaps(&responseMap)
+	}
+	responseMap[elapsedKey] = int(elapsed / time.Second)
+
+	return responseMap, nil
+}
+
+// elapsedSince returns the time elapsed since the request of the current generation of the resource was first
+// sent, or zero if the resource does not record it.
+func elapsedSince(cr interface{}, now time.Time) time.Duration {
+	if reader, ok := cr.(interfaces.ElapsedReader); ok {
+		return reader.GetElapsed(now)
+	}
+
+	return 0
+}
+
+// CheckResponseGuards verifies the response content type and body size against the limits defined in the spec.
+func CheckResponseGuards(spec interfaces.SimpleHTTPRequestSpec, res httpClient.HttpResponse) error {
+	guard, ok := spec.(interfaces.ResponseGuardAware)
+	if !ok {
+		return nil
+	}
+
+	if maxBodyBytes := guard.GetMaxBodyBytes(); maxBodyBytes != nil && int64(len(res.Body)) > *maxBodyBytes {
+		return errors.Errorf(errBodyTooLarge, len(res.Body), *maxBodyBytes)
+	}
+
+	if expected := guard.GetExpectedContentType(); expected != "" {
+		contentType := httpClient.HeaderValue(res.Headers, headerContentType)
+
+		if !mediaTypeMatches(contentType, expected) {
+			return errors.Errorf(errUnexpectedContentType, contentType, expected)
+		}
+	}
+
+	return nil
+}
+
+// truncateBody truncates the body to the maxBodyBytes of the spec, if any, without splitting a UTF-8 character.
+func truncateBody(spec interfaces.SimpleHTTPRequestSpec, body string) string {
+	guard, ok := spec.(interfaces.ResponseGuardAware)
+	if !ok || guard.GetMaxBodyBytes() == nil || int64(len(body)) <= *guard.GetMaxBodyBytes() {
+		return body
+	}
+
+	cut := int(*guard.GetMaxBodyBytes())
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return body[:cut]
+}
+
+// mediaTypeMatches reports whether both content types share the same media type, ignoring parameters and case.
+func mediaTypeMatches(contentType, expected string) bool {
+	actualType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	expectedType, _, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(actualType, expectedType)
+}
@@ -2,11 +2,14 @@ package disposablerequest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
 )
 
 func TestIsResponseAsExpected(t *testing.T) {
@@ -42,6 +45,73 @@ func TestIsResponseAsExpected(t *testing.T) {
 				err:      nil,
 			},
 		},
+		"TextBodyMatches": {
+			reason: "Should compare a plain-text body as a raw string when the response format is text",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ResponseFormat:   "text",
+					ExpectedResponse: `.bodyText == "1.2.3\n" and .body == .bodyText`,
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       "1.2.3\n",
+				},
+			},
+			want: want{
+				expected: true,
+				err:      nil,
+			},
+		},
+		"TextBodyNotParsed": {
+			reason: "Should not parse a text body that happens to be JSON",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ResponseFormat:   "text",
+					ExpectedResponse: `.bodyText == "{\"version\": \"1.2.3\"}"`,
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"version": "1.2.3"}`,
+				},
+			},
+			want: want{
+				expected: true,
+				err:      nil,
+			},
+		},
+		"MultiStatusPartialFailure": {
+			reason: "Should return false when some items of a multi-status response failed, even without expected response",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					MultiStatus: testMultiStatusCheck,
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 207,
+					Body:       `{"results": [{"status": 200}, {"status": 500}]}`,
+				},
+			},
+			want: want{
+				expected: false,
+				err:      nil,
+			},
+		},
+		"MultiStatusAllSucceeded": {
+			reason: "Should evaluate the expected response when every item of a multi-status response succeeded",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					MultiStatus:      testMultiStatusCheck,
+					ExpectedResponse: ".body.results | length == 2",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 207,
+					Body:       `{"results": [{"status": 200}, {"status": 201}]}`,
+				},
+			},
+			want: want{
+				expected: true,
+				err:      nil,
+			},
+		},
 		"ZeroStatusCode": {
 			reason: "Should return false when status code is zero",
 			args: args{
@@ -140,6 +210,91 @@ func TestIsResponseAsExpected(t *testing.T) {
 				err:      nil,
 			},
 		},
+		"JQFilterWithTrailersCheck": {
+			reason: "Should evaluate JQ filter checking trailers",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedResponse: ".trailers.\"Grpc-Status\"[0] == \"0\"",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{}`,
+					Trailers:   map[string][]string{"Grpc-Status": {"0"}},
+				},
+			},
+			want: want{
+				expected: true,
+				err:      nil,
+			},
+		},
+		"HTMLBodyWithoutContentTypeGuard": {
+			reason: "Should fail to evaluate a JSON filter against an HTML error page",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedResponse: ".body.status == \"success\"",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 502,
+					Body:       `<html><body>Bad Gateway</body></html>`,
+					Headers:    map[string][]string{"Content-Type": {"text/html"}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(ErrExpectedFormat, `failed to parse given mapping - .body.status == "success" jq error: expected an object but got: string ("<html><body>Bad Gateway< ...")`),
+			},
+		},
+		"HTMLBodyWithContentTypeGuard": {
+			reason: "Should not be expected, without evaluating jq, when the content type does not match",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedResponse:    ".body.status == \"success\"",
+					ExpectedContentType: "application/json",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 502,
+					Body:       `<html><body>Bad Gateway</body></html>`,
+					Headers:    map[string][]string{"Content-Type": {"text/html"}},
+				},
+			},
+			want: want{
+				expected: false,
+				err:      nil,
+			},
+		},
+		"BodyTooLarge": {
+			reason: "Should not be expected when the body exceeds maxBodyBytes",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedResponse: ".body.status == \"success\"",
+					MaxBodyBytes:     ptr.To[int64](8),
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{"status": "success"}`,
+				},
+			},
+			want: want{
+				expected: false,
+				err:      nil,
+			},
+		},
+		"JQFilterWithStatusTextCheck": {
+			reason: "Should evaluate JQ filter checking the reason phrase",
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedResponse: ".statusText == \"Accepted For Processing\"",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 202,
+					StatusText: "Accepted For Processing",
+					Body:       `{}`,
+				},
+			},
+			want: want{
+				expected: true,
+				err:      nil,
+			},
+		},
 		"EmptyResponseBody": {
 			reason: "Should handle empty response body with JQ filter",
 			args: args{
@@ -160,7 +315,7 @@ func TestIsResponseAsExpected(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, err := IsResponseAsExpected(tc.args.spec, tc.args.res)
+			got, err := IsResponseAsExpected(tc.args.spec, tc.args.res, 0)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nIsResponseAsExpected(...): -want error, +got error:\n%s", tc.reason, diff)
@@ -172,3 +327,176 @@ func TestIsResponseAsExpected(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckResponseGuards(t *testing.T) {
+	type args struct {
+		spec *v1alpha2.DisposableRequestParameters
+		res  httpClient.HttpResponse
+	}
+
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"NoGuards": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `<html></html>`,
+				},
+			},
+			want: nil,
+		},
+		"ContentTypeMatchesIgnoringParameters": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedContentType: "application/json",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{}`,
+					Headers:    map[string][]string{"Content-Type": {"Application/JSON; charset=utf-8"}},
+				},
+			},
+			want: nil,
+		},
+		"ContentTypeMismatch": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedContentType: "application/json",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 502,
+					Body:       `<html></html>`,
+					Headers:    map[string][]string{"Content-Type": {"text/html; charset=utf-8"}},
+				},
+			},
+			want: errors.Errorf(errUnexpectedContentType, "text/html; charset=utf-8", "application/json"),
+		},
+		"ContentTypeMissing": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					ExpectedContentType: "application/json",
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{}`,
+				},
+			},
+			want: errors.Errorf(errUnexpectedContentType, "", "application/json"),
+		},
+		"BodyWithinLimit": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					MaxBodyBytes: ptr.To[int64](2),
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{}`,
+				},
+			},
+			want: nil,
+		},
+		"BodyTooLarge": {
+			args: args{
+				spec: &v1alpha2.DisposableRequestParameters{
+					MaxBodyBytes: ptr.To[int64](1),
+				},
+				res: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       `{}`,
+				},
+			},
+			want: errors.Errorf(errBodyTooLarge, 2, 1),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CheckResponseGuards(tc.args.spec, tc.args.res)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("CheckResponseGuards(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestIsResponseAsExpectedElapsed(t *testing.T) {
+	spec := &v1alpha2.DisposableRequestParameters{
+		ExpectedResponse: `if .body.status == "PENDING" and .elapsed > 1800 then error("still pending after 30m") else .body.status == "DONE" end`,
+	}
+	pending := httpClient.HttpResponse{StatusCode: 200, Body: `{"status": "PENDING"}`}
+
+	cases := map[string]struct {
+		reason   string
+		elapsed  time.Duration
+		expected bool
+		wantErr  bool
+	}{
+		"PendingWithinThreshold": {
+			reason:  "Should keep waiting for a pending job while .elapsed is below the threshold",
+			elapsed: 10 * time.Minute,
+		},
+		"PendingPastThreshold": {
+			reason:  "Should fail a pending job once .elapsed exceeds the threshold",
+			elapsed: 31 * time.Minute,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsResponseAsExpected(spec, pending, tc.elapsed)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nIsResponseAsExpected(...): want error %v, got %v", tc.reason, tc.wantErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("\n%s\nIsResponseAsExpected(...): wanted %v, got %v", tc.reason, tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha2.DisposableRequestParameters
+		body   string
+		want   string
+	}{
+		"NoLimit": {
+			reason: "Should keep the body when the spec sets no maxBodyBytes",
+			spec:   &v1alpha2.DisposableRequestParameters{},
+			body:   `{"status": "success"}`,
+			want:   `{"status": "success"}`,
+		},
+		"WithinLimit": {
+			reason: "Should keep a body within maxBodyBytes",
+			spec:   &v1alpha2.DisposableRequestParameters{MaxBodyBytes: ptr.To[int64](2)},
+			body:   `{}`,
+			want:   `{}`,
+		},
+		"TooLarge": {
+			reason: "Should truncate a body exceeding maxBodyBytes to the limit",
+			spec:   &v1alpha2.DisposableRequestParameters{MaxBodyBytes: ptr.To[int64](8)},
+			body:   `{"status": "success"}`,
+			want:   `{"status`,
+		},
+		"MultiByteCharacter": {
+			reason: "Should not split a UTF-8 character at the limit",
+			spec:   &v1alpha2.DisposableRequestParameters{MaxBodyBytes: ptr.To[int64](2)},
+			body:   "aé",
+			want:   "a",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := truncateBody(tc.spec, tc.body)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ntruncateBody(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
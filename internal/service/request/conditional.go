@@ -0,0 +1,73 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+)
+
+const (
+	headerLastModified    = "Last-Modified"
+	headerIfModifiedSince = "If-Modified-Since"
+)
+
+// conditionalCache returns the cached response the OBSERVE request can be sent conditionally on, or nil. The
+// cache is only used when the spec enables it, the cached response carries a Last-Modified header, and the last
+// request sent was the same one, so that the cached response answers it. A cached body transformed for the status
+// only cannot answer the checks, which operate on the raw body.
+func conditionalCache(crCtx *service.RequestCRContext, requestDetails requestgen.RequestDetails, method string) interfaces.HTTPResponse {
+	aware, ok := crCtx.Spec().(interfaces.ConditionalObserveAware)
+	if !ok || !aware.GetIfModifiedSince() {
+		return nil
+	}
+	if statushandler.ResponseTransformScope(crCtx.Spec()) == common.ResponseTransformScopeStatus {
+		return nil
+	}
+
+	reader, ok := crCtx.GetRequestResource().(interfaces.ResponseCacheReader)
+	if !ok {
+		return nil
+	}
+
+	cache := reader.GetResponseCache()
+	if cache == nil || httpClient.HeaderValue(cache.GetHeaders(), headerLastModified) == "" {
+		return nil
+	}
+
+	lastRequest := crCtx.Status().GetRequestDetails()
+	if lastRequest.GetMethod() != method || lastRequest.GetURL() != requestDetails.RecordedURL() {
+		return nil
+	}
+
+	return cache
+}
+
+// addIfModifiedSince adds the If-Modified-Since header carrying the Last-Modified value of the cached response to
+// the request, if not already set by the mapping.
+func addIfModifiedSince(requestDetails *requestgen.RequestDetails, cache interfaces.HTTPResponse) {
+	lastModified := httpClient.HeaderValue(cache.GetHeaders(), headerLastModified)
+	for _, headers := range []interface{}{requestDetails.Headers.Encrypted, requestDetails.Headers.Decrypted} {
+		if headerMap, ok := headers.(map[string][]string); ok && httpClient.HeaderValues(headerMap, headerIfModifiedSince) == nil {
+			headerMap[headerIfModifiedSince] = []string{lastModified}
+		}
+	}
+}
+
+// notModified checks whether the response reports the resource as not modified since the cached response.
+func notModified(cache interfaces.HTTPResponse, details httpClient.HttpDetails, responseErr error) bool {
+	return cache != nil && responseErr == nil && details.HttpResponse.StatusCode == http.StatusNotModified
+}
+
+// cachedResponse returns the cached response as the response of the request.
+func cachedResponse(cache interfaces.HTTPResponse) httpClient.HttpResponse {
+	return httpClient.HttpResponse{
+		StatusCode: cache.GetStatusCode(),
+		Headers:    cache.GetHeaders(),
+		Body:       cache.GetBody(),
+	}
+}
@@ -0,0 +1,107 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/clients/http/fake"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+)
+
+const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+
+func TestIsUpToDateIfModifiedSince(t *testing.T) {
+	cases := map[string]struct {
+		reason          string
+		ifModifiedSince bool
+		second          fake.Response
+		wantHeaders     map[string][]string
+		wantBody        string
+	}{
+		"NotModified": {
+			reason:          "Should send If-Modified-Since and check the cached response when the resource was not modified",
+			ifModifiedSince: true,
+			second:          fake.Respond(http.StatusNotModified, ""),
+			wantHeaders:     map[string][]string{"If-Modified-Since": {lastModified}},
+			wantBody:        `{"id":"1","username":"john_doe"}`,
+		},
+		"Modified": {
+			reason:          "Should check the new response when the resource was modified",
+			ifModifiedSince: true,
+			second:          fake.Respond(http.StatusOK, `{"id":"1","username":"jane_doe"}`),
+			wantHeaders:     map[string][]string{"If-Modified-Since": {lastModified}},
+			wantBody:        `{"id":"1","username":"jane_doe"}`,
+		},
+		"Disabled": {
+			reason:      "Should not send If-Modified-Since unless enabled",
+			second:      fake.Respond(http.StatusOK, `{"id":"1","username":"john_doe"}`),
+			wantHeaders: map[string][]string{},
+			wantBody:    `{"id":"1","username":"john_doe"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := fake.NewClient(
+				fake.Respond(http.StatusOK, `{"id":"1","username":"john_doe"}`).WithHeaders(map[string][]string{"Last-Modified": {lastModified}}),
+				tc.second,
+			)
+			kube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), kube, logging.NewNopLogger(), client, nil)
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.IfModifiedSince = tc.ifModifiedSince
+				r.Spec.ForProvider.Payload.Body = `{"username": "john_doe"}`
+				r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+					{Method: http.MethodPost, URL: ".payload.baseUrl"},
+					{Method: http.MethodGet, URL: `(.payload.baseUrl + "/1")`},
+				}
+			})
+			crCtx := service.NewRequestCRContext(cr)
+
+			// The first observation caches the response and its Last-Modified header.
+			first, err := IsUpToDate(svcCtx, crCtx)
+			if err != nil {
+				t.Fatalf("\n%s\nIsUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			handler, err := statushandler.NewStatusHandler(svcCtx, crCtx, first.Details, first.ResponseError)
+			if err != nil {
+				t.Fatalf("\n%s\nNewStatusHandler(...): unexpected error: %v", tc.reason, err)
+			}
+			if err := handler.SetRequestStatus(); err != nil {
+				t.Fatalf("\n%s\nSetRequestStatus(): unexpected error: %v", tc.reason, err)
+			}
+
+			got, err := IsUpToDate(svcCtx, crCtx)
+			if err != nil {
+				t.Fatalf("\n%s\nIsUpToDate(...): unexpected error: %v", tc.reason, err)
+			}
+			if !got.Synced {
+				t.Errorf("\n%s\nIsUpToDate(...): want the resource to be up to date", tc.reason)
+			}
+			if got.Details.HttpResponse.StatusCode != http.StatusOK {
+				t.Errorf("\n%s\nIsUpToDate(...): want status code %d, got %d", tc.reason, http.StatusOK, got.Details.HttpResponse.StatusCode)
+			}
+			if diff := cmp.Diff(tc.wantBody, got.Details.HttpResponse.Body); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want checked body, +got:\n%s", tc.reason, diff)
+			}
+
+			calls := client.Calls()
+			if len(calls) != 2 {
+				t.Fatalf("\n%s\nIsUpToDate(...): want 2 requests, got %d", tc.reason, len(calls))
+			}
+			if diff := cmp.Diff(tc.wantHeaders, calls[1].Headers); diff != "" {
+				t.Errorf("\n%s\nIsUpToDate(...): -want headers of the second request, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
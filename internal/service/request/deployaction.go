@@ -1,11 +1,14 @@
 package request
 
 import (
-	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jsonschema"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestmapping"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
 // DeployAction executes the action based on the given Request resource and Mapping configuration.
@@ -17,21 +20,67 @@ func DeployAction(svcCtx *service.ServiceContext, crCtx *service.RequestCRContex
 		return nil
 	}
 
+	enabled, err := requestgen.IsMappingEnabled(svcCtx, crCtx, mapping)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		svcCtx.Logger.Debug("Skipping the request, the when filter of the mapping is false", "action", action)
+		return nil
+	}
+
+	if (action == common.ActionCreate || action == common.ActionRemove) && requestgen.ForEach(mapping) != "" {
+		return deployForEach(svcCtx, crCtx, mapping, action)
+	}
+
 	requestDetails, err := requestgen.GenerateValidRequestDetails(svcCtx, crCtx, mapping)
 	if err != nil {
 		return err
 	}
+	addIdempotencyKey(spec, crCtx.GetCR(), action, &requestDetails)
+
+	requestCtx, err := requestmapping.RequestContext(svcCtx.Ctx, mapping)
+	if err != nil {
+		return err
+	}
 
-	details, sendErr := svcCtx.HTTP.SendRequest(svcCtx.Ctx, requestmapping.GetEffectiveMethod(mapping), requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+	details, sendErr := svcCtx.HTTP.SendRequest(httpClient.ContextWithRedactedURL(requestCtx, requestDetails.RecordedURL()), requestmapping.GetEffectiveMethod(mapping), requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+	statushandler.TransformResponse(svcCtx.Logger, spec, common.ResponseTransformScopeResponse, &details.HttpResponse)
+
+	// A response not matching the response schema fails before its data is injected into secrets.
+	var schemaErr error
+	if statushandler.IsResponseSucceeded(spec, &details.HttpResponse) {
+		schemaErr = jsonschema.CheckResponse(svcCtx.Ctx, svcCtx.LocalKube, spec, details.HttpResponse.Body)
+	}
+	if schemaErr != nil && !jsonschema.IsMismatch(schemaErr) {
+		return schemaErr
+	}
 
 	// Apply response data to secrets and update CR status
-	secretConfigs := spec.GetSecretInjectionConfigs()
-	datapatcher.ApplyResponseDataToSecrets(svcCtx.Ctx, svcCtx.LocalKube, svcCtx.Logger, &details.HttpResponse, secretConfigs, crCtx.GetCR())
+	if schemaErr == nil {
+		applyResponseDataToSecrets(svcCtx, crCtx, requestDetails, &details.HttpResponse)
+	}
 
 	statusHandler, err := statushandler.NewStatusHandler(svcCtx, crCtx, details, sendErr)
 	if err != nil {
 		return err
 	}
+	statusHandler.SetResponseFailure(schemaErr)
+	statusHandler.RecordAttempt(action)
+
+	if err := statusHandler.SetRequestStatus(); err != nil {
+		return err
+	}
+
+	// The status update refreshes the resource, so the external name is set afterwards.
+	if sendErr == nil && schemaErr == nil {
+		setExternalNameFromResponse(svcCtx, spec, crCtx.GetCR(), action, details.HttpResponse)
+	}
+
+	if config := waitForReadyConfig(mapping); action == common.ActionCreate && config != nil &&
+		sendErr == nil && schemaErr == nil && !utils.IsHTTPError(details.HttpResponse.StatusCode) {
+		return waitForReady(svcCtx, crCtx, config)
+	}
 
-	return statusHandler.SetRequestStatus()
+	return nil
 }
@@ -2,7 +2,9 @@ package request
 
 import (
 	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
@@ -254,6 +256,59 @@ func TestDeployAction(t *testing.T) {
 				statusCode: 204,
 			},
 		},
+		"SuccessfulCustomMethodAction": {
+			reason: "Should send custom methods as is",
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					ObjectMeta: v1.ObjectMeta{
+						Name:      "test-request",
+						Namespace: "testns",
+					},
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Payload: v1alpha2.Payload{
+								BaseUrl: testURL + "/123",
+							},
+							Mappings: []v1alpha2.Mapping{
+								{
+									Method: "PURGE",
+									Action: "REMOVE",
+									URL:    ".payload.baseUrl",
+								},
+							},
+						},
+					},
+					Status: v1alpha2.RequestStatus{},
+				},
+				action: "REMOVE",
+				localKube: &test.MockClient{
+					MockGet:          test.NewMockGetFn(nil),
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				httpClient: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if method != "PURGE" {
+							return httpClient.HttpDetails{}, errBoom
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+								Headers:    testHeaders,
+							},
+							HttpRequest: httpClient.HttpRequest{
+								Method: "PURGE",
+								URL:    testURL + "/123",
+							},
+						}, nil
+					},
+				},
+			},
+			want: want{
+				err:        nil,
+				statusCode: 200,
+			},
+		},
 		"HttpRequestError": {
 			reason: "Should handle HTTP request errors",
 			args: args{
@@ -362,3 +417,119 @@ func TestDeployAction(t *testing.T) {
 		})
 	}
 }
+
+func TestMappingTimeout(t *testing.T) {
+	url := strconv.Quote(testURL)
+	mappings := []v1alpha2.Mapping{
+		{Action: "CREATE", URL: url, Timeout: &v1.Duration{Duration: 10 * time.Minute}},
+		{Action: "OBSERVE", URL: url, Timeout: &v1.Duration{Duration: 5 * time.Second}},
+		{Action: "UPDATE", URL: url},
+		{Action: "REMOVE", URL: url, Timeout: &v1.Duration{Duration: time.Minute}},
+	}
+
+	cases := map[string]struct {
+		action      string
+		wantTimeout time.Duration
+		wantSet     bool
+	}{
+		"CreateUsesItsTimeout":         {action: "CREATE", wantTimeout: 10 * time.Minute, wantSet: true},
+		"ObserveUsesItsTimeout":        {action: "OBSERVE", wantTimeout: 5 * time.Second, wantSet: true},
+		"UpdateFallsBackToWaitTimeout": {action: "UPDATE"},
+		"RemoveUsesItsTimeout":         {action: "REMOVE", wantTimeout: time.Minute, wantSet: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotTimeout time.Duration
+			var gotSet bool
+			http := &MockHttpClient{
+				MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+					gotTimeout, gotSet = httpClient.TimeoutFromContext(ctx)
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 200}}, nil
+				},
+			}
+			cr := &v1alpha2.Request{
+				ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+				Spec:       v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{Mappings: mappings}},
+			}
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+			crCtx := service.NewRequestCRContext(cr)
+
+			var err error
+			if tc.action == "OBSERVE" {
+				_, err = IsRemoved(svcCtx, crCtx)
+			} else {
+				err = DeployAction(svcCtx, crCtx, tc.action)
+			}
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.action, err)
+			}
+
+			if gotSet != tc.wantSet || gotTimeout != tc.wantTimeout {
+				t.Errorf("%s: want timeout %v (set: %t), got %v (set: %t)", tc.action, tc.wantTimeout, tc.wantSet, gotTimeout, gotSet)
+			}
+		})
+	}
+}
+
+func TestMappingWhen(t *testing.T) {
+	cases := map[string]struct {
+		when     string
+		wantSent bool
+		wantErr  bool
+	}{
+		"NoFilterSends": {
+			wantSent: true,
+		},
+		"FilterTrueSends": {
+			when:     ".payload.body.tier != .response.body.tier",
+			wantSent: true,
+		},
+		"FilterFalseSkips": {
+			when: ".payload.body.tier == .response.body.tier",
+		},
+		"FilterNotBooleanFails": {
+			when:    ".payload.body.tier",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sent := false
+			http := &MockHttpClient{
+				MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+					sent = true
+					return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 200}}, nil
+				},
+			}
+			cr := &v1alpha2.Request{
+				ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+				Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+					Payload: v1alpha2.Payload{Body: `{"tier": "gold"}`},
+					Mappings: []v1alpha2.Mapping{
+						{Action: "UPDATE", Method: "PATCH", URL: strconv.Quote(testURL), Body: "{ tier: .payload.body.tier }", When: tc.when},
+					},
+				}},
+				Status: v1alpha2.RequestStatus{Response: v1alpha2.Response{StatusCode: 200, Body: `{"tier": "silver"}`}},
+			}
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+			err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "UPDATE")
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("DeployAction(...): want error %t, got %v", tc.wantErr, err)
+			}
+			if sent != tc.wantSent {
+				t.Errorf("DeployAction(...): want request sent %t, got %t", tc.wantSent, sent)
+			}
+		})
+	}
+}
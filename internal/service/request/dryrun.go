@@ -0,0 +1,28 @@
+package request
+
+import (
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestmapping"
+)
+
+// DryRun renders the request of the given action into the request details of the status, without sending it.
+// Secret references are left unresolved in the rendered body and headers, so no secret value is written to the status.
+func DryRun(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, action string) error {
+	spec := crCtx.Spec()
+	mapping, err := requestmapping.GetMapping(spec, action, svcCtx.Logger)
+	if err != nil {
+		return err
+	}
+
+	requestDetails, err := requestgen.GenerateValidRequestDetails(svcCtx, crCtx, mapping)
+	if err != nil {
+		return err
+	}
+	addIdempotencyKey(spec, crCtx.GetCR(), action, &requestDetails)
+
+	body, _ := requestDetails.Body.Encrypted.(string)
+	headers, _ := requestDetails.Headers.Encrypted.(map[string][]string)
+	crCtx.StatusWriter().SetRequestDetails(requestDetails.RecordedURL(), requestmapping.GetEffectiveMethod(mapping), body, headers)
+	return nil
+}
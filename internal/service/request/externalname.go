@@ -0,0 +1,69 @@
+package request
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+)
+
+const errAdoptionNotConfirmed = "cannot confirm whether the external resource %q to adopt exists, OBSERVE request failed with status code %d"
+
+// setExternalNameFromResponse sets the external name of the resource to the value selected from the response of a
+// successful CREATE request, if configured. An external name already set is kept, so a retried CREATE request or an
+// imported resource never changes it. The annotation is only set on the given object, and persisted by the managed
+// reconciler once the creation succeeded.
+func setExternalNameFromResponse(svcCtx *service.ServiceContext, spec interfaces.MappedHTTPRequestSpec, cr metav1.Object, action string, response httpClient.HttpResponse) {
+	aware, ok := spec.(interfaces.ExternalNameAware)
+	if !ok || action != common.ActionCreate || aware.GetExternalNameFrom() == "" || !statushandler.IsResponseSucceeded(spec, &response) {
+		return
+	}
+
+	externalName, ok := statushandler.ExtractResponseValue(svcCtx.Logger, aware.GetExternalNameFrom(), response)
+	if !ok || externalName == "" {
+		svcCtx.Logger.Debug("The external name was not found in the response", "externalNameFrom", aware.GetExternalNameFrom())
+		return
+	}
+
+	if current := meta.GetExternalName(cr); current != "" {
+		if current != externalName {
+			svcCtx.Logger.Debug("Keeping the external name already set", "externalName", current, "fromResponse", externalName)
+		}
+		return
+	}
+
+	meta.SetExternalName(cr, externalName)
+}
+
+// adoptsExternalName checks if the resource adopts the existing external resource identified by its external name.
+// An external name defaulted to the name of the Request identifies no existing resource, unless externalNameFrom
+// is set, which disables the default.
+func adoptsExternalName(crCtx *service.RequestCRContext) bool {
+	cr := crCtx.GetCR()
+	externalName := meta.GetExternalName(cr)
+	if externalName == "" {
+		return false
+	}
+
+	aware, ok := crCtx.Spec().(interfaces.ExternalNameAware)
+	return externalName != cr.GetName() || (ok && aware.GetExternalNameFrom() != "")
+}
+
+// adoptionFailure returns the error of an OBSERVE request that did not find the external resource to adopt before
+// it was created. Only a response the is-removed check reports as not found lets the resource be created, so a
+// transient failure never duplicates the adopted resource.
+func adoptionFailure(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, responseErr error) error {
+	if err := determineIfRemoved(svcCtx, crCtx, details, responseErr); err != nil {
+		return err
+	}
+	if responseErr != nil {
+		return responseErr
+	}
+
+	return errors.Errorf(errAdoptionNotConfirmed, meta.GetExternalName(crCtx.GetCR()), details.HttpResponse.StatusCode)
+}
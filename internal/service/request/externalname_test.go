@@ -0,0 +1,103 @@
+package request
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExternalNameFrom(t *testing.T) {
+	mappings := []v1alpha2.Mapping{
+		{Action: "CREATE", Method: "POST", URL: strconv.Quote(testURL)},
+		{Action: "UPDATE", Method: "PUT", URL: "(\"" + testURL + "/\" + .externalName)"},
+	}
+
+	cases := map[string]struct {
+		reason           string
+		externalName     string
+		externalNameFrom string
+		statusCode       int
+		wantExternalName string
+		wantUpdateURL    string
+	}{
+		"SetFromResponse": {
+			reason:           "Should set the external name from the CREATE response and template it in later mappings",
+			externalNameFrom: ".body.id",
+			statusCode:       201,
+			wantExternalName: "123",
+			wantUpdateURL:    testURL + "/123",
+		},
+		"AlreadySet": {
+			reason:           "Should keep an external name already set",
+			externalName:     "42",
+			externalNameFrom: ".body.id",
+			statusCode:       201,
+			wantExternalName: "42",
+			wantUpdateURL:    testURL + "/42",
+		},
+		"FailedCreate": {
+			reason:           "Should not set the external name from a failed CREATE response",
+			externalNameFrom: ".body.id",
+			statusCode:       500,
+		},
+		"NotConfigured": {
+			reason:     "Should not set the external name without externalNameFrom",
+			statusCode: 201,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var sentURL string
+			http := &MockHttpClient{
+				MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+					sentURL = url
+					return httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{StatusCode: tc.statusCode, Body: `{"id": "123"}`},
+						HttpRequest:  httpClient.HttpRequest{Method: method, URL: url},
+					}, nil
+				},
+			}
+			cr := &v1alpha2.Request{
+				ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+				Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+					Mappings:         mappings,
+					ExternalNameFrom: tc.externalNameFrom,
+				}},
+			}
+			if tc.externalName != "" {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+			if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+				t.Fatalf("\n%s\nDeployAction(CREATE): unexpected error: %v", tc.reason, err)
+			}
+			if got := meta.GetExternalName(cr); got != tc.wantExternalName {
+				t.Errorf("\n%s\nDeployAction(CREATE): want external name %q, got %q", tc.reason, tc.wantExternalName, got)
+			}
+
+			if tc.wantUpdateURL == "" {
+				return
+			}
+			if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "UPDATE"); err != nil {
+				t.Fatalf("\n%s\nDeployAction(UPDATE): unexpected error: %v", tc.reason, err)
+			}
+			if sentURL != tc.wantUpdateURL {
+				t.Errorf("\n%s\nDeployAction(UPDATE): want URL %q, got %q", tc.reason, tc.wantUpdateURL, sentURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package request
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/jsonschema"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errForEachEmpty       = "the forEach filter %s of the mapping selected no elements"
+	errForEachItemsFailed = "%d of %d forEach items failed, failed items: %v"
+	errItemStatusCode     = "request failed with status code %d"
+	errGetLatestVersion   = "failed to get the latest version of the resource"
+)
+
+// deployForEach sends the request of the mapping once per element of its forEach array and records the outcome of
+// every request in status.items. The responses are aggregated into a single response whose body is the array of
+// their bodies, which fails if any of the requests failed. The response of every element is transformed and
+// checked against the response schema like a single response, while the external name is read from the aggregated
+// response. On a retry after such a failure, the elements whose
+// request succeeded are not sent again, unless the request rendered for them changed, e.g. because the element was
+// edited. Once every request succeeded, a later CREATE, e.g. of resources deleted out of band, sends them all again.
+//
+// A REMOVE mapping keeps the response its array was selected from, typically the aggregated response of the
+// CREATE mapping, and sends the request of every element again on a retry. An element reported as not found is
+// already removed, and the removal fails until every element is removed.
+func deployForEach(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping, action string) error {
+	items, err := requestgen.ForEachItems(svcCtx, crCtx, mapping)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return errors.Errorf(errForEachEmpty, requestgen.ForEach(mapping))
+	}
+
+	var previous []common.ItemResult
+	if action == common.ActionCreate {
+		previous = previousItems(crCtx)
+	}
+	results := make([]common.ItemResult, len(items))
+	var last httpClient.HttpDetails
+	for index, item := range items {
+		requestDetails, err := requestgen.GenerateItemRequestDetails(svcCtx, crCtx, mapping, index, item)
+		if err != nil {
+			results[index] = common.ItemResult{Index: index, Error: err.Error()}
+			continue
+		}
+
+		hash := requestHash(mapping, requestDetails)
+		if index < len(previous) && previous[index].Error == "" && previous[index].RequestHash == hash {
+			results[index] = previous[index]
+			continue
+		}
+
+		results[index], last = sendItem(svcCtx, crCtx, mapping, action, index, requestDetails)
+		results[index].RequestHash = hash
+	}
+
+	if action == common.ActionRemove {
+		return setRemovedItems(svcCtx, crCtx, results, last, itemsFailure(results))
+	}
+
+	details, err := aggregateItems(results, last)
+	if err != nil {
+		return err
+	}
+
+	failure := itemsFailure(results)
+	if failure == nil {
+		datapatcher.ApplyResponseDataToSecrets(svcCtx.Ctx, svcCtx.LocalKube, svcCtx.Logger, &details.HttpResponse, crCtx.Spec().GetSecretInjectionConfigs(), crCtx.GetCR())
+	}
+
+	statusHandler, err := statushandler.NewStatusHandler(svcCtx, crCtx, details, nil)
+	if err != nil {
+		return err
+	}
+	statusHandler.SetItems(results)
+	statusHandler.SetResponseFailure(failure)
+	statusHandler.RecordAttempt(action)
+
+	if err := statusHandler.SetRequestStatus(); err != nil {
+		return err
+	}
+
+	// The status update refreshes the resource, so the external name is set afterwards.
+	if failure == nil {
+		setExternalNameFromResponse(svcCtx, crCtx.Spec(), crCtx.GetCR(), action, details.HttpResponse)
+	}
+
+	return nil
+}
+
+// previousItems returns the outcome of the requests of the previous attempt, if any of them failed.
+func previousItems(crCtx *service.RequestCRContext) []common.ItemResult {
+	writer, ok := crCtx.GetCR().(interfaces.ItemsWriter)
+	if !ok || itemsFailure(writer.GetItems()) == nil {
+		return nil
+	}
+
+	return writer.GetItems()
+}
+
+// requestHash returns the hash of the request rendered for an element of the forEach array, telling whether the
+// element or the mapping changed since its request was sent. The secret values are left out of the hash.
+func requestHash(mapping interfaces.HTTPMapping, requestDetails requestgen.RequestDetails) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		requestmapping.GetEffectiveMethod(mapping),
+		requestDetails.RecordedURL(),
+		fmt.Sprint(requestDetails.Body.Encrypted),
+	}, "\x00")))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// sendItem sends the request rendered for an element of the forEach array of the mapping and returns its outcome.
+func sendItem(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping, action string, index int, requestDetails requestgen.RequestDetails) (common.ItemResult, httpClient.HttpDetails) {
+	result := common.ItemResult{Index: index}
+
+	setIdempotencyKey(crCtx.Spec(), action, &requestDetails, itemIdempotencyKey(crCtx.GetCR(), index))
+
+	requestCtx, err := requestmapping.RequestContext(svcCtx.Ctx, mapping)
+	if err != nil {
+		result.Error = err.Error()
+		return result, httpClient.HttpDetails{}
+	}
+
+	details, err := svcCtx.HTTP.SendRequest(httpClient.ContextWithRedactedURL(requestCtx, requestDetails.RecordedURL()), requestmapping.GetEffectiveMethod(mapping), requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+	statushandler.TransformResponse(svcCtx.Logger, crCtx.Spec(), common.ResponseTransformScopeResponse, &details.HttpResponse)
+	result.StatusCode = details.HttpResponse.StatusCode
+	result.Body = details.HttpResponse.Body
+
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case action == common.ActionRemove && isNotFoundStatusCode(crCtx.Spec(), details.HttpResponse.StatusCode):
+		// The element was already removed.
+	case !statushandler.IsResponseSucceeded(crCtx.Spec(), &details.HttpResponse):
+		result.Error = fmt.Sprintf(errItemStatusCode, details.HttpResponse.StatusCode)
+	default:
+		// The response of every element must match the response schema.
+		if err := jsonschema.CheckResponse(svcCtx.Ctx, svcCtx.LocalKube, crCtx.Spec(), details.HttpResponse.Body); err != nil {
+			result.Error = err.Error()
+		}
+	}
+
+	return result, details
+}
+
+// setRemovedItems records the outcome of the REMOVE requests sent for the elements of a forEach array, without
+// replacing the response the array was selected from, and returns the failure of the removal, if any.
+func setRemovedItems(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, results []common.ItemResult, last httpClient.HttpDetails, failure error) error {
+	cr := crCtx.GetCR()
+	if err := svcCtx.LocalKube.Get(svcCtx.Ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, cr); err != nil {
+		return errors.Wrap(err, errGetLatestVersion)
+	}
+
+	resource := utils.RequestResource{
+		StatusWriter:   crCtx.StatusWriter(),
+		Resource:       cr,
+		HttpRequest:    last.HttpRequest,
+		RequestContext: svcCtx.Ctx,
+		LocalClient:    svcCtx.LocalKube,
+	}
+	setters := []utils.SetRequestStatusFunc{
+		resource.SetItems(results),
+		resource.SetRequestDetails(),
+		resource.RecordAttempt(common.ActionRemove, failure),
+	}
+	if failure != nil {
+		setters = append(setters, resource.SetError(failure))
+	} else {
+		setters = append(setters, resource.ResetFailures())
+	}
+
+	if err := utils.SetRequestResourceStatus(resource, setters...); err != nil {
+		return errors.Wrap(err, utils.ErrFailedToSetStatus)
+	}
+
+	return failure
+}
+
+// aggregateItems returns the response aggregating the outcome of the requests, whose body is the array of their
+// bodies, parsed when they are JSON. Its status code is the one of the first failed request that got a response,
+// otherwise the one of the last request. The headers and the request details are the ones of the last request sent.
+func aggregateItems(results []common.ItemResult, last httpClient.HttpDetails) (httpClient.HttpDetails, error) {
+	bodies := make([]interface{}, len(results))
+	statusCode := results[len(results)-1].StatusCode
+	failedStatusCode := 0
+	for index, result := range results {
+		var body interface{}
+		if err := json_util.Unmarshal([]byte(result.Body), &body); err != nil {
+			body = result.Body
+		}
+		bodies[index] = body
+
+		if result.Error != "" && failedStatusCode == 0 {
+			failedStatusCode = result.StatusCode
+		}
+	}
+	if failedStatusCode != 0 {
+		statusCode = failedStatusCode
+	}
+
+	body, err := json.Marshal(bodies)
+	if err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	return httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: statusCode,
+			Headers:    last.HttpResponse.Headers,
+			Body:       string(body),
+		},
+		HttpRequest: last.HttpRequest,
+	}, nil
+}
+
+// itemsFailure returns an error reporting the indexes of the failed requests, or nil if none failed.
+func itemsFailure(results []common.ItemResult) error {
+	var failed []int
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result.Index)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return errors.Errorf(errForEachItemsFailed, len(failed), len(results), failed)
+}
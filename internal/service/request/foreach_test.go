@@ -0,0 +1,314 @@
+package request
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/clients/http/fake"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ignoreRequestHash ignores the hashes of the requests sent for the elements of a forEach array.
+var ignoreRequestHash = cmpopts.IgnoreFields(common.ItemResult{}, "RequestHash")
+
+func TestDeployForEach(t *testing.T) {
+	http := fake.NewClient(
+		fake.Respond(201, `{"id":"alice-id"}`),
+		fake.Respond(500, `{"error":"boom"}`),
+		fake.Respond(201, `{"id":"carol-id"}`),
+	)
+	cr := &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+		Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+			Payload: v1alpha2.Payload{Body: `{"users": ["alice", "bob", "carol"]}`},
+			Mappings: []v1alpha2.Mapping{{
+				Action:  "CREATE",
+				Method:  "POST",
+				ForEach: ".payload.body.users",
+				URL:     "(" + strconv.Quote(testURL+"/") + " + .item)",
+				Body:    "{ index: .index }",
+			}},
+		}},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+
+	wantItems := []common.ItemResult{
+		{Index: 0, StatusCode: 201, Body: `{"id":"alice-id"}`},
+		{Index: 1, StatusCode: 500, Body: `{"error":"boom"}`, Error: "request failed with status code 500"},
+		{Index: 2, StatusCode: 201, Body: `{"id":"carol-id"}`},
+	}
+	if diff := cmp.Diff(wantItems, cr.Status.Items, ignoreRequestHash); diff != "" {
+		t.Errorf("DeployAction(...): -want items, +got items:\n%s", diff)
+	}
+	http.AssertCalls(t,
+		fake.Call{Method: "POST", URL: testURL + "/alice", Body: `{"index":0}`},
+		fake.Call{Method: "POST", URL: testURL + "/bob", Body: `{"index":1}`},
+		fake.Call{Method: "POST", URL: testURL + "/carol", Body: `{"index":2}`},
+	)
+	if cr.Status.Failed != 1 || cr.Status.Error != "1 of 3 forEach items failed, failed items: [1]" {
+		t.Errorf("DeployAction(...): want one failure for item 1, got %d failures: %q", cr.Status.Failed, cr.Status.Error)
+	}
+	if want := `[{"id":"alice-id"},{"error":"boom"},{"id":"carol-id"}]`; cr.Status.Response.Body != want || cr.Status.Response.StatusCode != 500 {
+		t.Errorf("DeployAction(...): want aggregated response 500 %s, got %d %s", want, cr.Status.Response.StatusCode, cr.Status.Response.Body)
+	}
+
+	// The retry only sends the request of the failed item.
+	http.Queue(fake.Respond(201, `{"id":"bob-id"}`))
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error on retry: %v", err)
+	}
+	if calls := http.Calls(); len(calls) != 4 || calls[3].URL != testURL+"/bob" {
+		t.Errorf("DeployAction(...): want only the request of bob sent on retry, got calls %v", calls[3:])
+	}
+	if cr.Status.Failed != 0 || cr.Status.Response.StatusCode != 201 {
+		t.Errorf("DeployAction(...): want a successful retry, got status code %d with %d failures: %q", cr.Status.Response.StatusCode, cr.Status.Failed, cr.Status.Error)
+	}
+	if want := `[{"id":"alice-id"},{"id":"bob-id"},{"id":"carol-id"}]`; cr.Status.Response.Body != want {
+		t.Errorf("DeployAction(...): want aggregated body %s, got %s", want, cr.Status.Response.Body)
+	}
+}
+
+func TestDeployForEachEditedItem(t *testing.T) {
+	http := fake.NewClient(
+		fake.Respond(201, `{"id":"alice-id"}`),
+		fake.Respond(500, `{"error":"boom"}`),
+	)
+	cr := &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+		Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+			Payload: v1alpha2.Payload{Body: `{"users": ["alice", "bob"]}`},
+			Mappings: []v1alpha2.Mapping{{
+				Action:  "CREATE",
+				Method:  "POST",
+				ForEach: ".payload.body.users",
+				URL:     "(" + strconv.Quote(testURL+"/") + " + .item)",
+			}},
+		}},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+
+	// The element whose request succeeded is edited after the partial failure.
+	cr.Spec.ForProvider.Payload.Body = `{"users": ["alicia", "bob"]}`
+	http.Queue(fake.Respond(201, `{"id":"alicia-id"}`), fake.Respond(201, `{"id":"bob-id"}`))
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error on retry: %v", err)
+	}
+
+	http.AssertCalls(t,
+		fake.Call{Method: "POST", URL: testURL + "/alice"},
+		fake.Call{Method: "POST", URL: testURL + "/bob"},
+		fake.Call{Method: "POST", URL: testURL + "/alicia"},
+		fake.Call{Method: "POST", URL: testURL + "/bob"},
+	)
+	if want := `[{"id":"alicia-id"},{"id":"bob-id"}]`; cr.Status.Response.Body != want {
+		t.Errorf("DeployAction(...): want aggregated body %s, got %s", want, cr.Status.Response.Body)
+	}
+
+}
+
+func TestDeployForEachAfterSuccess(t *testing.T) {
+	http := fake.NewClient(
+		fake.Respond(201, `{"id":"alice-id"}`),
+		fake.Respond(201, `{"id":"bob-id"}`),
+	)
+	cr := &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+		Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+			Payload: v1alpha2.Payload{Body: `{"users": ["alice", "bob"]}`},
+			Mappings: []v1alpha2.Mapping{{
+				Action:  "CREATE",
+				Method:  "POST",
+				ForEach: ".payload.body.users",
+				URL:     "(" + strconv.Quote(testURL+"/") + " + .item)",
+			}},
+		}},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+
+	// The resources were deleted out of band, so they are created again.
+	http.Queue(fake.Respond(201, `{"id":"alice-id2"}`), fake.Respond(201, `{"id":"bob-id2"}`))
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error on the second CREATE: %v", err)
+	}
+
+	http.AssertCalls(t,
+		fake.Call{Method: "POST", URL: testURL + "/alice"},
+		fake.Call{Method: "POST", URL: testURL + "/bob"},
+		fake.Call{Method: "POST", URL: testURL + "/alice"},
+		fake.Call{Method: "POST", URL: testURL + "/bob"},
+	)
+	if want := `[{"id":"alice-id2"},{"id":"bob-id2"}]`; cr.Status.Response.Body != want {
+		t.Errorf("DeployAction(...): want aggregated body %s, got %s", want, cr.Status.Response.Body)
+	}
+}
+
+func TestDeployForEachResponseHandling(t *testing.T) {
+	http := fake.NewClient(
+		fake.Respond(201, `{"id":"alice-id","secret":"a"}`),
+		fake.Respond(201, `{"id":"bob-id","secret":"b"}`),
+	)
+	cr := &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+		Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+			Payload: v1alpha2.Payload{Body: `{"users": ["alice", "bob"]}`},
+			Mappings: []v1alpha2.Mapping{{
+				Action:  "CREATE",
+				Method:  "POST",
+				ForEach: ".payload.body.users",
+				URL:     "(" + strconv.Quote(testURL+"/") + " + .item)",
+			}},
+			ResponseTransform: &common.ResponseTransformConfig{JQ: "{id}", Scope: common.ResponseTransformScopeResponse},
+			ResponseSchema:    &common.ResponseSchema{Inline: `{"type": "object", "required": ["id"], "additionalProperties": false, "properties": {"id": {"type": "string"}}}`},
+			ExternalNameFrom:  ".body | fromjson | .[0].id",
+		}},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+
+	// The responses are transformed before they are checked against the schema, which rejects the secret field.
+	if want := `[{"id":"alice-id"},{"id":"bob-id"}]`; cr.Status.Response.Body != want || cr.Status.Error != "" {
+		t.Errorf("DeployAction(...): want the transformed aggregated body %s, got %s with error %q", want, cr.Status.Response.Body, cr.Status.Error)
+	}
+	if got := meta.GetExternalName(cr); got != "alice-id" {
+		t.Errorf("DeployAction(...): want the external name read from the aggregated response, got %q", got)
+	}
+	if len(cr.Status.History) != 1 || cr.Status.History[0].Action != "CREATE" {
+		t.Errorf("DeployAction(...): want the CREATE recorded in the history, got %v", cr.Status.History)
+	}
+
+	// A response not matching the schema fails its element.
+	cr.Spec.ForProvider.ResponseTransform = nil
+	cr.Spec.ForProvider.Payload.Body = `{"users": ["carol"]}`
+	http.Queue(fake.Respond(201, `{"id":"carol-id","secret":"c"}`))
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+	if len(cr.Status.Items) != 1 || !strings.Contains(cr.Status.Items[0].Error, "does not match responseSchema") {
+		t.Errorf("DeployAction(...): want the element failing the response schema, got %v", cr.Status.Items)
+	}
+}
+
+func TestDeployForEachRemove(t *testing.T) {
+	created := `[{"id":"a"},{"id":"b"},{"id":"c"}]`
+
+	cases := map[string]struct {
+		reason     string
+		responses  []fake.Response
+		wantItems  []common.ItemResult
+		wantErr    string
+		wantFailed int32
+	}{
+		"OneAlreadyGone": {
+			reason: "Should remove every element, counting the one already gone as removed",
+			responses: []fake.Response{
+				fake.Respond(204, ""),
+				fake.Respond(404, `{"error":"not found"}`),
+				fake.Respond(204, ""),
+			},
+			wantItems: []common.ItemResult{
+				{Index: 0, StatusCode: 204},
+				{Index: 1, StatusCode: 404, Body: `{"error":"not found"}`},
+				{Index: 2, StatusCode: 204},
+			},
+		},
+		"OneFailed": {
+			reason: "Should fail the removal while an element is not removed",
+			responses: []fake.Response{
+				fake.Respond(204, ""),
+				fake.Respond(500, `{"error":"boom"}`),
+				fake.Respond(404, ""),
+			},
+			wantItems: []common.ItemResult{
+				{Index: 0, StatusCode: 204},
+				{Index: 1, StatusCode: 500, Body: `{"error":"boom"}`, Error: "request failed with status code 500"},
+				{Index: 2, StatusCode: 404},
+			},
+			wantErr:    "1 of 3 forEach items failed, failed items: [1]",
+			wantFailed: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			http := fake.NewClient(tc.responses...)
+			cr := &v1alpha2.Request{
+				ObjectMeta: v1.ObjectMeta{Name: "test-request", Namespace: "testns"},
+				Spec: v1alpha2.RequestSpec{ForProvider: v1alpha2.RequestParameters{
+					Mappings: []v1alpha2.Mapping{{
+						Action:  "REMOVE",
+						Method:  "DELETE",
+						ForEach: ".response.body",
+						URL:     "(" + strconv.Quote(testURL+"/") + " + .item.id)",
+					}},
+				}},
+				Status: v1alpha2.RequestStatus{Response: v1alpha2.Response{StatusCode: 201, Body: created}},
+			}
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+
+			err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "REMOVE")
+			if tc.wantErr == "" && err != nil || tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Fatalf("\n%s\nDeployAction(...): want error %q, got %v", tc.reason, tc.wantErr, err)
+			}
+
+			http.AssertCalls(t,
+				fake.Call{Method: "DELETE", URL: testURL + "/a"},
+				fake.Call{Method: "DELETE", URL: testURL + "/b"},
+				fake.Call{Method: "DELETE", URL: testURL + "/c"},
+			)
+			if diff := cmp.Diff(tc.wantItems, cr.Status.Items, ignoreRequestHash); diff != "" {
+				t.Errorf("\n%s\nDeployAction(...): -want items, +got items:\n%s", tc.reason, diff)
+			}
+			if cr.Status.Response.Body != created {
+				t.Errorf("\n%s\nDeployAction(...): want the created elements kept in the response, got %s", tc.reason, cr.Status.Response.Body)
+			}
+			if cr.Status.Failed != tc.wantFailed {
+				t.Errorf("\n%s\nDeployAction(...): want %d failures, got %d: %q", tc.reason, tc.wantFailed, cr.Status.Failed, cr.Status.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+)
+
+// idempotencyKeyNamespace is the namespace of the UUIDs derived from the resource UID and generation.
+var idempotencyKeyNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://http.crossplane.io/idempotency-key"))
+
+// idempotencyKey returns the idempotency key of the resource for its current generation. It is the same on every
+// retry of the generation, and changes with the UID and the generation.
+func idempotencyKey(cr metav1.Object) string {
+	return uuid.NewSHA1(idempotencyKeyNamespace, []byte(string(cr.GetUID())+"/"+strconv.FormatInt(cr.GetGeneration(), 10))).String()
+}
+
+// itemIdempotencyKey returns the idempotency key of the request sent for an element of a forEach array, derived
+// from the idempotency key of the resource and the index of the element.
+func itemIdempotencyKey(cr metav1.Object, index int) string {
+	return uuid.NewSHA1(idempotencyKeyNamespace, []byte(idempotencyKey(cr)+"/"+strconv.Itoa(index))).String()
+}
+
+// addIdempotencyKey adds the idempotency key header to the CREATE request, if configured and not already set by
+// the mapping.
+func addIdempotencyKey(spec interfaces.MappedHTTPRequestSpec, cr metav1.Object, action string, requestDetails *requestgen.RequestDetails) {
+	setIdempotencyKey(spec, action, requestDetails, idempotencyKey(cr))
+}
+
+// setIdempotencyKey sets the idempotency key header of the CREATE request to the key, if configured and not
+// already set by the mapping.
+func setIdempotencyKey(spec interfaces.MappedHTTPRequestSpec, action string, requestDetails *requestgen.RequestDetails, key string) {
+	aware, ok := spec.(interfaces.IdempotencyKeyAware)
+	if !ok || action != common.ActionCreate || aware.GetIdempotencyKeyHeader() == "" {
+		return
+	}
+
+	header := aware.GetIdempotencyKeyHeader()
+	for _, headers := range []interface{}{requestDetails.Headers.Encrypted, requestDetails.Headers.Decrypted} {
+		if headerMap, ok := headers.(map[string][]string); ok && httpClient.HeaderValues(headerMap, header) == nil {
+			headerMap[header] = []string{key}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package request
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// sentIdempotencyKey runs the action and returns the value of the header sent with the request.
+func sentIdempotencyKey(t *testing.T, cr *v1alpha2.Request, action, header string) string {
+	t.Helper()
+
+	var sent []string
+	http := &MockHttpClient{
+		MockSendRequest: func(ctx context.Context, method string, url string, body httpClient.Data, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+			sent = headers.Decrypted.(map[string][]string)[header]
+			return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 201, Body: testRespID}}, nil
+		},
+	}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), http, nil)
+	if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), action); err != nil {
+		t.Fatalf("DeployAction(...): unexpected error: %v", err)
+	}
+
+	if len(sent) == 0 {
+		return ""
+	}
+	return sent[0]
+}
+
+func idempotentRequest(uid string, generation int64, mapping v1alpha2.Mapping, idempotencyKey *v1alpha2.IdempotencyKey) *v1alpha2.Request {
+	return &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       "test-request",
+			Namespace:  "testns",
+			UID:        types.UID(uid),
+			Generation: generation,
+		},
+		Spec: v1alpha2.RequestSpec{
+			ForProvider: v1alpha2.RequestParameters{
+				Mappings:       []v1alpha2.Mapping{mapping},
+				IdempotencyKey: idempotencyKey,
+			},
+		},
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	create := v1alpha2.Mapping{Action: "CREATE", URL: strconv.Quote(testURL)}
+	enabled := &v1alpha2.IdempotencyKey{}
+
+	first := sentIdempotencyKey(t, idempotentRequest("uid-1", 1, create, enabled), "CREATE", "Idempotency-Key")
+	if first == "" {
+		t.Fatalf("DeployAction(...): want an Idempotency-Key header on CREATE")
+	}
+
+	if retry := sentIdempotencyKey(t, idempotentRequest("uid-1", 1, create, enabled), "CREATE", "Idempotency-Key"); retry != first {
+		t.Errorf("DeployAction(...): want the same key on retry, got %q then %q", first, retry)
+	}
+
+	if next := sentIdempotencyKey(t, idempotentRequest("uid-1", 2, create, enabled), "CREATE", "Idempotency-Key"); next == first {
+		t.Errorf("DeployAction(...): want a new key for a new generation, got %q twice", first)
+	}
+
+	if other := sentIdempotencyKey(t, idempotentRequest("uid-2", 1, create, enabled), "CREATE", "Idempotency-Key"); other == first {
+		t.Errorf("DeployAction(...): want a new key for another resource, got %q twice", first)
+	}
+}
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	cases := map[string]struct {
+		mapping        v1alpha2.Mapping
+		action         string
+		idempotencyKey *v1alpha2.IdempotencyKey
+		header         string
+		want           string
+	}{
+		"Disabled": {
+			mapping: v1alpha2.Mapping{Action: "CREATE", URL: strconv.Quote(testURL)},
+			action:  "CREATE",
+			header:  "Idempotency-Key",
+		},
+		"CustomHeader": {
+			mapping:        v1alpha2.Mapping{Action: "CREATE", URL: strconv.Quote(testURL)},
+			action:         "CREATE",
+			idempotencyKey: &v1alpha2.IdempotencyKey{Header: "X-Request-Id"},
+			header:         "X-Request-Id",
+			want:           idempotencyKey(&v1.ObjectMeta{UID: "uid-1", Generation: 1}),
+		},
+		"MappingHeaderKept": {
+			mapping: v1alpha2.Mapping{
+				Action:  "CREATE",
+				URL:     strconv.Quote(testURL),
+				Headers: map[string][]string{"idempotency-key": {`"from-mapping"`}},
+			},
+			action:         "CREATE",
+			idempotencyKey: &v1alpha2.IdempotencyKey{},
+			header:         "Idempotency-Key",
+		},
+		"NotSentOnUpdate": {
+			mapping:        v1alpha2.Mapping{Action: "UPDATE", URL: strconv.Quote(testURL)},
+			action:         "UPDATE",
+			idempotencyKey: &v1alpha2.IdempotencyKey{},
+			header:         "Idempotency-Key",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := sentIdempotencyKey(t, idempotentRequest("uid-1", 1, tc.mapping, tc.idempotencyKey), tc.action, tc.header)
+			if got != tc.want {
+				t.Errorf("DeployAction(...): want %s header %q, got %q", tc.header, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package request
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+)
+
+const errPatchLastSuccessfulResponse = "cannot patch the secrets into the last successful response"
+
+// lastSuccessfulResponse returns the last successful response, with its secrets patched in, to inject the secrets
+// from instead of the given response. It returns nil unless the spec asks for it, the given response failed and a
+// successful response was recorded.
+func lastSuccessfulResponse(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, response *httpClient.HttpResponse) *httpClient.HttpResponse {
+	aware, ok := crCtx.Spec().(interfaces.LastSuccessfulResponseAware)
+	if !ok || !aware.GetSecretsFromLastSuccessfulResponse() {
+		return nil
+	}
+
+	if failed, _ := statushandler.IsResponseFailed(crCtx.Spec(), response); response.StatusCode != 0 && !failed {
+		return nil
+	}
+
+	reader, ok := crCtx.GetCR().(interfaces.LastSuccessfulResponseReader)
+	if !ok || reader.GetLastSuccessfulResponse() == nil {
+		return nil
+	}
+
+	// The secret values of the recorded response were replaced with placeholders.
+	patched, err := datapatcher.PatchSecretsIntoResponse(svcCtx.Ctx, svcCtx.LocalKube, reader.GetLastSuccessfulResponse(), svcCtx.Logger)
+	if err != nil {
+		svcCtx.Logger.Info(errPatchLastSuccessfulResponse, "error", err.Error())
+		return nil
+	}
+
+	return &httpClient.HttpResponse{
+		StatusCode: patched.GetStatusCode(),
+		Headers:    patched.GetHeaders(),
+		Body:       patched.GetBody(),
+	}
+}
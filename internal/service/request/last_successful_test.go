@@ -0,0 +1,173 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func TestSecretsFromLastSuccessfulResponse(t *testing.T) {
+	lastSuccessful := &v1alpha2.Response{StatusCode: http.StatusOK, Body: `{"url": "https://db.example.com"}`}
+
+	cases := map[string]struct {
+		reason         string
+		enabled        bool
+		lastSuccessful *v1alpha2.Response
+		response       httpClient.HttpResponse
+		want           string
+	}{
+		"FailedResponse": {
+			reason:         "Should inject the secret from the last successful response when the request fails",
+			enabled:        true,
+			lastSuccessful: lastSuccessful,
+			response:       httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable, Body: `{"error": "unavailable"}`},
+			want:           "https://db.example.com",
+		},
+		"Disabled": {
+			reason:         "Should inject the secret from the failed response unless the spec asks otherwise",
+			lastSuccessful: lastSuccessful,
+			response:       httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable, Body: `{"error": "unavailable"}`},
+			want:           "",
+		},
+		"NoLastSuccessfulResponse": {
+			reason:   "Should inject the secret from the failed response until a request succeeded",
+			enabled:  true,
+			response: httpClient.HttpResponse{StatusCode: http.StatusServiceUnavailable, Body: `{"error": "unavailable"}`},
+			want:     "",
+		},
+		"SuccessfulResponse": {
+			reason:         "Should inject the secret from a successful response",
+			enabled:        true,
+			lastSuccessful: lastSuccessful,
+			response:       httpClient.HttpResponse{StatusCode: http.StatusOK, Body: `{"url": "https://db2.example.com"}`},
+			want:           "https://db2.example.com",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				ObjectMeta: v1.ObjectMeta{Name: "test-request"},
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: v1alpha2.RequestParameters{
+						Mappings:                          []v1alpha2.Mapping{{Action: "CREATE", Method: "POST", URL: strconv.Quote(testURL)}},
+						SecretsFromLastSuccessfulResponse: tc.enabled,
+						SecretInjectionConfigs: []common.SecretInjectionConfig{{
+							SecretRef: common.SecretRef{Name: "endpoint", Namespace: "default"},
+							KeyMappings: []common.KeyInjection{{
+								SecretKey:            "url",
+								ResponseJQ:           ".body.url",
+								MissingFieldStrategy: common.SetEmptyMissingField,
+							}},
+						}},
+					},
+				},
+				Status: v1alpha2.RequestStatus{LastSuccessfulResponse: tc.lastSuccessful},
+			}
+
+			injected := map[string][]byte{"url": []byte("stale")}
+			localKube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					if secret, ok := obj.(*corev1.Secret); ok {
+						secret.Data = map[string][]byte{"url": []byte("stale")}
+					}
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					if secret, ok := obj.(*corev1.Secret); ok {
+						injected = secret.Data
+					}
+					return nil
+				},
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			mockHttp := &MockHttpClient{
+				MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+					return httpClient.HttpDetails{HttpResponse: tc.response}, nil
+				},
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), mockHttp, nil)
+			if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+				t.Fatalf("\n%s\nDeployAction(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if got := string(injected["url"]); got != tc.want {
+				t.Errorf("\n%s\nDeployAction(...): want the secret value %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSecretsFromLastSuccessfulResponseTransformed(t *testing.T) {
+	cr := &v1alpha2.Request{
+		ObjectMeta: v1.ObjectMeta{Name: "test-request"},
+		Spec: v1alpha2.RequestSpec{
+			ForProvider: v1alpha2.RequestParameters{
+				Mappings:                          []v1alpha2.Mapping{{Action: "CREATE", Method: "POST", URL: strconv.Quote(testURL)}},
+				SecretsFromLastSuccessfulResponse: true,
+				ResponseTransform:                 &common.ResponseTransformConfig{JQ: "{id}"},
+				SecretInjectionConfigs: []common.SecretInjectionConfig{{
+					SecretRef: common.SecretRef{Name: "endpoint", Namespace: "default"},
+					KeyMappings: []common.KeyInjection{{
+						SecretKey:            "url",
+						ResponseJQ:           ".body.url",
+						MissingFieldStrategy: common.SetEmptyMissingField,
+					}},
+				}},
+			},
+		},
+	}
+
+	injected := map[string][]byte{}
+	localKube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Name, secret.Namespace, secret.Data = key.Name, key.Namespace, injected
+			}
+			return nil
+		},
+		MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				injected = secret.Data
+			}
+			return nil
+		},
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	responses := []httpClient.HttpResponse{
+		{StatusCode: http.StatusOK, Body: `{"id": "42", "url": "https://db.example.com"}`},
+		{StatusCode: http.StatusServiceUnavailable, Body: `{"error": "unavailable"}`},
+	}
+	for i, response := range responses {
+		mockHttp := &MockHttpClient{
+			MockSendRequest: func(_ context.Context, _ string, _ string, _ httpClient.Data, _ httpClient.Data, _ *httpClient.TLSConfigData) (httpClient.HttpDetails, error) {
+				return httpClient.HttpDetails{HttpResponse: response}, nil
+			},
+		}
+
+		svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), mockHttp, nil)
+		if err := DeployAction(svcCtx, service.NewRequestCRContext(cr), "CREATE"); err != nil {
+			t.Fatalf("DeployAction(...): unexpected error: %v", err)
+		}
+		if got := cr.Status.Response.Body; i == 0 && got != `{"id":"42"}` {
+			t.Errorf("DeployAction(...): want the projection of the body stored in the response, got %s", got)
+		}
+	}
+
+	if got := string(injected["url"]); got != "https://db.example.com" {
+		t.Errorf("DeployAction(...): want the secret injected from the raw body of the last successful response, got %q", got)
+	}
+}
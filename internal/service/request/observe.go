@@ -1,19 +1,23 @@
 package request
 
 import (
-	"net/http"
+	"slices"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
-	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/observe"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestmapping"
-	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
 	"github.com/pkg/errors"
 )
 
+// errNotTemplated is returned when the request of an OBSERVE mapping cannot be templated yet, e.g. because it
+// references a field of a response that was not received.
+var errNotTemplated = errors.New("observe request could not be templated")
+
 const (
 	errNotValidJSON              = "%s is not a valid JSON string: %s"
 	errConvertResToMap           = "failed to convert response to map"
@@ -24,6 +28,8 @@ type ObserveRequestDetails struct {
 	Details       httpClient.HttpDetails
 	ResponseError error
 	Synced        bool
+	FailedCheck   string
+	Drift         []common.FieldDiff
 }
 
 // NewObserveRequestDetails is a constructor function that initializes
@@ -44,33 +50,81 @@ func FailedObserve() ObserveRequestDetails {
 	}
 }
 
-// IsUpToDate checks whether desired spec up to date with the observed state for a given request
+// IsUpToDate checks whether desired spec up to date with the observed state for a given request.
+// The OBSERVE mappings are tried in the order they are declared, e.g. by ID and then by a natural key before the ID
+// is known, and the first one finding the resource determines whether it is up to date. The resource is only
+// reported as not found once every mapping failed to find it.
 func IsUpToDate(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext) (ObserveRequestDetails, error) {
 	spec := crCtx.Spec()
-	mapping, err := requestmapping.GetMapping(spec, common.ActionObserve, svcCtx.Logger)
+	mappings, err := requestmapping.GetMappings(spec, common.ActionObserve, svcCtx.Logger)
 	if err != nil {
 		return FailedObserve(), err
 	}
 
 	objectNotCreated := !isObjectValidForObservation(crCtx)
+	if objectNotCreated && !observesBeforeCreate(spec) && !adoptsExternalName(crCtx) {
+		// Existing external resources are not looked up, jumping straight to
+		// creating the resource. An external resource identified by the
+		// external name is always looked up, so it is adopted instead.
+		return FailedObserve(), errors.New(observe.ErrObjectNotFound)
+	}
+
+	notFound := false
+	for _, mapping := range mappings {
+		var observeDetails ObserveRequestDetails
+		observeDetails, err = observeWithMapping(svcCtx, crCtx, mapping, objectNotCreated)
+		if !isNotFound(err) && !errors.Is(err, errNotTemplated) {
+			return observeDetails, err
+		}
+		notFound = notFound || isNotFound(err)
+	}
+
+	if notFound || objectNotCreated {
+		return FailedObserve(), errors.New(observe.ErrObjectNotFound)
+	}
+
+	return FailedObserve(), err
+}
+
+// observeWithMapping sends the request of an OBSERVE mapping and checks whether the resource is up to date.
+// It fails with errNotTemplated if the request of the mapping cannot be templated yet, and with
+// observe.ErrObjectNotFound if the mapping does not find the resource.
+func observeWithMapping(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping, objectNotCreated bool) (ObserveRequestDetails, error) {
+	spec := crCtx.Spec()
 
 	// Evaluate the HTTP request template. If successfully templated, attempt to
 	// observe the resource.
 	requestDetails, err := requestgen.GenerateValidRequestDetails(svcCtx, crCtx, mapping)
 	if err != nil {
-		if objectNotCreated {
-			// The initial request was not successfully templated. Cannot
-			// confirm existence of the resource, jumping to the default
-			// behavior of creating before observing.
-			err = errors.New(observe.ErrObjectNotFound)
-		}
+		return FailedObserve(), errors.Wrap(errNotTemplated, err.Error())
+	}
+
+	requestCtx, err := requestmapping.RequestContext(svcCtx.Ctx, mapping)
+	if err != nil {
 		return FailedObserve(), err
 	}
 
-	details, responseErr := svcCtx.HTTP.SendRequest(svcCtx.Ctx, requestmapping.GetEffectiveMethod(mapping), requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+	method := requestmapping.GetEffectiveMethod(mapping)
+	cache := conditionalCache(crCtx, requestDetails, method)
+	if cache != nil {
+		addIfModifiedSince(&requestDetails, cache)
+	}
+
+	details, responseErr := svcCtx.HTTP.SendRequest(httpClient.ContextWithRedactedURL(requestCtx, requestDetails.RecordedURL()), method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+	statushandler.TransformResponse(svcCtx.Logger, spec, common.ResponseTransformScopeResponse, &details.HttpResponse)
+	if notModified(cache, details, responseErr) {
+		// The resource did not change since the cached response, which is
+		// checked instead. Its data was already injected into secrets.
+		details.HttpResponse = cachedResponse(cache)
+		return determineIfUpToDate(svcCtx, crCtx, details, responseErr)
+	}
+
 	// The initial observation of an object requires a successful HTTP response
 	// to be considered existing.
-	if !utils.IsHTTPSuccess(details.HttpResponse.StatusCode) && objectNotCreated {
+	if !statushandler.IsResponseSucceeded(spec, &details.HttpResponse) && objectNotCreated {
+		if adoptsExternalName(crCtx) && !AssumesExists(spec) {
+			return FailedObserve(), adoptionFailure(svcCtx, crCtx, details, responseErr)
+		}
 		// Cannot confirm existence of the resource, jumping to the default
 		// behavior of creating before observing.
 		return FailedObserve(), errors.New(observe.ErrObjectNotFound)
@@ -80,11 +134,59 @@ func IsUpToDate(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext)
 	}
 
 	// Apply response data to secrets and update CR status with response
-	secretConfigs := spec.GetSecretInjectionConfigs()
-	datapatcher.ApplyResponseDataToSecrets(svcCtx.Ctx, svcCtx.LocalKube, svcCtx.Logger, &details.HttpResponse, secretConfigs, crCtx.GetCR())
+	applyResponseDataToSecrets(svcCtx, crCtx, requestDetails, &details.HttpResponse)
 	return determineIfUpToDate(svcCtx, crCtx, details, responseErr)
 }
 
+// IsRemoved sends the observe requests and checks whether the external resource is removed according to the
+// is-removed check. The resource is removed once no OBSERVE mapping finds it anymore.
+func IsRemoved(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext) (bool, error) {
+	mappings, err := requestmapping.GetMappings(crCtx.Spec(), common.ActionObserve, svcCtx.Logger)
+	if err != nil {
+		return false, err
+	}
+
+	removed := false
+	var templateErr error
+	for _, mapping := range mappings {
+		requestDetails, err := requestgen.GenerateValidRequestDetails(svcCtx, crCtx, mapping)
+		if err != nil {
+			templateErr = err
+			continue
+		}
+
+		requestCtx, err := requestmapping.RequestContext(svcCtx.Ctx, mapping)
+		if err != nil {
+			return false, err
+		}
+
+		details, responseErr := svcCtx.HTTP.SendRequest(httpClient.ContextWithRedactedURL(requestCtx, requestDetails.RecordedURL()), requestmapping.GetEffectiveMethod(mapping), requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+		statushandler.TransformResponse(svcCtx.Logger, crCtx.Spec(), common.ResponseTransformScopeResponse, &details.HttpResponse)
+		err = determineIfRemoved(svcCtx, crCtx, details, responseErr)
+		if isNotFound(err) {
+			removed = true
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		return false, responseErr
+	}
+
+	if !removed && templateErr != nil {
+		return false, templateErr
+	}
+
+	return removed, nil
+}
+
+// isNotFound checks if the error reports that the external resource was not found.
+func isNotFound(err error) bool {
+	return err != nil && err.Error() == observe.ErrObjectNotFound
+}
+
 // determineIfUpToDate determines if the object is up to date based on the response check.
 func determineIfUpToDate(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, responseErr error) (ObserveRequestDetails, error) {
 	responseChecker := observe.GetIsUpToDateResponseCheck(svcCtx, crCtx.Spec())
@@ -97,7 +199,15 @@ func determineIfUpToDate(svcCtx *service.ServiceContext, crCtx *service.RequestC
 		return FailedObserve(), err
 	}
 
-	return NewObserve(details, responseErr, result), nil
+	observeDetails := NewObserve(details, responseErr, result)
+	if reporter, ok := responseChecker.(observe.FailedCheckReporter); ok {
+		observeDetails.FailedCheck = reporter.FailedCheck()
+	}
+	if reporter, ok := responseChecker.(observe.DriftReporter); ok {
+		observeDetails.Drift = reporter.Drift()
+	}
+
+	return observeDetails, nil
 }
 
 // determineIfRemoved determines if the object is removed based on the response check.
@@ -107,14 +217,42 @@ func determineIfRemoved(svcCtx *service.ServiceContext, crCtx *service.RequestCR
 		return errors.Errorf(errExpectedResponseCheckType, "isRemovedCheck")
 	}
 
+	if isNotFoundStatusCode(crCtx.Spec(), details.HttpResponse.StatusCode) {
+		return errors.New(observe.ErrObjectNotFound)
+	}
+
 	return responseChecker.Check(svcCtx, crCtx, details, responseErr)
 }
 
-// isObjectValidForObservation checks if the object is valid for observation
+// observesBeforeCreate checks if the OBSERVE request should be sent before the resource was ever created,
+// to adopt an existing external resource. It defaults to true for specs without an observe policy.
+func observesBeforeCreate(spec interfaces.MappedHTTPRequestSpec) bool {
+	policy, ok := spec.(interfaces.ObservePolicyAware)
+	return !ok || policy.GetObserveBeforeCreate() || policy.GetAssumeExists()
+}
+
+// isNotFoundStatusCode checks if the status code of an OBSERVE response reports that the external resource does
+// not exist, without evaluating the is-removed check.
+func isNotFoundStatusCode(spec interfaces.MappedHTTPRequestSpec, statusCode int) bool {
+	policy, ok := spec.(interfaces.ObservePolicyAware)
+	return ok && slices.Contains(policy.GetNotFoundStatusCodes(), statusCode)
+}
+
+// AssumesExists checks if the external resource is assumed to exist when the OBSERVE request fails with
+// observe.ErrObjectNotFound, so it is updated instead of being created.
+func AssumesExists(spec interfaces.MappedHTTPRequestSpec) bool {
+	policy, ok := spec.(interfaces.ObservePolicyAware)
+	return ok && policy.GetAssumeExists()
+}
+
+// isObjectValidForObservation checks if the object is valid for observation.
+// The object is not considered created when the last request sent was a failed CREATE, whatever its method.
 func isObjectValidForObservation(crCtx *service.RequestCRContext) bool {
 	response := crCtx.Status().GetResponse()
 	requestDetails := crCtx.Status().GetRequestDetails()
+	createMethod := requestmapping.GetActionMethod(crCtx.Spec(), common.ActionCreate)
+	createFailed, _ := statushandler.IsResponseFailed(crCtx.Spec(), response)
 
 	return response.GetStatusCode() != 0 &&
-		!(requestDetails.GetMethod() == http.MethodPost && utils.IsHTTPError(response.GetStatusCode()))
+		!(requestDetails.GetMethod() == createMethod && createFailed)
 }
@@ -0,0 +1,153 @@
+package observe
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/pkg/errors"
+)
+
+const (
+	errSubCheckFailed = "%s sub-check %s failed"
+)
+
+// FailedCheckReporter is implemented by response checks that report which of their sub-checks failed.
+type FailedCheckReporter interface {
+	// FailedCheck returns the description of the sub-checks that failed on the last Check.
+	FailedCheck() string
+}
+
+// subCheckFunc evaluates a single sub-check, returning whether it passed.
+type subCheckFunc func(check interfaces.ResponseCheck) (bool, error)
+
+// combineChecks evaluates the sub-checks according to the combinator, stopping as soon as the result is known.
+// It returns the result and the description of the failed sub-checks.
+func combineChecks(checks []interfaces.DescribedResponseCheck, combinator string, evaluate subCheckFunc) (bool, string, error) {
+	var failed []string
+	for i, check := range checks {
+		passed, err := evaluate(check)
+		if err != nil {
+			return false, "", errors.Wrapf(err, errSubCheckFailed, combinatorName(combinator), describeCheck(i, check))
+		}
+
+		if passed && combinator == common.ResponseCheckCombinatorAny {
+			return true, "", nil
+		}
+
+		if !passed {
+			failed = append(failed, describeCheck(i, check))
+			if combinator != common.ResponseCheckCombinatorAny {
+				return false, failed[0], nil
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return false, strings.Join(failed, "; "), nil
+	}
+
+	return true, "", nil
+}
+
+// combinedChecks returns the sub-checks of the check if it combines several of them.
+func combinedChecks(check interfaces.ResponseCheck) (interfaces.CombinedResponseCheck, bool) {
+	combined, ok := check.(interfaces.CombinedResponseCheck)
+	if !ok || len(combined.GetChecks()) == 0 {
+		return nil, false
+	}
+
+	return combined, true
+}
+
+// describeCheck returns the description of the sub-check, or its position if it has none.
+func describeCheck(index int, check interfaces.DescribedResponseCheck) string {
+	if description := check.GetDescription(); description != "" {
+		return description
+	}
+
+	return fmt.Sprintf("checks[%d]", index)
+}
+
+// combinatorName returns the effective name of the combinator.
+func combinatorName(combinator string) string {
+	if combinator == common.ResponseCheckCombinatorAny {
+		return common.ResponseCheckCombinatorAny
+	}
+
+	return common.ResponseCheckCombinatorAll
+}
+
+// combinedIsUpToDateResponseCheck combines several up-to-date sub-checks.
+type combinedIsUpToDateResponseCheck struct {
+	failedCheck string
+}
+
+// Check evaluates the sub-checks of the expected response check.
+func (c *combinedIsUpToDateResponseCheck) Check(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, responseErr error) (bool, error) {
+	spec := crCtx.Spec()
+	responseCheckAware, ok := spec.(interfaces.ResponseCheckAware)
+	if !ok {
+		return false, errors.New("spec does not implement ResponseCheckAware")
+	}
+
+	combined, ok := combinedChecks(responseCheckAware.GetExpectedResponseCheck())
+	if !ok {
+		return false, errors.New("expectedResponseCheck does not define sub-checks")
+	}
+
+	isUpToDate, failedCheck, err := combineChecks(combined.GetChecks(), combined.GetCombinator(), func(check interfaces.ResponseCheck) (bool, error) {
+		if check.GetType() == common.ExpectedResponseCheckTypeCustom {
+			return (&customCheck{}).check(svcCtx, spec, details, check.GetLogic())
+		}
+
+		return (&defaultIsUpToDateResponseCheck{}).Check(svcCtx, crCtx, details, responseErr)
+	})
+	if err != nil {
+		return false, errors.Errorf(errExpectedFormat, "ExpectedResponseCheck", err.Error())
+	}
+
+	c.failedCheck = failedCheck
+	return isUpToDate, nil
+}
+
+// FailedCheck returns the description of the sub-checks that failed on the last Check.
+func (c *combinedIsUpToDateResponseCheck) FailedCheck() string {
+	return c.failedCheck
+}
+
+// combinedIsRemovedResponseCheck combines several is-removed sub-checks.
+type combinedIsRemovedResponseCheck struct{}
+
+// Check evaluates the sub-checks of the is-removed check.
+func (c *combinedIsRemovedResponseCheck) Check(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, responseErr error) error {
+	spec := crCtx.Spec()
+	responseCheckAware, ok := spec.(interfaces.ResponseCheckAware)
+	if !ok {
+		return errors.New("spec does not support custom response checks")
+	}
+
+	combined, ok := combinedChecks(responseCheckAware.GetIsRemovedCheck())
+	if !ok {
+		return errors.New("isRemovedCheck does not define sub-checks")
+	}
+
+	isRemoved, _, err := combineChecks(combined.GetChecks(), combined.GetCombinator(), func(check interfaces.ResponseCheck) (bool, error) {
+		if check.GetType() == common.ExpectedResponseCheckTypeCustom {
+			return (&customCheck{}).check(svcCtx, spec, details, check.GetLogic())
+		}
+
+		return details.HttpResponse.StatusCode == http.StatusNotFound, nil
+	})
+	if err != nil {
+		return errors.Errorf(errExpectedFormat, "isRemovedCheck", err.Error())
+	} else if isRemoved {
+		return errors.New(ErrObjectNotFound)
+	}
+
+	return nil
+}
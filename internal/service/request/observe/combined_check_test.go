@@ -0,0 +1,285 @@
+package observe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+var (
+	errBoom = errors.New("boom")
+
+	testStatusReadyCheck = v1alpha2.ResponseSubCheck{
+		Description: "status is ready",
+		Type:        common.ExpectedResponseCheckTypeCustom,
+		Logic:       `.response.body.status == "ready"`,
+	}
+
+	testRevisionCheck = v1alpha2.ResponseSubCheck{
+		Description: "revision matches",
+		Type:        common.ExpectedResponseCheckTypeCustom,
+		Logic:       `.response.body.revision == .payload.body.revision`,
+	}
+)
+
+func Test_combineChecks(t *testing.T) {
+	type args struct {
+		checks     []v1alpha2.ResponseSubCheck
+		combinator string
+	}
+
+	type want struct {
+		result      bool
+		failedCheck string
+		evaluated   []string
+		err         error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AllPass": {
+			args: args{
+				checks: []v1alpha2.ResponseSubCheck{{Description: "first", Logic: "true"}, {Description: "second", Logic: "true"}},
+			},
+			want: want{
+				result:    true,
+				evaluated: []string{"first", "second"},
+			},
+		},
+		"AllShortCircuitsOnFirstFailure": {
+			args: args{
+				checks:     []v1alpha2.ResponseSubCheck{{Description: "first", Logic: "false"}, {Description: "second", Logic: "error"}},
+				combinator: common.ResponseCheckCombinatorAll,
+			},
+			want: want{
+				result:      false,
+				failedCheck: "first",
+				evaluated:   []string{"first"},
+			},
+		},
+		"AnyShortCircuitsOnFirstSuccess": {
+			args: args{
+				checks:     []v1alpha2.ResponseSubCheck{{Description: "first", Logic: "false"}, {Description: "second", Logic: "true"}, {Description: "third", Logic: "error"}},
+				combinator: common.ResponseCheckCombinatorAny,
+			},
+			want: want{
+				result:    true,
+				evaluated: []string{"first", "second"},
+			},
+		},
+		"AnyReportsEveryFailure": {
+			args: args{
+				checks:     []v1alpha2.ResponseSubCheck{{Description: "first", Logic: "false"}, {Logic: "false"}},
+				combinator: common.ResponseCheckCombinatorAny,
+			},
+			want: want{
+				result:      false,
+				failedCheck: "first; checks[1]",
+				evaluated:   []string{"first", ""},
+			},
+		},
+		"EvaluationError": {
+			args: args{
+				checks: []v1alpha2.ResponseSubCheck{{Description: "first", Logic: "true"}, {Logic: "error"}},
+			},
+			want: want{
+				evaluated: []string{"first", ""},
+				err:       errors.Wrapf(errBoom, errSubCheckFailed, common.ResponseCheckCombinatorAll, "checks[1]"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			checks := (&v1alpha2.ExpectedResponseCheck{Checks: tc.args.checks}).GetChecks()
+
+			var evaluated []string
+			got, failedCheck, gotErr := combineChecks(checks, tc.args.combinator, func(check interfaces.ResponseCheck) (bool, error) {
+				evaluated = append(evaluated, check.(interfaces.DescribedResponseCheck).GetDescription())
+				if check.GetLogic() == "error" {
+					return false, errBoom
+				}
+				return check.GetLogic() == "true", nil
+			})
+
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("combineChecks(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("combineChecks(...): -want result, +got result: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.failedCheck, failedCheck); diff != "" {
+				t.Errorf("combineChecks(...): -want failed check, +got failed check: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.evaluated, evaluated); diff != "" {
+				t.Errorf("combineChecks(...): -want evaluated sub-checks, +got evaluated sub-checks: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_CombinedIsUpToDateCheck(t *testing.T) {
+	type args struct {
+		combinator string
+		body       string
+	}
+
+	type want struct {
+		result      bool
+		failedCheck string
+		err         error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AllPass": {
+			args: args{
+				body: `{"status":"ready","revision":3}`,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"AllReportsFailingSubCheck": {
+			args: args{
+				body: `{"status":"ready","revision":2}`,
+			},
+			want: want{
+				result:      false,
+				failedCheck: "revision matches",
+			},
+		},
+		"AnyPassesWithOneSubCheck": {
+			args: args{
+				combinator: common.ResponseCheckCombinatorAny,
+				body:       `{"status":"pending","revision":3}`,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"AnyReportsAllSubChecks": {
+			args: args{
+				combinator: common.ResponseCheckCombinatorAny,
+				body:       `{"status":"pending","revision":2}`,
+			},
+			want: want{
+				result:      false,
+				failedCheck: "status is ready; revision matches",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: v1alpha2.RequestParameters{
+						Payload: v1alpha2.Payload{
+							Body: `{"revision": 3}`,
+						},
+						ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+							Checks:     []v1alpha2.ResponseSubCheck{testStatusReadyCheck, testRevisionCheck},
+							Combinator: tc.args.combinator,
+						},
+					},
+				},
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{
+					Body:       tc.args.body,
+					StatusCode: 200,
+				},
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			crCtx := service.NewRequestCRContext(cr)
+			e, ok := GetIsUpToDateResponseCheck(svcCtx, crCtx.Spec()).(*combinedIsUpToDateResponseCheck)
+			if !ok {
+				t.Fatalf("GetIsUpToDateResponseCheck(...): expected a combined check")
+			}
+
+			got, gotErr := e.Check(svcCtx, crCtx, details, nil)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Check(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Check(...): -want result, +got result: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.failedCheck, e.FailedCheck()); diff != "" {
+				t.Errorf("FailedCheck(): -want failed check, +got failed check: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_CombinedIsRemovedCheck(t *testing.T) {
+	cases := map[string]struct {
+		statusCode int
+		body       string
+		want       error
+	}{
+		"RemovedByDefaultSubCheck": {
+			statusCode: 404,
+			want:       errors.New(ErrObjectNotFound),
+		},
+		"RemovedByCustomSubCheck": {
+			statusCode: 200,
+			body:       `{"state":"deleted"}`,
+			want:       errors.New(ErrObjectNotFound),
+		},
+		"NotRemoved": {
+			statusCode: 200,
+			body:       `{"state":"active"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: v1alpha2.RequestParameters{
+						IsRemovedCheck: v1alpha2.ExpectedResponseCheck{
+							Combinator: common.ResponseCheckCombinatorAny,
+							Checks: []v1alpha2.ResponseSubCheck{
+								{Type: common.ExpectedResponseCheckTypeDefault},
+								{Type: common.ExpectedResponseCheckTypeCustom, Logic: `.response.body.state == "deleted"`},
+							},
+						},
+					},
+				},
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{
+					Body:       tc.body,
+					StatusCode: tc.statusCode,
+				},
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			crCtx := service.NewRequestCRContext(cr)
+			gotErr := GetIsRemovedResponseCheck(svcCtx, crCtx.Spec()).Check(svcCtx, crCtx, details, nil)
+			if diff := cmp.Diff(tc.want, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Check(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
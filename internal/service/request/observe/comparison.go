@@ -0,0 +1,64 @@
+package observe
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const errNormalizeComparison = "cannot normalize the %s for comparison"
+
+// comparisonFilter returns the jq filter normalizing the bodies compared by the default up-to-date check, or an
+// empty string if the spec compares them as is. Each unordered array is sorted, then the normalizeJQ filter is
+// applied.
+func comparisonFilter(spec interfaces.MappedHTTPRequestSpec) string {
+	aware, ok := spec.(interfaces.ComparisonAware)
+	if !ok || aware.GetComparison() == nil {
+		return ""
+	}
+	config := aware.GetComparison()
+
+	filters := make([]string, 0, len(config.UnorderedArrays)+1)
+	for _, array := range config.UnorderedArrays {
+		filters = append(filters, sortArrayFilter(array))
+	}
+	if config.NormalizeJQ != "" {
+		filters = append(filters, "("+config.NormalizeJQ+")")
+	}
+
+	return strings.Join(filters, " | ")
+}
+
+// sortArrayFilter returns the jq filter sorting the unordered array by its key. A path that is missing or does not
+// hold an array selects nothing, so the body is left unchanged instead of gaining a null field.
+func sortArrayFilter(array common.UnorderedArray) string {
+	return fmt.Sprintf(`((%s) | select(type == "array")) |= sort_by(%s)`, array.Path, cmp.Or(array.SortBy, "."))
+}
+
+// normalizeForComparison applies the jq filter to the JSON body, named by what in errors. The same filter is
+// applied to both compared bodies, so they are normalized consistently.
+func normalizeForComparison(filter, what, body string) (string, error) {
+	if filter == "" || !json_util.IsJSONString(body) {
+		return body, nil
+	}
+
+	normalized, err := jq.ParseMapInterface(filter, json_util.JsonStringToMap(body))
+	if err != nil {
+		return "", errors.Wrapf(err, errNormalizeComparison, what)
+	}
+
+	encoded, err := json.Marshal(normalized)
+	if err != nil {
+		return "", errors.Wrapf(err, errNormalizeComparison, what)
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,93 @@
+package observe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func Test_DefaultIsUpToDateCheckComparison(t *testing.T) {
+	reordered := `{"id": "123", "tags": ["c", "a", "b"], "rules": [{"name": "y", "port": 2}, {"name": "x", "port": 1}]}`
+
+	cases := map[string]struct {
+		reason     string
+		comparison *common.ComparisonConfig
+		body       string
+		want       bool
+	}{
+		"OrderedByDefault": {
+			reason: "Should compare arrays as lists without a comparison",
+			body:   reordered,
+			want:   false,
+		},
+		"UnorderedArrays": {
+			reason: "Should treat reordered arrays compared as sets as equal",
+			comparison: &common.ComparisonConfig{UnorderedArrays: []common.UnorderedArray{
+				{Path: ".tags"},
+				{Path: ".rules", SortBy: ".name"},
+			}},
+			body: reordered,
+			want: true,
+		},
+		"UnorderedArraysDiffer": {
+			reason: "Should still detect a different element of an array compared as a set",
+			comparison: &common.ComparisonConfig{UnorderedArrays: []common.UnorderedArray{
+				{Path: ".tags"},
+				{Path: ".rules", SortBy: ".name"},
+			}},
+			body: `{"id": "123", "tags": ["c", "a", "d"], "rules": [{"name": "y", "port": 2}, {"name": "x", "port": 1}]}`,
+			want: false,
+		},
+		"MissingArray": {
+			reason: "Should leave the bodies unchanged for a path missing from both",
+			comparison: &common.ComparisonConfig{UnorderedArrays: []common.UnorderedArray{
+				{Path: ".tags"},
+				{Path: ".rules", SortBy: ".name"},
+				{Path: ".labels"},
+			}},
+			body: reordered,
+			want: true,
+		},
+		"NormalizeJQ": {
+			reason:     "Should apply the normalizeJQ filter to both bodies",
+			comparison: &common.ComparisonConfig{NormalizeJQ: `.tags |= sort | .rules |= sort_by(.port)`},
+			body:       reordered,
+			want:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: v1alpha2.RequestParameters{
+						Payload: v1alpha2.Payload{BaseUrl: "https://api.example.com/users"},
+						Mappings: []v1alpha2.Mapping{{
+							Method: "PUT",
+							Body:   `{ tags: ["a", "b", "c"], rules: [{name: "x", port: 1}, {name: "y", port: 2}] }`,
+							URL:    `(.payload.baseUrl + "/" + .response.body.id)`,
+						}},
+						Comparison: tc.comparison,
+					},
+				},
+				Status: v1alpha2.RequestStatus{Response: v1alpha2.Response{Body: `{"id": "123"}`, StatusCode: 200}},
+			}
+			details := httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{Body: tc.body, StatusCode: 200}}
+
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			got, err := (&defaultIsUpToDateResponseCheck{}).Check(svcCtx, service.NewRequestCRContext(cr), details, nil)
+			if err != nil {
+				t.Fatalf("\n%s\nCheck(...): unexpected error: %v", tc.reason, err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nCheck(...): want up to date %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
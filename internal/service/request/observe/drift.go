@@ -0,0 +1,102 @@
+package observe
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+const (
+	// maxDriftFields bounds the number of fields recorded in the drift, to bound the size of the status.
+	maxDriftFields = 20
+
+	// maxDriftValueLength bounds the length of the values recorded in the drift.
+	maxDriftValueLength = 256
+)
+
+// DriftReporter is implemented by response checks that report the fields differing from the observed state.
+type DriftReporter interface {
+	// Drift returns the fields that differed on the last Check, if the spec records them.
+	Drift() []common.FieldDiff
+}
+
+// driftBodies are the JSON bodies of the desired and observed state of a resource. The secret values of the
+// sensitive bodies are patched in, and are only used to find the fields that differ, whose values are then taken
+// from the redacted bodies.
+type driftBodies struct {
+	desired          string
+	observed         string
+	redactedDesired  string
+	redactedObserved string
+}
+
+// driftDiff returns the fields of the desired state differing from the observed state, if the spec records them.
+// A normalization filter that fails is logged and leaves the drift unrecorded, without failing the observation.
+func driftDiff(svcCtx *service.ServiceContext, spec interfaces.MappedHTTPRequestSpec, bodies driftBodies) []common.FieldDiff {
+	aware, ok := spec.(interfaces.DriftDiffAware)
+	if !ok || aware.GetDriftDiff() == nil {
+		return nil
+	}
+	normalizeJQ := aware.GetDriftDiff().NormalizeJQ
+
+	observed, err := normalizeObserved(normalizeJQ, bodies.observed)
+	if err != nil {
+		svcCtx.Logger.Info("Cannot normalize the response body to diff it", "normalizeJQ", normalizeJQ, "error", err)
+		return nil
+	}
+	redactedObserved, err := normalizeObserved(normalizeJQ, bodies.redactedObserved)
+	if err != nil {
+		svcCtx.Logger.Info("Cannot normalize the response body to diff it", "normalizeJQ", normalizeJQ, "error", err)
+		return nil
+	}
+	redactedDesired := json_util.JsonStringToMap(bodies.redactedDesired)
+
+	paths := json_util.Diff(observed, json_util.JsonStringToMap(bodies.desired))
+	if len(paths) > maxDriftFields {
+		paths = paths[:maxDriftFields]
+	}
+
+	drift := make([]common.FieldDiff, 0, len(paths))
+	for _, path := range paths {
+		desiredValue, _ := json_util.ValueAt(redactedDesired, path)
+		field := common.FieldDiff{
+			Path:    json_util.JQPath(path),
+			Desired: driftValue(desiredValue),
+		}
+		if observedValue, ok := json_util.ValueAt(redactedObserved, path); ok {
+			field.Observed = driftValue(observedValue)
+		}
+		drift = append(drift, field)
+	}
+
+	svcCtx.Logger.Debug("Resource drifted from its desired state", "drift", drift)
+	return drift
+}
+
+// normalizeObserved parses the body of the response and applies the normalization filter to it, if set.
+func normalizeObserved(normalizeJQ, body string) (map[string]interface{}, error) {
+	observed := json_util.JsonStringToMap(body)
+	if normalizeJQ == "" {
+		return observed, nil
+	}
+
+	return jq.ParseMapInterface(normalizeJQ, observed)
+}
+
+// driftValue returns the JSON encoding of a value recorded in the drift, truncated to maxDriftValueLength.
+func driftValue(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	if len(encoded) > maxDriftValueLength {
+		return strings.ToValidUTF8(string(encoded[:maxDriftValueLength]), "") + "..."
+	}
+	return string(encoded)
+}
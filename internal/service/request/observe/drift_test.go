@@ -0,0 +1,91 @@
+package observe
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func Test_DefaultIsUpToDateCheckDrift(t *testing.T) {
+	putMapping := v1alpha2.Mapping{
+		Method: "PUT",
+		Body:   `{ username: .payload.body.username, settings: { tier: .payload.body.tier, region: "eu" } }`,
+		URL:    `(.payload.baseUrl + "/" + .response.body.id)`,
+	}
+
+	cases := map[string]struct {
+		reason    string
+		driftDiff *common.DriftDiffConfig
+		body      string
+		want      []common.FieldDiff
+	}{
+		"ChangedField": {
+			reason:    "Should record the nested field whose value changed",
+			driftDiff: &common.DriftDiffConfig{},
+			body:      `{"id":"123","username":"john_doe","settings":{"tier":"free","region":"eu"}}`,
+			want:      []common.FieldDiff{{Path: ".settings.tier", Desired: `"pro"`, Observed: `"free"`}},
+		},
+		"MissingField": {
+			reason:    "Should record the fields missing from the response without an observed value",
+			driftDiff: &common.DriftDiffConfig{},
+			body:      `{"id":"123","settings":{"tier":"pro","region":"eu"}}`,
+			want:      []common.FieldDiff{{Path: ".username", Desired: `"john_doe"`}},
+		},
+		"Normalized": {
+			reason:    "Should diff the response normalized by normalizeJQ",
+			driftDiff: &common.DriftDiffConfig{NormalizeJQ: ".data"},
+			body:      `{"data":{"id":"123","username":"jane_doe","settings":{"tier":"pro","region":"us"}}}`,
+			want: []common.FieldDiff{
+				{Path: ".settings.region", Desired: `"eu"`, Observed: `"us"`},
+				{Path: ".username", Desired: `"john_doe"`, Observed: `"jane_doe"`},
+			},
+		},
+		"NotRecorded": {
+			reason: "Should not record the drift unless driftDiff is set",
+			body:   `{"id":"123","username":"john_doe","settings":{"tier":"free","region":"eu"}}`,
+		},
+		"UpToDate": {
+			reason:    "Should not record any drift when the resource is up to date",
+			driftDiff: &common.DriftDiffConfig{},
+			body:      `{"id":"123","username":"john_doe","settings":{"tier":"pro","region":"eu"}}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{
+				Spec: v1alpha2.RequestSpec{
+					ForProvider: v1alpha2.RequestParameters{
+						Payload: v1alpha2.Payload{
+							Body:    `{"username": "john_doe", "tier": "pro"}`,
+							BaseUrl: "https://api.example.com/users",
+						},
+						Mappings:  []v1alpha2.Mapping{testPostMapping, testGetMapping, putMapping},
+						DriftDiff: tc.driftDiff,
+					},
+				},
+				Status: v1alpha2.RequestStatus{
+					Response: v1alpha2.Response{Body: `{"id": "123"}`, StatusCode: http.StatusOK},
+				},
+			}
+
+			e := &defaultIsUpToDateResponseCheck{}
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			details := httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{Body: tc.body, StatusCode: http.StatusOK}}
+			if _, err := e.Check(svcCtx, service.NewRequestCRContext(cr), details, nil); err != nil {
+				t.Fatalf("\n%s\nCheck(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, e.Drift()); diff != "" {
+				t.Errorf("\n%s\nDrift(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -72,6 +72,10 @@ func GetIsRemovedResponseCheck(svcCtx *service.ServiceContext, spec interfaces.M
 		return isRemovedCheckFactoryMap[common.ExpectedResponseCheckTypeDefault]()
 	}
 
+	if _, ok := combinedChecks(responseCheckAware.GetIsRemovedCheck()); ok {
+		return &combinedIsRemovedResponseCheck{}
+	}
+
 	if factory, ok := isRemovedCheckFactoryMap[responseCheckAware.GetIsRemovedCheck().GetType()]; ok {
 		return factory()
 	}
@@ -23,11 +23,18 @@ var (
 )
 
 // defaultIsUpToDateResponseCheck performs a default comparison between the response and desired state.
-type defaultIsUpToDateResponseCheck struct{}
+type defaultIsUpToDateResponseCheck struct {
+	drift []common.FieldDiff
+}
 
 // Check performs a default comparison between the response and desired state.
 func (d *defaultIsUpToDateResponseCheck) Check(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, responseErr error) (bool, error) {
-	desiredState, err := d.desiredState(svcCtx, crCtx)
+	// HEAD responses have no body to compare with the desired state, a successful response is up to date.
+	if details.HttpRequest.Method == http.MethodHead {
+		return utils.IsHTTPSuccess(details.HttpResponse.StatusCode), nil
+	}
+
+	desiredState, redactedDesiredState, err := d.desiredState(svcCtx, crCtx)
 	if err != nil {
 		if isErrorMappingNotFound(err) {
 			return true, nil
@@ -35,11 +42,18 @@ func (d *defaultIsUpToDateResponseCheck) Check(svcCtx *service.ServiceContext, c
 		return false, err
 	}
 
-	return d.compareResponseAndDesiredState(svcCtx, details, desiredState)
+	return d.compareResponseAndDesiredState(svcCtx, crCtx, details, desiredState, redactedDesiredState)
+}
+
+// Drift returns the fields of the desired state that differed from the response on the last Check.
+func (d *defaultIsUpToDateResponseCheck) Drift() []common.FieldDiff {
+	return d.drift
 }
 
-// compareResponseAndDesiredState compares the response and desired state to determine if they are in sync.
-func (d *defaultIsUpToDateResponseCheck) compareResponseAndDesiredState(svcCtx *service.ServiceContext, details httpClient.HttpDetails, desiredState string) (bool, error) {
+// compareResponseAndDesiredState compares the response and desired state to determine if they are in sync, once
+// both are normalized by the comparison of the spec. The fields that differ are recorded if the spec records the
+// drift.
+func (d *defaultIsUpToDateResponseCheck) compareResponseAndDesiredState(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, desiredState, redactedDesiredState string) (bool, error) {
 	sensitiveBody, err := d.patchAndValidate(svcCtx, details.HttpResponse.Body)
 	if err != nil {
 		return false, err
@@ -50,11 +64,31 @@ func (d *defaultIsUpToDateResponseCheck) compareResponseAndDesiredState(svcCtx *
 		return false, err
 	}
 
+	filter := comparisonFilter(crCtx.Spec())
+	if sensitiveBody, err = normalizeForComparison(filter, "response body", sensitiveBody); err != nil {
+		return false, err
+	}
+	if sensitiveDesiredState, err = normalizeForComparison(filter, "desired state", sensitiveDesiredState); err != nil {
+		return false, err
+	}
+
 	synced, err := d.comparePatchedResults(sensitiveBody, sensitiveDesiredState, details.HttpResponse.StatusCode)
 	if err != nil {
 		return false, err
 	}
 
+	if !synced && json.IsJSONString(sensitiveBody) && json.IsJSONString(sensitiveDesiredState) {
+		// The redacted bodies normalize like the sensitive ones they only differ from by secret values.
+		redactedObserved, _ := normalizeForComparison(filter, "response body", details.HttpResponse.Body)
+		redactedDesired, _ := normalizeForComparison(filter, "desired state", redactedDesiredState)
+		d.drift = driftDiff(svcCtx, crCtx.Spec(), driftBodies{
+			desired:          sensitiveDesiredState,
+			observed:         sensitiveBody,
+			redactedDesired:  redactedDesired,
+			redactedObserved: redactedObserved,
+		})
+	}
+
 	return synced, nil
 }
 
@@ -96,17 +130,19 @@ func (d *defaultIsUpToDateResponseCheck) compareJSON(body, desiredState string,
 	return json.Contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(statusCode)
 }
 
-// desiredState returns the desired state for a given request
-func (d *defaultIsUpToDateResponseCheck) desiredState(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext) (string, error) {
+// desiredState returns the desired state for a given request, and the same state with its secret values redacted.
+func (d *defaultIsUpToDateResponseCheck) desiredState(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext) (string, string, error) {
 	requestDetails, err := d.requestDetails(svcCtx, crCtx, common.ActionUpdate)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	if bodyStr, ok := requestDetails.Body.Decrypted.(string); ok {
-		return bodyStr, nil
+	bodyStr, ok := requestDetails.Body.Decrypted.(string)
+	if !ok {
+		return "", "", nil
 	}
-	return "", nil
+	redactedBodyStr, _ := requestDetails.Body.Encrypted.(string)
+	return bodyStr, redactedBodyStr, nil
 }
 
 // customIsUpToDateResponseCheck performs a custom response check using JQ logic.
@@ -167,6 +203,10 @@ func GetIsUpToDateResponseCheck(svcCtx *service.ServiceContext, spec interfaces.
 		return isUpToDateChecksFactoryMap[common.ExpectedResponseCheckTypeDefault]()
 	}
 
+	if _, ok := combinedChecks(responseCheckAware.GetExpectedResponseCheck()); ok {
+		return &combinedIsUpToDateResponseCheck{}
+	}
+
 	if factory, ok := isUpToDateChecksFactoryMap[responseCheckAware.GetExpectedResponseCheck().GetType()]; ok {
 		return factory()
 	}
@@ -7,11 +7,14 @@ import (
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
 	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
+const requestContextKey = "request"
+
 // responseCheck is an interface for performing response checks.
 type responseCheck interface {
 	Check(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, details httpClient.HttpDetails, responseErr error) (bool, error)
@@ -30,6 +33,12 @@ func (c *customCheck) check(svcCtx *service.ServiceContext, spec interfaces.Mapp
 
 	sensitiveRequestContext := requestgen.GenerateRequestContext(spec, sensitiveResponse)
 
+	sensitiveRequest, err := sentRequestContext(svcCtx, details.HttpRequest)
+	if err != nil {
+		return false, err
+	}
+	sensitiveRequestContext[requestContextKey] = sensitiveRequest
+
 	jqQuery := utils.NormalizeWhitespace(logic)
 	sensitiveJQQuery, err := datapatcher.PatchSecretsIntoString(svcCtx.Ctx, svcCtx.LocalKube, jqQuery, svcCtx.Logger)
 	if err != nil {
@@ -45,3 +54,30 @@ func (c *customCheck) check(svcCtx *service.ServiceContext, spec interfaces.Mapp
 
 	return isExpected, nil
 }
+
+// sentRequestContext returns the request whose response is checked, with its secrets patched in and its body
+// converted to a map if it is JSON, so checks can compare the response to what was sent.
+func sentRequestContext(svcCtx *service.ServiceContext, request httpClient.HttpRequest) (map[string]interface{}, error) {
+	body, err := datapatcher.PatchSecretsIntoString(svcCtx.Ctx, svcCtx.LocalKube, request.Body, svcCtx.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	headers, err := datapatcher.PatchSecretsIntoHeaders(svcCtx.Ctx, svcCtx.LocalKube, request.Headers, svcCtx.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	requestMap, err := json_util.StructToMap(httpClient.HttpRequest{
+		Method:  request.Method,
+		URL:     request.URL,
+		Body:    body,
+		Headers: headers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	json_util.ConvertJSONStringsToMaps(&requestMap)
+	return requestMap, nil
+}
@@ -60,6 +60,60 @@ func Test_CustomCheck(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"CustomCheckOnTrailersPasses": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  common.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.trailers."Grpc-Status"[0] == "0"`,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{}`,
+						Trailers:   map[string][]string{"Grpc-Status": {"0"}},
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.trailers."Grpc-Status"[0] == "0"`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckOnStatusTextPasses": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+								Type:  common.ExpectedResponseCheckTypeCustom,
+								Logic: `.response.statusText == "Resource Locked"`,
+							},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{}`,
+						StatusCode: 409,
+						StatusText: "Resource Locked",
+					},
+				},
+				logic: `.response.statusText == "Resource Locked"`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
 		"CustomCheckFails": {
 			args: args{
 				ctx: context.Background(),
@@ -94,6 +148,123 @@ func Test_CustomCheck(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"CustomCheckOnTextBodyPasses": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ResponseFormat: common.ResponseFormatText,
+							Payload:        v1alpha2.Payload{Body: `{"version": "1.2.3"}`},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       "1.2.3",
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.bodyText == .payload.body.version`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckOnTextBodyFails": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ResponseFormat: common.ResponseFormatText,
+							Payload:        v1alpha2.Payload{Body: `{"version": "1.2.3"}`},
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       "1.2.4",
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.bodyText == .payload.body.version`,
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
+		"CustomCheckOnTextBodyNotParsed": {
+			args: args{
+				ctx: context.Background(),
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							ResponseFormat: common.ResponseFormatText,
+						},
+					},
+				},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"version":"1.2.3"}`,
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.body == .response.bodyText and .response.bodyText == "{\"version\":\"1.2.3\"}"`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckOnEchoedRequestPasses": {
+			args: args{
+				ctx: context.Background(),
+				cr:  &v1alpha2.Request{},
+				details: httpClient.HttpDetails{
+					HttpRequest: httpClient.HttpRequest{
+						Method:  "PUT",
+						URL:     "http://example.com/users/1",
+						Body:    `{"name":"john"}`,
+						Headers: map[string][]string{"If-Match": {"v1"}},
+					},
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"1","name":"john"}`,
+						Headers:    map[string][]string{"Etag": {"v1"}},
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.body.name == .request.body.name and .request.method == "PUT" and .response.headers.Etag == .request.headers."If-Match"`,
+			},
+			want: want{
+				result: true,
+				err:    nil,
+			},
+		},
+		"CustomCheckOnEchoedRequestFails": {
+			args: args{
+				ctx: context.Background(),
+				cr:  &v1alpha2.Request{},
+				details: httpClient.HttpDetails{
+					HttpRequest: httpClient.HttpRequest{
+						Method: "PUT",
+						URL:    "http://example.com/users/1",
+						Body:   `{"name":"john"}`,
+					},
+					HttpResponse: httpClient.HttpResponse{
+						Body:       `{"id":"1","name":"jane"}`,
+						StatusCode: 200,
+					},
+				},
+				logic: `.response.body.name == .request.body.name`,
+			},
+			want: want{
+				result: false,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -13,10 +13,12 @@ import (
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestmapping"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -67,6 +69,12 @@ var (
 		Method: "DELETE",
 		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
 	}
+
+	testHeadMapping = v1alpha2.Mapping{
+		Method: "HEAD",
+		Action: "OBSERVE",
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
 )
 
 type httpRequestModifier func(request *v1alpha2.Request)
@@ -157,6 +165,228 @@ func Test_isUpToDate(t *testing.T) {
 				},
 			},
 		},
+		"CreateFirstSkipsObserveBeforeCreate": {
+			args: args{
+				http: &MockHttpClient{},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ObserveBeforeCreate = ptr.To(false)
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{
+							Method: "GET",
+							URL:    "(\"http://some.org/\" + \"1423\")",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
+		"CreateFirstObservesAfterCreate": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.ObserveBeforeCreate = ptr.To(false)
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{
+							Method: "GET",
+							URL:    "(\"http://some.org/\" + \"1423\")",
+						},
+					}
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.StatusCode = 201
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"ImportByExternalName": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if url != "http://some.org/existing-user" {
+							return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: 404}}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"existing-user"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					meta.SetExternalName(r, "existing-user")
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{
+							Method: "GET",
+							URL:    "(\"http://some.org/\" + .externalName)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"existing-user"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"SecondObserveMappingFindsResource": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if url != "http://some.org/users?name=john_doe" {
+							return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound}}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.Body = `{"id": "123"}`
+					r.Status.Response.StatusCode = 201
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{
+							Action: "OBSERVE",
+							URL:    "(\"http://some.org/users/\" + .response.body.id)",
+						},
+						{
+							Action: "OBSERVE",
+							URL:    "(\"http://some.org/users?name=\" + .payload.body.username)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"SecondObserveMappingFindsResourceBeforeIDKnown": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						if url != "http://some.org/users?name=john_doe" {
+							return httpClient.HttpDetails{}, errors.Errorf("unexpected request to %s", url)
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{
+							Action: "OBSERVE",
+							URL:    "(\"http://some.org/users/\" + .response.body.id)",
+						},
+						{
+							Action: "OBSERVE",
+							URL:    "(\"http://some.org/users?name=\" + .payload.body.username)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"NoObserveMappingFindsResource": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusNotFound}}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.Body = `{"id": "123"}`
+					r.Status.Response.StatusCode = 201
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						{
+							Action: "OBSERVE",
+							URL:    "(\"http://some.org/users/\" + .response.body.id)",
+						},
+						{
+							Action: "OBSERVE",
+							URL:    "(\"http://some.org/users?name=\" + .payload.body.username)",
+						},
+					}
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
 		"ObjectNotFoundEmptyStatus": {
 			args: args{
 				http: &MockHttpClient{
@@ -351,6 +581,82 @@ func Test_isUpToDate(t *testing.T) {
 				},
 			},
 		},
+		"SuccessHEADEmptyBody": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Headers:    map[string][]string{"Etag": {`"v1"`}},
+								StatusCode: 200,
+							},
+							HttpRequest: httpClient.HttpRequest{
+								Method: method,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"id": "123"}`
+					r.Status.Response.StatusCode = 201
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						testPostMapping,
+						testHeadMapping,
+						testPutMapping,
+						testDeleteMapping,
+					}
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Headers:    map[string][]string{"Etag": {`"v1"`}},
+							StatusCode: 200,
+						},
+						HttpRequest: httpClient.HttpRequest{
+							Method: http.MethodHead,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"HEADObjectNotFound": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body, headers httpClient.Data, tlsConfigData *httpClient.TLSConfigData) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: http.StatusNotFound,
+							},
+							HttpRequest: httpClient.HttpRequest{
+								Method: method,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha2.Request) {
+					r.Status.Response.Body = `{"id": "123"}`
+					r.Status.Response.StatusCode = 201
+					r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+						testPostMapping,
+						testHeadMapping,
+						testPutMapping,
+						testDeleteMapping,
+					}
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
 		"MissingMappingObjectNotCreated": {
 			args: args{
 				http: &MockHttpClient{
@@ -657,6 +963,29 @@ func Test_isObjectValidForObservation(t *testing.T) {
 				valid: false,
 			},
 		},
+		"POSTMethodWithLogicallyFailedResponse": {
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							SuccessCondition: ".body.ok == true",
+						},
+					},
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{
+							Body:       `{"ok": false}`,
+							StatusCode: http.StatusOK,
+						},
+						RequestDetails: v1alpha2.Mapping{
+							Method: http.MethodPost,
+						},
+					},
+				},
+			},
+			want: want{
+				valid: false,
+			},
+		},
 		"POSTMethodWithErrorResponse": {
 			args: args{
 				cr: &v1alpha2.Request{
@@ -693,6 +1022,55 @@ func Test_isObjectValidForObservation(t *testing.T) {
 				valid: true,
 			},
 		},
+		"CustomCreateMethodWithErrorResponse": {
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Mappings: []v1alpha2.Mapping{{Method: "MKCOL", Action: "CREATE"}},
+						},
+					},
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{
+							Body:       "some response",
+							StatusCode: http.StatusConflict,
+						},
+						RequestDetails: v1alpha2.Mapping{
+							Method: "MKCOL",
+						},
+					},
+				},
+			},
+			want: want{
+				valid: false,
+			},
+		},
+		"POSTObserveWithErrorResponse": {
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: v1alpha2.RequestParameters{
+							Mappings: []v1alpha2.Mapping{
+								{Method: http.MethodPut, Action: "CREATE"},
+								{Method: http.MethodPost, Action: "OBSERVE"},
+							},
+						},
+					},
+					Status: v1alpha2.RequestStatus{
+						Response: v1alpha2.Response{
+							Body:       "some response",
+							StatusCode: http.StatusInternalServerError,
+						},
+						RequestDetails: v1alpha2.Mapping{
+							Method: http.MethodPost,
+						},
+					},
+				},
+			},
+			want: want{
+				valid: true,
+			},
+		},
 	}
 
 	for name, tc := range cases {
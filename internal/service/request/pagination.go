@@ -0,0 +1,43 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const errPageStatusCode = "page %s returned status code %d"
+
+// applyResponseDataToSecrets applies the response data to the secrets of the spec, following the pages of the
+// response with GET requests sending the headers of the request for the secret injection configs setting pagination.
+// A failed response is replaced with the last successful one when the spec asks for it.
+func applyResponseDataToSecrets(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, requestDetails requestgen.RequestDetails, response *httpClient.HttpResponse) {
+	if lastSuccessful := lastSuccessfulResponse(svcCtx, crCtx, response); lastSuccessful != nil {
+		// The pages of the last successful response are not fetched again.
+		datapatcher.ApplyResponseDataToSecrets(svcCtx.Ctx, svcCtx.LocalKube, svcCtx.Logger, lastSuccessful, crCtx.Spec().GetSecretInjectionConfigs(), crCtx.GetCR())
+		return
+	}
+
+	pages := &datapatcher.Pages{
+		URL: requestDetails.Url,
+		Fetch: func(url string) (*httpClient.HttpResponse, error) {
+			details, err := svcCtx.HTTP.SendRequest(svcCtx.Ctx, http.MethodGet, url, httpClient.Data{Encrypted: "", Decrypted: ""}, requestDetails.Headers, svcCtx.TLSConfigData)
+			if err != nil {
+				return nil, err
+			}
+			if utils.IsHTTPError(details.HttpResponse.StatusCode) {
+				return nil, errors.Errorf(errPageStatusCode, url, details.HttpResponse.StatusCode)
+			}
+
+			return &details.HttpResponse, nil
+		},
+	}
+
+	datapatcher.ApplyPagedResponseDataToSecrets(svcCtx.Ctx, svcCtx.LocalKube, svcCtx.Logger, response, crCtx.Spec().GetSecretInjectionConfigs(), crCtx.GetCR(), pages)
+}
@@ -0,0 +1,137 @@
+package requestgen
+
+import (
+	"maps"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+const (
+	headerContentType     = "Content-Type"
+	ndjsonContentType     = "application/x-ndjson"
+	mergePatchContentType = "application/merge-patch+json"
+
+	errNDJSONBody     = "the body of an ndjson mapping must be a JSON array"
+	errMergePatchBody = "the body of an autoMergePatch mapping must be a JSON object"
+)
+
+// formatBody serializes the body in the body format of the mapping, and sets the Content-Type header of the format
+// unless the headers set one. A JSON body is kept as is. An automatic merge patch is computed against the last
+// response in the template context, whose secrets are patched in. A binary body is never serialized, only its
+// Content-Type header is set.
+func formatBody(mapping interfaces.HTTPMapping, jqObject map[string]interface{}, body, headers *httpClient.Data) error {
+	if contentType, ok := binaryBodyContentType(mapping); ok {
+		setDefaultHeader(headers, headerContentType, contentType)
+		return nil
+	}
+
+	formatAware, ok := mapping.(interfaces.BodyFormatAware)
+	if !ok {
+		return nil
+	}
+
+	switch formatAware.GetBodyFormat() {
+	case common.BodyFormatNDJSON:
+		return formatNDJSONBody(body, headers)
+	case common.BodyFormatAutoMergePatch:
+		return formatMergePatchBody(jqObject, body, headers)
+	}
+
+	return nil
+}
+
+// formatNDJSONBody serializes a JSON array body as newline-delimited JSON.
+func formatNDJSONBody(body, headers *httpClient.Data) error {
+	decrypted, err := json_util.ToNDJSON(body.Decrypted.(string))
+	if err != nil {
+		return errors.Wrap(err, errNDJSONBody)
+	}
+	body.Decrypted = decrypted
+
+	// The body shown in the status is kept as is if it is not an array, e.g. the placeholder of a body loaded from
+	// a Secret.
+	if encrypted, err := json_util.ToNDJSON(body.Encrypted.(string)); err == nil {
+		body.Encrypted = encrypted
+	}
+
+	setDefaultHeader(headers, headerContentType, ndjsonContentType)
+	return nil
+}
+
+// formatMergePatchBody replaces a JSON object body, the desired state, with the merge patch of the fields that
+// differ from the body of the last response. The whole body is sent until a response with a JSON object body was
+// received.
+func formatMergePatchBody(jqObject map[string]interface{}, body, headers *httpClient.Data) error {
+	desired := json_util.JsonStringToMap(body.Decrypted.(string))
+	if desired == nil {
+		return errors.New(errMergePatchBody)
+	}
+
+	// The patch is computed on the sent body, holding the secret values, and the body shown in the status is
+	// restricted to the same fields, so a changed secret value is sent even though its placeholder is unchanged.
+	patch := json_util.MergePatch(patchedResponseBody(jqObject), desired)
+	decrypted, err := json_util.ConvertMapToJson(patch)
+	if err != nil {
+		return err
+	}
+	body.Decrypted = decrypted
+
+	if encryptedDesired := json_util.JsonStringToMap(body.Encrypted.(string)); encryptedDesired != nil {
+		if encrypted, err := json_util.ConvertMapToJson(patchFields(patch, encryptedDesired)); err == nil {
+			body.Encrypted = encrypted
+		}
+	}
+
+	setDefaultHeader(headers, headerContentType, mergePatchContentType)
+	return nil
+}
+
+// patchedResponseBody returns the body of the last response in the template context if it is a JSON object, or nil.
+func patchedResponseBody(jqObject map[string]interface{}) map[string]interface{} {
+	response, _ := jqObject["response"].(map[string]interface{})
+	body, _ := response["body"].(map[string]interface{})
+	return body
+}
+
+// patchFields returns the fields of values present in the patch, the nested objects of the patch being restricted
+// the same way.
+func patchFields(patch, values map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(patch))
+	for key, value := range patch {
+		nestedPatch, isMap := value.(map[string]interface{})
+		nestedValues, valuesIsMap := values[key].(map[string]interface{})
+		if isMap && valuesIsMap {
+			fields[key] = patchFields(nestedPatch, nestedValues)
+			continue
+		}
+		fields[key] = values[key]
+	}
+
+	return fields
+}
+
+// setDefaultHeader sets the header in the encrypted and decrypted headers, unless they already set it.
+func setDefaultHeader(headers *httpClient.Data, key, value string) {
+	headers.Encrypted = withDefaultHeader(headers.Encrypted.(map[string][]string), key, value)
+	headers.Decrypted = withDefaultHeader(headers.Decrypted.(map[string][]string), key, value)
+}
+
+// withDefaultHeader returns a copy of the headers setting the header to the value, unless they already set it.
+func withDefaultHeader(headers map[string][]string, key, value string) map[string][]string {
+	if httpClient.HeaderValues(headers, key) != nil {
+		return headers
+	}
+
+	withHeader := maps.Clone(headers)
+	if withHeader == nil {
+		withHeader = map[string][]string{}
+	}
+	withHeader[key] = []string{value}
+
+	return withHeader
+}
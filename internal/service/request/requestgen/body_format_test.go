@@ -0,0 +1,166 @@
+package requestgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_GenerateRequestDetailsNDJSON(t *testing.T) {
+	cases := map[string]struct {
+		reason          string
+		payloadBody     string
+		headers         map[string][]string
+		wantBody        string
+		wantContentType []string
+		wantErr         bool
+	}{
+		"OneObjectPerLine": {
+			reason:          "Should send the elements of the array one per line with the NDJSON content type",
+			payloadBody:     `{"events": [{"msg": "started", "level": "info"}, {"msg": "done", "level": "info"}]}`,
+			wantBody:        "{\"level\":\"info\",\"msg\":\"started\"}\n{\"level\":\"info\",\"msg\":\"done\"}\n",
+			wantContentType: []string{ndjsonContentType},
+		},
+		"EmptyArray": {
+			reason:          "Should send an empty body for an empty array",
+			payloadBody:     `{"events": []}`,
+			wantBody:        "",
+			wantContentType: []string{ndjsonContentType},
+		},
+		"ContentTypeSet": {
+			reason:          "Should keep the Content-Type set by the headers",
+			payloadBody:     `{"events": [{"msg": "started"}]}`,
+			headers:         map[string][]string{"content-type": {"application/jsonl"}},
+			wantBody:        "{\"msg\":\"started\"}\n",
+			wantContentType: []string{"application/jsonl"},
+		},
+		"NotAnArray": {
+			reason:      "Should fail to template a body that is not an array",
+			payloadBody: `{"events": {"msg": "started"}}`,
+			wantErr:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			forProvider := testForProvider
+			forProvider.Payload.Body = tc.payloadBody
+			mapping := v1alpha2.Mapping{
+				Method:     "POST",
+				URL:        ".payload.baseUrl",
+				Body:       ".payload.body.events",
+				BodyFormat: common.BodyFormatNDJSON,
+				Headers:    tc.headers,
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			got, err, _ := GenerateRequestDetails(svcCtx, &mapping, &forProvider, &v1alpha2.Response{}, nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nGenerateRequestDetails(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got.Body.Decrypted); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want body, +got body: %s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantBody, got.Body.Encrypted); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want status body, +got status body: %s", tc.reason, diff)
+			}
+			for _, headers := range []interface{}{got.Headers.Decrypted, got.Headers.Encrypted} {
+				contentType := headers.(map[string][]string)
+				var values []string
+				for key, v := range contentType {
+					if key == headerContentType || key == "content-type" {
+						values = v
+					}
+				}
+				if diff := cmp.Diff(tc.wantContentType, values); diff != "" {
+					t.Errorf("\n%s\nGenerateRequestDetails(...): -want Content-Type, +got Content-Type: %s", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func Test_GenerateRequestDetailsAutoMergePatch(t *testing.T) {
+	cases := map[string]struct {
+		reason       string
+		payloadBody  string
+		responseBody string
+		wantBody     string
+		wantErr      bool
+	}{
+		"OnlyChangedFields": {
+			reason:       "Should send only the fields that differ from the last response",
+			payloadBody:  `{"name": "alice", "role": "admin", "settings": {"theme": "dark", "lang": "en"}, "tags": ["a", "b"]}`,
+			responseBody: `{"id": 7, "name": "alice", "role": "viewer", "settings": {"theme": "light", "lang": "en"}, "tags": ["a", "b"]}`,
+			wantBody:     `{"role":"admin","settings":{"theme":"dark"}}`,
+		},
+		"RemovedField": {
+			reason:       "Should send null for a field the desired body sets to null",
+			payloadBody:  `{"name": "alice", "nickname": null}`,
+			responseBody: `{"id": 7, "name": "alice", "nickname": "al"}`,
+			wantBody:     `{"nickname":null}`,
+		},
+		"Unchanged": {
+			reason:       "Should send an empty patch when nothing changed",
+			payloadBody:  `{"name": "alice"}`,
+			responseBody: `{"id": 7, "name": "alice"}`,
+			wantBody:     `{}`,
+		},
+		"NoResponse": {
+			reason:      "Should send the whole desired body before a response was received",
+			payloadBody: `{"name": "alice", "role": "admin"}`,
+			wantBody:    `{"name":"alice","role":"admin"}`,
+		},
+		"NotAnObject": {
+			reason:       "Should fail to template a body that is not an object",
+			payloadBody:  `["alice"]`,
+			responseBody: `{"name": "alice"}`,
+			wantErr:      true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			forProvider := testForProvider
+			forProvider.Payload.Body = tc.payloadBody
+			mapping := v1alpha2.Mapping{
+				Method:     "PATCH",
+				URL:        ".payload.baseUrl",
+				Body:       ".payload.body",
+				BodyFormat: common.BodyFormatAutoMergePatch,
+			}
+			response := &v1alpha2.Response{}
+			if tc.responseBody != "" {
+				response = &v1alpha2.Response{StatusCode: 200, Body: tc.responseBody}
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			got, err, _ := GenerateRequestDetails(svcCtx, &mapping, &forProvider, response, nil)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nGenerateRequestDetails(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got.Body.Decrypted); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want body, +got body: %s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantBody, got.Body.Encrypted); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want status body, +got status body: %s", tc.reason, diff)
+			}
+			if diff := cmp.Diff([]string{mergePatchContentType}, got.Headers.Decrypted.(map[string][]string)[headerContentType]); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want Content-Type, +got Content-Type: %s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package requestgen
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	errBodyDenied         = "rendered request body matches bodyDenyPatterns[%d], refusing to send it"
+	errInvalidDenyPattern = "invalid bodyDenyPatterns[%d]"
+)
+
+// checkBodyDenyPatterns refuses a rendered body, secrets included, matching any deny pattern of the spec.
+// The error only references the pattern index so the matched content is never leaked.
+func checkBodyDenyPatterns(forProvider interfaces.MappedHTTPRequestSpec, body httpClient.Data) error {
+	denylist, ok := forProvider.(interfaces.BodyDenylistAware)
+	if !ok {
+		return nil
+	}
+
+	decrypted, ok := body.Decrypted.(string)
+	if !ok || decrypted == "" {
+		return nil
+	}
+
+	for i, pattern := range denylist.GetBodyDenyPatterns() {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return errors.Wrapf(err, errInvalidDenyPattern, i)
+		}
+
+		if re.MatchString(decrypted) {
+			return errors.Errorf(errBodyDenied, i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package requestgen
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+const (
+	errBodySourceKeyNotFound = "key %s not found in %s %s:%s"
+	errInvalidBodySource     = "bodyFrom must set exactly one of secretKeyRef, configMapKeyRef and base64"
+	errDecodeBase64Body      = "cannot decode the base64 body"
+
+	secretBodyPlaceholder = "{{%s:%s:%s}}"
+	binaryBodySummary     = "<%d bytes of binary data>"
+	binaryContentType     = "application/octet-stream"
+)
+
+// generateMappingBody generates the request body of the mapping, loading it from a Secret or ConfigMap key, or
+// from base64, when the mapping sets bodyFrom. A loaded body is sent verbatim unless templating is enabled, and a
+// binary one as raw bytes. The query the body was templated with, if any, is returned along with it.
+func generateMappingBody(svcCtx *service.ServiceContext, mapping interfaces.HTTPMapping, jqObject map[string]interface{}) (httpClient.Data, string, error) {
+	sourceAware, ok := mapping.(interfaces.BodySourceAware)
+	if !ok || sourceAware.GetBodyFrom() == nil {
+		body, err := generateBody(svcCtx, mapping.GetBody(), jqObject)
+		return body, mapping.GetBody(), err
+	}
+
+	source := sourceAware.GetBodyFrom()
+	content, placeholder, err := loadBodySource(svcCtx, source)
+	if err != nil {
+		return httpClient.Data{}, "", err
+	}
+
+	if isBinaryBody(source) {
+		return binaryBody(content, placeholder), "", nil
+	}
+
+	body := httpClient.Data{Encrypted: string(content), Decrypted: string(content)}
+	query := ""
+	if source.Template {
+		query = string(content)
+		if body, err = generateBody(svcCtx, query, jqObject); err != nil {
+			return httpClient.Data{}, "", err
+		}
+	}
+
+	// A body loaded from a Secret is only referenced by its placeholder outside of the sent request.
+	if placeholder != "" {
+		body.Encrypted = placeholder
+	}
+
+	return body, query, nil
+}
+
+// isBinaryBody checks if the body of the source is sent as raw bytes.
+func isBinaryBody(source *common.BodySource) bool {
+	return source.Binary || source.Base64 != ""
+}
+
+// binaryBody returns the binary content as a body sent as raw bytes, bypassing the string-based templating and
+// formatting. The status shows the placeholder of a body loaded from a Secret, or the size of the body otherwise.
+func binaryBody(content []byte, placeholder string) httpClient.Data {
+	if placeholder == "" {
+		placeholder = fmt.Sprintf(binaryBodySummary, len(content))
+	}
+
+	return httpClient.Data{Encrypted: placeholder, Decrypted: httpClient.NewBytesBodyStream(content)}
+}
+
+// binaryBodyContentType returns the Content-Type header of the binary body of the mapping, and whether its body is
+// binary.
+func binaryBodyContentType(mapping interfaces.HTTPMapping) (string, bool) {
+	sourceAware, ok := mapping.(interfaces.BodySourceAware)
+	if !ok || sourceAware.GetBodyFrom() == nil || !isBinaryBody(sourceAware.GetBodyFrom()) {
+		return "", false
+	}
+
+	if contentType := sourceAware.GetBodyFrom().ContentType; contentType != "" {
+		return contentType, true
+	}
+
+	return binaryContentType, true
+}
+
+// loadBodySource reads the body referenced or held by the source. For a Secret it also returns the placeholder
+// referencing the body, so the Secret content is not exposed.
+func loadBodySource(svcCtx *service.ServiceContext, source *common.BodySource) ([]byte, string, error) {
+	switch {
+	case source.Base64 != "" && source.SecretKeyRef == nil && source.ConfigMapKeyRef == nil:
+		content, err := base64.StdEncoding.DecodeString(source.Base64)
+		if err != nil {
+			return nil, "", errors.Wrap(err, errDecodeBase64Body)
+		}
+
+		return content, "", nil
+	case source.SecretKeyRef != nil && source.ConfigMapKeyRef == nil && source.Base64 == "":
+		ref := source.SecretKeyRef
+		secret, err := kubehandler.GetSecret(svcCtx.Ctx, svcCtx.LocalKube, ref.Name, ref.Namespace)
+		if err != nil {
+			return nil, "", err
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, "", errors.Errorf(errBodySourceKeyNotFound, ref.Key, "secret", ref.Name, ref.Namespace)
+		}
+
+		return value, fmt.Sprintf(secretBodyPlaceholder, ref.Name, ref.Namespace, ref.Key), nil
+	case source.ConfigMapKeyRef != nil && source.SecretKeyRef == nil && source.Base64 == "":
+		ref := source.ConfigMapKeyRef
+		configMap, err := kubehandler.GetConfigMap(svcCtx.Ctx, svcCtx.LocalKube, ref.Name, ref.Namespace)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if value, ok := configMap.Data[ref.Key]; ok {
+			return []byte(value), "", nil
+		}
+		if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return value, "", nil
+		}
+
+		return nil, "", errors.Errorf(errBodySourceKeyNotFound, ref.Key, "configmap", ref.Name, ref.Namespace)
+	default:
+		return nil, "", errors.New(errInvalidBodySource)
+	}
+}
@@ -0,0 +1,256 @@
+package requestgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testVerbatimBody = `{"username": .payload.body.username}`
+	testTemplateBody = `{ username: .payload.body.username, email: .payload.body.email }`
+)
+
+// testBodySourceClient returns a client serving the bodies of the "bodies" Secret and ConfigMap.
+func testBodySourceClient(data map[string]string) client.Client {
+	return &test.MockClient{
+		MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.Secret:
+				o.Data = map[string][]byte{}
+				for k, v := range data {
+					o.Data[k] = []byte(v)
+				}
+			case *corev1.ConfigMap:
+				o.Data = data
+			}
+			return nil
+		},
+	}
+}
+
+func Test_generateMappingBody(t *testing.T) {
+	errBoom := errors.New("boom")
+	secretRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "bodies", Namespace: "default"},
+		Key:             "body",
+	}
+	configMapRef := &common.ConfigMapKeySelector{Name: "bodies", Namespace: "default", Key: "body"}
+
+	type args struct {
+		localKube client.Client
+		bodyFrom  *common.BodySource
+	}
+	type want struct {
+		body httpClient.Data
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"InlineBody": {
+			args: args{},
+			want: want{
+				body: httpClient.Data{
+					Encrypted: `{"email":"john.doe@example.com","username":"john_doe"}`,
+					Decrypted: `{"email":"john.doe@example.com","username":"john_doe"}`,
+				},
+			},
+		},
+		"SecretVerbatim": {
+			args: args{
+				localKube: testBodySourceClient(map[string]string{"body": testVerbatimBody}),
+				bodyFrom:  &common.BodySource{SecretKeyRef: secretRef},
+			},
+			want: want{
+				body: httpClient.Data{
+					Encrypted: "{{bodies:default:body}}",
+					Decrypted: testVerbatimBody,
+				},
+			},
+		},
+		"SecretTemplated": {
+			args: args{
+				localKube: testBodySourceClient(map[string]string{"body": testTemplateBody}),
+				bodyFrom:  &common.BodySource{SecretKeyRef: secretRef, Template: true},
+			},
+			want: want{
+				body: httpClient.Data{
+					Encrypted: "{{bodies:default:body}}",
+					Decrypted: `{"email":"john.doe@example.com","username":"john_doe"}`,
+				},
+			},
+		},
+		"ConfigMapVerbatim": {
+			args: args{
+				localKube: testBodySourceClient(map[string]string{"body": testVerbatimBody}),
+				bodyFrom:  &common.BodySource{ConfigMapKeyRef: configMapRef},
+			},
+			want: want{
+				body: httpClient.Data{
+					Encrypted: testVerbatimBody,
+					Decrypted: testVerbatimBody,
+				},
+			},
+		},
+		"ConfigMapTemplated": {
+			args: args{
+				localKube: testBodySourceClient(map[string]string{"body": testTemplateBody}),
+				bodyFrom:  &common.BodySource{ConfigMapKeyRef: configMapRef, Template: true},
+			},
+			want: want{
+				body: httpClient.Data{
+					Encrypted: `{"email":"john.doe@example.com","username":"john_doe"}`,
+					Decrypted: `{"email":"john.doe@example.com","username":"john_doe"}`,
+				},
+			},
+		},
+		"MissingKey": {
+			args: args{
+				localKube: testBodySourceClient(map[string]string{"other": testVerbatimBody}),
+				bodyFrom:  &common.BodySource{ConfigMapKeyRef: configMapRef},
+			},
+			want: want{
+				err: errors.Errorf(errBodySourceKeyNotFound, "body", "configmap", "bodies", "default"),
+			},
+		},
+		"GetSecretFailed": {
+			args: args{
+				localKube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				bodyFrom:  &common.BodySource{SecretKeyRef: secretRef},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, fmt.Sprintf("failed to get secret %s:%s", "bodies", "default")),
+			},
+		},
+		"InvalidBase64": {
+			args: args{
+				bodyFrom: &common.BodySource{Base64: "not base64!"},
+			},
+			want: want{
+				err: errors.Wrap(base64.CorruptInputError(3), errDecodeBase64Body),
+			},
+		},
+		"BothSources": {
+			args: args{
+				bodyFrom: &common.BodySource{SecretKeyRef: secretRef, ConfigMapKeyRef: configMapRef},
+			},
+			want: want{
+				err: errors.New(errInvalidBodySource),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			svcCtx := service.NewServiceContext(context.Background(), tc.args.localKube, logging.NewNopLogger(), nil, nil)
+			mapping := testPostMapping
+			mapping.BodyFrom = tc.args.bodyFrom
+
+			got, _, err := generateMappingBody(svcCtx, &mapping, GenerateRequestContext(&testForProvider, nil))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("generateMappingBody(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got); diff != "" {
+				t.Errorf("generateMappingBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_GenerateRequestDetailsBinary(t *testing.T) {
+	// A protobuf-like message that is not valid UTF-8.
+	content := []byte{0x0a, 0x04, 0xff, 0xfe, 0x00, 0x80, 0x12, 0x02, 0xc3, 0x28}
+	secretRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "bodies", Namespace: "default"},
+		Key:             "body",
+	}
+
+	cases := map[string]struct {
+		reason          string
+		bodyFrom        *common.BodySource
+		headers         map[string][]string
+		wantStatusBody  string
+		wantContentType string
+	}{
+		"Base64": {
+			reason:          "Should send a base64 body as raw bytes with the default Content-Type",
+			bodyFrom:        &common.BodySource{Base64: base64.StdEncoding.EncodeToString(content)},
+			wantStatusBody:  fmt.Sprintf(binaryBodySummary, len(content)),
+			wantContentType: binaryContentType,
+		},
+		"Secret": {
+			reason:          "Should send the binary key of a Secret as raw bytes with its Content-Type",
+			bodyFrom:        &common.BodySource{SecretKeyRef: secretRef, Binary: true, ContentType: "application/x-protobuf"},
+			wantStatusBody:  "{{bodies:default:body}}",
+			wantContentType: "application/x-protobuf",
+		},
+		"ContentTypeSet": {
+			reason:          "Should keep the Content-Type set by the headers",
+			bodyFrom:        &common.BodySource{Base64: base64.StdEncoding.EncodeToString(content), ContentType: "application/x-protobuf"},
+			headers:         map[string][]string{"Content-Type": {"application/grpc"}},
+			wantStatusBody:  fmt.Sprintf(binaryBodySummary, len(content)),
+			wantContentType: "application/grpc",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gotBody []byte
+			var gotContentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotBody, _ = io.ReadAll(r.Body)
+				gotContentType = r.Header.Get("Content-Type")
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			mapping := v1alpha2.Mapping{Method: "POST", URL: ".payload.baseUrl", BodyFrom: tc.bodyFrom, Headers: tc.headers}
+			forProvider := testForProvider
+			forProvider.Payload.BaseUrl = server.URL
+
+			localKube := testBodySourceClient(map[string]string{"body": string(content)})
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+			details, err, _ := GenerateRequestDetails(svcCtx, &mapping, &forProvider, &v1alpha2.Response{}, nil)
+			if err != nil {
+				t.Fatalf("\n%s\nGenerateRequestDetails(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.wantStatusBody, details.Body.Encrypted); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want status body, +got status body: %s", tc.reason, diff)
+			}
+
+			client, err := httpClient.NewClient(logging.NewNopLogger(), 30*time.Second, "")
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %v", err)
+			}
+			if _, err := client.SendRequest(context.Background(), mapping.Method, details.Url, details.Body, details.Headers, &httpClient.TLSConfigData{}); err != nil {
+				t.Fatalf("\n%s\nSendRequest(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if !bytes.Equal(content, gotBody) {
+				t.Errorf("\n%s\nSendRequest(...): want body %x, got %x", tc.reason, content, gotBody)
+			}
+			if gotContentType != tc.wantContentType {
+				t.Errorf("\n%s\nSendRequest(...): want Content-Type %q, got %q", tc.reason, tc.wantContentType, gotContentType)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package requestgen
+
+import (
+	"os"
+	"sync"
+)
+
+const envContextKey = "env"
+
+var (
+	envAllowlistMu sync.RWMutex
+	envAllowlist   []string
+)
+
+// SetEnvAllowlist sets the names of the provider environment variables exposed to the templates under the env key.
+// Variables that are not allowlisted are never exposed, so templates referencing them resolve to null.
+func SetEnvAllowlist(names []string) {
+	envAllowlistMu.Lock()
+	defer envAllowlistMu.Unlock()
+
+	envAllowlist = append([]string(nil), names...)
+}
+
+// envContext returns the allowlisted environment variables that are set, or nil if none is.
+func envContext() map[string]interface{} {
+	envAllowlistMu.RLock()
+	defer envAllowlistMu.RUnlock()
+
+	var env map[string]interface{}
+	for _, name := range envAllowlist {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if env == nil {
+			env = map[string]interface{}{}
+		}
+		env[name] = value
+	}
+
+	return env
+}
@@ -0,0 +1,101 @@
+package requestgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_GenerateRequestDetailsWithEnv(t *testing.T) {
+	t.Setenv("BUILD_SHA", "4f2a9c1")
+	t.Setenv("DB_PASSWORD", "hunter2")
+
+	SetEnvAllowlist([]string{"BUILD_SHA", "UNSET_VARIABLE"})
+	defer SetEnvAllowlist(nil)
+
+	type want struct {
+		headers httpClient.Data
+		err     error
+	}
+	cases := map[string]struct {
+		headers map[string][]string
+		want    want
+	}{
+		"AllowlistedVariableResolves": {
+			headers: map[string][]string{"X-Build-Sha": {".env.BUILD_SHA"}},
+			want: want{
+				headers: httpClient.Data{
+					Encrypted: map[string][]string{"X-Build-Sha": {"4f2a9c1"}},
+					Decrypted: map[string][]string{"X-Build-Sha": {"4f2a9c1"}},
+				},
+			},
+		},
+		"NotAllowlistedVariableErrors": {
+			headers: map[string][]string{"X-Password": {".env.DB_PASSWORD"}},
+			want: want{
+				err: errors.New("failed to parse result on jq query: <nil>"),
+			},
+		},
+		"AllowlistedUnsetVariableErrors": {
+			headers: map[string][]string{"X-Unset": {".env.UNSET_VARIABLE"}},
+			want: want{
+				err: errors.New("failed to parse result on jq query: <nil>"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mapping := v1alpha2.Mapping{
+				Method:  "POST",
+				URL:     ".payload.baseUrl",
+				Headers: tc.headers,
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+			got, gotErr, _ := GenerateRequestDetails(svcCtx, &mapping, &testForProvider, &v1alpha2.Response{}, nil)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.headers, got.Headers); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_envContext(t *testing.T) {
+	t.Setenv("BUILD_SHA", "4f2a9c1")
+	t.Setenv("DB_PASSWORD", "hunter2")
+
+	cases := map[string]struct {
+		allowlist []string
+		want      map[string]interface{}
+	}{
+		"NoAllowlist": {
+			allowlist: nil,
+			want:      nil,
+		},
+		"OnlyAllowlistedVariables": {
+			allowlist: []string{"BUILD_SHA"},
+			want:      map[string]interface{}{"BUILD_SHA": "4f2a9c1"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetEnvAllowlist(tc.allowlist)
+			defer SetEnvAllowlist(nil)
+
+			if diff := cmp.Diff(tc.want, envContext()); diff != "" {
+				t.Errorf("envContext(): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package requestgen
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	itemContextKey  = "item"
+	indexContextKey = "index"
+
+	errEvaluateForEach = "failed to evaluate the forEach filter %s of the mapping"
+)
+
+// ForEach returns the jq filter selecting the array the request of the mapping is sent for, or an empty string.
+func ForEach(mapping interfaces.HTTPMapping) string {
+	if aware, ok := mapping.(interfaces.MappingForEachAware); ok {
+		return aware.GetForEach()
+	}
+
+	return ""
+}
+
+// ForEachItems returns the elements of the array selected by the forEach filter of the mapping, evaluated against
+// the template context of the mapping.
+func ForEachItems(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping) ([]interface{}, error) {
+	jqObject, err := templateContext(svcCtx, crCtx.Spec(), crCtx.Status().GetResponse(), crCtx.GetCR())
+	if err != nil {
+		return nil, err
+	}
+
+	if responseMap, ok := jqObject["response"].(map[string]interface{}); ok && !utils.IsTextResponseFormat(crCtx.Spec()) {
+		parseArrayBody(responseMap)
+	}
+
+	items, err := jq.ParseArray(ForEach(mapping), jqObject)
+	if err != nil {
+		return nil, errors.Wrapf(err, errEvaluateForEach, ForEach(mapping))
+	}
+
+	return items, nil
+}
+
+// GenerateItemRequestDetails generates the request details of the mapping for an element of its forEach array.
+// The element is exposed to the mapping under the item key, and its position in the array under the index key.
+func GenerateItemRequestDetails(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping, index int, item interface{}) (RequestDetails, error) {
+	response := crCtx.Status().GetResponse()
+	jqObject, err := templateContext(svcCtx, crCtx.Spec(), response, crCtx.GetCR())
+	if err != nil {
+		return RequestDetails{}, err
+	}
+	jqObject[itemContextKey] = item
+	jqObject[indexContextKey] = index
+
+	requestDetails, err, _ := renderRequestDetails(svcCtx, mapping, crCtx.Spec(), response, jqObject)
+	return requestDetails, err
+}
+
+// parseArrayBody parses the body of the response if it is a JSON array, e.g. the aggregated body of the requests of
+// a forEach CREATE mapping, so the forEach filter can select it as is. Only JSON objects are converted to maps in the
+// template context, so the other templates keep reading such a body as a string, e.g. '.response.body | fromjson'.
+func parseArrayBody(responseMap map[string]interface{}) {
+	body, ok := responseMap["body"].(string)
+	if !ok || !strings.HasPrefix(strings.TrimSpace(body), "[") {
+		return
+	}
+
+	var items []interface{}
+	if err := json_util.Unmarshal([]byte(body), &items); err == nil {
+		responseMap["body"] = items
+	}
+}
@@ -0,0 +1,160 @@
+package requestgen
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestprocessing"
+)
+
+const (
+	errHeaderNotString    = "template of header %s resolved to %s, which is not a string"
+	errHeaderKeyNotString = "template of header key %s resolved to %s, which is not a string"
+	errHeaderKeyCollision = "header keys %s and %s both resolve to header %s"
+)
+
+// templateHeaders applies the JQ queries of the header keys and values. The values of the headers the spec omits if
+// empty are dropped when they resolve to an empty string or null, and such a header is dropped when all its values
+// are.
+func templateHeaders(forProvider interfaces.MappedHTTPRequestSpec, headers map[string][]string, jqObject map[string]interface{}) (map[string][]string, error) {
+	headers, err := templateHeaderKeys(headers, jqObject)
+	if err != nil {
+		return nil, err
+	}
+
+	omitted := omitIfEmptyHeaders(forProvider)
+	if len(omitted) == 0 {
+		return requestprocessing.ApplyJQOnMapStrings(headers, jqObject)
+	}
+
+	required := make(map[string][]string, len(headers))
+	optional := make(map[string][]string)
+	for key, queries := range headers {
+		if omitted[http.CanonicalHeaderKey(key)] {
+			optional[key] = queries
+		} else {
+			required[key] = queries
+		}
+	}
+
+	generatedHeaders, err := requestprocessing.ApplyJQOnMapStrings(required, jqObject)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, queries := range optional {
+		values, err := templateOptionalHeader(key, queries, jqObject)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) > 0 {
+			generatedHeaders[key] = values
+		}
+	}
+
+	return generatedHeaders, nil
+}
+
+// templateHeaderKeys applies the JQ queries of the header keys, e.g. ("X-Tenant-" + .payload.tenant). Like for
+// values, a key that cannot be applied is kept as a literal header name. A templated key resolving to the same
+// header name as another key fails, since one of them would be silently merged into the other.
+func templateHeaderKeys(headers map[string][]string, jqObject map[string]interface{}) (map[string][]string, error) {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	type source struct {
+		key       string
+		templated bool
+	}
+
+	rendered := make(map[string][]string, len(headers))
+	sources := make(map[string]source, len(headers))
+	for _, key := range keys {
+		name := key
+		if result, err := jq.Parse(key, jqObject); err == nil {
+			value, ok := result.(string)
+			if !ok {
+				return nil, errors.Errorf(errHeaderKeyNotString, key, fmt.Sprint(result))
+			}
+			name = value
+		}
+
+		templated := name != key
+		canonical := http.CanonicalHeaderKey(name)
+		if other, ok := sources[canonical]; ok && (templated || other.templated) {
+			return nil, errors.Errorf(errHeaderKeyCollision, other.key, key, canonical)
+		}
+		sources[canonical] = source{key: key, templated: templated}
+
+		rendered[name] = headers[key]
+	}
+
+	return rendered, nil
+}
+
+// templateOptionalHeader applies the JQ queries of a header omitted if empty and returns its non-empty values.
+// Like for other headers, a query that cannot be applied is kept as a literal value.
+func templateOptionalHeader(key string, queries []string, jqObject map[string]interface{}) ([]string, error) {
+	var values []string
+	for _, query := range queries {
+		result, err := jq.Parse(query, jqObject)
+		if err != nil {
+			result = query
+		}
+
+		if result == nil {
+			continue
+		}
+
+		value, ok := result.(string)
+		if !ok {
+			return nil, errors.Errorf(errHeaderNotString, key, fmt.Sprint(result))
+		}
+
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// omitIfEmptyHeaders returns the canonical names of the headers the spec omits if empty.
+func omitIfEmptyHeaders(forProvider interfaces.MappedHTTPRequestSpec) map[string]bool {
+	aware, ok := forProvider.(interfaces.HeaderOptionsAware)
+	if !ok {
+		return nil
+	}
+
+	names := aware.GetOmitIfEmptyHeaders()
+	if len(names) == 0 {
+		return nil
+	}
+
+	omitted := make(map[string]bool, len(names))
+	for _, name := range names {
+		omitted[http.CanonicalHeaderKey(name)] = true
+	}
+
+	return omitted
+}
+
+// headerQueries returns the queries of the header keys and values.
+func headerQueries(headers map[string][]string) []string {
+	var queries []string
+	for key, values := range headers {
+		queries = append(queries, key)
+		queries = append(queries, values...)
+	}
+
+	return queries
+}
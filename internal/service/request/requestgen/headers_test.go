@@ -0,0 +1,112 @@
+package requestgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func Test_templateHeaders(t *testing.T) {
+	jqObject := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"token":   "",
+			"tenant":  "acme",
+			"retries": float64(3),
+		},
+	}
+
+	cases := map[string]struct {
+		headerOptions map[string]v1alpha2.HeaderOptions
+		headers       map[string][]string
+		want          map[string][]string
+		wantErr       string
+	}{
+		"EmptyValueSentWithoutOption": {
+			headers: map[string][]string{"X-Token": {".payload.token"}},
+			want:    map[string][]string{"X-Token": {""}},
+		},
+		"EmptyValueOmitted": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"X-Token": {OmitIfEmpty: true}},
+			headers: map[string][]string{
+				"X-Token":  {".payload.token"},
+				"X-Tenant": {".payload.tenant"},
+			},
+			want: map[string][]string{"X-Tenant": {"acme"}},
+		},
+		"NullValueOmitted": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"X-Token": {OmitIfEmpty: true}},
+			headers:       map[string][]string{"X-Token": {".payload.missing"}},
+			want:          map[string][]string{},
+		},
+		"OnlyEmptyValuesOmitted": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"X-Tenant": {OmitIfEmpty: true}},
+			headers:       map[string][]string{"X-Tenant": {".payload.token", ".payload.tenant", "static"}},
+			want:          map[string][]string{"X-Tenant": {"acme", "static"}},
+		},
+		"OptionMatchesHeaderCaseInsensitively": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"x-token": {OmitIfEmpty: true}},
+			headers:       map[string][]string{"X-TOKEN": {".payload.token"}},
+			want:          map[string][]string{},
+		},
+		"OptionDisabled": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"X-Token": {OmitIfEmpty: false}},
+			headers:       map[string][]string{"X-Token": {".payload.token"}},
+			want:          map[string][]string{"X-Token": {""}},
+		},
+		"TemplatedKey": {
+			headers: map[string][]string{
+				`("X-Tenant-" + .payload.tenant)`: {"enabled"},
+				"Content-Type":                    {"application/json"},
+			},
+			want: map[string][]string{
+				"X-Tenant-acme": {"enabled"},
+				"Content-Type":  {"application/json"},
+			},
+		},
+		"TemplatedKeyOmittedIfEmpty": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"X-Tenant-Acme": {OmitIfEmpty: true}},
+			headers:       map[string][]string{`("X-Tenant-" + .payload.tenant)`: {".payload.token"}},
+			want:          map[string][]string{},
+		},
+		"TemplatedKeyCollisionFails": {
+			headers: map[string][]string{
+				`("X-Tenant-" + .payload.tenant)`: {"enabled"},
+				"x-tenant-acme":                   {"disabled"},
+			},
+			wantErr: "both resolve to header X-Tenant-Acme",
+		},
+		"NonStringKeyFails": {
+			headers: map[string][]string{".payload.retries": {"3"}},
+			wantErr: "template of header key .payload.retries resolved to 3, which is not a string",
+		},
+		"NonStringValueFails": {
+			headerOptions: map[string]v1alpha2.HeaderOptions{"X-Retries": {OmitIfEmpty: true}},
+			headers:       map[string][]string{"X-Retries": {".payload.retries"}},
+			wantErr:       "template of header X-Retries resolved to 3, which is not a string",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			forProvider := &v1alpha2.RequestParameters{HeaderOptions: tc.headerOptions}
+
+			got, err := templateHeaders(forProvider, tc.headers, jqObject)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("templateHeaders(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("templateHeaders(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("templateHeaders(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package requestgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// redactedValue replaces the secret values in the request details written to the status.
+const redactedValue = "****"
+
+// redactSecretValues replaces the given secret values in the URL and the encrypted body and headers of the request
+// details, which are written to the status and logged, with redactedValue. The URL and the decrypted body and
+// headers, which are sent, keep the real values.
+func redactSecretValues(requestDetails *RequestDetails, secretValues []string) {
+	replacer := secretReplacer(secretValues)
+	if replacer == nil {
+		return
+	}
+
+	if redactedUrl := replacer.Replace(requestDetails.Url); redactedUrl != requestDetails.Url {
+		requestDetails.RedactedUrl = redactedUrl
+	}
+
+	if body, ok := requestDetails.Body.Encrypted.(string); ok {
+		requestDetails.Body.Encrypted = replacer.Replace(body)
+	}
+
+	if headers, ok := requestDetails.Headers.Encrypted.(map[string][]string); ok {
+		redacted := make(map[string][]string, len(headers))
+		for key, values := range headers {
+			redacted[key] = make([]string, len(values))
+			for i, value := range values {
+				redacted[key][i] = replacer.Replace(value)
+			}
+		}
+		requestDetails.Headers.Encrypted = redacted
+	}
+}
+
+// secretReplacer returns a replacer of the non-empty secret values and their encoded forms with redactedValue, the
+// longest values first so a value containing another one is fully redacted, or nil if there is no value to redact.
+func secretReplacer(secretValues []string) *strings.Replacer {
+	seen := map[string]bool{}
+	var values []string
+	for _, value := range secretValues {
+		if value == "" {
+			continue
+		}
+		for _, form := range encodedForms(value) {
+			if !seen[form] {
+				seen[form] = true
+				values = append(values, form)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	pairs := make([]string, 0, 2*len(values))
+	for _, value := range values {
+		pairs = append(pairs, value, redactedValue)
+	}
+
+	return strings.NewReplacer(pairs...)
+}
+
+// encodedForms returns the value and the forms it takes once templated into a JSON string, with or without HTML
+// escaping, or into a URL query or path, or with @uri.
+func encodedForms(value string) []string {
+	queryEscaped := url.QueryEscape(value)
+	forms := []string{value, queryEscaped, strings.ReplaceAll(queryEscaped, "+", "%20"), url.PathEscape(value)}
+
+	if escaped, err := json.Marshal(value); err == nil {
+		forms = append(forms, string(escaped[1:len(escaped)-1]))
+	}
+
+	var unescaped bytes.Buffer
+	encoder := json.NewEncoder(&unescaped)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err == nil {
+		escaped := bytes.TrimSpace(unescaped.Bytes())
+		forms = append(forms, string(escaped[1:len(escaped)-1]))
+	}
+
+	return forms
+}
@@ -0,0 +1,78 @@
+package requestgen
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+const (
+	refsContextKey = "refs"
+
+	errRequestRefNotFound = "referenced Request %s not found"
+	errRequestRefNotReady = "referenced Request %s is not ready"
+	errRequestRefGet      = "failed to get referenced Request %s"
+)
+
+// addRequestRefs exposes the status of the Requests referenced by the spec to the mappings under the refs key, by
+// name. The statuses are read once per reconciliation and cached in the service context.
+func addRequestRefs(svcCtx *service.ServiceContext, jqObject map[string]interface{}, forProvider interfaces.MappedHTTPRequestSpec) error {
+	refsAware, ok := forProvider.(interfaces.RequestRefsAware)
+	if !ok || len(refsAware.GetRequestRefs()) == 0 {
+		return nil
+	}
+
+	refs := map[string]interface{}{}
+	for _, name := range refsAware.GetRequestRefs() {
+		status, err := requestRefStatus(svcCtx, name)
+		if err != nil {
+			return err
+		}
+		refs[name] = status
+	}
+
+	jqObject[refsContextKey] = refs
+	return nil
+}
+
+// requestRefStatus returns the response and extracted values of the referenced Request. It fails if the Request
+// does not exist or is not ready.
+func requestRefStatus(svcCtx *service.ServiceContext, name string) (interface{}, error) {
+	if status, ok := svcCtx.RequestRefs[name]; ok {
+		return status, nil
+	}
+
+	ref := &v1alpha2.Request{}
+	if err := svcCtx.LocalKube.Get(svcCtx.Ctx, types.NamespacedName{Name: name}, ref); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, errors.Errorf(errRequestRefNotFound, name)
+		}
+		return nil, errors.Wrapf(err, errRequestRefGet, name)
+	}
+
+	if ref.Status.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return nil, errors.Errorf(errRequestRefNotReady, name)
+	}
+
+	status, err := json_util.StructToMap(map[string]interface{}{
+		"response":  ref.Status.Response,
+		"extracted": ref.Status.Extracted,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, errRequestRefGet, name)
+	}
+	json_util.ConvertJSONStringsToMaps(&status)
+
+	if svcCtx.RequestRefs == nil {
+		svcCtx.RequestRefs = map[string]interface{}{}
+	}
+	svcCtx.RequestRefs[name] = status
+	return status, nil
+}
@@ -0,0 +1,93 @@
+package requestgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func TestGenerateRequestDetailsRequestRefs(t *testing.T) {
+	teamRequest := func(ready bool) *v1alpha2.Request {
+		r := &v1alpha2.Request{}
+		r.Status.Response = v1alpha2.Response{StatusCode: 201, Body: `{"id": "42"}`}
+		if ready {
+			r.Status.SetConditions(xpv1.Available())
+		}
+		return r
+	}
+
+	cases := map[string]struct {
+		ref     *v1alpha2.Request
+		getErr  error
+		wantURL string
+		wantErr string
+	}{
+		"ResolvesReferencedStatus": {
+			ref:     teamRequest(true),
+			wantURL: "https://api.example.com/teams/42/members",
+		},
+		"ReferencedRequestNotFound": {
+			getErr:  kerrors.NewNotFound(schema.GroupResource{Group: "http.crossplane.io", Resource: "requests"}, "create-team"),
+			wantErr: "referenced Request create-team not found",
+		},
+		"ReferencedRequestNotReady": {
+			ref:     teamRequest(false),
+			wantErr: "referenced Request create-team is not ready",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gets := 0
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					gets++
+					if tc.getErr != nil {
+						return tc.getErr
+					}
+					tc.ref.DeepCopyInto(obj.(*v1alpha2.Request))
+					return nil
+				},
+			}
+			forProvider := v1alpha2.RequestParameters{
+				RequestRefs: []v1alpha2.RequestReference{{Name: "create-team"}},
+			}
+			mapping := v1alpha2.Mapping{
+				Method: "POST",
+				URL:    `"https://api.example.com/teams/\(.refs["create-team"].response.body.id)/members"`,
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+			for i := 0; i < 2; i++ {
+				got, err, _ := GenerateRequestDetails(svcCtx, &mapping, &forProvider, nil, nil)
+				if tc.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+						t.Fatalf("GenerateRequestDetails(...): want error containing %q, got %v", tc.wantErr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("GenerateRequestDetails(...): unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(tc.wantURL, got.Url); diff != "" {
+					t.Errorf("GenerateRequestDetails(...): -want URL, +got URL: %s", diff)
+				}
+			}
+
+			if gets != 1 {
+				t.Errorf("GenerateRequestDetails(...): want the referenced Request read once per reconciliation, got %d reads", gets)
+			}
+		})
+	}
+}
@@ -1,35 +1,66 @@
 package requestgen
 
 import (
+	"cmp"
 	"fmt"
 	"strings"
 
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	json_util "github.com/crossplane-contrib/provider-http/internal/json"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane-contrib/provider-http/internal/service/request/requestprocessing"
 	"github.com/crossplane-contrib/provider-http/internal/utils"
 )
 
+const (
+	externalNameContextKey   = "externalName"
+	providerConfigContextKey = "providerConfig"
+	metadataContextKey       = "metadata"
+
+	errEvaluateWhen = "failed to evaluate the when filter %s of the mapping"
+)
+
 type RequestDetails struct {
 	Url     string
 	Body    httpClient.Data
 	Headers httpClient.Data
+
+	// RedactedUrl is the URL with the secret values redacted, set only if it holds any.
+	RedactedUrl string
+}
+
+// RecordedURL returns the URL written to the status and logged, with the secret values redacted.
+func (d RequestDetails) RecordedURL() string {
+	return cmp.Or(d.RedactedUrl, d.Url)
 }
 
 // GenerateRequestDetails generates request details.
-func GenerateRequestDetails(svcCtx *service.ServiceContext, methodMapping interfaces.HTTPMapping, forProvider interfaces.MappedHTTPRequestSpec, response interfaces.HTTPResponse) (RequestDetails, error, bool) {
-	patchedResponse, err := datapatcher.PatchSecretsIntoResponse(svcCtx.Ctx, svcCtx.LocalKube, response, svcCtx.Logger)
+// The last response (status code, headers and body) is exposed to the mapping under the response key, which is nil
+// until a response was received, e.g. on the first Create. The external name of the resource, if set, is exposed
+// under the externalName key, its labels and annotations under metadata.labels and metadata.annotations, and the
+// base URL of the ProviderConfig, if set, under providerConfig.baseURL.
+// The status of the referenced Requests is exposed under the refs key, and the keys of the referenced Secrets under
+// the secrets key.
+// Secret values the response references are redacted from the encrypted body and headers written to the status.
+func GenerateRequestDetails(svcCtx *service.ServiceContext, methodMapping interfaces.HTTPMapping, forProvider interfaces.MappedHTTPRequestSpec, response interfaces.HTTPResponse, cr metav1.Object) (RequestDetails, error, bool) {
+	jqObject, err := templateContext(svcCtx, forProvider, response, cr)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	jqObject := GenerateRequestContext(forProvider, patchedResponse)
+	return renderRequestDetails(svcCtx, methodMapping, forProvider, response, jqObject)
+}
+
+// renderRequestDetails templates the request of the mapping against the given template context.
+func renderRequestDetails(svcCtx *service.ServiceContext, methodMapping interfaces.HTTPMapping, forProvider interfaces.MappedHTTPRequestSpec, response interfaces.HTTPResponse, jqObject map[string]interface{}) (RequestDetails, error, bool) {
 	url, err := generateURL(methodMapping.GetURL(), jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
@@ -39,21 +70,86 @@ func GenerateRequestDetails(svcCtx *service.ServiceContext, methodMapping interf
 		return RequestDetails{}, errors.Errorf(utils.ErrInvalidURL, url), false
 	}
 
-	body, err := generateBody(svcCtx, methodMapping.GetBody(), jqObject)
+	body, bodyQuery, err := generateMappingBody(svcCtx, methodMapping, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	headersData, err := generateHeaders(svcCtx, coalesceHeaders(methodMapping, forProvider), jqObject)
+	if err := checkBodyDenyPatterns(forProvider, body); err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	headers := httpClient.MergeDefaultHeaders(svcCtx.DefaultHeaders, coalesceHeaders(methodMapping, forProvider))
+	headersData, err := generateHeaders(svcCtx, forProvider, headers, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	return RequestDetails{Body: body, Url: url, Headers: headersData}, nil, true
+	if err := formatBody(methodMapping, jqObject, &body, &headersData); err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	// Secret values patched into the response, or exposed under secrets and referenced by the queries of the
+	// request, are redacted outside of the sent request.
+	secretValues, err := datapatcher.SecretValuesInResponse(svcCtx.Ctx, svcCtx.LocalKube, response, svcCtx.Logger)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+	queries := append([]string{methodMapping.GetURL(), bodyQuery, ForEach(methodMapping)}, headerQueries(headers)...)
+	secretValues = append(secretValues, secretRefValues(jqObject, queries...)...)
+
+	requestDetails := RequestDetails{Body: body, Url: url, Headers: headersData}
+	redactSecretValues(&requestDetails, secretValues)
+	return requestDetails, nil, true
+}
+
+// IsMappingEnabled checks whether the request of the mapping is sent, i.e. the mapping sets no when filter or the
+// filter evaluates to true against the template context of the mapping.
+func IsMappingEnabled(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping) (bool, error) {
+	conditionAware, ok := mapping.(interfaces.MappingConditionAware)
+	if !ok || conditionAware.GetWhen() == "" {
+		return true, nil
+	}
+
+	jqObject, err := templateContext(svcCtx, crCtx.Spec(), crCtx.Status().GetResponse(), crCtx.GetCR())
+	if err != nil {
+		return false, err
+	}
+
+	enabled, err := jq.ParseBool(conditionAware.GetWhen(), jqObject)
+	if err != nil {
+		return false, errors.Wrapf(err, errEvaluateWhen, conditionAware.GetWhen())
+	}
+
+	return enabled, nil
+}
+
+// templateContext returns the context the mappings are templated against, with the secrets referenced by the
+// response patched in.
+func templateContext(svcCtx *service.ServiceContext, forProvider interfaces.MappedHTTPRequestSpec, response interfaces.HTTPResponse, cr metav1.Object) (map[string]interface{}, error) {
+	patchedResponse, err := datapatcher.PatchSecretsIntoResponse(svcCtx.Ctx, svcCtx.LocalKube, response, svcCtx.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	jqObject := GenerateRequestContext(forProvider, lastResponse(patchedResponse))
+	addExternalName(jqObject, cr)
+	addMetadata(jqObject, cr)
+	addProviderConfig(jqObject, svcCtx.BaseURL)
+	if err := addRequestRefs(svcCtx, jqObject, forProvider); err != nil {
+		return nil, err
+	}
+	if err := addSecretRefs(svcCtx, jqObject, forProvider); err != nil {
+		return nil, err
+	}
+
+	return jqObject, nil
 }
 
 // GenerateRequestContext creates a JSON-compatible map from the specified Request's ForProvider and Response fields.
 // It merges the two maps, converts JSON strings to nested maps, and returns the resulting map.
+// The allowlisted provider environment variables are exposed under the env key. A response read as plain text
+// keeps its raw body, also exposed under the bodyText key.
 func GenerateRequestContext(forProvider interfaces.MappedHTTPRequestSpec, patchedResponse interfaces.HTTPResponse) map[string]interface{} {
 	baseMap, _ := json_util.StructToMap(forProvider)
 	statusMap, _ := json_util.StructToMap(map[string]interface{}{
@@ -63,15 +159,77 @@ func GenerateRequestContext(forProvider interfaces.MappedHTTPRequestSpec, patche
 	maps.Copy(baseMap, statusMap)
 	json_util.ConvertJSONStringsToMaps(&baseMap)
 
+	if env := envContext(); env != nil {
+		baseMap[envContextKey] = env
+	}
+
 	if responseMap, ok := baseMap["response"].(map[string]interface{}); ok {
 		if _, exists := responseMap["headers"]; !exists {
 			responseMap["headers"] = nil
 		}
+		if utils.IsTextResponseFormat(forProvider) && patchedResponse != nil {
+			responseMap["body"] = patchedResponse.GetBody()
+			responseMap[utils.BodyTextKey] = patchedResponse.GetBody()
+		}
 	}
 
 	return baseMap
 }
 
+// lastResponse returns the given response, or nil if no response was received yet.
+func lastResponse(response interfaces.HTTPResponse) interfaces.HTTPResponse {
+	if response == nil || response.GetStatusCode() == 0 {
+		return nil
+	}
+
+	return response
+}
+
+// addExternalName exposes the crossplane.io/external-name annotation of the resource to the mappings, if it is set.
+func addExternalName(jqObject map[string]interface{}, cr metav1.Object) {
+	if cr == nil {
+		return
+	}
+
+	if externalName := meta.GetExternalName(cr); externalName != "" {
+		jqObject[externalNameContextKey] = externalName
+	}
+}
+
+// addMetadata exposes the labels and annotations of the resource to the mappings, e.g. an external identifier set in
+// an annotation by another controller.
+func addMetadata(jqObject map[string]interface{}, cr metav1.Object) {
+	if cr == nil {
+		return
+	}
+
+	jqObject[metadataContextKey] = map[string]interface{}{
+		"labels":      stringMap(cr.GetLabels()),
+		"annotations": stringMap(cr.GetAnnotations()),
+	}
+}
+
+// stringMap converts the map to a map of the values jq expressions can read.
+func stringMap(m map[string]string) map[string]interface{} {
+	converted := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		converted[key] = value
+	}
+
+	return converted
+}
+
+// addProviderConfig exposes the base URL of the ProviderConfig to the mappings, if it is set.
+func addProviderConfig(jqObject map[string]interface{}, baseURL string) {
+	if baseURL == "" {
+		return
+	}
+
+	jqObject[providerConfigContextKey] = map[string]interface{}{
+		"baseURL": baseURL,
+	}
+}
+
 // GenerateValidRequestDetails generates valid request details based on the given Request resource and Mapping configuration.
 // It first attempts to generate request details using the HTTP response stored in the Request's status. If the generated
 // details are valid, the function returns them. If not, it falls back to using the cached response in the Request's status
@@ -82,12 +240,12 @@ func GenerateValidRequestDetails(svcCtx *service.ServiceContext, crCtx *service.
 	response := crCtx.Status().GetResponse()
 	cachedResponse := crCtx.CachedResponse().GetCachedResponse()
 
-	requestDetails, _, ok := GenerateRequestDetails(svcCtx, mapping, spec, response)
+	requestDetails, _, ok := GenerateRequestDetails(svcCtx, mapping, spec, response, crCtx.GetCR())
 	if IsRequestValid(requestDetails) && ok {
 		return requestDetails, nil
 	}
 
-	requestDetails, err, _ := GenerateRequestDetails(svcCtx, mapping, spec, cachedResponse)
+	requestDetails, err, _ := GenerateRequestDetails(svcCtx, mapping, spec, cachedResponse, crCtx.GetCR())
 	if err != nil {
 		return RequestDetails{}, err
 	}
@@ -108,14 +266,14 @@ func coalesceHeaders(mapping interfaces.HTTPMapping, spec interfaces.HTTPRequest
 	return spec.GetHeaders()
 }
 
-// generateURL applies a JQ filter to generate a URL.
+// generateURL applies a JQ filter to generate a URL, whose path is normalized.
 func generateURL(urlJQFilter string, jqObject map[string]interface{}) (string, error) {
 	getURL, err := requestprocessing.ApplyJQOnStr(urlJQFilter, jqObject)
 	if err != nil {
 		return "", err
 	}
 
-	return getURL, nil
+	return normalizeURL(getURL), nil
 }
 
 // generateBody applies a mapping body to generate the request body.
@@ -133,6 +291,9 @@ func generateBody(svcCtx *service.ServiceContext, mappingBody string, jqObject m
 		return httpClient.Data{}, err
 	}
 
+	// JSON bodies are serialized canonically, so the same logical body is byte-identical between reconciles.
+	body = json_util.Canonicalize(body)
+
 	sensitiveBody, err := datapatcher.PatchSecretsIntoString(svcCtx.Ctx, svcCtx.LocalKube, body, svcCtx.Logger)
 	if err != nil {
 		return httpClient.Data{}, err
@@ -145,8 +306,8 @@ func generateBody(svcCtx *service.ServiceContext, mappingBody string, jqObject m
 }
 
 // generateHeaders applies JQ queries to generate headers.
-func generateHeaders(svcCtx *service.ServiceContext, headers map[string][]string, jqObject map[string]interface{}) (httpClient.Data, error) {
-	generatedHeaders, err := requestprocessing.ApplyJQOnMapStrings(headers, jqObject)
+func generateHeaders(svcCtx *service.ServiceContext, forProvider interfaces.MappedHTTPRequestSpec, headers map[string][]string, jqObject map[string]interface{}) (httpClient.Data, error) {
+	generatedHeaders, err := templateHeaders(forProvider, headers, jqObject)
 	if err != nil {
 		return httpClient.Data{}, err
 	}
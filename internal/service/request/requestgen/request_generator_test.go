@@ -8,6 +8,9 @@ import (
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -49,6 +52,19 @@ var (
 		Method: "DELETE",
 		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
 	}
+
+	testConditionalPutMapping = v1alpha2.Mapping{
+		Method:  "PUT",
+		Body:    "{ username: \"john_doe_new_username\" }",
+		URL:     "(.payload.baseUrl + \"/\" + .response.body.id)",
+		Headers: map[string][]string{"If-Match": {".response.headers.Etag[0]"}},
+	}
+
+	testConditionalDeleteMapping = v1alpha2.Mapping{
+		Method:  "DELETE",
+		URL:     "(.payload.baseUrl + \"/\" + .response.body.id)",
+		Headers: map[string][]string{"If-Match": {".response.headers.Etag[0]"}},
+	}
 )
 
 var (
@@ -76,11 +92,14 @@ var (
 
 func Test_GenerateRequestDetails(t *testing.T) {
 	type args struct {
-		methodMapping v1alpha2.Mapping
-		forProvider   v1alpha2.RequestParameters
-		response      v1alpha2.Response
-		logger        logging.Logger
-		localKube     client.Client
+		methodMapping  v1alpha2.Mapping
+		forProvider    v1alpha2.RequestParameters
+		response       v1alpha2.Response
+		logger         logging.Logger
+		localKube      client.Client
+		cr             metav1.Object
+		baseURL        string
+		defaultHeaders map[string][]string
 	}
 	type want struct {
 		requestDetails RequestDetails
@@ -195,11 +214,217 @@ func Test_GenerateRequestDetails(t *testing.T) {
 				ok:  true,
 			},
 		},
+		"IfMatchFromLastResponseOnUpdate": {
+			args: args{
+				methodMapping: testConditionalPutMapping,
+				forProvider:   testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+					Headers:    map[string][]string{"Etag": {`"v1"`}},
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Body: httpClient.Data{
+						Encrypted: `{"username":"john_doe_new_username"}`,
+						Decrypted: `{"username":"john_doe_new_username"}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{"If-Match": {`"v1"`}},
+						Encrypted: map[string][]string{"If-Match": {`"v1"`}},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"IfMatchFromLastResponseOnDelete": {
+			args: args{
+				methodMapping: testConditionalDeleteMapping,
+				forProvider:   testForProvider,
+				response: v1alpha2.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+					Headers:    map[string][]string{"Etag": {`"v2"`}},
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/123",
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{"If-Match": {`"v2"`}},
+						Encrypted: map[string][]string{"If-Match": {`"v2"`}},
+					},
+					Body: httpClient.Data{
+						Decrypted: "",
+						Encrypted: "",
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"ExternalNameOnImport": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "GET",
+					URL:    `(.payload.baseUrl + "/" + .externalName)`,
+				},
+				forProvider: testForProvider,
+				response:    v1alpha2.Response{},
+				logger:      logging.NewNopLogger(),
+				cr: &v1alpha2.Request{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "existing-user"},
+					},
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/existing-user",
+					Body: httpClient.Data{
+						Encrypted: "",
+						Decrypted: "",
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"AnnotationInURL": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "GET",
+					URL:    `(.payload.baseUrl + "/" + .metadata.annotations["example.com/user-id"])`,
+					Headers: map[string][]string{
+						"X-Team": {".metadata.labels.team"},
+					},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha2.Response{},
+				logger:      logging.NewNopLogger(),
+				cr: &v1alpha2.Request{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels:      map[string]string{"team": "platform"},
+						Annotations: map[string]string{"example.com/user-id": "u-42"},
+					},
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users/u-42",
+					Body: httpClient.Data{
+						Encrypted: "",
+						Decrypted: "",
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{"X-Team": {"platform"}},
+						Encrypted: map[string][]string{"X-Team": {"platform"}},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"ProviderConfigBaseURL": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   "{ endpoint: .providerConfig.baseURL }",
+					URL:    `"\(.providerConfig.baseURL)/things"`,
+				},
+				forProvider: testForProvider,
+				response:    v1alpha2.Response{},
+				logger:      logging.NewNopLogger(),
+				baseURL:     "https://api.example.com/v2",
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/v2/things",
+					Body: httpClient.Data{
+						Encrypted: `{"endpoint":"https://api.example.com/v2"}`,
+						Decrypted: `{"endpoint":"https://api.example.com/v2"}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"ProviderConfigDefaultHeaders": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method:  "POST",
+					URL:     ".payload.baseUrl",
+					Headers: map[string][]string{"accept": {"text/csv"}},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha2.Response{},
+				logger:      logging.NewNopLogger(),
+				defaultHeaders: map[string][]string{
+					"Accept": {"application/json"},
+					"X-User": {".payload.body.username"},
+				},
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: "",
+						Decrypted: "",
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{"accept": {"text/csv"}, "X-User": {"john_doe"}},
+						Encrypted: map[string][]string{"accept": {"text/csv"}, "X-User": {"john_doe"}},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"NoLastResponseOnCreate": {
+			args: args{
+				methodMapping: v1alpha2.Mapping{
+					Method: "POST",
+					Body:   "{ hasResponse: (.response != null) }",
+					URL:    ".payload.baseUrl",
+				},
+				forProvider: testForProvider,
+				response:    v1alpha2.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url: "https://api.example.com/users",
+					Body: httpClient.Data{
+						Encrypted: `{"hasResponse":false}`,
+						Decrypted: `{"hasResponse":false}`,
+					},
+					Headers: httpClient.Data{
+						Decrypted: map[string][]string{},
+						Encrypted: map[string][]string{},
+					},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			svcCtx := service.NewServiceContext(context.Background(), tc.args.localKube, tc.args.logger, nil, nil)
-			got, gotErr, ok := GenerateRequestDetails(svcCtx, &tc.args.methodMapping, &tc.args.forProvider, &tc.args.response)
+			svcCtx := service.NewServiceContext(context.Background(), tc.args.localKube, tc.args.logger, nil, nil).WithBaseURL(tc.args.baseURL).WithDefaultHeaders(tc.args.defaultHeaders)
+			got, gotErr, ok := GenerateRequestDetails(svcCtx, &tc.args.methodMapping, &tc.args.forProvider, &tc.args.response, tc.args.cr)
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
 			}
@@ -457,3 +682,127 @@ func Test_generateRequestObject(t *testing.T) {
 		})
 	}
 }
+
+func Test_GenerateRequestDetailsStable(t *testing.T) {
+	mapping := v1alpha2.Mapping{
+		Method: "POST",
+		URL:    ".payload.baseUrl",
+		// A body template returning a JSON string, with keys out of order and an array whose order matters.
+		Body: `"{\"zone\": \"b\", \"tags\": [\"z\", \"a\"], \"owner\": {\"name\": \"john\", \"id\": 1}}"`,
+		Headers: map[string][]string{
+			"X-Zone":  {"b"},
+			"X-Owner": {"john", "doe"},
+		},
+	}
+	want := `{"owner":{"id":1,"name":"john"},"tags":["z","a"],"zone":"b"}`
+
+	svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+	var first RequestDetails
+	for i := 0; i < 20; i++ {
+		got, err, ok := GenerateRequestDetails(svcCtx, &mapping, &testForProvider, &v1alpha2.Response{}, nil)
+		if err != nil || !ok {
+			t.Fatalf("GenerateRequestDetails(...): unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(want, got.Body.Encrypted); diff != "" {
+			t.Fatalf("GenerateRequestDetails(...): -want body, +got body: %s", diff)
+		}
+		if i == 0 {
+			first = got
+			continue
+		}
+		if diff := cmp.Diff(first, got); diff != "" {
+			t.Fatalf("GenerateRequestDetails(...): -first result, +repeated result: %s", diff)
+		}
+	}
+}
+
+func Test_GenerateRequestDetailsLargeIntegers(t *testing.T) {
+	mapping := v1alpha2.Mapping{
+		Method: "PUT",
+		URL:    `(.payload.baseUrl + "/" + (.response.body.id|tostring))`,
+		Body:   `{ id: .response.body.id, parent: .payload.body.parent }`,
+	}
+	forProvider := v1alpha2.RequestParameters{
+		Payload: v1alpha2.Payload{BaseUrl: "https://api.example.com/users", Body: `{"parent": 10000000000000003}`},
+	}
+	response := &v1alpha2.Response{StatusCode: 200, Body: `{"id": 10000000000000001}`}
+
+	svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+	got, err, ok := GenerateRequestDetails(svcCtx, &mapping, &forProvider, response, nil)
+	if err != nil || !ok {
+		t.Fatalf("GenerateRequestDetails(...): unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff("https://api.example.com/users/10000000000000001", got.Url); diff != "" {
+		t.Errorf("GenerateRequestDetails(...): -want URL, +got URL: %s", diff)
+	}
+	if diff := cmp.Diff(`{"id":10000000000000001,"parent":10000000000000003}`, got.Body.Encrypted); diff != "" {
+		t.Errorf("GenerateRequestDetails(...): -want body, +got body: %s", diff)
+	}
+}
+
+func Test_GenerateRequestDetailsArrayBody(t *testing.T) {
+	mapping := v1alpha2.Mapping{
+		Method: "GET",
+		URL:    `(.payload.baseUrl + "/" + (.response.body | fromjson)[1].id)`,
+	}
+	response := &v1alpha2.Response{StatusCode: 201, Body: `[{"id": "a"}, {"id": "b"}]`}
+
+	svcCtx := service.NewServiceContext(context.Background(), nil, logging.NewNopLogger(), nil, nil)
+	got, err, ok := GenerateRequestDetails(svcCtx, &mapping, &testForProvider, response, nil)
+	if err != nil || !ok {
+		t.Fatalf("GenerateRequestDetails(...): unexpected error: %v", err)
+	}
+
+	// The body of a JSON array is only read as an array by the forEach filter.
+	if diff := cmp.Diff("https://api.example.com/users/b", got.Url); diff != "" {
+		t.Errorf("GenerateRequestDetails(...): -want URL, +got URL: %s", diff)
+	}
+}
+
+func Test_GenerateRequestDetailsRedactsSecrets(t *testing.T) {
+	localKube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secret.Data = map[string][]byte{"token": []byte("s3cr3t")}
+			}
+			return nil
+		}),
+	}
+	mapping := v1alpha2.Mapping{
+		Method: "PUT",
+		URL:    `(.payload.baseUrl + "?token=" + .response.body.token)`,
+		Body:   "{ token: .response.body.token, username: .payload.body.username }",
+		Headers: map[string][]string{
+			"X-Token": {`"Bearer \(.response.body.token)"`},
+		},
+	}
+	// The response stored in the status references the injected secret instead of the token.
+	response := &v1alpha2.Response{
+		StatusCode: 200,
+		Body:       `{"token": "{{auth:testns:token}}"}`,
+	}
+
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+	got, err, ok := GenerateRequestDetails(svcCtx, &mapping, &testForProvider, response, nil)
+	if err != nil || !ok {
+		t.Fatalf("GenerateRequestDetails(...): unexpected error: %v", err)
+	}
+
+	want := RequestDetails{
+		Url:         "https://api.example.com/users?token=s3cr3t",
+		RedactedUrl: "https://api.example.com/users?token=****",
+		Body: httpClient.Data{
+			Encrypted: `{"token":"****","username":"john_doe"}`,
+			Decrypted: `{"token":"s3cr3t","username":"john_doe"}`,
+		},
+		Headers: httpClient.Data{
+			Encrypted: map[string][]string{"X-Token": {"Bearer ****"}},
+			Decrypted: map[string][]string{"X-Token": {"Bearer s3cr3t"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateRequestDetails(...): -want, +got: %s", diff)
+	}
+}
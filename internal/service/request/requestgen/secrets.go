@@ -0,0 +1,123 @@
+package requestgen
+
+import (
+	"cmp"
+	"regexp"
+	"strconv"
+
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	kubehandler "github.com/crossplane-contrib/provider-http/internal/kube-handler"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+const secretsContextKey = "secrets"
+
+// addSecretRefs exposes the keys of the Secrets referenced by the spec to the mappings under the secrets key, by
+// Secret name, e.g. '.secrets["api"]["token"]'.
+func addSecretRefs(svcCtx *service.ServiceContext, jqObject map[string]interface{}, forProvider interfaces.MappedHTTPRequestSpec) error {
+	refsAware, ok := forProvider.(interfaces.SecretRefsAware)
+	if !ok || len(refsAware.GetSecretRefs()) == 0 {
+		return nil
+	}
+
+	secrets := map[string]interface{}{}
+	for _, ref := range refsAware.GetSecretRefs() {
+		secret, err := kubehandler.GetSecret(svcCtx.Ctx, svcCtx.LocalKube, ref.Name, ref.Namespace)
+		if err != nil {
+			return err
+		}
+
+		keys := make(map[string]interface{}, len(secret.Data))
+		for key, value := range secret.Data {
+			keys[key] = string(value)
+		}
+		secrets[ref.Name] = keys
+	}
+
+	jqObject[secretsContextKey] = secrets
+	return nil
+}
+
+// secretRefPattern matches a reference to the secrets key of the template context, and secretRefIndexPattern a
+// static index following it, e.g. .api, ."my-api" or ["api"].
+var (
+	secretRefPattern      = regexp.MustCompile(`\.(?:secrets\b|"secrets"|\["secrets"\])\??`)
+	secretRefIndexPattern = regexp.MustCompile(`^(?:\.([A-Za-z_][A-Za-z0-9_]*)|\.?\[("(?:[^"\\]|\\.)*")\]|\.("(?:[^"\\]|\\.)*"))\??`)
+)
+
+// secretRefValues returns the values of the Secret keys the queries reference under the secrets key, which are
+// redacted from the request details written to the status. All the values of a Secret are returned if it is indexed
+// dynamically, e.g. .secrets.api[.key], and all the values of all the Secrets if the secrets key is, e.g.
+// .secrets | keys.
+func secretRefValues(jqObject map[string]interface{}, queries ...string) []string {
+	secrets, ok := jqObject[secretsContextKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var values []string
+	for _, query := range queries {
+		for _, path := range secretRefPaths(query) {
+			values = append(values, secretValuesAt(secrets, path)...)
+		}
+	}
+
+	return values
+}
+
+// secretRefPaths returns the static indexes, at most the Secret name and key, following each reference to the
+// secrets key in the query.
+func secretRefPaths(query string) [][]string {
+	// The canonical form of a query has no whitespace between its indexes.
+	query = jq.Normalize(query)
+
+	var paths [][]string
+	for _, loc := range secretRefPattern.FindAllStringIndex(query, -1) {
+		path := []string{}
+		rest := query[loc[1]:]
+		for len(path) < 2 {
+			match := secretRefIndexPattern.FindStringSubmatch(rest)
+			if match == nil {
+				break
+			}
+			path = append(path, indexName(match))
+			rest = rest[len(match[0]):]
+		}
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// indexName returns the name of a static index matched by secretRefIndexPattern, unquoting a string index.
+func indexName(match []string) string {
+	if match[1] != "" {
+		return match[1]
+	}
+
+	quoted := cmp.Or(match[2], match[3])
+	if name, err := strconv.Unquote(quoted); err == nil {
+		return name
+	}
+	return quoted[1 : len(quoted)-1]
+}
+
+// secretValuesAt returns the value of the Secret key at the path, or all the values of the Secret or of all the
+// Secrets if the path has no key or no Secret name.
+func secretValuesAt(secrets map[string]interface{}, path []string) []string {
+	var values []string
+	for name, keys := range secrets {
+		if len(path) > 0 && name != path[0] {
+			continue
+		}
+		for key, value := range keys.(map[string]interface{}) {
+			if len(path) > 1 && key != path[1] {
+				continue
+			}
+			values = append(values, value.(string))
+		}
+	}
+
+	return values
+}
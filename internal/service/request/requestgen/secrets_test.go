@@ -0,0 +1,179 @@
+package requestgen
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func TestGenerateRequestDetailsSecretRefs(t *testing.T) {
+	apiSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"token":  []byte("s3cr3t"),
+			"tenant": []byte("acme"),
+		},
+	}
+
+	cases := map[string]struct {
+		getErr        error
+		wantHeaders   map[string][]string
+		wantSent      map[string][]string
+		wantEncrypted string
+		wantBody      string
+		wantErr       string
+	}{
+		"CombinesSecretValues": {
+			wantSent:      map[string][]string{"Authorization": {"Bearer s3cr3t"}},
+			wantHeaders:   map[string][]string{"Authorization": {"Bearer ****"}},
+			wantBody:      `{"tenant":"acme","token":"s3cr3t"}`,
+			wantEncrypted: `{"tenant":"****","token":"****"}`,
+		},
+		"SecretNotFound": {
+			getErr:  kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "api"),
+			wantErr: "api",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					if tc.getErr != nil {
+						return tc.getErr
+					}
+					if key.Name != "api" || key.Namespace != "default" {
+						t.Errorf("Get(...): unexpected Secret %s", key)
+					}
+					apiSecret.DeepCopyInto(obj.(*corev1.Secret))
+					return nil
+				},
+			}
+			forProvider := v1alpha2.RequestParameters{
+				SecretRefs: []xpv1.SecretReference{{Name: "api", Namespace: "default"}},
+			}
+			mapping := v1alpha2.Mapping{
+				Method: "POST",
+				URL:    `"https://api.example.com/users"`,
+				Body:   `{tenant: .secrets.api.tenant, token: .secrets["api"]["token"]}`,
+				Headers: map[string][]string{
+					"Authorization": {`"Bearer \(.secrets.api.token)"`},
+				},
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+			got, err, _ := GenerateRequestDetails(svcCtx, &mapping, &forProvider, nil, nil)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("GenerateRequestDetails(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateRequestDetails(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.wantSent, got.Headers.Decrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want sent headers, +got sent headers: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantHeaders, got.Headers.Encrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want status headers, +got status headers: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantBody, got.Body.Decrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want sent body, +got sent body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEncrypted, got.Body.Encrypted); diff != "" {
+				t.Errorf("GenerateRequestDetails(...): -want status body, +got status body: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateRequestDetailsSecretRefsRedaction(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		data     map[string]string
+		url      string
+		body     string
+		wantURL  string
+		wantBody string
+	}{
+		"UnreferencedKeyKept": {
+			reason:   "The values of the Secret keys the queries do not reference should not be redacted",
+			data:     map[string]string{"token": "s3cr3t", "enabled": "true"},
+			body:     `{enabled: true, token: .secrets.api.token}`,
+			wantBody: `{"enabled":true,"token":"****"}`,
+		},
+		"BracketIndexes": {
+			reason:   "A key referenced with bracket indexes should be redacted",
+			data:     map[string]string{"token": "s3cr3t", "enabled": "true"},
+			body:     `{enabled: true, token: .secrets [ "api" ] [ "token" ]}`,
+			wantBody: `{"enabled":true,"token":"****"}`,
+		},
+		"DynamicIndex": {
+			reason:   "All the values of a Secret indexed dynamically should be redacted",
+			data:     map[string]string{"token": "s3cr3t", "enabled": "true"},
+			body:     `{enabled: true, token: (.secrets.api | .token)}`,
+			wantBody: `{"enabled":****,"token":"****"}`,
+		},
+		"JSONEscapedValue": {
+			reason:   "A value escaped in a JSON body should be redacted",
+			data:     map[string]string{"token": `s3"cr3t`},
+			body:     `{token: .secrets.api.token}`,
+			wantBody: `{"token":"****"}`,
+		},
+		"URIEncodedValue": {
+			reason:  "A value encoded in the URL should be redacted",
+			data:    map[string]string{"token": "p@ss w0rd"},
+			url:     `"https://api.example.com/login?password=\(.secrets.api.token | @uri)"`,
+			wantURL: "https://api.example.com/login?password=****",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			localKube := &test.MockClient{
+				MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					secret := obj.(*corev1.Secret)
+					secret.Data = map[string][]byte{}
+					for k, v := range tc.data {
+						secret.Data[k] = []byte(v)
+					}
+					return nil
+				},
+			}
+			forProvider := v1alpha2.RequestParameters{
+				SecretRefs: []xpv1.SecretReference{{Name: "api", Namespace: "default"}},
+			}
+			mapping := v1alpha2.Mapping{Method: "POST", URL: `"https://api.example.com/users"`, Body: tc.body}
+			wantURL := "https://api.example.com/users"
+			if tc.url != "" {
+				mapping.URL, wantURL = tc.url, tc.wantURL
+			}
+
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+			got, err, _ := GenerateRequestDetails(svcCtx, &mapping, &forProvider, nil, nil)
+			if err != nil {
+				t.Fatalf("\n%s\nGenerateRequestDetails(...): unexpected error: %v", tc.reason, err)
+			}
+
+			if diff := cmp.Diff(wantURL, got.RecordedURL()); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want recorded URL, +got recorded URL: %s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantBody, got.Body.Encrypted); diff != "" {
+				t.Errorf("\n%s\nGenerateRequestDetails(...): -want status body, +got status body: %s", tc.reason, diff)
+			}
+		})
+	}
+}
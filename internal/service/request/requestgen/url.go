@@ -0,0 +1,50 @@
+package requestgen
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// normalizeURL normalizes the path of a generated URL, so a base URL and a path can be joined without minding their
+// slashes: duplicate slashes are collapsed and . and .. segments are resolved, keeping a trailing slash. The scheme,
+// host, query and fragment are kept as they are, as well as URLs that are not absolute or cannot be parsed.
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" || u.Opaque != "" {
+		return rawURL
+	}
+
+	escapedPath := u.EscapedPath()
+	normalized := normalizePath(escapedPath)
+	if normalized == escapedPath {
+		return rawURL
+	}
+
+	unescaped, err := url.PathUnescape(normalized)
+	if err != nil {
+		return rawURL
+	}
+	u.Path = unescaped
+	u.RawPath = normalized
+
+	return u.String()
+}
+
+// normalizePath collapses the duplicate slashes of an escaped path and resolves its . and .. segments. A path
+// ending with a slash, or with a . or .. segment, keeps a trailing slash.
+func normalizePath(p string) string {
+	if p == "" {
+		return p
+	}
+
+	last := p[strings.LastIndex(p, "/")+1:]
+	trailingSlash := last == "" || last == "." || last == ".."
+
+	cleaned := path.Clean("/" + p)
+	if trailingSlash && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	return cleaned
+}
@@ -0,0 +1,100 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_normalizeURL(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		url    string
+		want   string
+	}{
+		"TrailingAndLeadingSlash": {
+			reason: "Should collapse the slash ending the base URL and the one starting the path",
+			url:    "https://api.example.com/v1//users",
+			want:   "https://api.example.com/v1/users",
+		},
+		"TrailingSlashOnly": {
+			reason: "Should keep a URL joined with a single slash",
+			url:    "https://api.example.com/v1/users",
+			want:   "https://api.example.com/v1/users",
+		},
+		"NoSlash": {
+			reason: "Should keep a URL without a path",
+			url:    "https://api.example.com",
+			want:   "https://api.example.com",
+		},
+		"KeepTrailingSlash": {
+			reason: "Should keep the trailing slash of the path",
+			url:    "https://api.example.com//v1///users/",
+			want:   "https://api.example.com/v1/users/",
+		},
+		"RootPath": {
+			reason: "Should collapse a path of slashes to the root",
+			url:    "https://api.example.com//",
+			want:   "https://api.example.com/",
+		},
+		"DotSegments": {
+			reason: "Should resolve the . and .. segments of the path",
+			url:    "https://api.example.com/v1/./users/../groups/42",
+			want:   "https://api.example.com/v1/groups/42",
+		},
+		"DotDotAboveRoot": {
+			reason: "Should not resolve .. segments above the root",
+			url:    "https://api.example.com/../../users",
+			want:   "https://api.example.com/users",
+		},
+		"EndingWithDotDot": {
+			reason: "Should resolve a path ending with a .. segment to a directory",
+			url:    "https://api.example.com/v1/users/..",
+			want:   "https://api.example.com/v1/",
+		},
+		"QueryString": {
+			reason: "Should keep the query string and fragment, slashes included",
+			url:    "https://api.example.com/v1//users?next=/v1//users&page=2#top",
+			want:   "https://api.example.com/v1/users?next=/v1//users&page=2#top",
+		},
+		"EscapedSlash": {
+			reason: "Should keep the escaped characters of the path",
+			url:    "https://api.example.com/v1//files/a%2Fb%20c",
+			want:   "https://api.example.com/v1/files/a%2Fb%20c",
+		},
+		"Port": {
+			reason: "Should keep the user info and port of the host",
+			url:    "http://user@localhost:8080//users",
+			want:   "http://user@localhost:8080/users",
+		},
+		"NotAbsolute": {
+			reason: "Should keep a URL without a scheme as it is",
+			url:    "//users//42",
+			want:   "//users//42",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := normalizeURL(tc.url)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nnormalizeURL(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func Test_generateURLJoinsSlashes(t *testing.T) {
+	jqObject := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"baseUrl": "https://api.example.com/v1/",
+		},
+	}
+
+	got, err := generateURL(`(.payload.baseUrl + "/users")`, jqObject)
+	if err != nil {
+		t.Fatalf("generateURL(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("https://api.example.com/v1/users", got); diff != "" {
+		t.Errorf("generateURL(...): -want, +got:\n%s", diff)
+	}
+}
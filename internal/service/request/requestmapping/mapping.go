@@ -1,17 +1,24 @@
 package requestmapping
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
 )
 
 const (
 	ErrMappingNotFound = "%s or %s mapping doesn't exist in request, skipping operation"
+
+	errInvalidStreamPattern = "invalid stream matchPattern %s"
 )
 
 var (
@@ -64,6 +71,38 @@ func GetMapping(requestParams interfaces.MappedHTTPRequestSpec, action string, l
 	return nil, errors.Errorf(ErrMappingNotFound, action, method)
 }
 
+// GetMappings retrieves all the mappings of the action, in the order they are declared, e.g. several OBSERVE
+// mappings tried in sequence. Like GetMapping, it sets the method of the mappings found by action if it's not
+// defined, and falls back to the mappings of the default method of the action if none declares the action.
+func GetMappings(requestParams interfaces.MappedHTTPRequestSpec, action string, logger logging.Logger) ([]interfaces.HTTPMapping, error) {
+	method := getDefaultMethodByAction(action)
+
+	var mappings []interfaces.HTTPMapping
+	for _, mapping := range requestParams.GetMappings() {
+		if mapping.GetAction() == action {
+			if mapping.GetMethod() == "" {
+				mapping.SetMethod(method)
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+	if len(mappings) > 0 {
+		return mappings, nil
+	}
+
+	logger.Debug(fmt.Sprintf("Mapping not found for action %s, trying to find mappings by method %s", action, method))
+	for _, mapping := range requestParams.GetMappings() {
+		if mapping.GetMethod() == method {
+			mappings = append(mappings, mapping)
+		}
+	}
+	if len(mappings) > 0 {
+		return mappings, nil
+	}
+
+	return nil, errors.Errorf(ErrMappingNotFound, action, method)
+}
+
 // GetEffectiveMethod returns the effective HTTP method for a mapping.
 // If the mapping has a method defined, it returns that. Otherwise, it derives the method from the action.
 func GetEffectiveMethod(mapping interfaces.HTTPMapping) string {
@@ -73,6 +112,64 @@ func GetEffectiveMethod(mapping interfaces.HTTPMapping) string {
 	return getDefaultMethodByAction(mapping.GetAction())
 }
 
+// GetActionMethod returns the effective HTTP method of the mapping for the given action, or the default method of
+// the action if no mapping declares it.
+func GetActionMethod(requestParams interfaces.MappedHTTPRequestSpec, action string) string {
+	if mapping, found := getMappingByAction(requestParams, action); found {
+		return GetEffectiveMethod(mapping)
+	}
+
+	return getDefaultMethodByAction(action)
+}
+
+// RequestContext returns the context to send the request of the mapping with, overriding the resource WaitTimeout
+// with the mapping timeout if set, and reading the response as a stream if the mapping streams it.
+func RequestContext(ctx context.Context, mapping interfaces.HTTPMapping) (context.Context, error) {
+	if aware, ok := mapping.(interfaces.MappingTimeoutAware); ok && aware.GetTimeout() != nil {
+		ctx = httpClient.ContextWithTimeout(ctx, aware.GetTimeout().Duration)
+	}
+
+	if aware, ok := mapping.(interfaces.MappingStreamAware); ok && aware.GetStream() != nil {
+		options, err := streamOptions(aware.GetStream())
+		if err != nil {
+			return nil, err
+		}
+		ctx = httpClient.ContextWithStream(ctx, options)
+	}
+
+	return ctx, nil
+}
+
+// streamOptions returns the options reading a streamed response until a line matches the jq filter or the regular
+// expression of the configuration.
+func streamOptions(config *common.StreamConfig) (httpClient.StreamOptions, error) {
+	options := httpClient.StreamOptions{
+		MaxDuration: config.MaxDuration.Duration,
+		MaxBytes:    config.MaxBytes,
+	}
+
+	if config.MatchPattern != "" {
+		pattern, err := regexp.Compile(config.MatchPattern)
+		if err != nil {
+			return httpClient.StreamOptions{}, errors.Wrapf(err, errInvalidStreamPattern, config.MatchPattern)
+		}
+		options.Match = pattern.Match
+		return options, nil
+	}
+
+	options.Match = func(line []byte) bool {
+		var parsed interface{}
+		if err := json.Unmarshal(line, &parsed); err != nil {
+			return false
+		}
+
+		matched, err := jq.ParseBool(config.MatchJQ, parsed)
+		return err == nil && matched
+	}
+
+	return options, nil
+}
+
 // getDefaultMethodByAction returns the default HTTP method for the given action.
 func getDefaultMethodByAction(action string) string {
 	if defaultAction, ok := actionToMathodFactoryMap[action]; ok {
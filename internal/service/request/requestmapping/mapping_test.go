@@ -1,16 +1,21 @@
 package requestmapping
 
 import (
+	"context"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -324,6 +329,54 @@ func Test_GetMapping(t *testing.T) {
 	}
 }
 
+func Test_GetMappings(t *testing.T) {
+	observeByID := v1alpha2.Mapping{
+		Action: common.ActionObserve,
+		URL:    "(.payload.baseUrl + \"/\" + .response.body.id)",
+	}
+	observeByName := v1alpha2.Mapping{
+		Action: common.ActionObserve,
+		URL:    "(.payload.baseUrl + \"?name=\" + .payload.body.username)",
+	}
+
+	cases := map[string]struct {
+		mappings []v1alpha2.Mapping
+		action   string
+		want     []interfaces.HTTPMapping
+		err      error
+	}{
+		"AllMappingsOfActionInOrder": {
+			mappings: []v1alpha2.Mapping{testPostMapping, observeByID, testPutMapping, observeByName},
+			action:   common.ActionObserve,
+			want: []interfaces.HTTPMapping{
+				&v1alpha2.Mapping{Method: http.MethodGet, Action: common.ActionObserve, URL: observeByID.URL},
+				&v1alpha2.Mapping{Method: http.MethodGet, Action: common.ActionObserve, URL: observeByName.URL},
+			},
+		},
+		"FallbackToDefaultMethod": {
+			mappings: []v1alpha2.Mapping{testPostMapping, testGetMapping},
+			action:   common.ActionObserve,
+			want:     []interfaces.HTTPMapping{&testGetMapping},
+		},
+		"NotFound": {
+			mappings: []v1alpha2.Mapping{testPostMapping, testPutMapping},
+			action:   common.ActionObserve,
+			err:      errors.Errorf(ErrMappingNotFound, common.ActionObserve, http.MethodGet),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetMappings(&v1alpha2.RequestParameters{Mappings: tc.mappings}, tc.action, logging.NewNopLogger())
+			if diff := cmp.Diff(tc.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetMappings(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("GetMappings(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_getDefaultMethodByAction(t *testing.T) {
 	type args struct {
 		action string
@@ -468,3 +521,100 @@ func Test_GetEffectiveMethod(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetActionMethod(t *testing.T) {
+	cases := map[string]struct {
+		mappings []v1alpha2.Mapping
+		action   string
+		want     string
+	}{
+		"CustomMethodMapping": {
+			mappings: []v1alpha2.Mapping{{Method: "MKCOL", Action: common.ActionCreate}},
+			action:   common.ActionCreate,
+			want:     "MKCOL",
+		},
+		"MappingWithoutMethod": {
+			mappings: []v1alpha2.Mapping{{Action: common.ActionCreate}},
+			action:   common.ActionCreate,
+			want:     http.MethodPost,
+		},
+		"NoMappingForAction": {
+			mappings: []v1alpha2.Mapping{testGetMapping},
+			action:   common.ActionCreate,
+			want:     http.MethodPost,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetActionMethod(&v1alpha2.RequestParameters{Mappings: tc.mappings}, tc.action)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("GetActionMethod(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RequestContextStream(t *testing.T) {
+	cases := map[string]struct {
+		stream    *common.StreamConfig
+		matches   []string
+		unmatched []string
+		wantErr   string
+	}{
+		"MatchJQ": {
+			stream: &common.StreamConfig{MatchJQ: `.status == "done"`},
+			matches: []string{
+				`{"status":"done","result":{"id":42}}`,
+			},
+			unmatched: []string{
+				`{"status":"running","progress":50}`,
+				`not json`,
+			},
+		},
+		"MatchPattern": {
+			stream:    &common.StreamConfig{MatchPattern: `^(SUCCEEDED|FAILED)\b`},
+			matches:   []string{"SUCCEEDED job 42", "FAILED job 42"},
+			unmatched: []string{"RUNNING 50%"},
+		},
+		"InvalidPattern": {
+			stream:  &common.StreamConfig{MatchPattern: `(`},
+			wantErr: "invalid stream matchPattern (",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.stream.MaxDuration = metav1.Duration{Duration: time.Minute}
+			tc.stream.MaxBytes = 1024
+
+			ctx, err := RequestContext(context.Background(), &v1alpha2.Mapping{Stream: tc.stream})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("RequestContext(...): want error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RequestContext(...): unexpected error: %v", err)
+			}
+
+			options, ok := httpClient.StreamFromContext(ctx)
+			if !ok {
+				t.Fatalf("RequestContext(...): want stream options on the context")
+			}
+			if options.MaxDuration != time.Minute || options.MaxBytes != 1024 {
+				t.Errorf("RequestContext(...): unexpected bounds %s and %d", options.MaxDuration, options.MaxBytes)
+			}
+			for _, line := range tc.matches {
+				if !options.Match([]byte(line)) {
+					t.Errorf("Match(%q): want true, got false", line)
+				}
+			}
+			for _, line := range tc.unmatched {
+				if options.Match([]byte(line)) {
+					t.Errorf("Match(%q): want false, got true", line)
+				}
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 // ApplyJQOnStr applies a jq query to a Request, returning the result as a string.
-// The function handles complex results by converting them to JSON format.
+// The function handles complex results, objects and arrays, by converting them to JSON format.
 func ApplyJQOnStr(jqQuery string, baseMap map[string]interface{}) (string, error) {
 	if result, _ := jq.ParseMapInterface(jqQuery, baseMap); result != nil {
 		transformedData, err := json.Marshal(result)
@@ -17,6 +17,14 @@ func ApplyJQOnStr(jqQuery string, baseMap map[string]interface{}) (string, error
 		return string(transformedData), nil
 	}
 
+	if result, _ := jq.ParseArray(jqQuery, baseMap); result != nil {
+		transformedData, err := json.Marshal(result)
+		if err != nil {
+			return "", err
+		}
+		return string(transformedData), nil
+	}
+
 	stringResult, err := jq.ParseString(jqQuery, baseMap)
 	if err != nil {
 		return "", err
@@ -79,6 +79,16 @@ func Test_ApplyJQOnStr(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"SuccessArrayObject": {
+			args: args{
+				jqQuery:  `[{ name: .payload.body.username }, { email: .payload.body.email }]`,
+				jqObject: testJQObject,
+			},
+			want: want{
+				result: `[{"name":"john_doe"},{"email":"john.doe@example.com"}]`,
+				err:    nil,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
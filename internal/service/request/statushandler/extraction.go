@@ -0,0 +1,84 @@
+package statushandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+)
+
+// extractStatusFields evaluates the jq filters of the extractions against the response and returns the values by
+// key. Values other than strings are JSON encoded, and keys whose value is missing or cannot be evaluated are left
+// absent.
+func extractStatusFields(logger logging.Logger, extractions map[string]string, response httpClient.HttpResponse) map[string]string {
+	dataMap, err := responseDataMap(response)
+	if err != nil {
+		logger.Debug("failed to convert the response for the status extractions", "error", err)
+		return nil
+	}
+
+	extracted := make(map[string]string, len(extractions))
+	for key, responseJQ := range extractions {
+		if value, ok := extractValue(logger, responseJQ, dataMap); ok {
+			extracted[key] = value
+		}
+	}
+
+	return extracted
+}
+
+// ExtractResponseValue returns the value selected by the jq filter from the response as a string, JSON encoding it
+// unless it is a string. It returns false if the value is missing or cannot be evaluated.
+func ExtractResponseValue(logger logging.Logger, responseJQ string, response httpClient.HttpResponse) (string, bool) {
+	dataMap, err := responseDataMap(response)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("failed to convert the response for %s", responseJQ), "error", err)
+		return "", false
+	}
+
+	return extractValue(logger, responseJQ, dataMap)
+}
+
+// responseDataMap converts the response to the map the jq filters are evaluated against, with its JSON body parsed.
+func responseDataMap(response httpClient.HttpResponse) (map[string]interface{}, error) {
+	dataMap, err := json_util.StructToMap(response)
+	if err != nil {
+		return nil, err
+	}
+	json_util.ConvertJSONStringsToMaps(&dataMap)
+
+	return dataMap, nil
+}
+
+// extractValue returns the value selected by the jq filter as a string, JSON encoding it unless it is a string.
+func extractValue(logger logging.Logger, responseJQ string, dataMap map[string]interface{}) (string, bool) {
+	exists, err := jq.Exists(responseJQ, dataMap)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("failed to evaluate the status extraction %s", responseJQ), "error", err)
+		return "", false
+	}
+	if !exists {
+		return "", false
+	}
+
+	value, err := jq.Parse(responseJQ, dataMap)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("failed to evaluate the status extraction %s", responseJQ), "error", err)
+		return "", false
+	}
+
+	if str, ok := value.(string); ok {
+		return str, true
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("failed to encode the status extraction %s", responseJQ), "error", err)
+		return "", false
+	}
+
+	return string(encoded), true
+}
@@ -0,0 +1,74 @@
+package statushandler
+
+import (
+	"testing"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractStatusFields(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 201,
+		Body:       `{"id":"abc-123","links":{"self":"https://api.example.com/users/abc-123"},"quota":{"used":3,"limit":10},"tags":["a","b"],"active":true}`,
+		Headers:    map[string][]string{"Location": {"/users/abc-123"}},
+	}
+
+	cases := map[string]struct {
+		extractions map[string]string
+		want        map[string]string
+	}{
+		"NestedString": {
+			extractions: map[string]string{"url": ".body.links.self"},
+			want:        map[string]string{"url": "https://api.example.com/users/abc-123"},
+		},
+		"NonStringValuesJSONEncoded": {
+			extractions: map[string]string{
+				"used":   ".body.quota.used",
+				"quota":  ".body.quota",
+				"tags":   ".body.tags",
+				"active": ".body.active",
+			},
+			want: map[string]string{
+				"used":   "3",
+				"quota":  `{"limit":10,"used":3}`,
+				"tags":   `["a","b"]`,
+				"active": "true",
+			},
+		},
+		"HeadersAndStatusCode": {
+			extractions: map[string]string{
+				"location": ".headers.Location[0]",
+				"code":     ".statusCode",
+			},
+			want: map[string]string{
+				"location": "/users/abc-123",
+				"code":     "201",
+			},
+		},
+		"MissingPathLeftAbsent": {
+			extractions: map[string]string{
+				"id":      ".body.id",
+				"missing": ".body.owner.name",
+			},
+			want: map[string]string{"id": "abc-123"},
+		},
+		"InvalidFilterLeftAbsent": {
+			extractions: map[string]string{
+				"id":      ".body.id",
+				"invalid": ".body.id | tonumber",
+			},
+			want: map[string]string{"id": "abc-123"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := extractStatusFields(logging.NewNopLogger(), tc.extractions, response)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("extractStatusFields(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -2,9 +2,10 @@ package statushandler
 
 import (
 	"fmt"
-	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/service"
@@ -18,6 +19,13 @@ import (
 type RequestStatusHandler interface {
 	SetRequestStatus() error
 	ResetFailures()
+	SetFailedCheck(description string)
+	SetDrift(drift []common.FieldDiff)
+	CountSuccess()
+	SetCacheObservedGeneration(generation int64)
+	RecordAttempt(action string)
+	SetResponseFailure(failure error)
+	SetItems(items []common.ItemResult)
 }
 
 // requestStatusHandler sets the request status.
@@ -28,6 +36,12 @@ type requestStatusHandler struct {
 	resource      *utils.RequestResource
 	responseError error
 	forProvider   interfaces.MappedHTTPRequestSpec
+
+	// responseFailure fails the response whatever its status code, e.g. one not matching the response schema.
+	responseFailure error
+
+	// action is the action the request was sent for, recorded in the history if set.
+	action string
 }
 
 // SetRequestStatus updates the current Request's status to reflect the details of the last HTTP request that occurred.
@@ -35,6 +49,13 @@ type requestStatusHandler struct {
 // during the HTTP request. The function sets the status fields such as StatusCode, Headers, Body, Method, and Cache,
 // based on the outcome of the HTTP request and the presence of an error.
 func (r *requestStatusHandler) SetRequestStatus() error {
+	if httpClient.IsCanceled(r.responseError) {
+		// The reconcile was interrupted, e.g. it timed out, which is not a failure of the server. The request is
+		// retried on the next reconcile without counting as a failed attempt.
+		r.svcCtx.Logger.Debug("HTTP request canceled", "error", r.responseError)
+		return r.responseError
+	}
+
 	if r.responseError != nil {
 		r.svcCtx.Logger.Debug("error occurred during HTTP request", "error", r.responseError)
 		return r.setErrorAndReturn(r.responseError)
@@ -44,18 +65,30 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 		r.resource.SetStatusCode(),
 		r.resource.SetHeaders(),
 		r.resource.SetBody(),
+		r.resource.SetResponseJSON(storesResponseJSON(r.forProvider)),
+		r.resource.SetTrailers(),
+		r.resource.SetStatusText(),
+		r.resource.SetTiming(),
 		r.resource.SetRequestDetails(),
 	}
 
 	basicSetters = append(basicSetters, *r.extraSetters...)
 
-	if utils.IsHTTPError(r.resource.HttpResponse.StatusCode) {
-		return r.incrementFailures(basicSetters)
+	if r.responseFailure != nil {
+		return r.incrementFailures(basicSetters, r.responseFailure)
 	}
 
-	if utils.IsHTTPSuccess(r.resource.HttpResponse.StatusCode) {
+	if failed, failure := IsResponseFailed(r.forProvider, &r.resource.HttpResponse); failed {
+		return r.incrementFailures(basicSetters, failure)
+	}
+
+	if successCondition(r.forProvider) != "" || utils.IsHTTPSuccess(r.resource.HttpResponse.StatusCode) {
 		r.appendExtraSetters(r.forProvider, &basicSetters)
 	}
+	basicSetters = append(basicSetters, r.recordAttempt(nil))
+
+	// The checks were evaluated on the raw body, only its projection is stored.
+	TransformResponse(r.svcCtx.Logger, r.forProvider, common.ResponseTransformScopeStatus, &r.resource.HttpResponse)
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, basicSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
@@ -67,7 +100,7 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 // setErrorAndReturn sets the error message in the status of the Request.
 func (r *requestStatusHandler) setErrorAndReturn(err error) error {
 	r.svcCtx.Logger.Debug("Error occurred during HTTP request", "error", err)
-	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetError(err)); settingError != nil {
+	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetError(err), r.recordAttempt(err)); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
@@ -75,8 +108,17 @@ func (r *requestStatusHandler) setErrorAndReturn(err error) error {
 }
 
 // incrementFailures increments the failures counter and sets the error message in the status of the Request.
-func (r *requestStatusHandler) incrementFailures(combinedSetters []utils.SetRequestStatusFunc) error {
-	combinedSetters = append(combinedSetters, r.resource.SetError(nil)) // should increment failures counter
+// Without a failure describing why the response failed, a possible clock skew behind an authentication failure
+// is surfaced instead, or else the status code of the response with its error body.
+func (r *requestStatusHandler) incrementFailures(combinedSetters []utils.SetRequestStatusFunc, failure error) error {
+	if failure == nil {
+		failure = utils.DetectClockSkew(r.resource.HttpResponse, time.Now())
+	}
+	if failure == nil {
+		failure = utils.StatusCodeError(r.resource.HttpRequest.Method, r.resource.HttpResponse)
+	}
+	combinedSetters = append(combinedSetters, r.resource.SetError(failure), r.recordAttempt(failure))
+	TransformResponse(r.svcCtx.Logger, r.forProvider, common.ResponseTransformScopeStatus, &r.resource.HttpResponse)
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
@@ -86,14 +128,49 @@ func (r *requestStatusHandler) incrementFailures(combinedSetters []utils.SetRequ
 	return nil
 }
 
+// recordAttempt records the attempt in the history if its action is set.
+func (r *requestStatusHandler) recordAttempt(failure error) utils.SetRequestStatusFunc {
+	if r.action == "" {
+		return func() {}
+	}
+
+	return r.resource.RecordAttempt(r.action, failure)
+}
+
 func (r *requestStatusHandler) appendExtraSetters(forProvider interfaces.MappedHTTPRequestSpec, combinedSetters *[]utils.SetRequestStatusFunc) {
-	if r.resource.HttpRequest.Method != http.MethodGet {
+	if !utils.IsSafeMethod(r.resource.HttpRequest.Method) {
 		*combinedSetters = append(*combinedSetters, r.resource.ResetFailures())
 	}
+	*combinedSetters = append(*combinedSetters, r.lastSuccessfulResponse())
 
 	if r.shouldSetCache(forProvider) {
 		*combinedSetters = append(*combinedSetters, r.resource.SetCache())
 	}
+
+	if aware, ok := forProvider.(interfaces.StatusExtractionsAware); ok && len(aware.GetStatusExtractions()) > 0 {
+		extracted := extractStatusFields(r.svcCtx.Logger, aware.GetStatusExtractions(), r.resource.HttpResponse)
+		*combinedSetters = append(*combinedSetters, r.resource.SetExtracted(extracted))
+	}
+}
+
+// lastSuccessfulResponse records the response as the last successful one. Its body is the body stored in
+// status.response, unless secrets are injected from it when a request fails: the raw body is then recorded, since
+// the secret injection operates on the raw body when the response transform only applies to the status.
+func (r *requestStatusHandler) lastSuccessfulResponse() utils.SetRequestStatusFunc {
+	aware, ok := r.forProvider.(interfaces.LastSuccessfulResponseAware)
+	if !ok || !aware.GetSecretsFromLastSuccessfulResponse() {
+		return r.resource.SetLastSuccessfulResponse()
+	}
+
+	// The copy keeps the response as received, before its body is transformed for the status.
+	raw := *r.resource
+	return raw.SetLastSuccessfulResponse()
+}
+
+// storesResponseJSON checks whether the spec stores the body of a JSON response as a structured object.
+func storesResponseJSON(forProvider interfaces.MappedHTTPRequestSpec) bool {
+	aware, ok := forProvider.(interfaces.ResponseJSONAware)
+	return ok && aware.GetStoreResponseJSON()
 }
 
 // shouldSetCache determines whether the cache should be updated based on the provided mapping, HTTP response,
@@ -101,7 +178,7 @@ func (r *requestStatusHandler) appendExtraSetters(forProvider interfaces.MappedH
 // details are not valid, it means that instead of using the response, the cache should be used.
 func (r *requestStatusHandler) shouldSetCache(forProvider interfaces.MappedHTTPRequestSpec) bool {
 	for _, mapping := range forProvider.GetMappings() {
-		requestDetails, _, ok := requestgen.GenerateRequestDetails(r.svcCtx, mapping, forProvider, &r.resource.HttpResponse)
+		requestDetails, _, ok := requestgen.GenerateRequestDetails(r.svcCtx, mapping, forProvider, &r.resource.HttpResponse, r.resource.Resource)
 		if !(requestgen.IsRequestValid(requestDetails) && ok) {
 			return false
 		}
@@ -119,6 +196,63 @@ func (r *requestStatusHandler) ResetFailures() {
 	*r.extraSetters = append(*r.extraSetters, r.resource.ResetFailures())
 }
 
+// SetFailedCheck records the description of the response sub-checks that failed, clearing it when empty.
+func (r *requestStatusHandler) SetFailedCheck(description string) {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.SetFailedCheck(description))
+}
+
+// SetDrift records the fields that differed from the observed state, clearing them when empty.
+func (r *requestStatusHandler) SetDrift(drift []common.FieldDiff) {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.SetDrift(drift))
+}
+
+// CountSuccess counts the response as one more consecutive successful observation. A failed response starts the
+// count over instead.
+func (r *requestStatusHandler) CountSuccess() {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.IncrementConsecutiveSuccesses())
+}
+
+// SetCacheObservedGeneration records the generation of the spec the response found the resource up to date for,
+// zero if it did not.
+func (r *requestStatusHandler) SetCacheObservedGeneration(generation int64) {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.SetCacheObservedGeneration(generation))
+}
+
+// RecordAttempt records the outcome of the request in the history as an attempt of the action.
+func (r *requestStatusHandler) RecordAttempt(action string) {
+	r.action = action
+}
+
+// SetResponseFailure makes the response count as failed with the given failure, if not nil.
+func (r *requestStatusHandler) SetResponseFailure(failure error) {
+	r.responseFailure = failure
+}
+
+// SetItems records the outcome of the requests sent for the elements of a forEach array.
+func (r *requestStatusHandler) SetItems(items []common.ItemResult) {
+	if r.extraSetters == nil {
+		r.extraSetters = &[]utils.SetRequestStatusFunc{}
+	}
+
+	*r.extraSetters = append(*r.extraSetters, r.resource.SetItems(items))
+}
+
 // NewStatusHandler returns a new Request statusHandler
 func NewStatusHandler(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, requestDetails httpClient.HttpDetails, requestErr error) (RequestStatusHandler, error) {
 	resource := crCtx.GetCR()
@@ -139,6 +273,7 @@ func NewStatusHandler(svcCtx *service.ServiceContext, crCtx *service.RequestCRCo
 			HttpRequest:    requestDetails.HttpRequest,
 			RequestContext: svcCtx.Ctx,
 			LocalClient:    svcCtx.LocalKube,
+			StoredHeaders:  utils.StoredHeadersConfig(forProvider),
 		},
 		responseError: requestErr,
 		forProvider:   forProvider,
@@ -2,16 +2,20 @@ package statushandler
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
 	"github.com/crossplane-contrib/provider-http/internal/service"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -69,6 +73,13 @@ var (
 	}
 )
 
+// testForProviderWithSuccessCondition returns the test parameters with a success condition on the response body.
+func testForProviderWithSuccessCondition() v1alpha2.RequestParameters {
+	forProvider := testForProvider
+	forProvider.SuccessCondition = ".body.ok == true"
+	return forProvider
+}
+
 var testCr = &v1alpha2.Request{
 	Spec: v1alpha2.RequestSpec{
 		ForProvider: testForProvider,
@@ -93,6 +104,7 @@ func Test_SetRequestStatus(t *testing.T) {
 		err           error
 		httpRequest   httpClient.HttpRequest
 		failuresIndex int32
+		statusError   string
 	}
 	testCases := []struct {
 		name string
@@ -195,6 +207,118 @@ func Test_SetRequestStatus(t *testing.T) {
 				failuresIndex: 0,
 			},
 		},
+		{
+			name: "StatusCodeFailedWithClockSkew",
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: testForProvider,
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 403,
+						Body:       `{"message": "Signature expired"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+				err: nil,
+			},
+			want: want{
+				err:           nil,
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+				statusError:   "possible clock skew: the server rejected the request timestamp, check the provider pod clock",
+			},
+		},
+		{
+			name: "SuccessConditionNotMet",
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: testForProviderWithSuccessCondition(),
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 200,
+						Body:       `{"ok": false, "error": "quota exceeded"}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+				statusError:   "HTTP response with status code 200 does not meet the success condition .body.ok == true",
+			},
+		},
+		{
+			name: "StatusCodeFailedWithErrorBody",
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: testForProvider,
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 422,
+						Body:       "{\n  \"error\": \"email is already taken\"\n}",
+						Headers:    map[string][]string{"Content-Type": {"application/json"}},
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				httpRequest:   testRequest,
+				failuresIndex: 1,
+				statusError:   `HTTP POST request failed with status code: 422, response: {"error":"email is already taken"}`,
+			},
+		},
+		{
+			name: "SuccessConditionMetDespiteErrorStatusCode",
+			args: args{
+				cr: &v1alpha2.Request{
+					Spec: v1alpha2.RequestSpec{
+						ForProvider: testForProviderWithSuccessCondition(),
+					},
+					Status: v1alpha2.RequestStatus{
+						Failed: 2,
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				requestDetails: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 500,
+						Body:       `{"ok": true}`,
+						Headers:    testHeaders,
+					},
+					HttpRequest: testRequest,
+				},
+			},
+			want: want{
+				httpRequest:   testRequest,
+				failuresIndex: 0,
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -232,6 +356,12 @@ func Test_SetRequestStatus(t *testing.T) {
 				t.Fatalf("SetRequestStatus(...): -want RequestDetails.Method, +got RequestDetails.Method: %s", diff)
 			}
 
+			if tc.want.statusError != "" {
+				if diff := cmp.Diff(tc.want.statusError, tc.args.cr.Status.Error); diff != "" {
+					t.Fatalf("SetRequestStatus(...): -want Status.Error, +got Status.Error: %s", diff)
+				}
+			}
+
 			if tc.args.err != nil {
 				if diff := cmp.Diff(tc.args.err.Error(), tc.args.cr.Status.Error); diff != "" {
 					t.Fatalf("SetRequestStatus(...): -want Status.Error, +got Status.Error: %s", diff)
@@ -255,3 +385,163 @@ func Test_SetRequestStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSetRequestStatusCanceled(t *testing.T) {
+	cr := &v1alpha2.Request{
+		Spec: v1alpha2.RequestSpec{
+			ForProvider: testForProvider,
+		},
+		Status: v1alpha2.RequestStatus{
+			Failed: 1,
+			Error:  "boom",
+		},
+	}
+	updated := false
+	localKube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+		MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+			updated = true
+			return nil
+		},
+	}
+	canceled := &httpClient.CanceledError{Err: context.DeadlineExceeded}
+
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+	r, _ := NewStatusHandler(svcCtx, service.NewRequestCRContext(cr), httpClient.HttpDetails{HttpRequest: testRequest}, canceled)
+
+	if err := r.SetRequestStatus(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SetRequestStatus(): want the canceled request error, got %v", err)
+	}
+	if updated {
+		t.Errorf("SetRequestStatus(): want the status of a canceled request left unchanged")
+	}
+	if cr.Status.Failed != 1 || cr.Status.Error != "boom" {
+		t.Errorf("SetRequestStatus(): want a canceled request not to count as a failed attempt, got failed %d, error %q", cr.Status.Failed, cr.Status.Error)
+	}
+}
+
+func TestSetRequestStatusLastSuccessfulResponse(t *testing.T) {
+	cr := &v1alpha2.Request{Spec: v1alpha2.RequestSpec{ForProvider: testForProvider}}
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+
+	setStatus := func(response httpClient.HttpResponse, err error) {
+		r, handlerErr := NewStatusHandler(svcCtx, service.NewRequestCRContext(cr), httpClient.HttpDetails{HttpRequest: testRequest, HttpResponse: response}, err)
+		if handlerErr != nil {
+			t.Fatalf("NewStatusHandler(...): unexpected error: %v", handlerErr)
+		}
+		_ = r.SetRequestStatus()
+	}
+
+	good := httpClient.HttpResponse{StatusCode: 200, Body: `{"id": "123"}`, Headers: map[string][]string{"Etag": {"v1"}}}
+	setStatus(good, nil)
+	setStatus(httpClient.HttpResponse{StatusCode: 503, Body: `{"error": "unavailable"}`}, nil)
+	setStatus(httpClient.HttpResponse{}, errBoom)
+
+	want := &v1alpha2.Response{StatusCode: good.StatusCode, Body: good.Body, Headers: good.Headers}
+	if diff := cmp.Diff(want, cr.Status.LastSuccessfulResponse); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want last successful response kept across failures, +got:\n%s", diff)
+	}
+	if cr.Status.Response.StatusCode != 503 {
+		t.Errorf("SetRequestStatus(...): want the response of the failed request, got status code %d", cr.Status.Response.StatusCode)
+	}
+}
+
+func TestSetRequestStatusHistory(t *testing.T) {
+	type attempt struct {
+		statusCode int
+		err        error
+	}
+	longErr := errors.New(strings.Repeat("x", 300))
+
+	cases := map[string]struct {
+		reason      string
+		historySize *int32
+		attempts    []attempt
+		want        []common.Attempt
+	}{
+		"NewestFirst": {
+			reason: "Should record the attempts newest first",
+			attempts: []attempt{
+				{statusCode: 200},
+				{err: errBoom},
+				{statusCode: 500},
+			},
+			want: []common.Attempt{
+				{Action: v1alpha2.ActionCreate, StatusCode: 500, Error: "HTTP POST request failed with status code: 500"},
+				{Action: v1alpha2.ActionCreate, Error: errBoom.Error()},
+				{Action: v1alpha2.ActionCreate, StatusCode: 200},
+			},
+		},
+		"Capped": {
+			reason:      "Should drop the oldest attempts beyond the history size",
+			historySize: ptr.To[int32](2),
+			attempts: []attempt{
+				{statusCode: 200},
+				{statusCode: 201},
+				{statusCode: 202},
+			},
+			want: []common.Attempt{
+				{Action: v1alpha2.ActionCreate, StatusCode: 202},
+				{Action: v1alpha2.ActionCreate, StatusCode: 201},
+			},
+		},
+		"DefaultCap": {
+			reason:   "Should keep the last 5 attempts by default",
+			attempts: []attempt{{statusCode: 200}, {statusCode: 201}, {statusCode: 202}, {statusCode: 203}, {statusCode: 204}, {statusCode: 205}},
+			want: []common.Attempt{
+				{Action: v1alpha2.ActionCreate, StatusCode: 205},
+				{Action: v1alpha2.ActionCreate, StatusCode: 204},
+				{Action: v1alpha2.ActionCreate, StatusCode: 203},
+				{Action: v1alpha2.ActionCreate, StatusCode: 202},
+				{Action: v1alpha2.ActionCreate, StatusCode: 201},
+			},
+		},
+		"Disabled": {
+			reason:      "Should not record attempts with a history size of 0",
+			historySize: ptr.To[int32](0),
+			attempts:    []attempt{{statusCode: 200}},
+		},
+		"TruncatedError": {
+			reason:   "Should truncate the error of an attempt",
+			attempts: []attempt{{err: longErr}},
+			want: []common.Attempt{
+				{Action: v1alpha2.ActionCreate, Error: strings.Repeat("x", 256) + "..."},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha2.Request{Spec: v1alpha2.RequestSpec{ForProvider: testForProvider}}
+			cr.Spec.ForProvider.HistorySize = tc.historySize
+			localKube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+
+			for _, a := range tc.attempts {
+				details := httpClient.HttpDetails{HttpRequest: testRequest, HttpResponse: httpClient.HttpResponse{StatusCode: a.statusCode}}
+				r, err := NewStatusHandler(svcCtx, service.NewRequestCRContext(cr), details, a.err)
+				if err != nil {
+					t.Fatalf("NewStatusHandler(...): unexpected error: %v", err)
+				}
+				r.RecordAttempt(v1alpha2.ActionCreate)
+				_ = r.SetRequestStatus()
+			}
+
+			if diff := cmp.Diff(tc.want, cr.Status.History, cmpopts.IgnoreFields(common.Attempt{}, "Time")); diff != "" {
+				t.Errorf("\n%s\nSetRequestStatus(...): -want history, +got:\n%s", tc.reason, diff)
+			}
+			for i := 1; i < len(cr.Status.History); i++ {
+				if cr.Status.History[i].Time.After(cr.Status.History[i-1].Time.Time) {
+					t.Errorf("\n%s\nSetRequestStatus(...): want attempts ordered newest first, got %v", tc.reason, cr.Status.History)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package statushandler
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	errSuccessConditionNotMet    = "HTTP response with status code %d does not meet the success condition %s"
+	errSuccessConditionEvaluated = "failed to evaluate the success condition %s"
+)
+
+// IsResponseFailed checks if the response is a failure. When the spec sets a success condition, a response is failed
+// when it does not meet the condition, whatever its status code, and the returned error describes why. Otherwise, a
+// response is failed when its status code is an HTTP error.
+func IsResponseFailed(spec interfaces.MappedHTTPRequestSpec, response interfaces.HTTPResponse) (bool, error) {
+	condition := successCondition(spec)
+	if condition == "" {
+		return utils.IsHTTPError(response.GetStatusCode()), nil
+	}
+
+	if err := checkSuccessCondition(condition, response); err != nil {
+		return true, err
+	}
+
+	return false, nil
+}
+
+// IsResponseSucceeded checks if the response is successful: it meets the success condition of the spec if set,
+// otherwise its status code is an HTTP success.
+func IsResponseSucceeded(spec interfaces.MappedHTTPRequestSpec, response interfaces.HTTPResponse) bool {
+	condition := successCondition(spec)
+	if condition == "" {
+		return utils.IsHTTPSuccess(response.GetStatusCode())
+	}
+
+	return response.GetStatusCode() != 0 && checkSuccessCondition(condition, response) == nil
+}
+
+// successCondition returns the success condition of the spec, or an empty string.
+func successCondition(spec interfaces.MappedHTTPRequestSpec) string {
+	if aware, ok := spec.(interfaces.SuccessConditionAware); ok {
+		return aware.GetSuccessCondition()
+	}
+
+	return ""
+}
+
+// checkSuccessCondition returns an error if the response does not meet the success condition.
+func checkSuccessCondition(condition string, response interfaces.HTTPResponse) error {
+	responseMap, err := json_util.StructToMap(httpClient.HttpResponse{
+		StatusCode: response.GetStatusCode(),
+		Body:       response.GetBody(),
+		Headers:    response.GetHeaders(),
+		Trailers:   response.GetTrailers(),
+		StatusText: response.GetStatusText(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, errSuccessConditionEvaluated, condition)
+	}
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+
+	met, err := jq.ParseBool(condition, responseMap)
+	if err != nil {
+		return errors.Wrapf(err, errSuccessConditionEvaluated, condition)
+	}
+
+	if !met {
+		return errors.Errorf(errSuccessConditionNotMet, response.GetStatusCode(), condition)
+	}
+
+	return nil
+}
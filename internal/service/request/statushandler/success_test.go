@@ -0,0 +1,71 @@
+package statushandler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestIsResponseFailed(t *testing.T) {
+	cases := map[string]struct {
+		condition     string
+		response      httpClient.HttpResponse
+		wantFailed    bool
+		wantSucceeded bool
+		wantErr       string
+	}{
+		"DefaultSuccessStatusCode": {
+			response:      httpClient.HttpResponse{StatusCode: 200, Body: `{"ok": false}`},
+			wantSucceeded: true,
+		},
+		"DefaultErrorStatusCode": {
+			response:   httpClient.HttpResponse{StatusCode: 503},
+			wantFailed: true,
+		},
+		"LogicalFailureWithSuccessStatusCode": {
+			condition:  ".body.ok == true",
+			response:   httpClient.HttpResponse{StatusCode: 200, Body: `{"ok": false}`},
+			wantFailed: true,
+			wantErr:    "does not meet the success condition .body.ok == true",
+		},
+		"ConditionMet": {
+			condition:     ".body.ok == true",
+			response:      httpClient.HttpResponse{StatusCode: 200, Body: `{"ok": true}`},
+			wantSucceeded: true,
+		},
+		"ConditionOverridesErrorStatusCode": {
+			condition:     ".statusCode == 409 and .body.reason == \"AlreadyExists\"",
+			response:      httpClient.HttpResponse{StatusCode: 409, Body: `{"reason": "AlreadyExists"}`},
+			wantSucceeded: true,
+		},
+		"ConditionNotBoolean": {
+			condition:  ".body.ok",
+			response:   httpClient.HttpResponse{StatusCode: 200, Body: `{"ok": "yes"}`},
+			wantFailed: true,
+			wantErr:    "failed to evaluate the success condition .body.ok",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := &v1alpha2.RequestParameters{SuccessCondition: tc.condition}
+
+			failed, err := IsResponseFailed(spec, &tc.response)
+			if failed != tc.wantFailed {
+				t.Errorf("IsResponseFailed(...): want %t, got %t", tc.wantFailed, failed)
+			}
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("IsResponseFailed(...): unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("IsResponseFailed(...): want error containing %q, got %v", tc.wantErr, err)
+			}
+
+			if succeeded := IsResponseSucceeded(spec, &tc.response); succeeded != tc.wantSucceeded {
+				t.Errorf("IsResponseSucceeded(...): want %t, got %t", tc.wantSucceeded, succeeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package statushandler
+
+import (
+	"cmp"
+	"encoding/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+)
+
+// TransformResponse replaces the body of the response with its projection through the response transform of the
+// spec, if it has one with the given scope. A body that cannot be transformed, e.g. because it is not JSON, is
+// kept as is.
+func TransformResponse(logger logging.Logger, spec interfaces.MappedHTTPRequestSpec, scope string, response *httpClient.HttpResponse) {
+	if response.Body == "" || ResponseTransformScope(spec) != scope {
+		return
+	}
+	transform := spec.(interfaces.ResponseTransformAware).GetResponseTransform()
+
+	body, err := transformBody(transform.JQ, response.Body)
+	if err != nil {
+		logger.Info("Cannot transform the response body, keeping it whole", "jq", transform.JQ, "error", err)
+		return
+	}
+
+	response.Body = body
+}
+
+// ResponseTransformScope returns the scope of the response transform of the spec, Status by default, or an empty
+// string if the spec does not transform the responses.
+func ResponseTransformScope(spec interfaces.MappedHTTPRequestSpec) string {
+	aware, ok := spec.(interfaces.ResponseTransformAware)
+	if !ok || aware.GetResponseTransform() == nil {
+		return ""
+	}
+
+	return cmp.Or(aware.GetResponseTransform().Scope, common.ResponseTransformScopeStatus)
+}
+
+// transformBody applies the jq filter to the JSON body and returns the result, JSON encoded unless it is a string.
+func transformBody(filter, body string) (string, error) {
+	var parsed interface{}
+	if err := json_util.Unmarshal([]byte(body), &parsed); err != nil {
+		return "", err
+	}
+
+	value, err := jq.Parse(filter, parsed)
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
@@ -0,0 +1,110 @@
+package statushandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+const testVerboseBody = `{"id":"123","status":{"phase":"Ready","history":["Pending","Ready"]},"links":{"self":"/users/123"}}`
+
+func TestTransformResponse(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		transform *common.ResponseTransformConfig
+		scope     string
+		body      string
+		want      string
+	}{
+		"Projection": {
+			reason:    "Should replace the body with its projection",
+			transform: &common.ResponseTransformConfig{JQ: `{id, phase: .status.phase}`},
+			scope:     common.ResponseTransformScopeStatus,
+			body:      testVerboseBody,
+			want:      `{"id":"123","phase":"Ready"}`,
+		},
+		"String": {
+			reason:    "Should not JSON encode a string result",
+			transform: &common.ResponseTransformConfig{JQ: `.status.phase`},
+			scope:     common.ResponseTransformScopeStatus,
+			body:      testVerboseBody,
+			want:      "Ready",
+		},
+		"OtherScope": {
+			reason:    "Should keep the body of a transform with another scope",
+			transform: &common.ResponseTransformConfig{JQ: `{id}`, Scope: common.ResponseTransformScopeResponse},
+			scope:     common.ResponseTransformScopeStatus,
+			body:      testVerboseBody,
+			want:      testVerboseBody,
+		},
+		"NoTransform": {
+			reason: "Should keep the body without a transform",
+			scope:  common.ResponseTransformScopeStatus,
+			body:   testVerboseBody,
+			want:   testVerboseBody,
+		},
+		"NotJSON": {
+			reason:    "Should keep a body that is not JSON",
+			transform: &common.ResponseTransformConfig{JQ: `{id}`},
+			scope:     common.ResponseTransformScopeStatus,
+			body:      "<html>oops</html>",
+			want:      "<html>oops</html>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := &v1alpha2.RequestParameters{ResponseTransform: tc.transform}
+			response := httpClient.HttpResponse{StatusCode: 200, Body: tc.body}
+
+			TransformResponse(logging.NewNopLogger(), spec, tc.scope, &response)
+			if response.Body != tc.want {
+				t.Errorf("\n%s\nTransformResponse(...): want body %q, got %q", tc.reason, tc.want, response.Body)
+			}
+		})
+	}
+}
+
+func TestSetRequestStatusResponseTransform(t *testing.T) {
+	forProvider := testForProvider
+	forProvider.SuccessCondition = `.body.status.history | length == 2`
+	forProvider.StatusExtractions = []v1alpha2.StatusExtraction{{Key: "self", ResponseJQ: ".body.links.self"}}
+	forProvider.ResponseTransform = &common.ResponseTransformConfig{JQ: `{id, phase: .status.phase}`}
+	cr := &v1alpha2.Request{Spec: v1alpha2.RequestSpec{ForProvider: forProvider}}
+
+	localKube := &test.MockClient{
+		MockGet:          test.NewMockGetFn(nil),
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+	}
+	svcCtx := service.NewServiceContext(context.Background(), localKube, logging.NewNopLogger(), nil, nil)
+	response := httpClient.HttpResponse{StatusCode: 200, Body: testVerboseBody}
+
+	r, err := NewStatusHandler(svcCtx, service.NewRequestCRContext(cr), httpClient.HttpDetails{HttpRequest: testRequest, HttpResponse: response}, nil)
+	if err != nil {
+		t.Fatalf("NewStatusHandler(...): unexpected error: %v", err)
+	}
+	if err := r.SetRequestStatus(); err != nil {
+		t.Fatalf("SetRequestStatus(...): unexpected error: %v", err)
+	}
+
+	want := `{"id":"123","phase":"Ready"}`
+	if cr.Status.Response.Body != want {
+		t.Errorf("SetRequestStatus(...): want the stored body %q, got %q", want, cr.Status.Response.Body)
+	}
+	if cr.Status.LastSuccessfulResponse == nil || cr.Status.LastSuccessfulResponse.Body != want {
+		t.Errorf("SetRequestStatus(...): want the stored last successful body %q, got %v", want, cr.Status.LastSuccessfulResponse)
+	}
+	if cr.Status.Failed != 0 {
+		t.Errorf("SetRequestStatus(...): want the success condition evaluated on the raw body, got %d failures", cr.Status.Failed)
+	}
+	if got := cr.Status.Extracted["self"]; got != "/users/123" {
+		t.Errorf("SetRequestStatus(...): want the extraction evaluated on the raw body, got %q", got)
+	}
+}
@@ -0,0 +1,127 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	json_util "github.com/crossplane-contrib/provider-http/internal/json"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestgen"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/requestmapping"
+	"github.com/crossplane-contrib/provider-http/internal/service/request/statushandler"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	// defaultReadyInterval is the time between two polls of a wait without an interval.
+	defaultReadyInterval = 5 * time.Second
+
+	errNotReady        = "resource not ready after %s: %s"
+	errNoReadyResponse = "no response received"
+)
+
+// waitForReadyConfig returns the configuration of the wait for the resource created by the mapping to be ready,
+// or nil when the mapping does not wait.
+func waitForReadyConfig(mapping interfaces.HTTPMapping) *common.WaitForReadyConfig {
+	if aware, ok := mapping.(interfaces.MappingWaitForReadyAware); ok {
+		return aware.GetWaitForReady()
+	}
+
+	return nil
+}
+
+// waitForReady polls the created resource with the OBSERVE request until the readiness filter of the configuration
+// holds, and records the ready response in the status. Responses that are not ready yet and failed polls are
+// retried until the deadline, which fails with the reason of the last poll.
+func waitForReady(svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, config *common.WaitForReadyConfig) error {
+	mapping, err := requestmapping.GetMapping(crCtx.Spec(), common.ActionObserve, svcCtx.Logger)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(svcCtx.Ctx, config.Deadline.Duration)
+	defer cancel()
+
+	reason := errNoReadyResponse
+	for {
+		requestDetails, details, ready, notReady, err := pollReady(ctx, svcCtx, crCtx, mapping, config.ReadyJQ)
+		if err != nil {
+			return err
+		}
+		if ready {
+			applyResponseDataToSecrets(svcCtx, crCtx, requestDetails, &details.HttpResponse)
+
+			statusHandler, err := statushandler.NewStatusHandler(svcCtx, crCtx, details, nil)
+			if err != nil {
+				return err
+			}
+			return statusHandler.SetRequestStatus()
+		}
+		if ctx.Err() == nil {
+			reason = notReady
+		}
+		svcCtx.Logger.Debug("Waiting for the resource to be ready", "reason", reason)
+
+		select {
+		case <-ctx.Done():
+			return errors.Errorf(errNotReady, time.Since(start).Round(time.Millisecond), reason)
+		case <-time.After(readyInterval(config)):
+		}
+	}
+}
+
+// pollReady sends the OBSERVE request once and evaluates the readiness filter against its response. When the
+// resource is not ready, the reason is returned instead. Only errors generating the request are returned as errors.
+func pollReady(ctx context.Context, svcCtx *service.ServiceContext, crCtx *service.RequestCRContext, mapping interfaces.HTTPMapping, readyJQ string) (requestgen.RequestDetails, httpClient.HttpDetails, bool, string, error) {
+	requestDetails, err := requestgen.GenerateValidRequestDetails(svcCtx, crCtx, mapping)
+	if err != nil {
+		return requestDetails, httpClient.HttpDetails{}, false, "", err
+	}
+
+	requestCtx, err := requestmapping.RequestContext(ctx, mapping)
+	if err != nil {
+		return requestDetails, httpClient.HttpDetails{}, false, "", err
+	}
+
+	details, err := svcCtx.HTTP.SendRequest(httpClient.ContextWithRedactedURL(requestCtx, requestDetails.RecordedURL()), requestmapping.GetEffectiveMethod(mapping), requestDetails.Url, requestDetails.Body, requestDetails.Headers, svcCtx.TLSConfigData)
+	if err != nil {
+		return requestDetails, details, false, err.Error(), nil
+	}
+	statushandler.TransformResponse(svcCtx.Logger, crCtx.Spec(), common.ResponseTransformScopeResponse, &details.HttpResponse)
+	if utils.IsHTTPError(details.HttpResponse.StatusCode) {
+		return requestDetails, details, false, fmt.Sprintf("status code %d", details.HttpResponse.StatusCode), nil
+	}
+
+	dataMap, err := json_util.StructToMap(details.HttpResponse)
+	if err != nil {
+		return requestDetails, details, false, err.Error(), nil
+	}
+	json_util.ConvertJSONStringsToMaps(&dataMap)
+
+	ready, err := jq.ParseBool(readyJQ, dataMap)
+	if err != nil {
+		return requestDetails, details, false, fmt.Sprintf("cannot evaluate readyJQ %s: %s", readyJQ, err), nil
+	}
+	if !ready {
+		return requestDetails, details, false, fmt.Sprintf("readyJQ %s is false", readyJQ), nil
+	}
+
+	return requestDetails, details, true, "", nil
+}
+
+// readyInterval returns the time between two polls of the wait.
+func readyInterval(config *common.WaitForReadyConfig) time.Duration {
+	if config.Interval == nil || config.Interval.Duration <= 0 {
+		return defaultReadyInterval
+	}
+
+	return config.Interval.Duration
+}
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	"github.com/crossplane-contrib/provider-http/internal/clients/http/fake"
+	"github.com/crossplane-contrib/provider-http/internal/service"
+)
+
+func TestDeployActionWaitForReady(t *testing.T) {
+	provisioning := fake.Respond(http.StatusOK, `{"id":"1","status":"PROVISIONING"}`)
+
+	cases := map[string]struct {
+		reason    string
+		responses []fake.Response
+		always    *fake.Response
+		deadline  time.Duration
+		wantCalls int
+		wantBody  string
+		wantErr   string
+	}{
+		"ProvisioningThenReady": {
+			reason: "Should poll the created resource until it is ready and record the ready response",
+			responses: []fake.Response{
+				fake.Respond(http.StatusAccepted, `{"id":"1","status":"PROVISIONING"}`),
+				provisioning,
+				fake.Respond(http.StatusNotFound, ""),
+				fake.Respond(http.StatusOK, `{"id":"1","status":"ACTIVE"}`),
+			},
+			deadline:  time.Minute,
+			wantCalls: 4,
+			wantBody:  `{"id":"1","status":"ACTIVE"}`,
+		},
+		"Deadline": {
+			reason: "Should fail with the reason of the last poll once the deadline is exceeded",
+			responses: []fake.Response{
+				fake.Respond(http.StatusAccepted, `{"id":"1","status":"PROVISIONING"}`),
+			},
+			always:   &provisioning,
+			deadline: 50 * time.Millisecond,
+			wantErr:  `readyJQ .body.status == "ACTIVE" is false`,
+		},
+		"CreateFailed": {
+			reason: "Should not wait when the request of the mapping failed",
+			responses: []fake.Response{
+				fake.Respond(http.StatusConflict, `{"error":"exists"}`),
+			},
+			deadline:  time.Minute,
+			wantCalls: 1,
+			wantBody:  `{"error":"exists"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := fake.NewClient(tc.responses...)
+			if tc.always != nil {
+				client.Always(*tc.always)
+			}
+			kube := &test.MockClient{
+				MockGet:          test.NewMockGetFn(nil),
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+			}
+			svcCtx := service.NewServiceContext(context.Background(), kube, logging.NewNopLogger(), client, nil)
+			cr := httpRequest(func(r *v1alpha2.Request) {
+				r.Spec.ForProvider.Mappings = []v1alpha2.Mapping{
+					{Action: common.ActionCreate, Method: http.MethodPost, URL: ".payload.baseUrl", WaitForReady: &common.WaitForReadyConfig{
+						ReadyJQ:  `.body.status == "ACTIVE"`,
+						Interval: &metav1.Duration{Duration: time.Millisecond},
+						Deadline: metav1.Duration{Duration: tc.deadline},
+					}},
+					{Action: common.ActionObserve, Method: http.MethodGet, URL: `(.payload.baseUrl + "/" + .response.body.id)`},
+				}
+			})
+
+			err := DeployAction(svcCtx, service.NewRequestCRContext(cr), common.ActionCreate)
+			if tc.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), "resource not ready after") || !strings.HasSuffix(err.Error(), tc.wantErr) {
+					t.Fatalf("\n%s\nDeployAction(...): want not ready error ending with %q, got %v", tc.reason, tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nDeployAction(...): unexpected error: %v", tc.reason, err)
+			}
+
+			calls := client.Calls()
+			if len(calls) != tc.wantCalls {
+				t.Fatalf("\n%s\nDeployAction(...): want %d requests, got %d", tc.reason, tc.wantCalls, len(calls))
+			}
+			for _, call := range calls[1:] {
+				if call.Method != http.MethodGet || call.URL != testURL+"/1" {
+					t.Errorf("\n%s\nDeployAction(...): want polls GET %s/1, got %s %s", tc.reason, testURL, call.Method, call.URL)
+				}
+			}
+			if diff := cmp.Diff(tc.wantBody, cr.Status.Response.Body); diff != "" {
+				t.Errorf("\n%s\nDeployAction(...): -want response body, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
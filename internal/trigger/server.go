@@ -0,0 +1,146 @@
+// Package trigger serves the endpoint external systems call to re-run a DisposableRequest on demand, instead of
+// waiting for its next reconcile.
+package trigger
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+)
+
+const (
+	// Pattern is the path of the endpoint triggering a DisposableRequest.
+	Pattern = "POST /namespaces/{namespace}/disposablerequests/{name}/trigger"
+
+	shutdownTimeout = 5 * time.Second
+	bearerPrefix    = "Bearer "
+
+	errGetDisposableRequest = "cannot get disposable request"
+	errGetTokenSecret       = "cannot get trigger token secret"
+	errAnnotate             = "cannot annotate disposable request"
+)
+
+// Server serves the trigger endpoint. A DisposableRequest is only triggered when it sets a trigger, the request
+// names the namespace of its token secret, and presents the token of this secret as a bearer token. It is then
+// annotated with the time it was triggered at, which makes the controller send it again.
+type Server struct {
+	kube   client.Client
+	logger logging.Logger
+	addr   string
+	now    func() time.Time
+}
+
+// NewServer returns a Server listening on the address, reading and annotating the DisposableRequests with the
+// client.
+func NewServer(kube client.Client, l logging.Logger, addr string) *Server {
+	return &Server{
+		kube:   kube,
+		logger: l,
+		addr:   addr,
+		now:    time.Now,
+	}
+}
+
+// Start serves the trigger endpoint until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(Pattern, s)
+	server := &http.Server{Addr: s.addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// NeedLeaderElection reports that every replica of the provider serves the endpoint, since it only annotates
+// resources.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeHTTP triggers the DisposableRequest named by the path of the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+	if !ok || token == "" {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	log := s.logger.WithValues("disposableRequest", name, "namespace", namespace)
+
+	status, err := s.trigger(r.Context(), namespace, name, token)
+	if err != nil {
+		log.Info("Cannot trigger the disposable request", "error", err)
+	}
+	if status == http.StatusAccepted {
+		log.Debug("Triggered the disposable request")
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
+
+// trigger annotates the DisposableRequest if the token is its trigger token, and returns the status code answering
+// the trigger. Whether the resource exists or sets a trigger is not disclosed to callers without its token.
+func (s *Server) trigger(ctx context.Context, namespace, name, token string) (int, error) {
+	cr := &v1alpha2.DisposableRequest{}
+	if err := s.kube.Get(ctx, types.NamespacedName{Name: name}, cr); err != nil {
+		if kerrors.IsNotFound(err) {
+			return http.StatusForbidden, nil
+		}
+		return http.StatusInternalServerError, errors.Wrap(err, errGetDisposableRequest)
+	}
+
+	trigger := cr.Spec.ForProvider.Trigger
+	if trigger == nil || trigger.TokenSecretRef.Namespace != namespace {
+		return http.StatusForbidden, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := s.kube.Get(ctx, types.NamespacedName{Name: trigger.TokenSecretRef.Name, Namespace: namespace}, secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			return http.StatusForbidden, errors.Wrap(err, errGetTokenSecret)
+		}
+		return http.StatusInternalServerError, errors.Wrap(err, errGetTokenSecret)
+	}
+
+	want := secret.Data[trigger.TokenSecretRef.Key]
+	if len(want) == 0 || subtle.ConstantTimeCompare(want, []byte(token)) != 1 {
+		return http.StatusForbidden, nil
+	}
+
+	patch := client.MergeFrom(cr.DeepCopy())
+	annotations := cr.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[common.AnnotationKeyRerunRequestedAt] = s.now().UTC().Format(time.RFC3339)
+	cr.SetAnnotations(annotations)
+
+	if err := s.kube.Patch(ctx, cr, patch); err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, errAnnotate)
+	}
+
+	return http.StatusAccepted, nil
+}
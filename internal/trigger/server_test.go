@@ -0,0 +1,133 @@
+package trigger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
+)
+
+const (
+	testName      = "sync-users"
+	testNamespace = "team-a"
+	testToken     = "s3cr3t"
+)
+
+func TestServeHTTP(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	trigger := &v1alpha2.Trigger{TokenSecretRef: xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "trigger-token", Namespace: testNamespace},
+		Key:             "token",
+	}}
+
+	cases := map[string]struct {
+		reason        string
+		path          string
+		authorization string
+		trigger       *v1alpha2.Trigger
+		missing       bool
+		wantStatus    int
+		wantTriggered bool
+	}{
+		"Authorized": {
+			reason:        "Should annotate the resource when the token of its trigger is presented",
+			path:          "/namespaces/team-a/disposablerequests/sync-users/trigger",
+			authorization: "Bearer " + testToken,
+			trigger:       trigger,
+			wantStatus:    http.StatusAccepted,
+			wantTriggered: true,
+		},
+		"NoToken": {
+			reason:     "Should reject a trigger without a bearer token",
+			path:       "/namespaces/team-a/disposablerequests/sync-users/trigger",
+			trigger:    trigger,
+			wantStatus: http.StatusUnauthorized,
+		},
+		"WrongToken": {
+			reason:        "Should reject a trigger presenting another token",
+			path:          "/namespaces/team-a/disposablerequests/sync-users/trigger",
+			authorization: "Bearer guess",
+			trigger:       trigger,
+			wantStatus:    http.StatusForbidden,
+		},
+		"OtherNamespace": {
+			reason:        "Should reject a trigger through another namespace than the one of the token secret",
+			path:          "/namespaces/team-b/disposablerequests/sync-users/trigger",
+			authorization: "Bearer " + testToken,
+			trigger:       trigger,
+			wantStatus:    http.StatusForbidden,
+		},
+		"TriggerNotEnabled": {
+			reason:        "Should reject a trigger of a resource without a trigger",
+			path:          "/namespaces/team-a/disposablerequests/sync-users/trigger",
+			authorization: "Bearer " + testToken,
+			wantStatus:    http.StatusForbidden,
+		},
+		"NotFound": {
+			reason:        "Should reject a trigger of a missing resource like an unauthorized one",
+			path:          "/namespaces/team-a/disposablerequests/sync-users/trigger",
+			authorization: "Bearer " + testToken,
+			missing:       true,
+			wantStatus:    http.StatusForbidden,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var annotations map[string]string
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					switch o := obj.(type) {
+					case *v1alpha2.DisposableRequest:
+						if tc.missing || key.Name != testName {
+							return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+						}
+						o.SetName(testName)
+						o.Spec.ForProvider.Trigger = tc.trigger
+					case *corev1.Secret:
+						if key.Namespace != testNamespace {
+							return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+						}
+						o.Data = map[string][]byte{"token": []byte(testToken)}
+					}
+					return nil
+				},
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					annotations = obj.GetAnnotations()
+					return nil
+				},
+			}
+
+			s := NewServer(kube, logging.NewNopLogger(), "")
+			s.now = func() time.Time { return now }
+			mux := http.NewServeMux()
+			mux.Handle(Pattern, s)
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("\n%s\nServeHTTP(...): want status %d, got %d", tc.reason, tc.wantStatus, rec.Code)
+			}
+			if got := annotations[common.AnnotationKeyRerunRequestedAt]; tc.wantTriggered != (got == "2024-05-01T12:00:00Z") {
+				t.Errorf("\n%s\nServeHTTP(...): want triggered %t, got annotation %q", tc.reason, tc.wantTriggered, got)
+			}
+		})
+	}
+}
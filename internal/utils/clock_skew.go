@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	// ClockSkewThreshold is the difference between the server Date header and the local time above which
+	// an authentication failure is reported as a possible clock skew.
+	ClockSkewThreshold = 5 * time.Minute
+
+	errClockSkewDate    = "possible clock skew: server time %s differs from local time by %s, check the provider pod clock"
+	errClockSkewMessage = "possible clock skew: the server rejected the request timestamp, check the provider pod clock"
+	headerDate          = "Date"
+)
+
+// clockSkewPattern matches the error messages commonly returned for requests signed with a skewed timestamp,
+// e.g. AWS RequestTimeTooSkewed or "Signature expired".
+var clockSkewPattern = regexp.MustCompile(`(?i)(clock[\s_-]?skew|time[\s_-]?skew|too[\s_-]?skewed|signature[\s_-]+(has[\s_-]+)?expired|request[\s_-]+(has[\s_-]+)?expired|timestamp[^"]{0,40}(expired|outside|too old|out of range))`)
+
+// DetectClockSkew checks if an authentication failure is likely caused by a skewed local clock, based on the
+// server Date header and known error messages. It returns an error describing the possible skew, or nil.
+func DetectClockSkew(response httpClient.HttpResponse, now time.Time) error {
+	if !isAuthFailure(response.StatusCode) {
+		return nil
+	}
+
+	if serverTime, ok := serverDate(response.Headers); ok {
+		skew := now.Sub(serverTime)
+		if skew < 0 {
+			skew = -skew
+		}
+
+		if skew > ClockSkewThreshold {
+			return errors.Errorf(errClockSkewDate, serverTime.UTC().Format(time.RFC3339), skew.Round(time.Second))
+		}
+	}
+
+	if clockSkewPattern.MatchString(response.Body) {
+		return errors.New(errClockSkewMessage)
+	}
+
+	return nil
+}
+
+// isAuthFailure checks if the status code may be returned for a rejected request signature or timestamp.
+func isAuthFailure(statusCode int) bool {
+	return statusCode == http.StatusBadRequest || statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+}
+
+// serverDate returns the time from the response Date header, if present and valid.
+func serverDate(headers map[string][]string) (time.Time, bool) {
+	date := httpClient.HeaderValue(headers, headerDate)
+	if date == "" {
+		return time.Time{}, false
+	}
+
+	serverTime, err := http.ParseTime(date)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return serverTime, true
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func Test_DetectClockSkew(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	type args struct {
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotAnAuthFailure": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusOK,
+					Headers:    map[string][]string{"Date": {now.Add(time.Hour).Format(http.TimeFormat)}},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ServerDateSkewed": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusForbidden,
+					Headers:    map[string][]string{"Date": {now.Add(-10 * time.Minute).Format(http.TimeFormat)}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errClockSkewDate, "2024-05-01T11:50:00Z", 10*time.Minute),
+			},
+		},
+		"LowerCaseDateHeader": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusForbidden,
+					Headers:    map[string][]string{"date": {now.Add(-10 * time.Minute).Format(http.TimeFormat)}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errClockSkewDate, "2024-05-01T11:50:00Z", 10*time.Minute),
+			},
+		},
+		"ServerDateWithinThreshold": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusUnauthorized,
+					Headers:    map[string][]string{"Date": {now.Add(30 * time.Second).Format(http.TimeFormat)}},
+					Body:       `{"error": "invalid credentials"}`,
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"KnownSkewErrorMessage": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusForbidden,
+					Body:       `<Error><Code>RequestTimeTooSkewed</Code><Message>The difference between the request time and the current time is too large.</Message></Error>`,
+				},
+			},
+			want: want{
+				err: errors.New(errClockSkewMessage),
+			},
+		},
+		"SignatureExpiredMessage": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusBadRequest,
+					Body:       `{"message": "Signature expired: 20240501T115000Z is now earlier than 20240501T115500Z"}`,
+				},
+			},
+			want: want{
+				err: errors.New(errClockSkewMessage),
+			},
+		},
+		"InvalidDateHeaderIgnored": {
+			args: args{
+				response: httpClient.HttpResponse{
+					StatusCode: http.StatusUnauthorized,
+					Headers:    map[string][]string{"Date": {"not a date"}},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DetectClockSkew(tc.args.response, now)
+			if diff := cmp.Diff(tc.want.err, got, test.EquateErrors()); diff != "" {
+				t.Fatalf("DetectClockSkew(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const (
+	errStatusCodeWithBody = ErrStatusCode + ", response: %s"
+
+	// maxErrorBodyLength is the number of bytes of an error body quoted in the error of a response.
+	maxErrorBodyLength = 512
+)
+
+// StatusCodeError returns the error of a response failing with an HTTP error status code. It quotes the body of
+// the response, so the explanation of the server is surfaced in the status instead of only its status code.
+func StatusCodeError(method string, response httpClient.HttpResponse) error {
+	summary := errorBodySummary(response)
+	if summary == "" {
+		return errors.Errorf(ErrStatusCode, method, strconv.Itoa(response.StatusCode))
+	}
+
+	return errors.Errorf(errStatusCodeWithBody, method, strconv.Itoa(response.StatusCode), summary)
+}
+
+// errorBodySummary returns the body of the response as a single line truncated to maxErrorBodyLength bytes, or an
+// empty string if the body does not explain the error in text. JSON bodies are compacted, text bodies have their
+// whitespace collapsed, and other bodies, e.g. HTML error pages or binary data, are left out.
+func errorBodySummary(response httpClient.HttpResponse) string {
+	body := strings.TrimSpace(response.Body)
+	if body == "" {
+		return ""
+	}
+
+	mediaType := contentMediaType(response.Headers)
+	switch {
+	case isJSONMediaType(mediaType), mediaType == "" && json.Valid([]byte(body)):
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, []byte(body)); err == nil {
+			body = compacted.String()
+		}
+	case mediaType == "", mediaType == "text/plain":
+		body = NormalizeWhitespace(body)
+	default:
+		return ""
+	}
+
+	return truncateErrorBody(body)
+}
+
+// truncateErrorBody truncates the body to maxErrorBodyLength bytes, without splitting a UTF-8 character.
+func truncateErrorBody(body string) string {
+	if len(body) <= maxErrorBodyLength {
+		return body
+	}
+
+	cut := maxErrorBodyLength
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return body[:cut] + "..."
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestStatusCodeError(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		response httpClient.HttpResponse
+		want     string
+	}{
+		"JSON": {
+			reason: "Should quote a JSON error body compacted",
+			response: httpClient.HttpResponse{
+				StatusCode: 422,
+				Headers:    map[string][]string{"Content-Type": {"application/json; charset=utf-8"}},
+				Body:       "{\n  \"message\": \"Validation Failed\",\n  \"errors\": [\"name is required\"]\n}",
+			},
+			want: `HTTP POST request failed with status code: 422, response: {"message":"Validation Failed","errors":["name is required"]}`,
+		},
+		"ProblemJSON": {
+			reason: "Should quote a problem details body",
+			response: httpClient.HttpResponse{
+				StatusCode: 409,
+				Headers:    map[string][]string{"content-type": {"application/problem+json"}},
+				Body:       `{"title": "Conflict", "detail": "name already exists"}`,
+			},
+			want: `HTTP POST request failed with status code: 409, response: {"title":"Conflict","detail":"name already exists"}`,
+		},
+		"Text": {
+			reason: "Should quote a text error body on a single line",
+			response: httpClient.HttpResponse{
+				StatusCode: 400,
+				Headers:    map[string][]string{"Content-Type": {"text/plain"}},
+				Body:       "invalid request:\n  missing name\n",
+			},
+			want: "HTTP POST request failed with status code: 400, response: invalid request: missing name",
+		},
+		"NoContentType": {
+			reason: "Should quote a JSON error body without a Content-Type",
+			response: httpClient.HttpResponse{
+				StatusCode: 500,
+				Body:       `{ "error": "boom" }`,
+			},
+			want: `HTTP POST request failed with status code: 500, response: {"error":"boom"}`,
+		},
+		"HTML": {
+			reason: "Should not quote an HTML error page",
+			response: httpClient.HttpResponse{
+				StatusCode: 502,
+				Headers:    map[string][]string{"Content-Type": {"text/html"}},
+				Body:       "<html><body><h1>502 Bad Gateway</h1></body></html>",
+			},
+			want: "HTTP POST request failed with status code: 502",
+		},
+		"Empty": {
+			reason:   "Should not quote an empty body",
+			response: httpClient.HttpResponse{StatusCode: 404, Body: "\n"},
+			want:     "HTTP POST request failed with status code: 404",
+		},
+		"Truncated": {
+			reason: "Should truncate a long error body without splitting a character",
+			response: httpClient.HttpResponse{
+				StatusCode: 400,
+				Headers:    map[string][]string{"Content-Type": {"text/plain"}},
+				Body:       "x" + strings.Repeat("é", 600),
+			},
+			want: "HTTP POST request failed with status code: 400, response: x" + strings.Repeat("é", 255) + "...",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := StatusCodeError("POST", tc.response).Error()
+			if got != tc.want {
+				t.Errorf("\n%s\nStatusCodeError(...): want %q, got %q", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+var (
+	pollJitterMu sync.RWMutex
+	pollJitter   float64
+)
+
+// SetPollJitter sets the fraction of the poll interval added at random to the poll interval of every resource, e.g.
+// 0.1 to spread the polls of resources created together over a tenth of the interval. Zero disables the jitter.
+func SetPollJitter(fraction float64) {
+	pollJitterMu.Lock()
+	defer pollJitterMu.Unlock()
+
+	pollJitter = fraction
+}
+
+// WithPollJitter returns a poll interval hook adding the poll jitter to the poll interval returned by the hook.
+func WithPollJitter(hook managed.PollIntervalHook) managed.PollIntervalHook {
+	return func(mg resource.Managed, pollInterval time.Duration) time.Duration {
+		pollJitterMu.RLock()
+		fraction := pollJitter
+		pollJitterMu.RUnlock()
+
+		return jitter(hook(mg, pollInterval), fraction, rand.Float64())
+	}
+}
+
+// jitter returns the interval increased by the fraction of it scaled by r, a random number in [0, 1). A zero
+// interval, which does not requeue the resource, is kept.
+func jitter(interval time.Duration, fraction, r float64) time.Duration {
+	if interval <= 0 || fraction <= 0 {
+		return interval
+	}
+
+	return interval + time.Duration(float64(interval)*fraction*r)
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+func TestJitter(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		interval time.Duration
+		fraction float64
+		r        float64
+		want     time.Duration
+	}{
+		"NoJitter": {
+			reason:   "Should keep the interval without a jitter",
+			interval: time.Minute,
+			want:     time.Minute,
+		},
+		"Lowest": {
+			reason:   "Should keep the interval for the lowest random number",
+			interval: time.Minute,
+			fraction: 0.5,
+			want:     time.Minute,
+		},
+		"Scaled": {
+			reason:   "Should add the fraction of the interval scaled by the random number",
+			interval: time.Minute,
+			fraction: 0.5,
+			r:        0.5,
+			want:     75 * time.Second,
+		},
+		"NoRequeue": {
+			reason:   "Should keep a zero interval, which does not requeue the resource",
+			fraction: 0.5,
+			r:        0.5,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := jitter(tc.interval, tc.fraction, tc.r); got != tc.want {
+				t.Errorf("\n%s\njitter(%s, %v, %v): want %s, got %s", tc.reason, tc.interval, tc.fraction, tc.r, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWithPollJitterBounds(t *testing.T) {
+	SetPollJitter(0.2)
+	defer SetPollJitter(0)
+
+	hook := WithPollJitter(func(_ resource.Managed, pollInterval time.Duration) time.Duration {
+		return pollInterval
+	})
+
+	lowest, highest := time.Minute, time.Minute+12*time.Second
+	spread := map[time.Duration]bool{}
+	for range 1000 {
+		got := hook(nil, time.Minute)
+		if got < lowest || got >= highest {
+			t.Fatalf("WithPollJitter(...): want a poll interval in [%s, %s), got %s", lowest, highest, got)
+		}
+		spread[got] = true
+	}
+
+	if len(spread) < 2 {
+		t.Errorf("WithPollJitter(...): want jittered poll intervals, got %d distinct ones", len(spread))
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+)
+
+// BodyTextKey is the key of the raw response body in the context of the checks, when the response bodies are
+// read as plain text.
+const BodyTextKey = "bodyText"
+
+// IsTextResponseFormat checks if the spec reads the response bodies as plain text, without parsing them as JSON.
+func IsTextResponseFormat(spec interface{}) bool {
+	aware, ok := spec.(interfaces.ResponseFormatAware)
+	return ok && aware.GetResponseFormat() == common.ResponseFormatText
+}
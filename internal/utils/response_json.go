@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// ResponseJSON returns the body of the response as a structured object, or nil if the response does not have a
+// JSON Content-Type, e.g. application/json or application/problem+json, or its body is not a JSON object.
+func ResponseJSON(response httpClient.HttpResponse) *runtime.RawExtension {
+	if !isJSONContentType(response.Headers) {
+		return nil
+	}
+
+	body := bytes.TrimSpace([]byte(response.Body))
+	if len(body) == 0 || body[0] != '{' || !json.Valid(body) {
+		return nil
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err != nil {
+		return nil
+	}
+
+	return &runtime.RawExtension{Raw: compacted.Bytes()}
+}
+
+// isJSONContentType checks if the Content-Type header of the headers is a JSON media type.
+func isJSONContentType(headers map[string][]string) bool {
+	return isJSONMediaType(contentMediaType(headers))
+}
+
+// isJSONMediaType checks if the media type is JSON, e.g. application/json or application/problem+json.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// contentMediaType returns the media type of the Content-Type header of the headers, or an empty string if it is
+// missing or invalid.
+func contentMediaType(headers map[string][]string) string {
+	for key, values := range headers {
+		if http.CanonicalHeaderKey(key) != "Content-Type" || len(values) == 0 {
+			continue
+		}
+
+		mediaType, _, err := mime.ParseMediaType(values[0])
+		if err != nil {
+			return ""
+		}
+		return mediaType
+	}
+
+	return ""
+}
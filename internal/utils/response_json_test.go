@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestResponseJSON(t *testing.T) {
+	cases := map[string]struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		"JSONObject": {
+			contentType: "application/json",
+			body:        "{\n  \"id\": \"123\",\n  \"owner\": {\"name\": \"john\"}\n}",
+			want:        `{"id":"123","owner":{"name":"john"}}`,
+		},
+		"JSONWithCharset": {
+			contentType: "application/json; charset=utf-8",
+			body:        `{"id": "123"}`,
+			want:        `{"id":"123"}`,
+		},
+		"StructuredSyntaxSuffix": {
+			contentType: "application/problem+json",
+			body:        `{"title": "Not Found"}`,
+			want:        `{"title":"Not Found"}`,
+		},
+		"NotJSONContentType": {
+			contentType: "text/plain",
+			body:        `{"id": "123"}`,
+		},
+		"JSONArray": {
+			contentType: "application/json",
+			body:        `[{"id": "123"}]`,
+		},
+		"InvalidJSON": {
+			contentType: "application/json",
+			body:        `{"id": `,
+		},
+		"NoContentType": {
+			body: `{"id": "123"}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			response := httpClient.HttpResponse{StatusCode: 200, Body: tc.body, Headers: map[string][]string{}}
+			if tc.contentType != "" {
+				response.Headers["Content-Type"] = []string{tc.contentType}
+			}
+
+			got := ResponseJSON(response)
+			if tc.want == "" {
+				if got != nil {
+					t.Errorf("ResponseJSON(...): want nil, got %s", string(got.Raw))
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("ResponseJSON(...): want %s, got nil", tc.want)
+			}
+			if diff := cmp.Diff(tc.want, string(got.Raw)); diff != "" {
+				t.Errorf("ResponseJSON(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
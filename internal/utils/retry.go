@@ -25,6 +25,12 @@ func RetriesLimitReached(statusFailed int32, rollbackRetriesLimit *int32) bool {
 	return statusFailed >= *rollbackRetriesLimit
 }
 
+// IsRetryableStatusCode determines if a failed request with the given status code may be retried.
+// When no retryable status codes are configured, every status code is retryable.
+func IsRetryableStatusCode(retryableStatusCodes []string, statusCode int) bool {
+	return len(retryableStatusCodes) == 0 || MatchesStatusCode(retryableStatusCodes, statusCode)
+}
+
 // WaitTimeout returns the wait timeout duration.
 func WaitTimeout(timeout *v1.Duration) time.Duration {
 	if timeout != nil {
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+const headerRetryAfter = "Retry-After"
+
+// RetryAfter returns the delay the Retry-After header of a 429 Too Many Requests or 503 Service Unavailable response
+// asks to wait before sending the request again, capped at max. The header holds either a number of seconds or an
+// HTTP date. It returns false if the response has another status code or no valid Retry-After header.
+func RetryAfter(response httpClient.HttpResponse, now time.Time, max time.Duration) (time.Duration, bool) {
+	if response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	delay, ok := ParseRetryAfter(httpClient.HeaderValue(response.Headers, headerRetryAfter), now)
+	if !ok {
+		return 0, false
+	}
+
+	if max > 0 && delay > max {
+		delay = max
+	}
+
+	return delay, true
+}
+
+// ParseRetryAfter parses a Retry-After header value, either delta-seconds or an HTTP date, into the delay to wait
+// from now. A date in the past is a delay of zero.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+
+	return 0, true
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	cases := map[string]struct {
+		response httpClient.HttpResponse
+		max      time.Duration
+		want     time.Duration
+		wantOK   bool
+	}{
+		"DeltaSeconds": {
+			response: httpClient.HttpResponse{StatusCode: 429, Headers: map[string][]string{"Retry-After": {"120"}}},
+			want:     2 * time.Minute,
+			wantOK:   true,
+		},
+		"HTTPDate": {
+			response: httpClient.HttpResponse{StatusCode: 503, Headers: map[string][]string{"retry-after": {now.Add(90 * time.Second).Format(http.TimeFormat)}}},
+			want:     90 * time.Second,
+			wantOK:   true,
+		},
+		"PastHTTPDate": {
+			response: httpClient.HttpResponse{StatusCode: 429, Headers: map[string][]string{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}}},
+			want:     0,
+			wantOK:   true,
+		},
+		"Capped": {
+			response: httpClient.HttpResponse{StatusCode: 429, Headers: map[string][]string{"Retry-After": {"86400"}}},
+			max:      10 * time.Minute,
+			want:     10 * time.Minute,
+			wantOK:   true,
+		},
+		"Invalid": {
+			response: httpClient.HttpResponse{StatusCode: 429, Headers: map[string][]string{"Retry-After": {"soon"}}},
+		},
+		"Negative": {
+			response: httpClient.HttpResponse{StatusCode: 429, Headers: map[string][]string{"Retry-After": {"-5"}}},
+		},
+		"Missing": {
+			response: httpClient.HttpResponse{StatusCode: 429},
+		},
+		"OtherStatusCode": {
+			response: httpClient.HttpResponse{StatusCode: 500, Headers: map[string][]string{"Retry-After": {"120"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := RetryAfter(tc.response, now, tc.max)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("RetryAfter(...): want %v (%t), got %v (%t)", tc.want, tc.wantOK, got, ok)
+			}
+		})
+	}
+}
@@ -149,6 +149,56 @@ func Test_RollBackEnabled(t *testing.T) {
 	}
 }
 
+func Test_IsRetryableStatusCode(t *testing.T) {
+	type args struct {
+		retryableStatusCodes []string
+		statusCode           int
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCodesConfigured": {
+			args: args{
+				retryableStatusCodes: nil,
+				statusCode:           400,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"RetryableCode": {
+			args: args{
+				retryableStatusCodes: []string{"502-504"},
+				statusCode:           503,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"TerminalCode": {
+			args: args{
+				retryableStatusCodes: []string{"502-504"},
+				statusCode:           422,
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsRetryableStatusCode(tc.args.retryableStatusCodes, tc.args.statusCode)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("IsRetryableStatusCode(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_WaitTimeout(t *testing.T) {
 	type args struct {
 		timeout *v1.Duration
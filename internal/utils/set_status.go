@@ -2,9 +2,12 @@ package utils
 
 import (
 	"context"
+	"time"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	"github.com/crossplane-contrib/provider-http/apis/interfaces"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,6 +26,8 @@ type RequestResource struct {
 	HttpResponse   httpClient.HttpResponse
 	HttpRequest    httpClient.HttpRequest
 	LocalClient    client.Client
+	// StoredHeaders bounds the headers and trailers stored in status.response, nil for the defaults.
+	StoredHeaders *common.StoredHeadersConfig
 }
 
 func (rr *RequestResource) SetStatusCode() SetRequestStatusFunc {
@@ -36,7 +41,7 @@ func (rr *RequestResource) SetStatusCode() SetRequestStatusFunc {
 func (rr *RequestResource) SetHeaders() SetRequestStatusFunc {
 	return func() {
 		if rr.HttpResponse.Headers != nil {
-			rr.StatusWriter.SetHeaders(rr.HttpResponse.Headers)
+			rr.StatusWriter.SetHeaders(BoundHeaders(rr.HttpResponse.Headers, rr.StoredHeaders))
 		}
 	}
 }
@@ -49,6 +54,30 @@ func (rr *RequestResource) SetBody() SetRequestStatusFunc {
 	}
 }
 
+func (rr *RequestResource) SetTrailers() SetRequestStatusFunc {
+	return func() {
+		if rr.HttpResponse.StatusCode != 0 {
+			rr.StatusWriter.SetTrailers(BoundHeaders(rr.HttpResponse.Trailers, rr.StoredHeaders))
+		}
+	}
+}
+
+func (rr *RequestResource) SetStatusText() SetRequestStatusFunc {
+	return func() {
+		if rr.HttpResponse.StatusCode != 0 {
+			rr.StatusWriter.SetStatusText(rr.HttpResponse.StatusText)
+		}
+	}
+}
+
+func (rr *RequestResource) SetTiming() SetRequestStatusFunc {
+	return func() {
+		if rr.HttpResponse.StatusCode != 0 {
+			rr.StatusWriter.SetTiming(rr.HttpResponse.Timing)
+		}
+	}
+}
+
 func (rr *RequestResource) SetRequestDetails() SetRequestStatusFunc {
 	return func() {
 		if rr.HttpRequest.Method != "" {
@@ -73,6 +102,151 @@ func (rr *RequestResource) SetLastReconcileTime() SetRequestStatusFunc {
 	}
 }
 
+func (rr *RequestResource) SetMultiStatus(result *common.MultiStatusResult) SetRequestStatusFunc {
+	return func() {
+		if multiStatus, ok := rr.StatusWriter.(interfaces.MultiStatusWriter); ok {
+			multiStatus.SetMultiStatus(result)
+		}
+	}
+}
+
+func (rr *RequestResource) SetFailedCheck(description string) SetRequestStatusFunc {
+	return func() {
+		if failedCheck, ok := rr.StatusWriter.(interfaces.FailedCheckWriter); ok {
+			failedCheck.SetFailedCheck(description)
+		}
+	}
+}
+
+// SetDrift records the fields that differed from the observed state, clearing them when empty.
+func (rr *RequestResource) SetDrift(drift []common.FieldDiff) SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.DriftWriter); ok {
+			writer.SetDrift(drift)
+		}
+	}
+}
+
+// RecordAttempt records the attempt of the action in the history, with the status code of the response if any and
+// the error it failed with.
+func (rr *RequestResource) RecordAttempt(action string, failure error) SetRequestStatusFunc {
+	return func() {
+		writer, ok := rr.StatusWriter.(interfaces.HistoryWriter)
+		if !ok {
+			return
+		}
+
+		attempt := common.Attempt{Time: v1.Now(), Action: action, StatusCode: rr.HttpResponse.StatusCode}
+		if failure != nil {
+			attempt.Error = failure.Error()
+		}
+		writer.RecordAttempt(attempt)
+	}
+}
+
+// SetCacheObservedGeneration records the generation of the spec the response found the resource up to date for,
+// zero if it did not.
+func (rr *RequestResource) SetCacheObservedGeneration(generation int64) SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.CacheObservedGenerationWriter); ok {
+			writer.SetCacheObservedGeneration(generation)
+		}
+	}
+}
+
+// IncrementConsecutiveSuccesses counts one more successful observation since the last failed request.
+func (rr *RequestResource) IncrementConsecutiveSuccesses() SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.ConsecutiveSuccessesWriter); ok {
+			writer.IncrementConsecutiveSuccesses()
+		}
+	}
+}
+
+func (rr *RequestResource) SetResponseJSON(enabled bool) SetRequestStatusFunc {
+	return func() {
+		responseJSON, ok := rr.StatusWriter.(interfaces.ResponseJSONWriter)
+		if !ok || rr.HttpResponse.StatusCode == 0 {
+			return
+		}
+
+		if !enabled {
+			responseJSON.SetResponseJSON(nil)
+			return
+		}
+		responseJSON.SetResponseJSON(ResponseJSON(rr.HttpResponse))
+	}
+}
+
+func (rr *RequestResource) SetLastSuccessfulResponse() SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.LastSuccessfulResponseWriter); ok {
+			writer.SetLastSuccessfulResponse(rr.HttpResponse.StatusCode, rr.HttpResponse.Headers, rr.HttpResponse.Body)
+		}
+	}
+}
+
+func (rr *RequestResource) SetExtracted(values map[string]string) SetRequestStatusFunc {
+	return func() {
+		if extracted, ok := rr.StatusWriter.(interfaces.ExtractedWriter); ok {
+			extracted.SetExtracted(values)
+		}
+	}
+}
+
+func (rr *RequestResource) SetItems(items []common.ItemResult) SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.ItemsWriter); ok {
+			writer.SetItems(items)
+		}
+	}
+}
+
+// SetRetryAfter records the delay the Retry-After header of the response asks to wait, capped at max, or clears
+// it if the response has none.
+func (rr *RequestResource) SetRetryAfter(max time.Duration) SetRequestStatusFunc {
+	return func() {
+		writer, ok := rr.StatusWriter.(interfaces.RetryAfterWriter)
+		if !ok {
+			return
+		}
+
+		delay, ok := RetryAfter(rr.HttpResponse, time.Now(), max)
+		if !ok {
+			writer.SetRetryAfter(nil)
+			return
+		}
+		writer.SetRetryAfter(&v1.Duration{Duration: delay})
+	}
+}
+
+// ClearRetryAfter clears the delay requested by the Retry-After header of a previous response.
+func (rr *RequestResource) ClearRetryAfter() SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.RetryAfterWriter); ok {
+			writer.SetRetryAfter(nil)
+		}
+	}
+}
+
+// SetContinuation records the continuation token extracted from the response, sent with the next request.
+func (rr *RequestResource) SetContinuation(token string) SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.ContinuationWriter); ok {
+			writer.SetContinuation(token)
+		}
+	}
+}
+
+// SetStartTime records the time the request of the current generation of the resource was first sent.
+func (rr *RequestResource) SetStartTime() SetRequestStatusFunc {
+	return func() {
+		if writer, ok := rr.StatusWriter.(interfaces.StartTimeWriter); ok {
+			writer.SetStartTime(time.Now())
+		}
+	}
+}
+
 func (rr *RequestResource) SetCache() SetRequestStatusFunc {
 	return func() {
 		if cached, ok := rr.StatusWriter.(interfaces.RequestStatusWriter); ok {
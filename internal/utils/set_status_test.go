@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane-contrib/provider-http/apis/common"
 	v1alpha1_disposable "github.com/crossplane-contrib/provider-http/apis/disposablerequest/v1alpha2"
 	v1alpha1_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
 	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
@@ -98,6 +99,9 @@ var (
 		HttpResponse: httpClient.HttpResponse{
 			StatusCode: 200,
 			Body:       `{"ids":"123","username":"john_doe"}`,
+			Trailers:   map[string][]string{"Grpc-Status": {"0"}},
+			StatusText: "OK",
+			Timing:     common.Timing{TTFBMs: 10, TotalMs: 12},
 		},
 		HttpRequest: httpClient.HttpRequest{
 			Method: "GET",
@@ -130,6 +134,9 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 					testRequestResource.SetRequestDetails(),
 					testRequestResource.SetHeaders(),
 					testRequestResource.SetStatusCode(),
+					testRequestResource.SetTrailers(),
+					testRequestResource.SetStatusText(),
+					testRequestResource.SetTiming(),
 					testRequestResource.ResetFailures(),
 					testRequestResource.SetCache(),
 				},
@@ -147,6 +154,9 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 					testRequestResource.SetRequestDetails(),
 					testRequestResource.SetHeaders(),
 					testRequestResource.SetStatusCode(),
+					testRequestResource.SetTrailers(),
+					testRequestResource.SetStatusText(),
+					testRequestResource.SetTiming(),
 					testRequestResource.ResetFailures(),
 					testRequestResource.SetCache(),
 					testRequestResource.SetError(errBoom),
@@ -177,6 +187,18 @@ func Test_SetRequestResourceStatus(t *testing.T) {
 				t.Fatalf("SetRequestResourceStatus(...): -want response status code, +got response status code: %s", diff)
 			}
 
+			if diff := cmp.Diff(tc.args.rr.HttpResponse.Trailers, testRequestCr.Status.Response.Trailers); diff != "" {
+				t.Fatalf("SetRequestResourceStatus(...): -want response trailers, +got response trailers: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.args.rr.HttpResponse.StatusText, testRequestCr.Status.Response.StatusText); diff != "" {
+				t.Fatalf("SetRequestResourceStatus(...): -want response status text, +got response status text: %s", diff)
+			}
+
+			if diff := cmp.Diff(&tc.args.rr.HttpResponse.Timing, testRequestCr.Status.Response.Timing); diff != "" {
+				t.Fatalf("SetRequestResourceStatus(...): -want response timing, +got response timing: %s", diff)
+			}
+
 			if diff := cmp.Diff(tc.args.rr.HttpResponse.StatusCode, testRequestCr.Status.Cache.Response.StatusCode); diff != "" {
 				t.Fatalf("SetRequestResourceStatus(...): -want cache status code, +got cahce status code: %s", diff)
 			}
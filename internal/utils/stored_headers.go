@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/interfaces"
+)
+
+const (
+	// defaultMaxStoredHeaderCount is the number of response header values stored in the status by default.
+	defaultMaxStoredHeaderCount = 100
+	// defaultMaxStoredHeaderBytes is the total size of the response headers stored in the status by default.
+	defaultMaxStoredHeaderBytes = 16 << 10
+)
+
+// StoredHeadersConfig returns the bounds of the response headers the spec stores in the status, or nil for the
+// defaults.
+func StoredHeadersConfig(spec interface{}) *common.StoredHeadersConfig {
+	if aware, ok := spec.(interfaces.StoredHeadersAware); ok {
+		return aware.GetStoredHeaders()
+	}
+
+	return nil
+}
+
+// BoundHeaders returns the headers to store in status.response, bounded in number of values and total size so a
+// response with thousands of headers, e.g. Set-Cookie headers, does not blow up the status. The first value of
+// every header is kept before the second ones, and so on, so the most repeated headers lose their values first.
+// The values exceeding the bounds are left out, and their number is recorded in the TruncatedHeadersMarker header.
+// Headers within the bounds are returned as is.
+func BoundHeaders(headers map[string][]string, config *common.StoredHeadersConfig) map[string][]string {
+	maxCount, maxBytes := defaultMaxStoredHeaderCount, defaultMaxStoredHeaderBytes
+	if config != nil {
+		maxCount = cmp.Or(config.MaxCount, maxCount)
+		maxBytes = cmp.Or(config.MaxBytes, maxBytes)
+	}
+
+	count, size := 0, 0
+	for name, values := range headers {
+		count += len(values)
+		for _, value := range values {
+			size += len(name) + len(value)
+		}
+	}
+	if count <= maxCount && size <= maxBytes {
+		return headers
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	bounded := make(map[string][]string, len(headers)+1)
+	count, size = 0, 0
+	omitted := 0
+	for i, left := 0, true; left; i++ {
+		left = false
+		for _, name := range names {
+			if i >= len(headers[name]) {
+				continue
+			}
+			left = true
+
+			value := headers[name][i]
+			if count >= maxCount || size+len(name)+len(value) > maxBytes {
+				omitted++
+				continue
+			}
+			bounded[name] = append(bounded[name], value)
+			count++
+			size += len(name) + len(value)
+		}
+	}
+	bounded[common.TruncatedHeadersMarker] = []string{strconv.Itoa(omitted)}
+
+	return bounded
+}
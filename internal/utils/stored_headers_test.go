@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	v1alpha2_request "github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	httpClient "github.com/crossplane-contrib/provider-http/internal/clients/http"
+)
+
+// setCookies returns n distinct Set-Cookie header values.
+func setCookies(n int) []string {
+	cookies := make([]string, n)
+	for i := range cookies {
+		cookies[i] = fmt.Sprintf("session%d=abc", i)
+	}
+	return cookies
+}
+
+func TestBoundHeaders(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		headers map[string][]string
+		config  *common.StoredHeadersConfig
+		want    map[string][]string
+	}{
+		"WithinBounds": {
+			reason:  "Should store headers within the bounds as is",
+			headers: map[string][]string{"Content-Type": {"application/json"}, "Etag": {`"v1"`}},
+			want:    map[string][]string{"Content-Type": {"application/json"}, "Etag": {`"v1"`}},
+		},
+		"Nil": {
+			reason: "Should store no headers when the response has none",
+		},
+		"DefaultMaxCount": {
+			reason:  "Should keep at most 100 values by default, leaving out the values of the most repeated header",
+			headers: map[string][]string{"Content-Type": {"application/json"}, "Set-Cookie": setCookies(1000)},
+			want: map[string][]string{
+				"Content-Type":                {"application/json"},
+				"Set-Cookie":                  setCookies(99),
+				common.TruncatedHeadersMarker: {"901"},
+			},
+		},
+		"DefaultMaxBytes": {
+			reason:  "Should keep at most 16KiB of headers by default",
+			headers: map[string][]string{"X-Large": {strings.Repeat("a", 10000), strings.Repeat("b", 10000)}},
+			want: map[string][]string{
+				"X-Large":                     {strings.Repeat("a", 10000)},
+				common.TruncatedHeadersMarker: {"1"},
+			},
+		},
+		"MaxCount": {
+			reason:  "Should keep the first value of every header before the other values of a repeated header",
+			headers: map[string][]string{"Etag": {`"v1"`}, "Set-Cookie": setCookies(5), "Vary": {"Accept"}},
+			config:  &common.StoredHeadersConfig{MaxCount: 4},
+			want: map[string][]string{
+				"Etag":                        {`"v1"`},
+				"Set-Cookie":                  setCookies(2),
+				"Vary":                        {"Accept"},
+				common.TruncatedHeadersMarker: {"3"},
+			},
+		},
+		"MaxBytes": {
+			reason:  "Should keep the smaller headers fitting in the remaining size after a value is left out",
+			headers: map[string][]string{"Set-Cookie": {strings.Repeat("a", 100)}, "Vary": {"Accept"}},
+			config:  &common.StoredHeadersConfig{MaxBytes: 50},
+			want: map[string][]string{
+				"Vary":                        {"Accept"},
+				common.TruncatedHeadersMarker: {"1"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := BoundHeaders(tc.headers, tc.config)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nBoundHeaders(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetRequestResourceStatusBoundsHeaders(t *testing.T) {
+	cr := &v1alpha2_request.Request{}
+	headers := map[string][]string{"Set-Cookie": setCookies(10)}
+	resource := RequestResource{
+		StatusWriter:   cr,
+		Resource:       cr,
+		RequestContext: context.Background(),
+		HttpResponse:   httpClient.HttpResponse{StatusCode: 200, Headers: headers, Trailers: headers},
+		LocalClient:    &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+		StoredHeaders:  &common.StoredHeadersConfig{MaxCount: 3},
+	}
+
+	if err := SetRequestResourceStatus(resource, resource.SetHeaders(), resource.SetTrailers(), resource.SetLastSuccessfulResponse(), resource.SetCache()); err != nil {
+		t.Fatalf("SetRequestResourceStatus(...): unexpected error: %v", err)
+	}
+
+	want := map[string][]string{"Set-Cookie": setCookies(3), common.TruncatedHeadersMarker: {"7"}}
+	bounded := map[string]map[string][]string{
+		"status.response.headers":  cr.Status.Response.Headers,
+		"status.response.trailers": cr.Status.Response.Trailers,
+	}
+	for field, got := range bounded {
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("SetRequestResourceStatus(...): want the %s truncated, -want, +got:\n%s", field, diff)
+		}
+	}
+
+	// The responses read back from the status to process the next requests keep all the headers.
+	whole := map[string]map[string][]string{
+		"status.lastSuccessfulResponse.headers": cr.Status.LastSuccessfulResponse.Headers,
+		"status.cache.response.headers":         cr.Status.Cache.Response.Headers,
+	}
+	for field, got := range whole {
+		if diff := cmp.Diff(headers, got); diff != "" {
+			t.Errorf("SetRequestResourceStatus(...): want the %s whole, -want, +got:\n%s", field, diff)
+		}
+	}
+	if got := len(resource.HttpResponse.Headers["Set-Cookie"]); got != 10 {
+		t.Errorf("SetRequestResourceStatus(...): want the headers of the response left whole, got %d values", got)
+	}
+}
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -30,11 +33,55 @@ func IsHTTPSuccess(statusCode int) bool {
 	return statusCode >= 200 && statusCode < 300
 }
 
+// IsSafeMethod checks if an HTTP method is a read-only method, that does not change the state of the server.
+func IsSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsHTTPError checks if an HTTP status code indicates an error.
 func IsHTTPError(statusCode int) bool {
 	return statusCode >= 400 && statusCode < 600
 }
 
+// MatchesStatusCode checks if an HTTP status code matches any of the given codes or inclusive ranges (e.g. "404", "500-599").
+// Malformed entries never match.
+func MatchesStatusCode(patterns []string, statusCode int) bool {
+	for _, pattern := range patterns {
+		low, high, err := parseStatusCodeRange(pattern)
+		if err == nil && statusCode >= low && statusCode <= high {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseStatusCodeRange parses a single status code or an inclusive range of status codes.
+func parseStatusCodeRange(pattern string) (int, int, error) {
+	lowStr, highStr, isRange := strings.Cut(strings.TrimSpace(pattern), "-")
+
+	low, err := strconv.Atoi(strings.TrimSpace(lowStr))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !isRange {
+		return low, low, nil
+	}
+
+	high, err := strconv.Atoi(strings.TrimSpace(highStr))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return low, high, nil
+}
+
 func IsUrlValid(input string) bool {
 	u, err := url.ParseRequestURI(input)
 	return err == nil && u.Scheme != "" && u.Host != ""
@@ -126,6 +126,96 @@ func Test_IsHTTPError(t *testing.T) {
 	}
 }
 
+func Test_IsSafeMethod(t *testing.T) {
+	cases := map[string]struct {
+		method string
+		want   bool
+	}{
+		"GET":          {method: "GET", want: true},
+		"HEAD":         {method: "HEAD", want: true},
+		"OPTIONS":      {method: "OPTIONS", want: true},
+		"POST":         {method: "POST", want: false},
+		"DELETE":       {method: "DELETE", want: false},
+		"CustomMethod": {method: "PURGE", want: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSafeMethod(tc.method)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("IsSafeMethod(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_MatchesStatusCode(t *testing.T) {
+	type args struct {
+		patterns   []string
+		statusCode int
+	}
+	type want struct {
+		result bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SingleCodeMatches": {
+			args: args{
+				patterns:   []string{"404"},
+				statusCode: 404,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"RangeMatchesInclusive": {
+			args: args{
+				patterns:   []string{"400", "500-599"},
+				statusCode: 599,
+			},
+			want: want{
+				result: true,
+			},
+		},
+		"NoMatch": {
+			args: args{
+				patterns:   []string{"502-504"},
+				statusCode: 500,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"MalformedPatternIgnored": {
+			args: args{
+				patterns:   []string{"5xx", "500-"},
+				statusCode: 500,
+			},
+			want: want{
+				result: false,
+			},
+		},
+		"EmptyPatterns": {
+			args: args{
+				patterns:   nil,
+				statusCode: 500,
+			},
+			want: want{
+				result: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := MatchesStatusCode(tc.args.patterns, tc.args.statusCode)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("MatchesStatusCode(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_IsUrlValid(t *testing.T) {
 	type args struct {
 		url string
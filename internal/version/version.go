@@ -0,0 +1,5 @@
+// Package version contains the version of the provider.
+package version
+
+// Version is the version of the provider, set at build time with the -X linker flag.
+var Version = "0.0.0-dev"
@@ -0,0 +1,136 @@
+// Package webhook validates the jq expressions of Requests when they are admitted, so a syntax error is rejected
+// with the path of its field instead of failing the reconciles later.
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+	datapatcher "github.com/crossplane-contrib/provider-http/internal/data-patcher"
+	"github.com/crossplane-contrib/provider-http/internal/jq"
+	"github.com/crossplane-contrib/provider-http/internal/utils"
+)
+
+const (
+	errNotRequest = "managed resource is not a Request custom resource"
+
+	// placeholderValue replaces the secret placeholders before an expression is compiled, since they are only
+	// resolved when the request is sent. It is valid jq both inside and outside of a string.
+	placeholderValue = "0"
+)
+
+// RequestValidator rejects the Requests whose jq expressions do not compile, reporting the path of every field
+// holding one.
+type RequestValidator struct{}
+
+var _ admission.CustomValidator = &RequestValidator{}
+
+// SetupRequestValidator registers the RequestValidator with the webhook server of the manager.
+func SetupRequestValidator(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha2.Request{}).
+		WithValidator(&RequestValidator{}).
+		Complete()
+}
+
+// ValidateCreate validates the jq expressions of a created Request.
+func (v *RequestValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, validateRequest(obj)
+}
+
+// ValidateUpdate validates the jq expressions of an updated Request.
+func (v *RequestValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, validateRequest(newObj)
+}
+
+// ValidateDelete accepts every deletion.
+func (v *RequestValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateRequest returns an Invalid error listing the fields of the Request whose jq expression does not
+// compile, or nil.
+func validateRequest(obj runtime.Object) error {
+	cr, ok := obj.(*v1alpha2.Request)
+	if !ok {
+		return errors.New(errNotRequest)
+	}
+
+	errs := ValidateRequestParameters(&cr.Spec.ForProvider, field.NewPath("spec", "forProvider"))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return kerrors.NewInvalid(v1alpha2.SchemeGroupVersion.WithKind(v1alpha2.RequestKind).GroupKind(), cr.GetName(), errs)
+}
+
+// ValidateRequestParameters compiles every jq expression of the parameters and returns an error for each one that
+// does not compile, with the path of its field.
+func ValidateRequestParameters(params *v1alpha2.RequestParameters, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	validate := func(fieldPath *field.Path, expression string) {
+		if expression == "" {
+			return
+		}
+		if err := jq.Compile(datapatcher.ReplacePlaceholders(utils.NormalizeWhitespace(expression), placeholderValue)); err != nil {
+			errs = append(errs, field.Invalid(fieldPath, expression, err.Error()))
+		}
+	}
+
+	for i, mapping := range params.Mappings {
+		mappingPath := path.Child("mappings").Index(i)
+		validate(mappingPath.Child("url"), mapping.URL)
+		validate(mappingPath.Child("body"), mapping.Body)
+		validate(mappingPath.Child("when"), mapping.When)
+		validate(mappingPath.Child("forEach"), mapping.ForEach)
+		if mapping.Stream != nil {
+			validate(mappingPath.Child("stream", "matchJQ"), mapping.Stream.MatchJQ)
+		}
+		if mapping.WaitForReady != nil {
+			validate(mappingPath.Child("waitForReady", "readyJQ"), mapping.WaitForReady.ReadyJQ)
+		}
+	}
+
+	validateCheck := func(checkPath *field.Path, check v1alpha2.ExpectedResponseCheck) {
+		if check.Type == common.ExpectedResponseCheckTypeCustom {
+			validate(checkPath.Child("logic"), check.Logic)
+		}
+		for i, subCheck := range check.Checks {
+			if subCheck.Type == common.ExpectedResponseCheckTypeCustom {
+				validate(checkPath.Child("checks").Index(i).Child("logic"), subCheck.Logic)
+			}
+		}
+	}
+	validateCheck(path.Child("expectedResponseCheck"), params.ExpectedResponseCheck)
+	validateCheck(path.Child("isRemovedCheck"), params.IsRemovedCheck)
+
+	validate(path.Child("successCondition"), params.SuccessCondition)
+	validate(path.Child("externalNameFrom"), params.ExternalNameFrom)
+	for i, extraction := range params.StatusExtractions {
+		validate(path.Child("statusExtractions").Index(i).Child("responseJQ"), extraction.ResponseJQ)
+	}
+	if params.DriftDiff != nil {
+		validate(path.Child("driftDiff", "normalizeJQ"), params.DriftDiff.NormalizeJQ)
+	}
+	if params.Comparison != nil {
+		for i, array := range params.Comparison.UnorderedArrays {
+			arrayPath := path.Child("comparison", "unorderedArrays").Index(i)
+			validate(arrayPath.Child("path"), array.Path)
+			validate(arrayPath.Child("sortBy"), array.SortBy)
+		}
+		validate(path.Child("comparison", "normalizeJQ"), params.Comparison.NormalizeJQ)
+	}
+	if params.ResponseTransform != nil {
+		validate(path.Child("responseTransform", "jq"), params.ResponseTransform.JQ)
+	}
+
+	return errs
+}
@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane-contrib/provider-http/apis/common"
+	"github.com/crossplane-contrib/provider-http/apis/request/v1alpha2"
+)
+
+func request(params v1alpha2.RequestParameters) *v1alpha2.Request {
+	r := &v1alpha2.Request{}
+	r.SetName("example")
+	r.Spec.ForProvider = params
+
+	return r
+}
+
+func TestValidateCreate(t *testing.T) {
+	validMapping := v1alpha2.Mapping{
+		Method: "POST",
+		URL:    ".payload.baseUrl",
+		Body:   `{ username: .payload.body.username, password: "{{ password:secret:key }}" }`,
+	}
+
+	cases := map[string]struct {
+		reason    string
+		params    v1alpha2.RequestParameters
+		wantPaths []string
+	}{
+		"Valid": {
+			reason: "Should accept a request whose jq expressions all compile",
+			params: v1alpha2.RequestParameters{
+				Mappings: []v1alpha2.Mapping{validMapping},
+				ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+					Type:  common.ExpectedResponseCheckTypeCustom,
+					Logic: `if .response.statusCode == 200 then true else false end`,
+				},
+			},
+		},
+		"UnquotedPlaceholder": {
+			reason: "Should accept a secret placeholder used outside of a string",
+			params: v1alpha2.RequestParameters{
+				Mappings: []v1alpha2.Mapping{{
+					Method: "POST",
+					URL:    ".payload.baseUrl",
+					Body:   `{ count: {{ count:secret:key }} }`,
+				}},
+			},
+		},
+		"DefaultCheckIgnoresLogic": {
+			reason: "Should not compile the logic of a check that is not custom",
+			params: v1alpha2.RequestParameters{
+				Mappings: []v1alpha2.Mapping{validMapping},
+				ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+					Type:  common.ExpectedResponseCheckTypeDefault,
+					Logic: "if then",
+				},
+			},
+		},
+		"BrokenURL": {
+			reason: "Should reject a mapping whose URL does not compile",
+			params: v1alpha2.RequestParameters{
+				Mappings: []v1alpha2.Mapping{validMapping, {
+					Method: "GET",
+					URL:    `(.payload.baseUrl + "/" `,
+				}},
+			},
+			wantPaths: []string{"spec.forProvider.mappings[1].url"},
+		},
+		"BrokenChecks": {
+			reason: "Should reject every check whose logic does not compile",
+			params: v1alpha2.RequestParameters{
+				Mappings: []v1alpha2.Mapping{validMapping},
+				ExpectedResponseCheck: v1alpha2.ExpectedResponseCheck{
+					Type:  common.ExpectedResponseCheckTypeCustom,
+					Logic: "if .response.statusCode == 200 then true",
+				},
+				IsRemovedCheck: v1alpha2.ExpectedResponseCheck{
+					Checks: []v1alpha2.ResponseSubCheck{
+						{Type: common.ExpectedResponseCheckTypeCustom, Logic: ".response.statusCode == 404"},
+						{Type: common.ExpectedResponseCheckTypeCustom, Logic: ".response.body | select(.id ==)"},
+					},
+				},
+			},
+			wantPaths: []string{
+				"spec.forProvider.expectedResponseCheck.logic",
+				"spec.forProvider.isRemovedCheck.checks[1].logic",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&RequestValidator{}).ValidateCreate(context.Background(), request(tc.params))
+			if tc.wantPaths == nil {
+				if err != nil {
+					t.Fatalf("\n%s\nValidateCreate(...): unexpected error: %v", tc.reason, err)
+				}
+				return
+			}
+
+			if !kerrors.IsInvalid(err) {
+				t.Fatalf("\n%s\nValidateCreate(...): want an Invalid error, got %v", tc.reason, err)
+			}
+
+			var gotPaths []string
+			for _, cause := range err.(*kerrors.StatusError).ErrStatus.Details.Causes {
+				gotPaths = append(gotPaths, cause.Field)
+			}
+			if diff := cmp.Diff(tc.wantPaths, gotPaths); diff != "" {
+				t.Errorf("\n%s\nValidateCreate(...): -want field paths, +got field paths:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateRequestParametersWaitForReady(t *testing.T) {
+	params := &v1alpha2.RequestParameters{
+		Mappings: []v1alpha2.Mapping{{
+			Method:       "POST",
+			URL:          ".payload.baseUrl",
+			WaitForReady: &common.WaitForReadyConfig{ReadyJQ: ".response.body.status =="},
+		}},
+		StatusExtractions: []v1alpha2.StatusExtraction{{ResponseJQ: ".response.body.id"}},
+	}
+
+	errs := ValidateRequestParameters(params, field.NewPath("spec", "forProvider"))
+	if len(errs) != 1 || errs[0].Field != "spec.forProvider.mappings[0].waitForReady.readyJQ" {
+		t.Errorf("ValidateRequestParameters(...): want an error for the readyJQ only, got %v", errs)
+	}
+}